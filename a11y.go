@@ -0,0 +1,153 @@
+package rego
+
+import (
+	"io"
+	"net"
+	"os"
+	"path"
+	"strings"
+)
+
+// =============================================================================
+// 无障碍：朗读通知与焦点变化
+// =============================================================================
+
+// Politeness 朗读的紧急程度
+type Politeness int
+
+const (
+	// Polite 在读屏软件空闲时朗读
+	Polite Politeness = iota
+	// Assertive 打断当前朗读，立即朗读
+	Assertive
+)
+
+func (p Politeness) String() string {
+	if p == Assertive {
+		return "assertive"
+	}
+	return "polite"
+}
+
+// a11yQueueSize 等待写出的通知数，读取端过慢时丢弃新的 polite 通知
+const a11yQueueSize = 64
+
+// announcer 将通知和焦点变化逐行写到无障碍输出
+//
+// 每行格式为 "<kind>: <text>"，kind 为 polite、assertive 或 focus，便于读屏脚本按行处理。
+type announcer struct {
+	lines     chan string
+	done      chan struct{}
+	lastFocus string
+}
+
+func newAnnouncer(w io.Writer) *announcer {
+	a := &announcer{lines: make(chan string, a11yQueueSize), done: make(chan struct{})}
+	go func() {
+		defer close(a.done)
+		for line := range a.lines {
+			io.WriteString(w, line)
+		}
+		if c, ok := w.(io.Closer); ok && w != os.Stdout && w != os.Stderr {
+			c.Close()
+		}
+	}()
+	return a
+}
+
+// newAnnouncerFromEnv 按环境变量 REGO_ACCESSIBILITY 开启无障碍模式：
+// stdout、stderr、unix:<socket 路径> 或 tcp:<地址>；未设置或连接失败时返回 nil
+func newAnnouncerFromEnv() *announcer {
+	target := os.Getenv("REGO_ACCESSIBILITY")
+	switch {
+	case target == "":
+		return nil
+	case target == "stdout":
+		return newAnnouncer(os.Stdout)
+	case target == "stderr":
+		return newAnnouncer(os.Stderr)
+	}
+	network, addr, ok := strings.Cut(target, ":")
+	if !ok || (network != "unix" && network != "tcp") {
+		return nil
+	}
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil
+	}
+	return newAnnouncer(conn)
+}
+
+func (a *announcer) send(kind, text string) {
+	line := kind + ": " + strings.ReplaceAll(text, "\n", " ") + "\n"
+	if kind == Assertive.String() {
+		a.lines <- line
+		return
+	}
+	select {
+	case a.lines <- line:
+	default:
+	}
+}
+
+// focusChanged 焦点组件变化时输出其名称
+func (a *announcer) focusChanged(ctx *componentContext, key string) {
+	if key == a.lastFocus {
+		return
+	}
+	a.lastFocus = key
+	if key == "" {
+		return
+	}
+	name := path.Base(key)
+	if ctx != nil && ctx.a11yName != "" {
+		name = ctx.a11yName
+	}
+	a.send("focus", name)
+}
+
+// close 写出剩余的通知并关闭输出
+func (a *announcer) close() {
+	close(a.lines)
+	<-a.done
+}
+
+// Announce 通过读屏软件朗读 text，未开启无障碍模式时不做任何事
+//
+// 应在事件处理器或 Effect 中调用（如保存成功、出现错误），而不是在每帧渲染时调用。
+func Announce(c C, text string, p Politeness) {
+	ctx := c.(*componentContext)
+	if ctx.runtime == nil || ctx.runtime.a11y == nil {
+		return
+	}
+	ctx.runtime.a11y.send(p.String(), text)
+}
+
+// AccessibleName 设置组件获得焦点时朗读的名称，未设置时使用组件 key
+func AccessibleName(c C, name string) {
+	c.(*componentContext).a11yName = name
+}
+
+// EnableAccessibility 开启无障碍模式，将通知与焦点变化写到 w（用于测试或自定义输出）
+func (r *Runtime) EnableAccessibility(w io.Writer) {
+	if r.a11y != nil {
+		r.a11y.close()
+	}
+	r.a11y = newAnnouncer(w)
+}
+
+// announceFocus 在每帧渲染后检查焦点是否变化
+func (r *Runtime) announceFocus() {
+	if r.a11y == nil {
+		return
+	}
+	r.a11y.focusChanged(r.focusManager.CurrentContext(), r.focusManager.Current())
+}
+
+// closeAccessibility 退出时写出剩余的通知
+func (r *Runtime) closeAccessibility() {
+	if r.a11y != nil {
+		r.a11y.close()
+		r.a11y = nil
+	}
+}
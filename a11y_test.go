@@ -0,0 +1,42 @@
+package rego
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestAnnounce(t *testing.T) {
+	field := func(c C, name string) Node {
+		AccessibleName(c, name)
+		focus := UseFocus(c)
+		UseKey(c, func(key Key, r rune) {
+			if focus.IsFocused && key == KeyEnter {
+				Announce(c, name+" saved", Polite)
+			}
+		})
+		return Text(name)
+	}
+	app := func(c C) Node {
+		return VStack(
+			field(c.Child("name"), "Name"),
+			field(c.Child("email"), "Email"),
+		)
+	}
+
+	var out bytes.Buffer
+	tr := NewTestRuntime(app, newTestScreen(20, 2))
+	tr.EnableAccessibility(&out)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyTab, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	tr.closeAccessibility()
+
+	want := "focus: Name\nfocus: Email\npolite: Email saved\n"
+	if out.String() != want {
+		t.Errorf("unexpected accessibility output:\n%q\nwant:\n%q", out.String(), want)
+	}
+}
@@ -0,0 +1,110 @@
+package rego
+
+import "github.com/gdamore/tcell/v2"
+
+// =============================================================================
+// Alert - 提示框
+// =============================================================================
+
+// AlertKind 提示框的类型，决定图标与颜色
+type AlertKind int
+
+const (
+	AlertInfo AlertKind = iota
+	AlertSuccess
+	AlertWarn
+	AlertError
+)
+
+func (k AlertKind) icon() string {
+	switch k {
+	case AlertSuccess:
+		return "✓"
+	case AlertWarn:
+		return "⚠"
+	case AlertError:
+		return "✗"
+	default:
+		return "ℹ"
+	}
+}
+
+// color 返回类型在主题中对应的颜色
+func (k AlertKind) color(t Theme) Color {
+	switch k {
+	case AlertSuccess:
+		return t.Success
+	case AlertWarn:
+		return t.Warning
+	case AlertError:
+		return t.Error
+	default:
+		return t.Primary
+	}
+}
+
+type alertNode struct {
+	kind      AlertKind
+	title     string
+	body      string
+	theme     Theme
+	onDismiss func()
+}
+
+// Alert 创建一个带图标和边框的提示框，颜色取自主题（默认 DefaultTheme）：
+//
+//	rego.Alert(rego.AlertWarn, "配置已过期", "请重新运行 init").Theme(rego.UseTheme(c)).OnDismiss(hide)
+func Alert(kind AlertKind, title, body string) *alertNode {
+	return &alertNode{kind: kind, title: title, body: body, theme: DefaultTheme}
+}
+
+// Theme 使用 t 中的颜色与边框样式，通常传入 UseTheme(c)
+func (a *alertNode) Theme(t Theme) *alertNode {
+	a.theme = t
+	return a
+}
+
+// OnDismiss 在右上角显示 [x]，点击时调用 fn
+func (a *alertNode) OnDismiss(fn func()) *alertNode {
+	a.onDismiss = fn
+	return a
+}
+
+// box 构造提示框的内容
+func (a *alertNode) box() *boxNode {
+	color := a.kind.color(a.theme)
+	border := a.theme.BorderStyle
+	if border == BorderNone {
+		border = BorderSingle
+	}
+	content := VStack(
+		Text(a.kind.icon()+" "+a.title).Bold().Color(color),
+		When(a.body != "", Text(a.body).Wrap(true)),
+	)
+	return Box(content).Border(border).BorderColor(color).Padding(0, 1)
+}
+
+func (a *alertNode) render(screen tcell.Screen, x, y, width, height int) int {
+	used := a.box().render(screen, x, y, width, height)
+	if a.onDismiss != nil && width >= 8 && used > 0 {
+		style := tcell.StyleDefault.Foreground(colorToTcell(a.kind.color(a.theme)))
+		col := x + width - 4
+		for i, r := range "[x]" {
+			screen.SetContent(col+i, y, r, nil, style)
+		}
+		addClickRegion(screen, Rect{X: col, Y: y, W: 3, H: 1}, a.onDismiss)
+	}
+	return used
+}
+
+func (a *alertNode) measureHeight(width int) int {
+	return measureNodeHeight(a.box(), width)
+}
+
+func (a *alertNode) measureWidth() int {
+	w := (&hstackNode{}).measureWidth(a.box())
+	if a.onDismiss != nil {
+		w += 4 // 标题栏右侧的 [x]
+	}
+	return w
+}
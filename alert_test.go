@@ -0,0 +1,46 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAlert_RenderAndDismiss(t *testing.T) {
+	dismissed := false
+	app := func(c C) Node {
+		theme := DefaultTheme
+		theme.Warning = Magenta
+		return VStack(
+			Alert(AlertWarn, "Config outdated", "Run init again to regenerate the file").Theme(theme).OnDismiss(func() { dismissed = true }),
+			Alert(AlertInfo, "Tip", ""),
+		)
+	}
+
+	screen := newTestScreen(30, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasSuffix(lines[0], "[x]┐") || !strings.Contains(lines[1], "⚠ Config outdated") || !strings.Contains(lines[2], "Run init again") {
+		t.Fatalf("unexpected alert:\n%s", getScreenContent(screen))
+	}
+	_, _, style, _ := screen.GetContent(2, 1)
+	if fg, _, _ := style.Decompose(); fg != colorToTcell(Magenta) {
+		t.Errorf("expected title to use the theme's warning color")
+	}
+	// 正文换行后，第二个提示框没有正文与 [x]
+	var tip int
+	for i, line := range lines {
+		if strings.Contains(line, "ℹ Tip") {
+			tip = i
+		}
+	}
+	if tip == 0 || strings.Contains(lines[tip-1], "[x]") {
+		t.Fatalf("unexpected info alert:\n%s", getScreenContent(screen))
+	}
+
+	mouseClick(tr, 27, 0)
+	if !dismissed {
+		t.Error("expected OnDismiss to be called")
+	}
+}
@@ -1,6 +1,9 @@
 package rego
 
 import (
+	"math"
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
 )
@@ -12,6 +15,12 @@ func drawAnsiString(screen tcell.Screen, x, y, width, height int, text string, b
 }
 
 func renderAnsi(screen tcell.Screen, x, y, width, height int, text string, baseStyle tcell.Style) int {
+	return layoutAnsi(screen, x, y, width, height, text, baseStyle, true)
+}
+
+// layoutAnsi 排版并绘制 ANSI 文本，返回占用的行数
+// wrap 为 false 时超出宽度的部分被截断；screen 为 nil 时只计算行数
+func layoutAnsi(screen tcell.Screen, x, y, width, height int, text string, baseStyle tcell.Style, wrap bool) int {
 	currentStyle := baseStyle
 	curX, curY := x, y
 	lines := 1
@@ -47,6 +56,9 @@ func renderAnsi(screen tcell.Screen, x, y, width, height int, text string, baseS
 		}
 
 		w := runewidth.RuneWidth(r)
+		if curX+w > x+width && !wrap {
+			continue
+		}
 		if curX+w > x+width {
 			curX = x
 			curY++
@@ -56,7 +68,7 @@ func renderAnsi(screen tcell.Screen, x, y, width, height int, text string, baseS
 			}
 		}
 
-		if curY < y+height {
+		if screen != nil && curY < y+height {
 			screen.SetContent(curX, curY, r, nil, currentStyle)
 		}
 		curX += w
@@ -65,6 +77,74 @@ func renderAnsi(screen tcell.Screen, x, y, width, height int, text string, baseS
 	return lines
 }
 
+// =============================================================================
+// Ansi 节点
+// =============================================================================
+
+type ansiNode struct {
+	content string
+	style   Style
+	wrap    bool
+}
+
+// Ansi 创建一个渲染 ANSI 转义序列的文本节点，适合显示 lipgloss、命令输出或日志中已着色的字符串
+func Ansi(s string) *ansiNode {
+	return &ansiNode{content: s, style: defaultStyle()}
+}
+
+// Wrap 设置超出宽度时是否自动换行（默认截断）
+func (a *ansiNode) Wrap(w bool) *ansiNode {
+	a.wrap = w
+	return a
+}
+
+// Apply 设置未被转义序列覆盖部分的基础样式
+func (a *ansiNode) Apply(s Style) *ansiNode {
+	a.style = s
+	return a
+}
+
+func (a *ansiNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	return layoutAnsi(screen, x, y, width, height, a.content, a.style.toTcell(), a.wrap)
+}
+
+func (a *ansiNode) measureHeight(width int) int {
+	if width <= 0 {
+		return 1
+	}
+	return layoutAnsi(nil, 0, 0, width, math.MaxInt32, a.content, tcell.StyleDefault, a.wrap)
+}
+
+// measureWidth 返回最长一行的显示宽度（不含转义序列）
+func (a *ansiNode) measureWidth() int {
+	widest := 0
+	for _, line := range strings.Split(stripAnsi(a.content), "\n") {
+		widest = max(widest, runewidth.StringWidth(line))
+	}
+	return widest
+}
+
+// stripAnsi 去除字符串中的 CSI 转义序列
+func stripAnsi(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\x1b' && i+1 < len(runes) && runes[i+1] == '[' {
+			j := i + 2
+			for j < len(runes) && (runes[j] < 0x40 || runes[j] > 0x7E) {
+				j++
+			}
+			i = j
+			continue
+		}
+		b.WriteRune(runes[i])
+	}
+	return b.String()
+}
+
 func applySGR(style tcell.Style, params []rune) tcell.Style {
 	if len(params) == 0 {
 		return tcell.StyleDefault
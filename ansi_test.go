@@ -0,0 +1,37 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestAnsi_RenderAndMeasure(t *testing.T) {
+	colored := "\x1b[31mred\x1b[0m and \x1b[1;32mgreen\x1b[0m text"
+
+	if w := Ansi(colored).measureWidth(); w != 18 {
+		t.Errorf("expected width 18, got %d", w)
+	}
+	if h := Ansi(colored).Wrap(true).measureHeight(10); h != 2 {
+		t.Errorf("expected wrapped height 2, got %d", h)
+	}
+	if h := Ansi(colored).measureHeight(10); h != 1 {
+		t.Errorf("expected truncated height 1, got %d", h)
+	}
+
+	app := func(c C) Node {
+		return VStack(Ansi(colored), Text("next"))
+	}
+	screen := newTestScreen(10, 2)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if lines[0] != "red and gr" || !strings.HasPrefix(lines[1], "next") {
+		t.Errorf("unexpected content: %q", lines)
+	}
+	if _, _, style, _ := screen.GetContent(0, 0); style != tcell.StyleDefault.Foreground(tcell.PaletteColor(1)) {
+		t.Errorf("expected red foreground, got %v", style)
+	}
+}
@@ -0,0 +1,77 @@
+package rego
+
+// =============================================================================
+// UseAsync / Suspense - 异步数据加载
+// =============================================================================
+
+// AsyncState 是 UseAsync 返回的加载状态
+type AsyncState[T any] struct {
+	Data    T // 最近一次成功加载的数据，重新加载期间保留旧值
+	Err     error
+	Loading bool
+}
+
+// UseAsync 在后台协程中执行 fn，deps 变化时重新执行；结果在 UI 循环中写回并触发刷新
+//
+// 过期的结果（deps 已变化或组件已被释放，见 UseEffect）会被丢弃；组件只是不再渲染时结果照常写回。
+func UseAsync[T any](c C, fn func() (T, error), deps ...any) AsyncState[T] {
+	ctx := c.(*componentContext)
+	state := Use(c, "async", AsyncState[T]{Loading: true})
+	gen := UseRef(c, 0)
+
+	UseEffect(c, func() func() {
+		gen.Current++
+		g := gen.Current
+		state.Set(AsyncState[T]{Data: state.Val.Data, Loading: true})
+
+		go func() {
+			data, err := fn()
+			apply := func() {
+				if gen.Current != g {
+					return
+				}
+				if err != nil {
+					state.Set(AsyncState[T]{Data: state.Val.Data, Err: err})
+				} else {
+					state.Set(AsyncState[T]{Data: data})
+				}
+			}
+			if ctx.runtime != nil {
+				ctx.runtime.post(apply)
+			} else {
+				apply()
+			}
+		}()
+		return func() { gen.Current++ }
+	}, deps...)
+
+	if state.Val.Loading {
+		ctx.asyncLoading = true
+	}
+	return state.Val
+}
+
+// Suspense 在子树中任意 UseAsync 仍在加载时显示 fallback，全部完成后显示 child
+//
+// child 中的组件需使用 c.Child(...) 创建，才能被识别为该边界的子树。
+func Suspense(c C, fallback Node, child Node) Node {
+	ctx := c.(*componentContext)
+	ctx.readsSubtree = true
+	if ctx.asyncLoading || ctx.subtreeLoading() {
+		return c.Wrap(fallback)
+	}
+	return c.Wrap(child)
+}
+
+// subtreeLoading 本帧执行过的后代组件中是否有 UseAsync 仍在加载
+func (c *componentContext) subtreeLoading() bool {
+	for _, child := range c.children {
+		if c.runtime != nil && child.seenFrame != c.runtime.frame {
+			continue
+		}
+		if child.asyncLoading || child.subtreeLoading() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuspense_ShowsFallbackWhileLoading(t *testing.T) {
+	release := make(chan struct{})
+	profile := func(c C) Node {
+		user := UseAsync(c, func() (string, error) {
+			<-release
+			return "alice", nil
+		})
+		return Text("user: " + user.Data)
+	}
+	app := func(c C) Node {
+		page := c.Child("page")
+		return Suspense(page, Text("loading..."), profile(page.Child("profile")))
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "loading...") {
+		t.Fatalf("expected fallback while loading, got %q", content)
+	}
+
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		tr.Render()
+		if strings.Contains(getScreenContent(screen), "user: alice") {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Errorf("expected loaded content, got %q", getScreenContent(screen))
+}
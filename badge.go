@@ -0,0 +1,112 @@
+package rego
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Badge / Tag / Avatar - 徽标、标签与头像
+// =============================================================================
+
+// chipNode 是 Badge 和 Tag 共用的行内节点：左右各留 1 格 padding，整体填充背景色
+type chipNode struct {
+	label string
+	style Style
+}
+
+// Badge 创建一个用于计数的徽标，默认红底粗体，如 Badge("3").Color(Green)
+func Badge(label string) *chipNode {
+	return &chipNode{label: label, style: Style{fg: White, bg: Red, bold: true}}
+}
+
+// Tag 创建一个状态标签，默认灰底，如 Tag("beta").Color(Cyan)
+func Tag(label string) *chipNode {
+	return &chipNode{label: label, style: Style{fg: Black, bg: Gray}}
+}
+
+// avatarColors Avatar 按名字选取的背景色及其上的文字颜色
+var avatarColors = []struct{ bg, fg Color }{
+	{Red, White}, {Green, Black}, {Yellow, Black}, {Blue, White}, {Magenta, White}, {Cyan, Black},
+}
+
+// Avatar 创建一个显示名字缩写的头像，背景色由名字决定（同名总是同色），可用 Color 覆盖：
+//
+//	rego.HStack(rego.Avatar("Ada Lovelace"), rego.Text(msg)).Gap(1) // " AL "
+func Avatar(name string) *chipNode {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	c := avatarColors[h.Sum32()%uint32(len(avatarColors))]
+	return &chipNode{label: initials(name), style: Style{fg: c.fg, bg: c.bg, bold: true}}
+}
+
+// initials 返回名字的缩写：多个单词时取首尾单词的首字母，否则取前两个字符；
+// 全角字符本身占两列，只取一个
+func initials(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '_' || r == '.'
+	})
+	if len(words) == 0 {
+		return "?"
+	}
+	first := []rune(words[0])
+	if runewidth.RuneWidth(first[0]) > 1 {
+		return string(first[0])
+	}
+	out := []rune{first[0]}
+	if len(words) > 1 {
+		if r := []rune(words[len(words)-1])[0]; runewidth.RuneWidth(r) == 1 {
+			out = append(out, r)
+		}
+	} else if len(first) > 1 && runewidth.RuneWidth(first[1]) == 1 {
+		out = append(out, first[1])
+	}
+	return strings.ToUpper(string(out))
+}
+
+// Color 设置填充的背景色
+func (n *chipNode) Color(c Color) *chipNode {
+	n.style.bg = c
+	return n
+}
+
+// TextColor 设置文字颜色
+func (n *chipNode) TextColor(c Color) *chipNode {
+	n.style.fg = c
+	return n
+}
+
+// Bold 加粗文字
+func (n *chipNode) Bold() *chipNode {
+	n.style.bold = true
+	return n
+}
+
+func (n *chipNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if height <= 0 || width <= 0 {
+		return 0
+	}
+	style := n.style.toTcell()
+	col := x
+	for _, r := range " " + n.label + " " {
+		w := runewidth.RuneWidth(r)
+		if col+w > x+width {
+			break
+		}
+		screen.SetContent(col, y, r, nil, style)
+		col += w
+	}
+	return 1
+}
+
+func (n *chipNode) measureWidth() int {
+	return runewidth.StringWidth(n.label) + 2
+}
+
+func (n *chipNode) measureHeight(width int) int {
+	return 1
+}
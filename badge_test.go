@@ -0,0 +1,52 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBadgeAndTag_InlineInHStack(t *testing.T) {
+	app := func(c C) Node {
+		return HStack(Text("Inbox"), Badge("3"), Tag("beta").Color(Cyan), Text("end")).Gap(1)
+	}
+
+	screen := newTestScreen(30, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "Inbox  3   beta  end") {
+		t.Errorf("unexpected layout: %q", content)
+	}
+}
+
+func TestAvatar_InitialsAndColor(t *testing.T) {
+	for name, want := range map[string]string{
+		"Ada Lovelace":     "AL",
+		"grace":            "GR",
+		"build-agent":      "BA",
+		"张三":               "张",
+		"":                 "?",
+		"Ken Thompson Jr.": "KJ",
+	} {
+		if got := initials(name); got != want {
+			t.Errorf("initials(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if Avatar("ada").style != Avatar("ada").style {
+		t.Error("expected the same name to get the same color")
+	}
+	if got := Avatar("ada").Color(Blue).style.bg; got != Blue {
+		t.Errorf("Color override = %v, want Blue", got)
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(func(c C) Node {
+		return HStack(Avatar("Ada Lovelace"), Text("hi")).Gap(1)
+	}, screen)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.HasPrefix(content, " AL  hi") {
+		t.Errorf("unexpected layout: %q", content)
+	}
+}
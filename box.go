@@ -11,6 +11,9 @@ import (
 type boxNode struct {
 	child Node
 	style Style
+
+	resizable bool
+	onResize  func(width, height int)
 }
 
 // Box 创建一个容器节点
@@ -28,6 +31,18 @@ func (b *boxNode) Apply(s Style) *boxNode {
 }
 
 func (b *boxNode) render(screen tcell.Screen, x, y, width, height int) int {
+	used := b.renderBox(screen, x, y, width, height)
+	if b.resizable && used > 0 {
+		w := width
+		if b.style.width > 0 && b.style.width < width {
+			w = b.style.width
+		}
+		addResizeRegion(screen, Rect{X: x, Y: y, W: w, H: used}, b.resizeMin(), b.onResize)
+	}
+	return used
+}
+
+func (b *boxNode) renderBox(screen tcell.Screen, x, y, width, height int) int {
 	if height <= 0 || width <= 0 {
 		return 0
 	}
@@ -0,0 +1,188 @@
+package rego
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Breadcrumb - 面包屑导航
+// =============================================================================
+
+// breadcrumbEllipsis 宽度不足时替代中间段的省略号
+const breadcrumbEllipsis = "…"
+
+type BreadcrumbProps struct {
+	Segments   []string
+	OnNavigate func(index int) // 点击或回车选中第 index 段时调用（最后一段为当前位置，不可选）
+	Separator  string          // 段之间的分隔符，默认 " › "
+}
+
+// Breadcrumb 创建一条面包屑导航，宽度不足时保留首段和尽量多的末尾段，中间段折叠为 …
+//
+// 聚焦时 ←/→ 选择上级段，Enter 跳转；鼠标点击任意上级段跳转。
+//
+//	rego.Breadcrumb(c.Child("path"), rego.BreadcrumbProps{
+//		Segments:   strings.Split(dir, "/"),
+//		OnNavigate: func(i int) { cd(strings.Join(parts[:i+1], "/")) },
+//	})
+func Breadcrumb(c C, props BreadcrumbProps) Node {
+	focus := UseFocus(c)
+	theme := UseTheme(c)
+	selected := Use(c, "selected", -1) // 键盘选中的段，-1 表示当前位置
+
+	last := len(props.Segments) - 1
+	sel := selected.Val
+	if sel >= last {
+		sel = -1
+	}
+	navigate := func(i int) {
+		if props.OnNavigate != nil && i >= 0 && i < last {
+			props.OnNavigate(i)
+		}
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused || last <= 0 {
+			return
+		}
+		cur := selected.Val
+		if cur >= last {
+			cur = -1
+		}
+		switch key {
+		case KeyLeft:
+			if cur < 0 {
+				selected.Set(last - 1)
+			} else {
+				selected.Set(max(cur-1, 0))
+			}
+		case KeyRight:
+			if cur >= 0 && cur < last-1 {
+				selected.Set(cur + 1)
+			} else {
+				selected.Set(-1)
+			}
+		case KeyEnter:
+			if cur >= 0 {
+				selected.Set(-1)
+				navigate(cur)
+			}
+		}
+	})
+
+	sep := props.Separator
+	if sep == "" {
+		sep = " › "
+	}
+	return c.Wrap(&breadcrumbNode{
+		segments: props.Segments,
+		sep:      sep,
+		selected: If(focus.IsFocused, sel, -1),
+		navigate: navigate,
+		theme:    theme,
+	})
+}
+
+type breadcrumbNode struct {
+	segments []string
+	sep      string
+	selected int
+	navigate func(int)
+	theme    Theme
+}
+
+// visible 返回宽度 width 内显示的段下标，-1 表示省略号
+func (b *breadcrumbNode) visible(width int) []int {
+	n := len(b.segments)
+	all := make([]int, n)
+	for i := range all {
+		all[i] = i
+	}
+	if n <= 2 || b.widthOf(all) <= width {
+		return all
+	}
+	// 首段 + … + 从末尾开始尽量多的段
+	for start := 2; start < n; start++ {
+		shown := append([]int{0, -1}, all[start:]...)
+		if b.widthOf(shown) <= width || start == n-1 {
+			return shown
+		}
+	}
+	return all
+}
+
+func (b *breadcrumbNode) label(i int) string {
+	if i < 0 {
+		return breadcrumbEllipsis
+	}
+	return b.segments[i]
+}
+
+func (b *breadcrumbNode) widthOf(shown []int) int {
+	w := 0
+	for k, i := range shown {
+		if k > 0 {
+			w += runewidth.StringWidth(b.sep)
+		}
+		w += runewidth.StringWidth(b.label(i))
+	}
+	return w
+}
+
+func (b *breadcrumbNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if width <= 0 || height <= 0 || len(b.segments) == 0 {
+		return 0
+	}
+	last := len(b.segments) - 1
+	muted := tcell.StyleDefault.Foreground(colorToTcell(b.theme.Muted))
+	col, end := x, x+width
+
+	// put 绘制一段文字，超出宽度时截断，返回绘制的宽度
+	put := func(text string, style tcell.Style) int {
+		if col >= end {
+			return 0
+		}
+		start := col
+		for _, r := range runewidth.Truncate(text, end-col, breadcrumbEllipsis) {
+			screen.SetContent(col, y, r, nil, style)
+			col += runewidth.RuneWidth(r)
+		}
+		return col - start
+	}
+
+	for k, i := range b.visible(width) {
+		if k > 0 {
+			put(b.sep, muted)
+		}
+		var style tcell.Style
+		switch {
+		case i < 0:
+			style = muted
+		case i == b.selected:
+			style = tcell.StyleDefault.Background(colorToTcell(b.theme.Primary)).Foreground(colorToTcell(b.theme.OnColor))
+		case i == last:
+			style = tcell.StyleDefault.Bold(true)
+		default:
+			style = tcell.StyleDefault.Foreground(colorToTcell(b.theme.Primary))
+		}
+		start := col
+		w := put(b.label(i), style)
+		if i >= 0 && i < last && w > 0 {
+			index := i
+			addClickRegion(screen, Rect{X: start, Y: y, W: w, H: 1}, func() { b.navigate(index) })
+		}
+	}
+	return 1
+}
+
+func (b *breadcrumbNode) measureHeight(width int) int {
+	if len(b.segments) == 0 {
+		return 0
+	}
+	return 1
+}
+
+func (b *breadcrumbNode) measureWidth() int {
+	return b.widthOf(b.visible(1 << 30))
+}
@@ -0,0 +1,64 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestBreadcrumb_TruncatesMiddle(t *testing.T) {
+	segments := []string{"home", "user", "projects", "rego", "docs"}
+	for _, tc := range []struct {
+		width int
+		want  string
+	}{
+		{36, "home › user › projects › rego › docs"},
+		{35, "home › … › projects › rego › docs"},
+		{30, "home › … › rego › docs"},
+		{15, "home › … › docs"},
+		{14, "home › … › do…"},
+	} {
+		screen := newTestScreen(tc.width, 1)
+		tr := NewTestRuntime(func(c C) Node {
+			return Breadcrumb(c.Child("crumb"), BreadcrumbProps{Segments: segments})
+		}, screen)
+		tr.Render()
+		if got := strings.TrimRight(getScreenContent(screen), " \n"); got != tc.want {
+			t.Errorf("width %d: got %q, want %q", tc.width, got, tc.want)
+		}
+	}
+}
+
+func TestBreadcrumb_Navigate(t *testing.T) {
+	var navigated []int
+	app := func(c C) Node {
+		return Breadcrumb(c.Child("crumb"), BreadcrumbProps{
+			Segments:   []string{"src", "pkg", "main.go"},
+			Separator:  " / ",
+			OnNavigate: func(i int) { navigated = append(navigated, i) },
+		})
+	}
+
+	screen := newTestScreen(30, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if got := strings.TrimRight(getScreenContent(screen), " \n"); got != "src / pkg / main.go" {
+		t.Fatalf("unexpected breadcrumb %q", got)
+	}
+
+	// 点击上级段跳转，点击当前段无效
+	mouseClick(tr, 1, 0)
+	mouseClick(tr, 15, 0)
+
+	// ← 从当前位置选中上一级，再 ← 选中首段，Enter 跳转
+	tr.DispatchKey(tcell.KeyLeft, 0, 0)
+	tr.DispatchKey(tcell.KeyLeft, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+
+	if len(navigated) != 2 || navigated[0] != 0 || navigated[1] != 0 {
+		t.Errorf("navigated = %v, want [0 0]", navigated)
+	}
+}
@@ -1,7 +1,9 @@
 package rego
 
 import (
+	"strings"
 	"sync"
+	"time"
 )
 
 // Bridge 是 UI 侧持有的句柄，用于与 Core 通信
@@ -9,9 +11,69 @@ type Bridge[S any, Q any, A any] struct {
 	ctx         C
 	state       *State[S]
 	interaction *State[*pendingInteraction[Q, A]]
+	streams     *bridgeStreams
+	middleware  *bridgeMiddleware
+	useIndex    int // 本次渲染中 Use 的调用序号
 	handle      *bridgeHandle[S, Q, A]
 }
 
+// BridgeEventKind 标识 Bridge 上发生的调用
+type BridgeEventKind int
+
+const (
+	BridgeUpdate BridgeEventKind = iota // Core 调用 Update
+	BridgeAsk                           // Core 调用 Ask
+	BridgeSubmit                        // UI 调用 Submit
+)
+
+func (k BridgeEventKind) String() string {
+	switch k {
+	case BridgeUpdate:
+		return "update"
+	case BridgeAsk:
+		return "ask"
+	case BridgeSubmit:
+		return "submit"
+	}
+	return "unknown"
+}
+
+// BridgeEvent 描述一次 Bridge 调用，Payload 为对应的状态、问题或回答
+type BridgeEvent struct {
+	Kind    BridgeEventKind
+	Time    time.Time
+	Payload any
+}
+
+// bridgeMiddleware 保存跨渲染持久的拦截器，按 Use 的调用顺序存放
+type bridgeMiddleware struct {
+	mu       sync.Mutex
+	handlers []func(BridgeEvent)
+}
+
+func (m *bridgeMiddleware) set(i int, fn func(BridgeEvent)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i < len(m.handlers) {
+		m.handlers[i] = fn
+	} else {
+		m.handlers = append(m.handlers, fn)
+	}
+}
+
+func (m *bridgeMiddleware) emit(kind BridgeEventKind, payload any) {
+	m.mu.Lock()
+	handlers := append([]func(BridgeEvent){}, m.handlers...)
+	m.mu.Unlock()
+	if len(handlers) == 0 {
+		return
+	}
+	ev := BridgeEvent{Kind: kind, Time: time.Now(), Payload: payload}
+	for _, fn := range handlers {
+		fn(ev)
+	}
+}
+
 type pendingInteraction[Q any, A any] struct {
 	question Q
 	answerCh chan A
@@ -39,11 +101,31 @@ func (b *Bridge[S, Q, A]) Interaction() Q {
 // Submit 提交用户的回答，解除 Core 的阻塞
 func (b *Bridge[S, Q, A]) Submit(answer A) {
 	if b.interaction.Val != nil {
+		b.middleware.emit(BridgeSubmit, answer)
 		b.interaction.Val.answerCh <- answer
 		b.interaction.Set(nil)
 	}
 }
 
+// Use 注册一个拦截器，观察 Update/Ask/Submit 调用，可用于日志、指标或会话录制回放
+//
+// 与 Hook 一样应在每次渲染时按相同顺序调用，重复调用会替换同一位置的拦截器而不是叠加。
+// 拦截器在发起调用的协程中同步执行（Update/Ask 来自 Core，Submit 来自 UI）。
+func (b *Bridge[S, Q, A]) Use(fn func(event BridgeEvent)) {
+	b.middleware.set(b.useIndex, fn)
+	b.useIndex++
+}
+
+// StreamValue 返回名为 name 的流目前累积的文本
+func (b *Bridge[S, Q, A]) StreamValue(name string) string {
+	return b.streams.value(name)
+}
+
+// Streaming 名为 name 的流是否仍在写入（通道尚未关闭）
+func (b *Bridge[S, Q, A]) Streaming(name string) bool {
+	return b.streams.active(name)
+}
+
 // Handle 返回给 Core 使用的句柄
 func (b *Bridge[S, Q, A]) Handle() Handle[S, Q, A] {
 	return b.handle
@@ -53,6 +135,9 @@ func (b *Bridge[S, Q, A]) Handle() Handle[S, Q, A] {
 type Handle[S any, Q any, A any] interface {
 	Update(state S)
 	Ask(question Q) A
+	// Stream 返回一个用于逐段写入文本（如 LLM token）的通道，写入内容在 UI 侧按帧合并，
+	// 通过 Bridge.StreamValue(name) 读取；写完后关闭通道。每次调用都会开始一段新的流并清空同名文本
+	Stream(name string) chan<- string
 }
 
 type bridgeHandle[S any, Q any, A any] struct {
@@ -61,24 +146,97 @@ type bridgeHandle[S any, Q any, A any] struct {
 }
 
 func (h *bridgeHandle[S, Q, A]) Update(state S) {
+	h.bridge.middleware.emit(BridgeUpdate, state)
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.bridge.state.Set(state)
 }
 
 func (h *bridgeHandle[S, Q, A]) Ask(question Q) A {
+	h.bridge.middleware.emit(BridgeAsk, question)
 	answerCh := make(chan A)
 
 	// 在 UI 线程设置交互状态
-	h.bridge.interaction.Set(&pendingInteraction[Q, A]{
-		question: question,
-		answerCh: answerCh,
-	})
+	pending := &pendingInteraction[Q, A]{question: question, answerCh: answerCh}
+	h.onUI(func() { h.bridge.interaction.Set(pending) })
 
 	// 阻塞等待 UI 侧通过 Submit 传回结果
 	return <-answerCh
 }
 
+// onUI 在 UI 线程执行 fn；没有运行时（如单独构建组件）时直接执行
+func (h *bridgeHandle[S, Q, A]) onUI(fn func()) {
+	if r := h.bridge.ctx.(*componentContext).runtime; r != nil {
+		r.post(fn)
+		return
+	}
+	fn()
+}
+
+func (h *bridgeHandle[S, Q, A]) Stream(name string) chan<- string {
+	return h.bridge.streams.open(name, h.bridge.ctx)
+}
+
+// =============================================================================
+// bridgeStreams - 按帧合并的文本流
+// =============================================================================
+
+// bridgeStreamBuffer 流通道的缓冲大小
+const bridgeStreamBuffer = 256
+
+type bridgeStreams struct {
+	mu      sync.Mutex
+	streams map[string]*bridgeStream
+}
+
+type bridgeStream struct {
+	text   strings.Builder
+	closed bool
+}
+
+// open 为 name 创建新的写入通道并清空已累积的文本，之前同名通道的后续写入将被忽略
+func (s *bridgeStreams) open(name string, c C) chan<- string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.streams == nil {
+		s.streams = make(map[string]*bridgeStream)
+	}
+	stream := &bridgeStream{}
+	s.streams[name] = stream
+	ch := make(chan string, bridgeStreamBuffer)
+
+	go func() {
+		for chunk := range ch {
+			s.mu.Lock()
+			stream.text.WriteString(chunk)
+			s.mu.Unlock()
+			// 多次写入之间的 Refresh 会被合并到同一帧
+			c.Refresh()
+		}
+		s.mu.Lock()
+		stream.closed = true
+		s.mu.Unlock()
+		c.Refresh()
+	}()
+	return ch
+}
+
+func (s *bridgeStreams) value(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stream, ok := s.streams[name]; ok {
+		return stream.text.String()
+	}
+	return ""
+}
+
+func (s *bridgeStreams) active(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stream, ok := s.streams[name]
+	return ok && !stream.closed
+}
+
 // UseBridge 创建一个双向通信桥梁
 // S: 状态类型, Q: 问题类型, A: 回答类型
 func UseBridge[S any, Q any, A any](c C, initial S) *Bridge[S, Q, A] {
@@ -92,6 +250,8 @@ func UseBridge[S any, Q any, A any](c C, initial S) *Bridge[S, Q, A] {
 		ctx:         c,
 		state:       state,
 		interaction: interaction,
+		streams:     UseRef(c, &bridgeStreams{}).Current,
+		middleware:  UseRef(c, &bridgeMiddleware{}).Current,
 	}
 	b.handle = &bridgeHandle[S, Q, A]{bridge: b}
 	return b
@@ -0,0 +1,97 @@
+package rego
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestBridge_Stream(t *testing.T) {
+	var bridge *Bridge[string, string, string]
+	app := func(c C) Node {
+		bridge = UseBridge[string, string, string](c, "")
+		return Text(bridge.StreamValue("answer"))
+	}
+
+	screen := newTestScreen(30, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	ch := bridge.Handle().Stream("answer")
+	for _, tok := range []string{"Hel", "lo", ", ", "world"} {
+		ch <- tok
+	}
+	close(ch)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for bridge.Streaming("answer") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	tr.Render()
+
+	if got := bridge.StreamValue("answer"); got != "Hello, world" {
+		t.Errorf("expected coalesced stream value, got %q", got)
+	}
+
+	// 新的流从空文本开始
+	next := bridge.Handle().Stream("answer")
+	if got := bridge.StreamValue("answer"); got != "" {
+		t.Errorf("expected new stream to start empty, got %q", got)
+	}
+	close(next)
+}
+
+func TestBridge_Middleware(t *testing.T) {
+	var bridge *Bridge[int, string, bool]
+	var events []BridgeEvent
+	asked := make(chan struct{}, 1)
+	app := func(c C) Node {
+		bridge = UseBridge[int, string, bool](c, 0)
+		bridge.Use(func(ev BridgeEvent) {
+			events = append(events, ev)
+			if ev.Kind == BridgeAsk {
+				asked <- struct{}{}
+			}
+		})
+		return Text(bridge.Interaction())
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.Render() // 重复注册不应叠加
+
+	handle := bridge.Handle()
+	handle.Update(1)
+
+	answered := make(chan bool)
+	go func() { answered <- handle.Ask("continue?") }()
+	<-asked
+	// Ask 把交互状态投递到 UI 线程，由下一帧执行
+	for i := 0; !bridge.HasInteraction(); i++ {
+		if i == 1000 {
+			t.Fatal("interaction never reached the UI")
+		}
+		tr.Render()
+		runtime.Gosched()
+	}
+	bridge.Submit(true)
+	<-answered
+
+	kinds := make([]BridgeEventKind, len(events))
+	for i, ev := range events {
+		kinds[i] = ev.Kind
+	}
+	want := []BridgeEventKind{BridgeUpdate, BridgeAsk, BridgeSubmit}
+	if len(kinds) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, kinds)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, kinds)
+		}
+	}
+	if events[1].Payload != "continue?" || events[2].Payload != true {
+		t.Errorf("unexpected payloads: %+v", events)
+	}
+}
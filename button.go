@@ -1,5 +1,7 @@
 package rego
 
+import "time"
+
 // =============================================================================
 // Button - 按钮组件
 // =============================================================================
@@ -10,14 +12,25 @@ type ButtonProps struct {
 	Primary bool
 }
 
+// buttonPressDuration 按下效果的持续时间
+const buttonPressDuration = 150 * time.Millisecond
+
 func Button(c C, props ButtonProps) Node {
 	focus := UseFocus(c)
+	pressed := Use(c, "pressed", false)
 
+	activate := func() {
+		pressed.Set(true)
+		c.(*componentContext).clock().AfterFunc(buttonPressDuration, func() { pressed.Set(false) })
+		if props.OnClick != nil {
+			props.OnClick()
+		}
+	}
+
+	// 聚焦时 Enter/Space 激活
 	UseKey(c, func(key Key, r rune) {
-		if focus.IsFocused && (key == KeyEnter || r == ' ') {
-			if props.OnClick != nil {
-				props.OnClick()
-			}
+		if focus.IsFocused && (key == KeyEnter || key == KeySpace || r == ' ') {
+			activate()
 		}
 	})
 
@@ -26,9 +39,7 @@ func Button(c C, props ButtonProps) Node {
 		if ev.Type == MouseEventClick && ev.Button == MouseButtonLeft {
 			if c.Rect().Contains(ev.X, ev.Y) {
 				focus.Focus() // 点击聚焦
-				if props.OnClick != nil {
-					props.OnClick()
-				}
+				activate()
 			}
 		}
 	})
@@ -44,5 +55,58 @@ func Button(c C, props ButtonProps) Node {
 		style = style.Background(Green).Color(Black)
 	}
 
+	if pressed.Val {
+		style = style.Background(Cyan).Color(Black).Bold()
+	}
+
 	return c.Wrap(Box(style).Padding(0, 1))
 }
+
+// =============================================================================
+// ButtonGroup - 按钮组
+// =============================================================================
+
+type ButtonGroupProps struct {
+	Buttons []ButtonProps
+	Gap     int
+}
+
+// ButtonGroup 水平排列一组按钮，组内任一按钮聚焦时可用 ←/→ 在按钮间切换
+func ButtonGroup(c C, props ButtonGroupProps) Node {
+	ctx := c.(*componentContext)
+
+	children := make([]C, len(props.Buttons))
+	for i := range props.Buttons {
+		children[i] = c.Child("button", i)
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if key != KeyLeft && key != KeyRight {
+			return
+		}
+		if ctx.runtime == nil || len(children) == 0 {
+			return
+		}
+		fm := ctx.runtime.focusManager
+		for i, child := range children {
+			if !fm.IsFocused(child.(*componentContext).focusKey()) {
+				continue
+			}
+			next := i + 1
+			if key == KeyLeft {
+				next = i - 1
+			}
+			if next >= 0 && next < len(children) {
+				fm.Focus(children[next].(*componentContext).focusKey())
+				c.Refresh()
+			}
+			return
+		}
+	})
+
+	nodes := make([]Node, len(props.Buttons))
+	for i, bp := range props.Buttons {
+		nodes[i] = Button(children[i], bp)
+	}
+	return c.Wrap(HStack(nodes...).Gap(props.Gap))
+}
@@ -0,0 +1,55 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestButton_KeyboardActivation(t *testing.T) {
+	clicks := 0
+	app := func(c C) Node {
+		return Button(c.Child("ok"), ButtonProps{Label: "OK", OnClick: func() { clicks++ }})
+	}
+
+	screen := newTestScreen(20, 3)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.DispatchKey(tcell.KeyRune, ' ', 0)
+	if clicks != 2 {
+		t.Errorf("expected 2 activations, got %d", clicks)
+	}
+}
+
+func TestButtonGroup_ArrowNavigation(t *testing.T) {
+	var clicked string
+	app := func(c C) Node {
+		return ButtonGroup(c.Child("group"), ButtonGroupProps{
+			Buttons: []ButtonProps{
+				{Label: "Yes", OnClick: func() { clicked = "yes" }},
+				{Label: "No", OnClick: func() { clicked = "no" }},
+			},
+			Gap: 1,
+		})
+	}
+
+	screen := newTestScreen(30, 3)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyRight, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	if clicked != "no" {
+		t.Errorf("expected second button to be activated, got %q", clicked)
+	}
+
+	tr.DispatchKey(tcell.KeyLeft, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	if clicked != "yes" {
+		t.Errorf("expected first button to be activated, got %q", clicked)
+	}
+}
@@ -0,0 +1,129 @@
+package rego
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// 屏幕截取
+// =============================================================================
+
+func (c *componentContext) CaptureScreen() string {
+	if c.runtime == nil || c.runtime.screen == nil {
+		return ""
+	}
+	return captureScreen(c.runtime.screen, false)
+}
+
+func (c *componentContext) CaptureScreenANSI() string {
+	if c.runtime == nil || c.runtime.screen == nil {
+		return ""
+	}
+	return captureScreen(c.runtime.screen, true)
+}
+
+// CaptureScreen 返回当前屏幕上的文字（用于测试和录制演示），格式同 C.CaptureScreen
+func (r *Runtime) CaptureScreen() string {
+	if r.screen == nil {
+		return ""
+	}
+	return captureScreen(r.screen, false)
+}
+
+// CaptureScreenANSI 返回带 ANSI 转义序列的屏幕内容（用于测试和录制演示）
+func (r *Runtime) CaptureScreenANSI() string {
+	if r.screen == nil {
+		return ""
+	}
+	return captureScreen(r.screen, true)
+}
+
+// captureScreen 逐行读取屏幕内容，去掉行尾空白和末尾的空行；styled 为 true 时带 SGR 转义序列
+func captureScreen(screen tcell.Screen, styled bool) string {
+	_, h := screen.Size()
+	lines := make([]string, 0, h)
+	for y := 0; y < h; y++ {
+		lines = append(lines, captureLine(screen, y, styled))
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// captureLine 读取屏幕第 y 行的内容，去掉行尾空白；styled 为 true 时带 SGR 转义序列
+func captureLine(screen tcell.Screen, y int, styled bool) string {
+	w, _ := screen.Size()
+	var line strings.Builder
+	current := tcell.StyleDefault
+	end := 0 // 最后一个可见单元格之后的位置（字节），带样式时有背景或属性的空白也算可见
+	for x := 0; x < w; {
+		r, comb, style, width := screen.GetContent(x, y)
+		if styled && style != current {
+			line.WriteString("\x1b[0m" + sgr(style))
+			current = style
+		}
+		if r == 0 {
+			r = ' '
+		}
+		line.WriteRune(r)
+		for _, c := range comb {
+			line.WriteRune(c)
+		}
+		if r != ' ' || (styled && style != tcell.StyleDefault) {
+			end = line.Len()
+		}
+		x += max(width, 1)
+	}
+	s := line.String()[:end]
+	if styled && strings.Contains(s, "\x1b[") {
+		s += "\x1b[0m"
+	}
+	return s
+}
+
+// sgr 返回设置样式的 SGR 转义序列，默认样式返回空串
+func sgr(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	var codes []string
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attrs&a.mask != 0 {
+			codes = append(codes, a.code)
+		}
+	}
+	if style.GetUnderlineStyle() != tcell.UnderlineStyleNone {
+		codes = append(codes, "4")
+	}
+	codes = append(codes, sgrColor(fg, 38)...)
+	codes = append(codes, sgrColor(bg, 48)...)
+	if len(codes) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// sgrColor 返回前景（base=38）或背景（base=48）颜色的 SGR 参数
+func sgrColor(c tcell.Color, base int) []string {
+	switch {
+	case !c.Valid():
+		return nil
+	case c.IsRGB():
+		r, g, b := c.RGB()
+		return []string{strconv.Itoa(base), "2", strconv.Itoa(int(r)), strconv.Itoa(int(g)), strconv.Itoa(int(b))}
+	default:
+		return []string{strconv.Itoa(base), "5", strconv.Itoa(int(c - tcell.ColorValid))}
+	}
+}
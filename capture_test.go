@@ -0,0 +1,35 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCaptureScreen(t *testing.T) {
+	var plain, styled string
+	app := func(c C) Node {
+		UseKey(c, func(key Key, r rune) {
+			if r == 'e' {
+				plain = c.CaptureScreen()
+				styled = c.CaptureScreenANSI()
+			}
+		})
+		return VStack(
+			Text("你好 world"),
+			HStack(Text("red").Color(Red), Text(" plain")),
+		)
+	}
+	screen := newTestScreen(20, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, 'e', 0)
+
+	if want := "你好 world\nred plain"; plain != want {
+		t.Errorf("CaptureScreen() = %q, want %q", plain, want)
+	}
+	red := sgr(tcell.StyleDefault.Foreground(colorToTcell(Red)))
+	if want := "你好 world\n\x1b[0m" + red + "red\x1b[0m plain\x1b[0m"; styled != want {
+		t.Errorf("CaptureScreenANSI() = %q, want %q", styled, want)
+	}
+}
@@ -0,0 +1,95 @@
+package rego
+
+import "time"
+
+// =============================================================================
+// ChatList - 聊天消息列表
+// =============================================================================
+
+// 内置的消息角色
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleSystem    = "system"
+)
+
+// chatIndent 消息正文在对侧留出的空白，用来区分左右两侧的消息
+const chatIndent = 6
+
+// ChatMessage 是聊天列表中的一条消息
+type ChatMessage struct {
+	ID        string    // 可选，设置后作为 Markdown 渲染结果的缓存键
+	Role      string    // RoleUser、RoleAssistant、RoleSystem 或自定义角色
+	Name      string    // 显示的名字，为空时按角色显示
+	Content   string    // Markdown 正文
+	Time      time.Time // 为零时不显示时间
+	Streaming bool      // 仍在生成中：正文按纯文本显示并带光标，生成完成后再按 Markdown 排版
+}
+
+// ChatList 创建一个聊天消息列表：用户消息靠右，其余消息靠左，正文按 Markdown 渲染
+//
+// 列表默认跟随最新消息滚动，向上翻阅期间有新消息时底部显示 ↓ N new messages 提示。
+// 正在生成的回复可以作为最后一条 Streaming 消息传入：
+//
+//	msgs := messages.Val
+//	if reply.Val != "" {
+//		msgs = append(msgs, rego.ChatMessage{Role: rego.RoleAssistant, Content: reply.Val, Streaming: true})
+//	}
+//	rego.ChatList(c.Child("chat"), msgs).Flex(1)
+func ChatList(c C, messages []ChatMessage) *componentNode {
+	theme := UseTheme(c)
+	items := make([]Node, len(messages))
+	for i, msg := range messages {
+		items[i] = chatMessageNode(msg, theme)
+	}
+	return TailBox(c, VStack(items...).Gap(1)).NewMessagesPill(true)
+}
+
+// chatRole 返回角色的默认名字与颜色
+func chatRole(role string, t Theme) (string, Color) {
+	switch role {
+	case RoleUser:
+		return "你", t.Primary
+	case RoleAssistant:
+		return "助手", t.Success
+	case RoleSystem:
+		return "系统", t.Warning
+	default:
+		return role, t.Muted
+	}
+}
+
+// chatMessageNode 一条消息：名字与时间组成的标题行，以及缩进的正文
+func chatMessageNode(msg ChatMessage, t Theme) Node {
+	name, color := chatRole(msg.Role, t)
+	if msg.Name != "" {
+		name = msg.Name
+	}
+	parts := []Node{Text(name).Bold().Color(color)}
+	if !msg.Time.IsZero() {
+		parts = append(parts, Text(msg.Time.Format("15:04")).Dim())
+	}
+	if msg.Streaming {
+		parts = append(parts, Text("…").Color(t.Muted))
+	}
+	header := HStack(parts...).Gap(1)
+
+	var body Node
+	if msg.Streaming {
+		body = Text(msg.Content + "▌").Wrap(true)
+	} else {
+		md := Markdown(msg.Content)
+		if t.Markdown != "" {
+			md = md.Theme(t.Markdown)
+		}
+		if msg.ID != "" {
+			md = md.CacheKey("chat:" + msg.ID)
+		}
+		body = md
+	}
+
+	if msg.Role == RoleUser {
+		return VStack(header.Justify(AlignRight), Box(body).PaddingAll(0, 0, 0, chatIndent))
+	}
+	return VStack(header, Box(body).PaddingAll(0, chatIndent, 0, 0))
+}
@@ -0,0 +1,71 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestChatList_Layout(t *testing.T) {
+	at := time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC)
+	messages := []ChatMessage{
+		{Role: RoleUser, Content: "hello", Time: at},
+		{Role: RoleAssistant, Name: "bot", Content: "partial answer", Streaming: true},
+	}
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(func(c C) Node {
+		return ChatList(c.Child("chat"), messages)
+	}, screen)
+	tr.Render()
+
+	rows := strings.Split(getScreenContent(screen), "\n")
+	if got := strings.TrimRight(rows[0], " │"); !strings.HasSuffix(got, " 你 09:30") || len(got) < 30 {
+		t.Errorf("expected user header right-aligned, got %q", rows[0])
+	}
+	content := strings.Join(rows, "\n")
+	if !strings.Contains(content, "hello") {
+		t.Errorf("expected user message body, got:\n%s", content)
+	}
+	var header string
+	for _, row := range rows {
+		if strings.HasPrefix(row, "bot") {
+			header = row
+		}
+	}
+	if !strings.HasPrefix(header, "bot …") {
+		t.Errorf("expected assistant header on the left with streaming marker, got:\n%s", content)
+	}
+	if !strings.Contains(content, "partial answer▌") {
+		t.Errorf("expected streaming body with cursor, got:\n%s", content)
+	}
+}
+
+func TestChatList_NewMessagesPill(t *testing.T) {
+	count := 6
+	app := func(c C) Node {
+		messages := make([]ChatMessage, count)
+		for i := range messages {
+			messages[i] = ChatMessage{Role: RoleAssistant, Content: fmt.Sprintf("reply %d", i), Streaming: true}
+		}
+		return Box(ChatList(c.Child("chat"), messages)).Height(5)
+	}
+
+	screen := newTestScreen(30, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "reply 5") {
+		t.Fatalf("expected list to follow the latest message, got:\n%s", content)
+	}
+
+	tr.handleEvent(tcell.NewEventMouse(1, 1, tcell.WheelUp, 0))
+	tr.Render()
+	count++
+	tr.Render()
+
+	if content := getScreenContent(screen); !strings.Contains(content, "↓ 1 new message") {
+		t.Errorf("expected new messages pill, got:\n%s", content)
+	}
+}
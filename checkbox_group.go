@@ -0,0 +1,192 @@
+package rego
+
+import (
+	"sort"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// CheckboxGroup - 复选框组
+// =============================================================================
+
+type CheckboxGroupProps struct {
+	Options        []string
+	Selected       []int // 已选中的选项下标
+	OnChanged      func(selected []int)
+	SelectAll      bool   // 是否显示三态的"全选"表头
+	SelectAllLabel string // 全选表头文字，默认为"全选"
+	Columns        int    // 列数，<=1 表示垂直排列
+}
+
+// CheckboxGroup 创建一组复选框
+//
+// 聚焦时方向键移动光标，Space/Enter 切换当前项；光标位于全选表头时切换全部选项。
+func CheckboxGroup(c C, props CheckboxGroupProps) Node {
+	focus := UseFocus(c)
+	cols := props.Columns
+	if cols < 1 {
+		cols = 1
+	}
+	// 光标位置：-1 表示全选表头
+	first := 0
+	if props.SelectAll {
+		first = -1
+	}
+	cursor := Use(c, "cursor", first)
+	count := len(props.Options)
+
+	checked := make(map[int]bool, len(props.Selected))
+	for _, i := range props.Selected {
+		checked[i] = true
+	}
+
+	emit := func(next map[int]bool) {
+		if props.OnChanged == nil {
+			return
+		}
+		out := make([]int, 0, len(next))
+		for i, on := range next {
+			if on && i >= 0 && i < count {
+				out = append(out, i)
+			}
+		}
+		sort.Ints(out)
+		props.OnChanged(out)
+	}
+
+	toggle := func(i int) {
+		next := make(map[int]bool, len(checked)+1)
+		if i < 0 {
+			// 全选表头：未全部选中时全选，否则全部取消
+			if len(props.Selected) < count {
+				for j := 0; j < count; j++ {
+					next[j] = true
+				}
+			}
+		} else {
+			for k, v := range checked {
+				next[k] = v
+			}
+			next[i] = !next[i]
+		}
+		emit(next)
+	}
+
+	move := func(delta int) {
+		cursor.Update(func(v int) int {
+			v += delta
+			if v < first {
+				v = first
+			}
+			if v >= count {
+				v = count - 1
+			}
+			return v
+		})
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused {
+			return
+		}
+		switch {
+		case key == KeyUp:
+			if cursor.Val >= 0 && cursor.Val < cols {
+				move(first - cursor.Val)
+			} else {
+				move(-cols)
+			}
+		case key == KeyDown:
+			if cursor.Val < 0 {
+				move(1)
+			} else {
+				move(cols)
+			}
+		case key == KeyLeft && cols > 1:
+			move(-1)
+		case key == KeyRight && cols > 1:
+			move(1)
+		case key == KeyEnter || key == KeySpace || r == ' ':
+			toggle(cursor.Val)
+		}
+	})
+
+	// 每列宽度：图标 + 空格 + 最长标签 + 间距
+	colWidth := 0
+	for _, opt := range props.Options {
+		if w := runewidth.StringWidth(opt); w > colWidth {
+			colWidth = w
+		}
+	}
+	colWidth += 4 + 2
+
+	headerRows := 0
+	if props.SelectAll {
+		headerRows = 1
+	}
+
+	UseMouse(c, func(ev MouseEvent) {
+		if ev.Type != MouseEventClick || ev.Button != MouseButtonLeft {
+			return
+		}
+		rect := c.Rect()
+		if !rect.Contains(ev.X, ev.Y) {
+			return
+		}
+		focus.Focus()
+		row := ev.Y - rect.Y - headerRows
+		if row < 0 {
+			cursor.Set(-1)
+			toggle(-1)
+			return
+		}
+		// 减去水平 padding
+		col := (ev.X - rect.X - 1) / colWidth
+		i := row*cols + col
+		if ev.X-rect.X >= 1 && col < cols && i < count {
+			cursor.Set(i)
+			toggle(i)
+		}
+	})
+
+	item := func(i int, icon, label string) *textNode {
+		t := Text(icon + " " + label)
+		if cols > 1 {
+			t = t.Width(colWidth)
+		}
+		if focus.IsFocused && cursor.Val == i {
+			t = t.Color(Green)
+		}
+		return t
+	}
+
+	var rows []Node
+	if props.SelectAll {
+		icon := "[ ]"
+		if n := len(props.Selected); n > 0 && n >= count {
+			icon = "[x]"
+		} else if n > 0 {
+			icon = "[-]"
+		}
+		label := props.SelectAllLabel
+		if label == "" {
+			label = "全选"
+		}
+		rows = append(rows, item(-1, icon, label).Bold())
+	}
+
+	for start := 0; start < count; start += cols {
+		var cells []Node
+		for i := start; i < start+cols && i < count; i++ {
+			cells = append(cells, item(i, If(checked[i], "[x]", "[ ]"), props.Options[i]))
+		}
+		if cols == 1 {
+			rows = append(rows, cells[0])
+		} else {
+			rows = append(rows, HStack(cells...))
+		}
+	}
+
+	return c.Wrap(Box(VStack(rows...)).Padding(0, 1))
+}
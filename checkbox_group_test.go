@@ -0,0 +1,78 @@
+package rego
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCheckboxGroup_SelectAll(t *testing.T) {
+	app := func(c C) Node {
+		selected := Use(c, "selected", []int{1})
+		return CheckboxGroup(c.Child("group"), CheckboxGroupProps{
+			Options:   []string{"Go", "Rust", "Zig"},
+			Selected:  selected.Val,
+			OnChanged: selected.Set,
+			SelectAll: true,
+		})
+	}
+
+	screen := newTestScreen(30, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "[-] 全选") || !strings.Contains(content, "[x] Rust") {
+		t.Fatalf("expected partial select-all header, got:\n%s", content)
+	}
+
+	// 光标在表头，Space 全选
+	tr.DispatchKey(tcell.KeyRune, ' ', 0)
+	tr.Render()
+	if !strings.Contains(getScreenContent(screen), "[x] 全选") {
+		t.Errorf("expected all selected, got:\n%s", getScreenContent(screen))
+	}
+
+	// 再次 Space 全部取消
+	tr.DispatchKey(tcell.KeyRune, ' ', 0)
+	tr.Render()
+	if !strings.Contains(getScreenContent(screen), "[ ] 全选") {
+		t.Errorf("expected none selected, got:\n%s", getScreenContent(screen))
+	}
+}
+
+func TestCheckboxGroup_ToggleColumns(t *testing.T) {
+	var got []int
+	app := func(c C) Node {
+		selected := Use(c, "selected", []int{})
+		return CheckboxGroup(c.Child("group"), CheckboxGroupProps{
+			Options:  []string{"a", "b", "c", "d"},
+			Selected: selected.Val,
+			OnChanged: func(s []int) {
+				got = s
+				selected.Set(s)
+			},
+			Columns: 2,
+		})
+	}
+
+	screen := newTestScreen(30, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 第一行 ← → 移动，↓ 移动一整行
+	tr.DispatchKey(tcell.KeyRight, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("expected [1 3], got %v", got)
+	}
+}
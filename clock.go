@@ -0,0 +1,186 @@
+package rego
+
+import (
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Clock - 可替换的时钟
+// =============================================================================
+
+// Clock 是组件获取时间和调度定时器所用的时钟
+//
+// 定时器以回调形式提供，测试中的 FakeClock 可以在推进时间时同步执行它们。
+type Clock interface {
+	Now() time.Time
+	// AfterFunc 在 d 之后调用 fn，返回的 stop 取消尚未执行的调用
+	AfterFunc(d time.Duration, fn func()) (stop func())
+	// Every 每隔 d 调用一次 fn，直到调用返回的 stop
+	Every(d time.Duration, fn func()) (stop func())
+}
+
+// realClock 基于系统时间的时钟，回调在独立的协程中执行
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) AfterFunc(d time.Duration, fn func()) func() {
+	t := time.AfterFunc(d, fn)
+	return func() { t.Stop() }
+}
+
+func (realClock) Every(d time.Duration, fn func()) func() {
+	ticker := time.NewTicker(d)
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				fn()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(stop)
+		})
+	}
+}
+
+// FakeClock 是用于测试的时钟：时间只在调用 Advance 时前进，到期的定时器在 Advance 中同步执行
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+	seq    int
+}
+
+type fakeTimer struct {
+	at       time.Time
+	interval time.Duration // 大于 0 表示周期定时器
+	fn       func()
+	seq      int // 到期时间相同时按注册顺序执行
+	stopped  bool
+}
+
+// NewFakeClock 创建一个从 start 开始的 FakeClock
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now 返回当前的模拟时间
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc 实现 Clock
+func (c *FakeClock) AfterFunc(d time.Duration, fn func()) func() {
+	return c.schedule(d, 0, fn)
+}
+
+// Every 实现 Clock，d 必须大于 0
+func (c *FakeClock) Every(d time.Duration, fn func()) func() {
+	if d <= 0 {
+		panic("rego: non-positive interval for FakeClock.Every")
+	}
+	return c.schedule(d, d, fn)
+}
+
+func (c *FakeClock) schedule(d, interval time.Duration, fn func()) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	t := &fakeTimer{at: c.now.Add(d), interval: interval, fn: fn, seq: c.seq}
+	c.timers = append(c.timers, t)
+	return func() {
+		c.mu.Lock()
+		t.stopped = true
+		c.mu.Unlock()
+	}
+}
+
+// Advance 把时间推进 d，按到期顺序同步执行期间到期的定时器；周期定时器可能执行多次
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	for {
+		next := c.nextDue(target)
+		if next == nil {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		c.now = next.at
+		if next.interval > 0 {
+			next.at = next.at.Add(next.interval)
+		} else {
+			next.stopped = true
+		}
+		c.mu.Unlock()
+		next.fn() // 回调中可能注册或取消定时器，不持有锁
+		c.mu.Lock()
+	}
+}
+
+// nextDue 返回不晚于 target 的最早到期定时器，同时清理已停止的定时器
+func (c *FakeClock) nextDue(target time.Time) *fakeTimer {
+	var next *fakeTimer
+	live := c.timers[:0]
+	for _, t := range c.timers {
+		if t.stopped {
+			continue
+		}
+		live = append(live, t)
+		if t.at.After(target) {
+			continue
+		}
+		if next == nil || t.at.Before(next.at) || (t.at.Equal(next.at) && t.seq < next.seq) {
+			next = t
+		}
+	}
+	c.timers = live
+	return next
+}
+
+// clock 返回组件所在运行时的时钟
+func (c *componentContext) clock() Clock {
+	if c.runtime != nil && c.runtime.clock != nil {
+		return c.runtime.clock
+	}
+	return realClock{}
+}
+
+// UseInterval 每隔 d 在 UI 循环中调用一次 fn 并触发刷新；d 变化时重新计时，d <= 0 时停止
+func UseInterval(c C, d time.Duration, fn func()) {
+	ctx := c.(*componentContext)
+	handler := UseRef(c, fn)
+	handler.Current = fn // 始终调用最新一次渲染传入的回调
+
+	UseEffect(c, func() func() {
+		if d <= 0 {
+			return nil
+		}
+		return ctx.clock().Every(d, func() {
+			if ctx.runtime != nil {
+				ctx.runtime.post(func() { handler.Current() })
+			} else {
+				handler.Current()
+			}
+		})
+	}, d)
+}
+
+// AdvanceTime 推进运行时的 FakeClock（通过 Options.Clock 传入），同步执行到期的定时器；
+// 定时器投递到 UI 循环的任务在下一次 Render 时执行。使用系统时钟时不做任何事
+func (r *Runtime) AdvanceTime(d time.Duration) {
+	if fc, ok := r.clock.(*FakeClock); ok {
+		fc.Advance(d)
+	}
+}
@@ -0,0 +1,93 @@
+package rego
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withFakeClock 让测试运行时使用 FakeClock，定时器只随 AdvanceTime 触发
+func withFakeClock() Options {
+	return Options{Clock: NewFakeClock(time.Now())}
+}
+
+func TestFakeClock_Advance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	var fired []string
+	clock.AfterFunc(300*time.Millisecond, func() { fired = append(fired, "timeout@"+clock.Now().Sub(start).String()) })
+	stopTick := clock.Every(100*time.Millisecond, func() { fired = append(fired, "tick@"+clock.Now().Sub(start).String()) })
+	stopped := clock.AfterFunc(50*time.Millisecond, func() { fired = append(fired, "cancelled") })
+	stopped()
+
+	clock.Advance(250 * time.Millisecond)
+	want := []string{"tick@100ms", "tick@200ms"}
+	if !reflect.DeepEqual(fired, want) {
+		t.Fatalf("after 250ms fired %v, want %v", fired, want)
+	}
+	if got := clock.Now().Sub(start); got != 250*time.Millisecond {
+		t.Errorf("Now() advanced %v, want 250ms", got)
+	}
+
+	// 同一时刻到期时按注册顺序执行
+	clock.Advance(50 * time.Millisecond)
+	want = append(want, "timeout@300ms", "tick@300ms")
+	if !reflect.DeepEqual(fired, want) {
+		t.Fatalf("after 300ms fired %v, want %v", fired, want)
+	}
+
+	stopTick()
+	clock.Advance(time.Second)
+	if len(fired) != len(want) {
+		t.Errorf("expected no timers after stop, got %v", fired)
+	}
+}
+
+func TestSpinner_AdvanceTime(t *testing.T) {
+	app := func(c C) Node {
+		return Spinner(c.Child("spinner"), "loading")
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+	if content := getScreenContent(screen); !strings.HasPrefix(content, spinnerFrames[0]) {
+		t.Fatalf("expected first frame, got %q", content)
+	}
+
+	tr.AdvanceTime(250 * time.Millisecond)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.HasPrefix(content, spinnerFrames[2]) {
+		t.Errorf("expected third frame after 250ms, got %q", content)
+	}
+}
+
+func TestUseInterval(t *testing.T) {
+	interval := 100 * time.Millisecond
+	app := func(c C) Node {
+		count := Use(c, "count", 0)
+		UseInterval(c, interval, func() { count.Set(count.Val + 1) })
+		return Text(fmt.Sprintf("count=%d", count.Val))
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+	tr.AdvanceTime(300 * time.Millisecond)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "count=3") {
+		t.Fatalf("expected 3 ticks, got %q", content)
+	}
+
+	// 间隔变化后重新计时，<= 0 时停止
+	interval = 0
+	tr.Render()
+	tr.AdvanceTime(time.Second)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "count=3") {
+		t.Errorf("expected interval stopped, got %q", content)
+	}
+}
@@ -0,0 +1,76 @@
+package rego
+
+import (
+	"os"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// ColorMode - 高对比度 / 单色渲染
+// =============================================================================
+
+// ColorMode 控制渲染时如何处理颜色
+type ColorMode int
+
+const (
+	// ColorAuto 正常彩色渲染；设置了 NO_COLOR 环境变量时按 ColorMonochrome 处理
+	ColorAuto ColorMode = iota
+	// ColorNormal 始终彩色渲染，忽略 NO_COLOR
+	ColorNormal
+	// ColorHighContrast 去掉暗淡效果，前景色与背景色拉开亮度差
+	ColorHighContrast
+	// ColorMonochrome 不输出任何颜色，用反色和粗体代替背景色与强调色
+	ColorMonochrome
+)
+
+// resolve 返回实际生效的模式
+func (m ColorMode) resolve() ColorMode {
+	if m == ColorAuto {
+		if os.Getenv("NO_COLOR") != "" {
+			return ColorMonochrome
+		}
+		return ColorNormal
+	}
+	return m
+}
+
+// filter 按模式转换单元格样式
+func (m ColorMode) filter(style tcell.Style) tcell.Style {
+	switch m {
+	case ColorMonochrome:
+		fg, bg, _ := style.Decompose()
+		style = style.Foreground(tcell.ColorDefault).Background(tcell.ColorDefault)
+		if bg != tcell.ColorDefault {
+			// 选中、聚焦等以背景色表达的状态改用反色
+			style = style.Reverse(true)
+		} else if fg != tcell.ColorDefault && fg != tcell.ColorGray && fg != tcell.ColorWhite {
+			// 强调色改用粗体，灰色等次要文字保持普通
+			style = style.Bold(true)
+		}
+		return style
+	case ColorHighContrast:
+		fg, bg, _ := style.Decompose()
+		style = style.Dim(false)
+		if bg != tcell.ColorDefault {
+			if luminance(bg) > 0.5 {
+				return style.Foreground(tcell.ColorBlack)
+			}
+			return style.Foreground(tcell.ColorWhite)
+		}
+		if fg != tcell.ColorDefault && luminance(fg) < 0.6 {
+			return style.Foreground(tcell.ColorWhite).Bold(true)
+		}
+		return style
+	}
+	return style
+}
+
+// luminance 返回颜色的相对亮度（0~1），无法取得 RGB 时按暗色处理
+func luminance(c tcell.Color) float64 {
+	r, g, b := c.RGB()
+	if r < 0 {
+		return 0
+	}
+	return (0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)) / 255
+}
@@ -0,0 +1,54 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestColorMode_Resolve(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if m := ColorAuto.resolve(); m != ColorMonochrome {
+		t.Errorf("expected NO_COLOR to select monochrome, got %v", m)
+	}
+	if m := ColorNormal.resolve(); m != ColorNormal {
+		t.Errorf("expected explicit ColorNormal to ignore NO_COLOR, got %v", m)
+	}
+}
+
+func TestColorMode_Render(t *testing.T) {
+	app := func(c C) Node {
+		return VStack(
+			Text("sel").Background(Green).Color(Black),
+			Text("hint").Color(Gray).Dim(),
+		)
+	}
+
+	cases := []struct {
+		mode      ColorMode
+		check     func(sel, hint tcell.Style) bool
+		describes string
+	}{
+		{ColorMonochrome, func(sel, hint tcell.Style) bool {
+			fg, bg, attr := sel.Decompose()
+			_, hbg, _ := hint.Decompose()
+			return fg == tcell.ColorDefault && bg == tcell.ColorDefault && attr&tcell.AttrReverse != 0 && hbg == tcell.ColorDefault
+		}, "background replaced by reverse video"},
+		{ColorHighContrast, func(sel, hint tcell.Style) bool {
+			fg, _, attr := hint.Decompose()
+			sfg, _, _ := sel.Decompose()
+			return attr&tcell.AttrDim == 0 && fg == tcell.ColorWhite && sfg == tcell.ColorWhite
+		}, "dim removed and muted text brightened"},
+	}
+	for _, tc := range cases {
+		screen := newTestScreen(10, 2)
+		tr := NewTestRuntime(app, screen)
+		tr.apply(Options{ColorMode: tc.mode})
+		tr.Render()
+		_, _, sel, _ := screen.GetContent(0, 0)
+		_, _, hint, _ := screen.GetContent(0, 1)
+		if !tc.check(sel, hint) {
+			t.Errorf("mode %v: expected %s, got sel=%v hint=%v", tc.mode, tc.describes, sel, hint)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package rego
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// Columns - 自动平衡的多列布局（瀑布流）
+// =============================================================================
+
+type columnsNode struct {
+	children []Node
+	n        int
+	gap      int
+	minWidth int
+	measure  *measurePool
+}
+
+// Columns 把 children 分到 n 列中：按顺序把每个子节点放进当前最矮的一列，使各列总高度尽量接近，
+// 适合卡片墙、设置页等高度不一的内容
+//
+//	rego.Columns(3, cards...).Gap(1).MinColumnWidth(24)
+func Columns(n int, children ...Node) *columnsNode {
+	return &columnsNode{children: children, n: max(n, 1)}
+}
+
+// Gap 设置列之间以及同一列中子节点之间的间距
+func (c *columnsNode) Gap(g int) *columnsNode {
+	c.gap = g
+	return c
+}
+
+// MinColumnWidth 设置每列的最小宽度，宽度不够时减少列数
+func (c *columnsNode) MinColumnWidth(w int) *columnsNode {
+	c.minWidth = w
+	return c
+}
+
+// columnWidth 返回给定总宽度下实际的列数和每列宽度
+func (c *columnsNode) columnWidth(width int) (int, int) {
+	n := c.n
+	for n > 1 && (width-c.gap*(n-1))/n < max(c.minWidth, 1) {
+		n--
+	}
+	return n, max((width-c.gap*(n-1))/n, 0)
+}
+
+// layout 返回每列中子节点的下标及其高度
+func (c *columnsNode) layout(width int) (cols [][]int, heights []int, colW int) {
+	n, colW := c.columnWidth(width)
+	cols = make([][]int, n)
+	totals := make([]int, n)
+	heights = make([]int, len(c.children))
+	c.measure.each(len(c.children), func(i int) {
+		if c.children[i] != nil {
+			heights[i] = measureNodeHeight(c.children[i], colW)
+		}
+	})
+	for i, child := range c.children {
+		if child == nil {
+			continue
+		}
+		shortest := 0
+		for k := 1; k < n; k++ {
+			if totals[k] < totals[shortest] {
+				shortest = k
+			}
+		}
+		if len(cols[shortest]) > 0 {
+			totals[shortest] += c.gap
+		}
+		totals[shortest] += heights[i]
+		cols[shortest] = append(cols[shortest], i)
+	}
+	return cols, heights, colW
+}
+
+func (c *columnsNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	cols, heights, colW := c.layout(width)
+	used := 0
+	for k, col := range cols {
+		cx := x + k*(colW+c.gap)
+		cy := y
+		for j, i := range col {
+			if j > 0 {
+				cy += c.gap
+			}
+			if cy >= y+height {
+				break
+			}
+			h := min(heights[i], y+height-cy)
+			c.children[i].render(screen, cx, cy, colW, h)
+			cy += heights[i]
+		}
+		used = max(used, min(cy-y, height))
+	}
+	return used
+}
+
+func (c *columnsNode) measureHeight(width int) int {
+	cols, heights, _ := c.layout(width)
+	tallest := 0
+	for _, col := range cols {
+		total := 0
+		for j, i := range col {
+			if j > 0 {
+				total += c.gap
+			}
+			total += heights[i]
+		}
+		tallest = max(tallest, total)
+	}
+	return tallest
+}
+
+func (c *columnsNode) measureWidth() int {
+	w := 0
+	for _, child := range c.children {
+		if child != nil {
+			w = max(w, (&hstackNode{}).measureWidth(child))
+		}
+	}
+	return c.n*max(w, c.minWidth) + c.gap*(c.n-1)
+}
@@ -0,0 +1,44 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumns_BalancesHeights(t *testing.T) {
+	card := func(name string, lines int) Node {
+		rows := []Node{Text(name)}
+		for i := 1; i < lines; i++ {
+			rows = append(rows, Text(strings.Repeat(".", i)))
+		}
+		return VStack(rows...)
+	}
+	node := Columns(2, card("tall", 4), card("b", 1), card("c", 1), card("d", 1)).Gap(1)
+
+	screen := newTestScreen(11, 5)
+	tr := NewTestRuntime(func(c C) Node { return node }, screen)
+	tr.Render()
+
+	want := strings.Join([]string{
+		"tall  b",
+		".",
+		"..    c",
+		"...",
+		"      d",
+	}, "\n")
+	lines := strings.Split(getScreenContent(screen), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	if got := strings.Join(lines, "\n"); got != want {
+		t.Errorf("unexpected layout:\n%s\nwant:\n%s", got, want)
+	}
+	if h := node.measureHeight(11); h != 5 {
+		t.Errorf("measureHeight = %d, want 5", h)
+	}
+
+	// 宽度不够时减少列数
+	if n, w := Columns(3).MinColumnWidth(8).columnWidth(20); n != 2 || w != 10 {
+		t.Errorf("columnWidth = %d, %d, want 2, 10", n, w)
+	}
+}
@@ -0,0 +1,171 @@
+package rego
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// 配置文件读取（键位、主题等）
+// =============================================================================
+
+// readConfig 读取 .json 或 .toml 配置文件，返回以表名嵌套的 map
+//
+// TOML 只支持配置所需的子集：[table] 表头、字符串、整数、布尔值、字符串数组与 # 注释。
+func readConfig(path string) (map[string]any, error) {
+	path = expandHome(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseConfig(path, data)
+}
+
+// parseConfig 按扩展名解析配置内容，name 用于错误信息
+func parseConfig(name string, data []byte) (map[string]any, error) {
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		var out map[string]any
+		if err := json.Unmarshal(data, &out); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return out, nil
+	}
+	out, err := parseTOML(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", name, err)
+	}
+	return out, nil
+}
+
+// expandHome 将开头的 ~ 展开为用户主目录
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// parseTOML 解析 TOML 子集，错误信息以 "行号: " 开头
+func parseTOML(src string) (map[string]any, error) {
+	root := map[string]any{}
+	table := root
+	for i, raw := range strings.Split(src, "\n") {
+		line := strings.TrimSpace(stripTOMLComment(raw))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%d: invalid table header %q", i+1, line)
+			}
+			table = root
+			for _, name := range strings.Split(strings.Trim(line, "[]"), ".") {
+				name = strings.TrimSpace(name)
+				sub, ok := table[name].(map[string]any)
+				if !ok {
+					sub = map[string]any{}
+					table[name] = sub
+				}
+				table = sub
+			}
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%d: expected key = value", i+1)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		v, err := parseTOMLValue(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("%d: %s: %w", i+1, key, err)
+		}
+		table[key] = v
+	}
+	return root, nil
+}
+
+func parseTOMLValue(s string) (any, error) {
+	switch {
+	case strings.HasPrefix(s, "["):
+		if !strings.HasSuffix(s, "]") {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		var out []any
+		for _, item := range splitTOMLArray(s[1 : len(s)-1]) {
+			v, err := parseTOMLValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case strings.HasPrefix(s, `"`):
+		return strconv.Unquote(s)
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		return s[1 : len(s)-1], nil
+	case s == "true" || s == "false":
+		return s == "true", nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	return nil, fmt.Errorf("unsupported value %q", s)
+}
+
+// splitTOMLArray 按逗号拆分数组元素，忽略字符串中的逗号
+func splitTOMLArray(s string) []string {
+	var items []string
+	var quote rune
+	start := 0
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote && (quote == '\'' || i == 0 || s[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	items = append(items, s[start:])
+
+	out := items[:0]
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// stripTOMLComment 去掉字符串之外的 # 注释
+func stripTOMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote && (quote == '\'' || line[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
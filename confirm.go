@@ -0,0 +1,225 @@
+package rego
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// UseConfirm - 确认框
+// =============================================================================
+
+// confirmMaxWidth 确认框的最大宽度
+const confirmMaxWidth = 50
+
+// ConfirmProps 是确认框的内容
+type ConfirmProps struct {
+	Title        string
+	Body         string
+	Danger       bool   // 危险操作：使用主题的错误色，默认选中取消
+	ConfirmLabel string // 确认按钮的文字，默认 "确定"
+	CancelLabel  string // 取消按钮的文字，默认 "取消"
+}
+
+// Confirm 是 UseConfirm 返回的确认框
+type Confirm struct {
+	ctx   *componentContext
+	mu    sync.Mutex
+	theme Theme
+}
+
+// confirmDialog 一个等待回答的确认框
+type confirmDialog struct {
+	props ConfirmProps
+	theme Theme
+	owner *Confirm
+	yes   bool // 当前选中确认按钮
+	ch    chan bool
+}
+
+// confirmStore 保存运行时中等待回答的确认框，同一时间只显示最早的一个
+type confirmStore struct {
+	mu    sync.Mutex
+	queue []*confirmDialog
+}
+
+// UseConfirm 返回一个确认框，Show 以模态浮层显示并在回答后通过通道返回结果
+//
+// 确认框打开期间按键和鼠标只交给确认框：y 确认，n/Esc 取消，←/→/Tab 切换按钮，Enter 选择。
+// 运行时退出时尚未回答的确认框按取消处理。
+//
+//	confirm := rego.UseConfirm(c)
+//	go func() {
+//		if <-confirm.Show(rego.ConfirmProps{Title: "删除待办？", Body: todo.Title, Danger: true}) {
+//			remove(todo.ID)
+//		}
+//	}()
+func UseConfirm(c C) *Confirm {
+	ctx := c.(*componentContext)
+	theme := UseTheme(c)
+	cf := UseRef(c, &Confirm{ctx: ctx}).Current
+	cf.mu.Lock()
+	cf.theme = theme
+	cf.mu.Unlock()
+
+	// 退出时清理
+	UseEffect(c, func() func() {
+		return func() {
+			if ctx.runtime != nil {
+				ctx.runtime.confirms.cancel(cf)
+			}
+		}
+	})
+	return cf
+}
+
+// Show 显示确认框，返回的通道在回答后收到 true（确认）或 false（取消），可以在任意协程中调用
+func (cf *Confirm) Show(props ConfirmProps) <-chan bool {
+	ch := make(chan bool, 1)
+	r := cf.ctx.runtime
+	if r == nil {
+		ch <- false
+		return ch
+	}
+	cf.mu.Lock()
+	theme := cf.theme
+	cf.mu.Unlock()
+
+	r.confirms.mu.Lock()
+	r.confirms.queue = append(r.confirms.queue, &confirmDialog{props: props, theme: theme, owner: cf, yes: !props.Danger, ch: ch})
+	r.confirms.mu.Unlock()
+	r.scheduleRefresh()
+	return ch
+}
+
+// active 返回正在显示的确认框
+func (s *confirmStore) active() *confirmDialog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) == 0 {
+		return nil
+	}
+	return s.queue[0]
+}
+
+// answer 关闭确认框并发送结果
+func (s *confirmStore) answer(d *confirmDialog, yes bool) {
+	s.mu.Lock()
+	for i, q := range s.queue {
+		if q == d {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			d.ch <- yes
+			break
+		}
+	}
+	s.mu.Unlock()
+}
+
+// cancel 以取消回答 owner 的所有确认框
+func (s *confirmStore) cancel(owner *Confirm) {
+	s.mu.Lock()
+	kept := s.queue[:0]
+	for _, d := range s.queue {
+		if d.owner == owner {
+			d.ch <- false
+			continue
+		}
+		kept = append(kept, d)
+	}
+	s.queue = kept
+	s.mu.Unlock()
+}
+
+// handleKey 处理确认框打开期间的按键
+func (s *confirmStore) handleKey(key Key, r rune) {
+	d := s.active()
+	if d == nil {
+		return
+	}
+	switch {
+	case r == 'y' || r == 'Y':
+		s.answer(d, true)
+	case r == 'n' || r == 'N' || key == KeyEsc:
+		s.answer(d, false)
+	case key == KeyLeft || key == KeyRight || key == KeyTab:
+		s.mu.Lock()
+		d.yes = !d.yes
+		s.mu.Unlock()
+	case key == KeyEnter:
+		s.answer(d, d.yes)
+	}
+}
+
+// addDialog 把正在显示的确认框作为浮层放在屏幕中央
+func (s *confirmStore) addDialog(r *Runtime, screenW, screenH int) {
+	d := s.active()
+	if d == nil {
+		return
+	}
+	s.mu.Lock()
+	node := &confirmNode{box: d.box(func(yes bool) { s.answer(d, yes) })}
+	s.mu.Unlock()
+
+	w := min(confirmMaxWidth, screenW-4)
+	if w <= 0 {
+		return
+	}
+	h := min(measureNodeHeight(node.box, w), screenH)
+	r.addOverlay(node, (screenW-w)/2, max((screenH-h)/2, 0), w, h)
+}
+
+// box 构造确认框的内容，choose 在点击按钮时调用
+func (d *confirmDialog) box(choose func(yes bool)) Node {
+	color := If(d.props.Danger, d.theme.Error, d.theme.Primary)
+	title := d.props.Title
+	if d.props.Danger {
+		title = "⚠ " + title
+	}
+	button := func(label string, selected bool, yes bool) Node {
+		t := Text(" " + label + " ").OnClick(func() { choose(yes) })
+		if selected {
+			return t.Background(If(yes, color, d.theme.Muted)).Color(d.theme.OnColor).Bold()
+		}
+		return t.Color(d.theme.Muted)
+	}
+	cancel := d.props.CancelLabel
+	if cancel == "" {
+		cancel = "取消"
+	}
+	ok := d.props.ConfirmLabel
+	if ok == "" {
+		ok = "确定"
+	}
+	border := d.theme.BorderStyle
+	if border == BorderNone {
+		border = BorderRounded
+	}
+	return Box(VStack(
+		Text(title).Bold().Color(color),
+		When(d.props.Body != "", Text(d.props.Body).Wrap(true)),
+		Text(""),
+		HStack(Spacer(), button(cancel, !d.yes, false), Text(" "), button(ok, d.yes, true)),
+	)).Border(border).BorderColor(color).Padding(0, 1)
+}
+
+// confirmNode 确认框浮层：隐藏光标，并且只有确认框内的区域可以点击
+type confirmNode struct {
+	box Node
+}
+
+func (n *confirmNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if rt := screenRuntime(screen); rt != nil {
+		rt.clickFloor = len(rt.clickRegions)
+	}
+	screen.HideCursor()
+	return n.box.render(screen, x, y, width, height)
+}
+
+func (n *confirmNode) measureHeight(width int) int {
+	return measureNodeHeight(n.box, width)
+}
+
+func (n *confirmNode) measureWidth() int {
+	return (&hstackNode{}).measureWidth(n.box)
+}
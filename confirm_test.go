@@ -0,0 +1,108 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestUseConfirm_KeysAreModal(t *testing.T) {
+	var confirm *Confirm
+	keys := 0
+	app := func(c C) Node {
+		confirm = UseConfirm(c)
+		UseKey(c, func(Key, rune) { keys++ })
+		return Text("main")
+	}
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	ch := confirm.Show(ConfirmProps{Title: "删除文件？", Body: "a.txt", Danger: true, ConfirmLabel: "删除"})
+	tr.Render()
+	content := getScreenContent(screen)
+	for _, want := range []string{"⚠ 删除文件？", "a.txt", "取消", "删除"} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in dialog, got:\n%s", want, content)
+		}
+	}
+
+	// 危险操作默认选中取消；按键不会传给组件
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	if answer(t, ch) {
+		t.Error("expected Enter on default button to cancel a dangerous action")
+	}
+	if keys != 0 {
+		t.Errorf("component received %d keys while dialog was open", keys)
+	}
+	if strings.Contains(getScreenContent(screen), "a.txt") {
+		t.Error("expected dialog to close after answering")
+	}
+
+	ch = confirm.Show(ConfirmProps{Title: "继续？"})
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, 'y', 0)
+	if !answer(t, ch) {
+		t.Error("expected y to confirm")
+	}
+	tr.DispatchKey(tcell.KeyRune, 'y', 0)
+	if keys != 1 {
+		t.Errorf("keys = %d, want 1 after dialog closed", keys)
+	}
+}
+
+func TestUseConfirm_Click(t *testing.T) {
+	var confirm *Confirm
+	clicked := false
+	app := func(c C) Node {
+		confirm = UseConfirm(c)
+		return Text("under").OnClick(func() { clicked = true })
+	}
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	ch := confirm.Show(ConfirmProps{Title: "确认？", ConfirmLabel: "好"})
+	tr.Render()
+
+	// 点击确认框以外的区域没有效果
+	mouseClick(tr, 0, 0)
+	tr.Render()
+	if clicked {
+		t.Error("click reached content under the dialog")
+	}
+
+	x, y := findText(screen, "好")
+	if x < 0 {
+		t.Fatalf("confirm button not found:\n%s", getScreenContent(screen))
+	}
+	mouseClick(tr, x, y)
+	if !answer(t, ch) {
+		t.Error("expected clicking the confirm button to confirm")
+	}
+}
+
+// answer 返回确认框的结果，尚未回答时测试失败
+func answer(t *testing.T, ch <-chan bool) bool {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	default:
+		t.Fatal("dialog has not been answered")
+		return false
+	}
+}
+
+// findText 返回 s 在屏幕上第一次出现的位置，找不到时返回 -1
+func findText(screen tcell.SimulationScreen, s string) (int, int) {
+	for y, row := range strings.Split(getScreenContent(screen), "\n") {
+		if i := strings.Index(row, s); i >= 0 {
+			return runewidth.StringWidth(row[:i]), y
+		}
+	}
+	return -1, -1
+}
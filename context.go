@@ -2,7 +2,9 @@ package rego
 
 import (
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // Rect 表示屏幕上的矩形区域
@@ -15,6 +17,16 @@ func (r Rect) Contains(x, y int) bool {
 	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
 }
 
+// Intersect 返回两个矩形的交集，不相交时返回空矩形
+func (r Rect) Intersect(o Rect) Rect {
+	x1, y1 := max(r.X, o.X), max(r.Y, o.Y)
+	x2, y2 := min(r.X+r.W, o.X+o.W), min(r.Y+r.H, o.Y+o.H)
+	if x2 <= x1 || y2 <= y1 {
+		return Rect{}
+	}
+	return Rect{X: x1, Y: y1, W: x2 - x1, H: y2 - y1}
+}
+
 // MouseEventType 鼠标事件类型
 type MouseEventType int
 
@@ -25,6 +37,8 @@ const (
 	MouseEventMove
 	MouseEventScrollUp
 	MouseEventScrollDown
+	MouseEventScrollLeft
+	MouseEventScrollRight
 )
 
 // MouseButton 鼠标按钮
@@ -42,6 +56,17 @@ type MouseEvent struct {
 	X, Y   int
 	Button MouseButton
 	Type   MouseEventType
+	Mod    Modifiers // 事件发生时按下的修饰键
+
+	// LocalX, LocalY 相对于接收事件的组件区域（c.Rect()）左上角的坐标，事件在区域外时可能为负或超出宽高
+	LocalX, LocalY int
+	// Hit 事件落在组件的可见区域内且没有被浮动窗口遮挡；为 false 时除 Release 外的事件都以 Move 送达
+	Hit bool
+}
+
+// In 检查事件是否落在 r 内，并返回相对于 r 左上角的坐标，用于组件内部各部分的点击判断
+func (ev MouseEvent) In(r Rect) (x, y int, ok bool) {
+	return ev.X - r.X, ev.Y - r.Y, r.Contains(ev.X, ev.Y)
 }
 
 // C 是组件上下文接口
@@ -58,11 +83,32 @@ type C interface {
 	// SetCursor 设置光标位置（用于 IME 输入定位）
 	SetCursor(x, y int)
 
+	// HideCursor 本帧不显示该组件及其子组件中的光标
+	HideCursor()
+
 	// Wrap 包装节点以追踪其位置（用于鼠标点击）
 	Wrap(node Node) *componentNode
 
 	// Rect 获取当前组件的屏幕区域
 	Rect() Rect
+
+	// WrapContent 标记组件的内容节点（如输入框去掉边框和标签后的文本区域），渲染时记录其位置
+	WrapContent(node Node) *componentNode
+
+	// ContentRect 获取上一帧 WrapContent 标记的节点的屏幕区域，位于 ScrollBox 内时已计入滚动偏移
+	ContentRect() Rect
+
+	// SetTitle 设置终端窗口标题
+	SetTitle(title string)
+
+	// Bell 响铃；传入 message 时同时通过 OSC 777 发送桌面通知（终端不在前台时提醒用户）
+	Bell(message ...string)
+
+	// CaptureScreen 返回上一帧渲染到屏幕上的文字（去掉行尾空白），可用于导出对话或附在问题报告中
+	CaptureScreen() string
+
+	// CaptureScreenANSI 与 CaptureScreen 相同，但用 ANSI 转义序列保留颜色和样式
+	CaptureScreenANSI() string
 }
 
 // =============================================================================
@@ -75,7 +121,9 @@ type componentContext struct {
 	children map[string]*componentContext
 
 	// 布局追踪
-	rect Rect
+	rect        Rect
+	visible     Rect // rect 在屏幕上实际可见的部分（位于 ScrollBox 内时会被视口裁切）
+	contentRect Rect // WrapContent 标记的内容区域
 
 	// 状态存储
 	states map[string]any
@@ -93,11 +141,43 @@ type componentContext struct {
 	memoIndex int
 
 	// Context 值存储
-	contextValues map[string]any
+	contextValues map[string]contextEntry
+
+	// 最近一次执行所在的帧，本帧是否有 UseAsync 仍在加载，以及是否调用了 Suspense
+	// （其结果取决于后代的加载状态，后代变化时需要重新执行）
+	seenFrame    uint64
+	asyncLoading bool
+	readsSubtree bool
+
+	// 组件函数累计执行的次数（同一轮构建中多次获取上下文只计一次）
+	renders    int
+	renderPass uint64
+
+	// 上次执行后自身（dirty）或后代（childDirty）是否调用过 Refresh / State.Set，
+	// Memo 据此判断能否复用上次的节点
+	dirty      atomic.Bool
+	childDirty atomic.Bool
+	cached     *memoCache
+
+	// 获得焦点时朗读的名称
+	a11yName string
+
+	// 获得焦点时的光标样式，nil 表示使用运行时默认值
+	cursorStyle *CursorStyle
+
+	// 获得焦点时自己处理 Tab（Shift+Tab 仍用于切换焦点）
+	capturesTab bool
+
+	// 本帧调用了 HideCursor
+	cursorHidden bool
+
+	// 获得焦点时接收文字输入（输入框等），此时单字符的全局热键不触发
+	acceptsText bool
 
 	// 事件处理器
 	keyHandler   func(Key, rune)
 	mouseHandler func(MouseEvent)
+	shortcuts    []shortcut
 
 	// 运行时引用
 	runtime *Runtime
@@ -124,17 +204,26 @@ func (c *componentContext) Child(key string, index ...int) C {
 		fullKey = fmt.Sprintf("%s[%d]", key, index[0])
 	}
 
-	if child, ok := c.children[fullKey]; ok {
-		child.reset()
-		return child
+	child := c.childContext(fullKey)
+	child.reset()
+	if c.runtime != nil {
+		c.runtime.frameTrace.enterBuild(child)
 	}
+	return child
+}
 
-	child := newComponentContext(fullKey, c, c.runtime)
-	c.children[fullKey] = child
+// childContext 获取或创建子组件上下文，不重置其状态
+func (c *componentContext) childContext(key string) *componentContext {
+	if child, ok := c.children[key]; ok {
+		return child
+	}
+	child := newComponentContext(key, c, c.runtime)
+	c.children[key] = child
 	return child
 }
 
 func (c *componentContext) Refresh() {
+	c.markDirty()
 	if c.runtime != nil {
 		c.runtime.scheduleRefresh()
 	}
@@ -142,17 +231,48 @@ func (c *componentContext) Refresh() {
 
 func (c *componentContext) Quit() {
 	if c.runtime != nil {
-		c.runtime.quit()
+		c.runtime.requestQuit()
 	}
 }
 
 func (c *componentContext) SetCursor(x, y int) {
 	if c.runtime != nil {
-		c.runtime.setCursor(x, y)
+		c.runtime.setCursorFrom(c, x, y)
+	}
+}
+
+func (c *componentContext) HideCursor() {
+	c.cursorHidden = true
+}
+
+// cursorAllowed 报告该组件及其祖先本帧都没有调用 HideCursor
+func (c *componentContext) cursorAllowed() bool {
+	for p := c; p != nil; p = p.parent {
+		if p.cursorHidden {
+			return false
+		}
 	}
+	return true
+}
+
+// withinFocus 报告该组件或它的某个祖先是当前聚焦的组件
+func (c *componentContext) withinFocus() bool {
+	if c.runtime == nil || c.runtime.focusManager == nil {
+		return false
+	}
+	focused := c.runtime.focusManager.CurrentContext()
+	for p := c; p != nil && focused != nil; p = p.parent {
+		if p == focused {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *componentContext) Wrap(node Node) *componentNode {
+	if c.runtime != nil {
+		c.runtime.frameTrace.wrapBuild(c)
+	}
 	return &componentNode{ctx: c, node: node}
 }
 
@@ -160,13 +280,57 @@ func (c *componentContext) Rect() Rect {
 	return c.rect
 }
 
+func (c *componentContext) WrapContent(node Node) *componentNode {
+	return &componentNode{ctx: c, node: node, content: true}
+}
+
+func (c *componentContext) ContentRect() Rect {
+	return c.contentRect
+}
+
+// keyMods 返回正在处理的按键的修饰键
+func (c *componentContext) keyMods() Modifiers {
+	if c.runtime == nil {
+		return ModNone
+	}
+	return c.runtime.keyMods
+}
+
+func (c *componentContext) SetTitle(title string) {
+	if c.runtime != nil {
+		c.runtime.setTitle(title)
+	}
+}
+
+func (c *componentContext) Bell(message ...string) {
+	if c.runtime != nil {
+		c.runtime.bell(strings.Join(message, " "))
+	}
+}
+
 // reset 重置组件状态索引（每次渲染前调用）
 func (c *componentContext) reset() {
+	if c.runtime != nil {
+		c.seenFrame = c.runtime.frame
+		if c.renderPass != c.runtime.buildPass {
+			c.renderPass = c.runtime.buildPass
+			c.renders++
+		}
+	}
+	c.asyncLoading = false
+	c.readsSubtree = false
+	c.dirty.Store(false)
+	c.childDirty.Store(false)
 	c.effectIndex = 0
 	c.refIndex = 0
 	c.memoIndex = 0
 	c.keyHandler = nil
 	c.mouseHandler = nil
+	c.shortcuts = c.shortcuts[:0]
+	c.cursorStyle = nil
+	c.capturesTab = false
+	c.cursorHidden = false
+	c.acceptsText = false
 }
 
 // getState 获取状态值
@@ -218,8 +382,18 @@ func (c *componentContext) setMemoSlot(key int, slot *memoSlot) {
 	c.memos[key] = slot
 }
 
+// stale 报告组件在最近一帧中没有渲染（如切走的标签页、离开的路由页面），
+// 这样的子树保留着上次的区域与 handler，不应再收到事件
+func (c *componentContext) stale() bool {
+	return c.runtime != nil && c.seenFrame != c.runtime.frame
+}
+
 // dispatchKeyEvent 分发键盘事件（广播模式：所有 handler 都会收到）
 func (c *componentContext) dispatchKeyEvent(key Key, r rune) {
+	if c.stale() {
+		return
+	}
+
 	// 1. 自己先处理（父组件优先，处理全局快捷键如 Tab）
 	if c.keyHandler != nil {
 		c.keyHandler(key, r)
@@ -233,14 +407,27 @@ func (c *componentContext) dispatchKeyEvent(key Key, r rune) {
 
 // dispatchMouseEvent 分发鼠标事件
 func (c *componentContext) dispatchMouseEvent(ev MouseEvent) {
-	// 检查事件是否落在自己的矩形区域内
-	inRect := c.rect.Contains(ev.X, ev.Y)
+	if c.stale() {
+		return
+	}
+
+	// 检查事件是否落在自己的可见区域内；点击还要求按下的位置也在区域内
+	inRect := c.visible.Contains(ev.X, ev.Y)
+	if ev.Type == MouseEventClick && c.runtime != nil && !c.runtime.pressedIn(c.visible) {
+		inRect = false
+	}
+	// 被浮动窗口覆盖的位置只交给窗口内的组件
+	if inRect && c.runtime != nil && !c.runtime.windows.reachable(c, ev.X, ev.Y) {
+		inRect = false
+	}
 
 	if c.mouseHandler != nil {
 		// 即使不在区域内也发送事件，让 handler 自己决定是否处理 (比如用于 MouseLeave)
 		// 但为了方便，我们可以在 ev 中标记是否在区域内
 		evIn := ev
-		if !inRect {
+		evIn.LocalX, evIn.LocalY, _ = ev.In(c.rect)
+		evIn.Hit = inRect
+		if !inRect && ev.Type != MouseEventRelease { // 松开总是原样送达，便于拖动的组件结束拖动
 			// 如果不在区域内，且类型是 Move，这通常意味着 MouseLeave
 			// 或者简单的“非我区域的移动”
 			evIn.Type = MouseEventMove
@@ -1,6 +1,9 @@
 package rego
 
-import "sync"
+import (
+	"reflect"
+	"sync"
+)
 
 // =============================================================================
 // Context - 跨组件状态共享
@@ -74,18 +77,54 @@ func (ctx *Context[T]) ProvideH(c C, value T, children ...Node) Node {
 
 // UseContext 获取 Context 的值
 // 从当前组件向上查找，直到找到 Provider 或返回默认值
+//
+// 组件可能先于祖先的 Provide 执行，此时读到的是上一帧提供的值；
+// 本帧 Provide 的值有变化时运行时会再执行一遍组件树，因此结果与渲染顺序无关。
+// 副作用在组件树执行完、Context 值一致后才运行，不会看到旧的值。
 func UseContext[T any](c C, ctx *Context[T]) T {
+	return useContext(c, ctx, nil).(T)
+}
+
+// UseContextSelector 获取 Context 值中组件真正关心的部分
+//
+// 读取只按 selector 的结果记录：Memo 子树中的组件用它读取时，只有选出的部分变化才会使子树重新执行，
+// 无关字段的变化直接复用上一次的节点。结果与上一次渲染深度相等时返回上一次的结果，
+// 以它作为 UseMemo/UseEffect 的依赖时同样不会导致重新计算。
+func UseContextSelector[T any, U any](c C, ctx *Context[T], selector func(T) U) U {
+	selected := useContext(c, ctx, func(v any) any { return selector(v.(T)) }).(U)
+	prev := UseRef(c, selected)
+	if !reflect.DeepEqual(prev.Current, selected) {
+		prev.Current = selected
+	}
+	return prev.Current
+}
+
+// useContext 查找 Context 值并记录这次读取，selector 非空时返回并记录选出的部分
+func useContext[T any](c C, ctx *Context[T], selector func(any) any) any {
 	cc := c.(*componentContext)
 
-	// 从当前组件向上查找
-	for current := cc; current != nil; current = current.parent {
-		if value, ok := current.getContextValue(ctx.key); ok {
-			return value.(T)
-		}
+	// 首次执行时 Provide 可能尚未调用，沿用上一次提供的值；重新执行时只认本帧提供的值
+	currentOnly := cc.runtime != nil && cc.runtime.contextPass > 1
+	value := any(ctx.defaultValue)
+	provider := cc.findProvider(ctx.key, currentOnly)
+	if provider != nil {
+		value = provider.contextValues[ctx.key].value
+	}
+	if selector != nil {
+		value = selector(value)
 	}
 
-	// 未找到，返回默认值
-	return ctx.defaultValue
+	if cc.runtime != nil {
+		cc.runtime.contextReads = append(cc.runtime.contextReads, contextRead{
+			consumer: cc,
+			provider: provider,
+			key:      ctx.key,
+			value:    value,
+			fallback: ctx.defaultValue,
+			selector: selector,
+		})
+	}
+	return value
 }
 
 // =============================================================================
@@ -95,19 +134,124 @@ func UseContext[T any](c C, ctx *Context[T]) T {
 // 在 componentContext 中添加 context 值存储
 // 注意：这些方法需要添加到 context.go 中
 
-// setContextValue 设置 context 值
+// contextEntry 记录组件提供的 context 值及提供时的帧序号
+type contextEntry struct {
+	value any
+	frame uint64
+}
+
+// contextRead 记录一次 UseContext 读取，用于帧末校验
+type contextRead struct {
+	consumer *componentContext
+	provider *componentContext // 提供所读值的组件，读到默认值时为 nil
+	key      string
+	value    any // 读到的值，带 selector 时为选出的部分
+	fallback any
+	selector func(any) any
+}
+
+// changed 报告 current 与读取时的值是否不同；带 selector 的读取只比较选出的部分
+func (read contextRead) changed(current any) bool {
+	if read.selector != nil {
+		current = read.selector(current)
+	}
+	return !reflect.DeepEqual(current, read.value)
+}
+
+// maxContextPasses 一帧内为使 Context 值一致最多执行组件树的次数
+const maxContextPasses = 3
+
+// frame 返回当前渲染帧序号
+func (c *componentContext) frame() uint64 {
+	if c.runtime == nil {
+		return 0
+	}
+	return c.runtime.frame
+}
+
+// contextChange 本轮执行中新提供或值发生变化的 Provide
+type contextChange struct {
+	provider *componentContext
+	key      string
+}
+
+// setContextValue 设置 context 值；与上一帧提供的值不同时记录下来，帧末只校验受影响的读取
 func (c *componentContext) setContextValue(key string, value any) {
 	if c.contextValues == nil {
-		c.contextValues = make(map[string]any)
+		c.contextValues = make(map[string]contextEntry)
+	}
+	frame := c.frame()
+	prev, ok := c.contextValues[key]
+	c.contextValues[key] = contextEntry{value: value, frame: frame}
+	if c.runtime == nil || ok && prev.frame+1 >= frame && reflect.DeepEqual(prev.value, value) {
+		return
 	}
-	c.contextValues[key] = value
+	c.runtime.contextChanges = append(c.runtime.contextChanges, contextChange{provider: c, key: key})
 }
 
 // getContextValue 获取 context 值
-func (c *componentContext) getContextValue(key string) (any, bool) {
+func (c *componentContext) getContextValue(key string) (contextEntry, bool) {
 	if c.contextValues == nil {
-		return nil, false
+		return contextEntry{}, false
 	}
 	v, ok := c.contextValues[key]
 	return v, ok
 }
+
+// lookupContext 从当前组件向上查找 context 值
+// currentOnly 为 true 时只接受本帧提供的值，否则也接受上一次提供的值（Provide 可能尚未执行）
+func (c *componentContext) lookupContext(key string, currentOnly bool) (any, bool) {
+	if p := c.findProvider(key, currentOnly); p != nil {
+		return p.contextValues[key].value, true
+	}
+	return nil, false
+}
+
+// findProvider 从当前组件向上查找提供 key 的组件，没有时返回 nil
+func (c *componentContext) findProvider(key string, currentOnly bool) *componentContext {
+	frame := c.frame()
+	for current := c; current != nil; current = current.parent {
+		if entry, ok := current.getContextValue(key); ok {
+			if !currentOnly || entry.frame == frame {
+				return current
+			}
+		}
+	}
+	return nil
+}
+
+// contextChanged 检查本帧的 Context 读取是否与组件树执行完后的最终值一致
+//
+// 只校验可能读错的读取：所读的 Provide 本帧没有执行，或者读取者的祖先中有 Provide 新提供了值、
+// 值发生了变化；其余读取读到的上一帧的值就是本帧的值，不再逐一比较
+func (r *Runtime) contextChanged() bool {
+	for _, read := range r.contextReads {
+		stale := read.provider != nil && read.provider.contextValues[read.key].frame != r.frame
+		if !stale && !r.providerChanged(read) {
+			continue
+		}
+		final, ok := read.consumer.lookupContext(read.key, true)
+		if !ok {
+			final = read.fallback
+		}
+		if read.changed(final) {
+			return true
+		}
+	}
+	return false
+}
+
+// providerChanged 报告本轮中是否有 read 的读取者或其祖先对同一 Context 新提供了值或改变了值
+func (r *Runtime) providerChanged(read contextRead) bool {
+	for _, change := range r.contextChanges {
+		if change.key != read.key {
+			continue
+		}
+		for c := read.consumer; c != nil; c = c.parent {
+			if c == change.provider {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type progressState struct {
+	Progress int
+	Logs     []string
+}
+
+func TestUseContextSelector(t *testing.T) {
+	stateCtx := CreateContext(progressState{})
+	state := progressState{Progress: 10}
+	computed := 0
+
+	consumer := func(c C) Node {
+		progress := UseContextSelector(c, stateCtx, func(s progressState) int { return s.Progress })
+		label := UseMemo(c, func() string {
+			computed++
+			return "progress"
+		}, progress)
+		return Text(label)
+	}
+	app := func(c C) Node {
+		return stateCtx.Provide(c, state, consumer(c.Child("consumer")))
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.Render()
+	base := computed
+
+	// 无关字段变化不会导致重新计算
+	state.Logs = append(state.Logs, "scan")
+	tr.Render()
+	tr.Render()
+	if computed != base {
+		t.Errorf("expected memo to be reused when unrelated fields change, computed %d times", computed)
+	}
+
+	state.Progress = 20
+	tr.Render()
+	tr.Render()
+	if computed != base+1 {
+		t.Errorf("expected memo to be recomputed when selected field changes, computed %d times", computed)
+	}
+}
+
+func TestUseContextSelector_SkipsMemoOnUnrelatedChange(t *testing.T) {
+	stateCtx := CreateContext(progressState{})
+	var state *State[progressState]
+	consumer := func(c C) Node {
+		progress := UseContextSelector(c, stateCtx, func(s progressState) int { return s.Progress })
+		return Text(fmt.Sprintf("%d%%", progress))
+	}
+	app := func(c C) Node {
+		state = Use(c, "state", progressState{Progress: 10})
+		return stateCtx.Provide(c, state.Val, Memo(c, "progress", consumer))
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	base := tr.RenderCount("progress")
+
+	state.Set(progressState{Progress: 10, Logs: []string{"scan"}})
+	tr.Render()
+	if got := tr.RenderCount("progress"); got != base {
+		t.Errorf("progress renders after unrelated change = %d, want %d", got, base)
+	}
+
+	state.Set(progressState{Progress: 20, Logs: []string{"scan"}})
+	tr.Render()
+	if got := tr.RenderCount("progress"); got != base+1 {
+		t.Errorf("progress renders after selected change = %d, want %d", got, base+1)
+	}
+	if content := getScreenContent(screen); !strings.HasPrefix(content, "20%") {
+		t.Errorf("expected updated progress, got %q", content)
+	}
+}
+
+func TestUseContext_IndependentOfRenderOrder(t *testing.T) {
+	langCtx := CreateContext("en")
+	provide := true
+	var seen []string
+
+	consumer := func(c C) Node {
+		lang := UseContext(c, langCtx)
+		seen = append(seen, lang)
+		return Text(lang)
+	}
+	app := func(c C) Node {
+		// 子组件作为参数先于 Provide 执行
+		child := consumer(c.Child("consumer"))
+		if provide {
+			return langCtx.Provide(c, "zh", child)
+		}
+		return child
+	}
+
+	screen := newTestScreen(10, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); content[:2] != "zh" {
+		t.Errorf("expected provided value on first frame, got %q", content)
+	}
+
+	// 不再提供时回到默认值，而不是残留上一帧的值
+	provide = false
+	tr.Render()
+	if content := getScreenContent(screen); content[:2] != "en" {
+		t.Errorf("expected default value once provider is gone, got %q", content)
+	}
+	if len(seen) > 4 {
+		t.Errorf("expected at most two passes per frame, got %v", seen)
+	}
+}
+
+func TestUseContext_EffectsSeeSettledValue(t *testing.T) {
+	langCtx := CreateContext("en")
+	lang := "zh"
+	var effects []string
+	selects := 0
+
+	consumer := func(c C) Node {
+		v := UseContext(c, langCtx)
+		UseContextSelector(c, langCtx, func(s string) int { selects++; return len(s) })
+		UseEffect(c, func() func() {
+			effects = append(effects, v)
+			return nil
+		}, v)
+		return Text(v)
+	}
+	app := func(c C) Node {
+		// 子组件先于 Provide 执行，第一遍读到的是上一帧的值
+		return langCtx.Provide(c, lang, consumer(c.Child("consumer")))
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(10, 1))
+	tr.Render()
+	lang = "fr"
+	tr.Render()
+	if want := []string{"zh", "fr"}; fmt.Sprint(effects) != fmt.Sprint(want) {
+		t.Errorf("effects ran with %v, want %v", effects, want)
+	}
+
+	// Provide 的值没有变化时只执行一遍，也不再逐一比较读取
+	selects = 0
+	tr.Render()
+	if selects != 1 {
+		t.Errorf("selector called %d times for an unchanged provider, want 1", selects)
+	}
+}
@@ -0,0 +1,66 @@
+package rego
+
+import "github.com/gdamore/tcell/v2"
+
+// =============================================================================
+// 光标形状
+// =============================================================================
+
+// CursorShape 光标形状
+type CursorShape int
+
+const (
+	CursorDefault CursorShape = iota // 终端默认
+	CursorBlock
+	CursorUnderline
+	CursorBar
+)
+
+// CursorStyle 光标形状及是否闪烁，通过 DECSCUSR 序列设置
+type CursorStyle struct {
+	Shape CursorShape
+	Blink bool
+}
+
+// toTcell 转换为 tcell 的光标样式
+func (s CursorStyle) toTcell() tcell.CursorStyle {
+	switch s.Shape {
+	case CursorBlock:
+		if s.Blink {
+			return tcell.CursorStyleBlinkingBlock
+		}
+		return tcell.CursorStyleSteadyBlock
+	case CursorUnderline:
+		if s.Blink {
+			return tcell.CursorStyleBlinkingUnderline
+		}
+		return tcell.CursorStyleSteadyUnderline
+	case CursorBar:
+		if s.Blink {
+			return tcell.CursorStyleBlinkingBar
+		}
+		return tcell.CursorStyleSteadyBar
+	}
+	return tcell.CursorStyleDefault
+}
+
+// UseCursorStyle 设置组件获得焦点时的光标样式，覆盖 Options.CursorStyle
+//
+// 例如输入框显示竖线光标，其余界面保持方块光标。
+func UseCursorStyle(c C, style CursorStyle) {
+	ctx := c.(*componentContext)
+	ctx.cursorStyle = &style
+}
+
+// applyCursorStyle 按当前焦点选择光标样式，变化时才写出
+func (r *Runtime) applyCursorStyle() {
+	style := r.cursorStyle
+	if ctx := r.focusManager.CurrentContext(); ctx != nil && ctx.cursorStyle != nil {
+		style = *ctx.cursorStyle
+	}
+	if r.appliedCursor != nil && *r.appliedCursor == style {
+		return
+	}
+	r.appliedCursor = &style
+	r.screen.SetCursorStyle(style.toTcell())
+}
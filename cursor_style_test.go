@@ -0,0 +1,83 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// cursorScreen 记录光标样式的设置
+type cursorScreen struct {
+	tcell.SimulationScreen
+	styles []tcell.CursorStyle
+}
+
+func (s *cursorScreen) SetCursorStyle(cs tcell.CursorStyle, _ ...tcell.Color) {
+	s.styles = append(s.styles, cs)
+}
+
+func TestCursorStyle_FocusOverride(t *testing.T) {
+	app := func(c C) Node {
+		editor := c.Child("editor")
+		UseFocus(editor)
+		UseCursorStyle(editor, CursorStyle{Shape: CursorBar})
+
+		other := c.Child("other")
+		UseFocus(other)
+
+		return VStack(Cursor(c), Text("x"))
+	}
+
+	screen := &cursorScreen{SimulationScreen: newTestScreen(10, 2)}
+	tr := NewTestRuntime(app, screen)
+	tr.apply(Options{CursorStyle: CursorStyle{Shape: CursorBlock, Blink: true}})
+
+	tr.Render()
+	tr.Render() // 样式未变化时不重复写出
+	tr.DispatchKey(tcell.KeyTab, 0, 0)
+	tr.Render()
+
+	want := []tcell.CursorStyle{tcell.CursorStyleSteadyBar, tcell.CursorStyleBlinkingBlock}
+	if len(screen.styles) != len(want) {
+		t.Fatalf("expected cursor styles %v, got %v", want, screen.styles)
+	}
+	for i := range want {
+		if screen.styles[i] != want[i] {
+			t.Errorf("style %d: expected %v, got %v", i, want[i], screen.styles[i])
+		}
+	}
+}
+
+func TestCursor_FocusedComponentWins(t *testing.T) {
+	hide := false
+	field := func(c C, label string) Node {
+		focus := UseFocus(c)
+		if hide && focus.IsFocused {
+			c.HideCursor()
+		}
+		return c.Wrap(HStack(Text(label), Cursor(c)))
+	}
+	app := func(c C) Node {
+		return VStack(field(c.Child("a"), "a"), field(c.Child("b"), "bb"))
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(10, 2))
+	tr.Render()
+	// 后渲染的 b 不再覆盖聚焦的 a
+	if !tr.showCursor || tr.cursorX != 1 || tr.cursorY != 0 {
+		t.Fatalf("cursor = (%d, %d) shown=%v, want (1, 0)", tr.cursorX, tr.cursorY, tr.showCursor)
+	}
+
+	tr.DispatchKey(tcell.KeyTab, 0, 0)
+	tr.Render()
+	if tr.cursorX != 2 || tr.cursorY != 1 {
+		t.Errorf("cursor = (%d, %d), want (2, 1)", tr.cursorX, tr.cursorY)
+	}
+
+	// 聚焦的组件隐藏光标后，其他组件的光标照常显示
+	hide = true
+	tr.Render()
+	if !tr.showCursor || tr.cursorX != 1 || tr.cursorY != 0 {
+		t.Errorf("cursor = (%d, %d) shown=%v, want (1, 0)", tr.cursorX, tr.cursorY, tr.showCursor)
+	}
+}
@@ -0,0 +1,172 @@
+package rego
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// 损坏区域 - 滚动时让终端平移行，只补画新露出的内容
+// =============================================================================
+
+// tcell 只输出内容变化的单元格，但 ScrollBox 滚动一行时视口内几乎每个单元格都会变化。
+// ScrollBox 渲染时报告滚动的屏幕行区间和行数（scrollHint），输出前对照上一帧的单元格：
+// 若用 DECSTBM + SU/SD 让终端平移这些行后可复用的单元格明显更多，就先发送滚动指令，
+// 再把平移后已经正确的单元格标记为干净，tcell 只需输出其余单元格。
+
+// scrollHint 某个 ScrollBox 在本帧滚动的行区间 [top, bottom) 与内容上移的行数（负数为下移）
+type scrollHint struct {
+	top, bottom int
+	delta       int
+}
+
+// cellBufferScreen 是可以直接访问单元格缓冲的 screen（tcell 的终端与模拟 screen 均满足）
+type cellBufferScreen interface {
+	GetCells() *tcell.CellBuffer
+	Lock()
+	Unlock()
+}
+
+type frameCell struct {
+	str   string
+	style tcell.Style
+}
+
+// damageTracker 记录上一帧输出到终端的内容与本帧的滚动区域
+type damageTracker struct {
+	out   io.Writer // 滚动指令的输出目标，通常是终端 tty
+	w, h  int
+	cells []frameCell
+	hints []scrollHint
+}
+
+// newDamageTracker 为 screen 创建损坏区域跟踪，screen 不是终端时返回 nil
+func newDamageTracker(screen tcell.Screen) *damageTracker {
+	tty, ok := screen.Tty()
+	if !ok || tty == nil {
+		return nil
+	}
+	return &damageTracker{out: tty}
+}
+
+// addScrollHint 记录一个滚动区域
+func (d *damageTracker) addScrollHint(top, bottom, delta int) {
+	if d == nil || delta == 0 || bottom-top <= 1 {
+		return
+	}
+	d.hints = append(d.hints, scrollHint{top: top, bottom: bottom, delta: delta})
+}
+
+// flush 在 Show 之前调用：应用值得平移的滚动区域，返回本帧需要输出的单元格数
+func (d *damageTracker) flush(screen tcell.Screen) int {
+	hints := d.hints
+	d.hints = d.hints[:0]
+	cs, ok := screen.(cellBufferScreen)
+	if !ok {
+		return 0
+	}
+	cs.Lock()
+	defer cs.Unlock()
+	cb := cs.GetCells()
+	w, h := cb.Size()
+	if w == d.w && h == d.h {
+		for _, hint := range hints {
+			d.applyScroll(cb, hint)
+		}
+	}
+	dirty := 0
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if cb.Dirty(x, y) {
+				dirty++
+			}
+		}
+	}
+	return dirty
+}
+
+// applyScroll 比较平移与不平移时能复用的单元格数，至少多出一整行时才发送滚动指令
+func (d *damageTracker) applyScroll(cb *tcell.CellBuffer, hint scrollHint) {
+	top, bottom := max(hint.top, 0), min(hint.bottom, d.h)
+	n := hint.delta
+	if n < 0 {
+		n = -n
+	}
+	if n >= bottom-top {
+		return
+	}
+
+	// src 返回平移后终端第 y 行显示的上一帧行号，-1 表示新露出的空行
+	src := func(y int) int {
+		if s := y + hint.delta; s >= top && s < bottom {
+			return s
+		}
+		return -1
+	}
+	gain := 0
+	for y := top; y < bottom; y++ {
+		for x := 0; x < d.w; x++ {
+			str, style, _ := cb.Get(x, y)
+			cur := frameCell{str, style}
+			if s := src(y); s >= 0 && d.cells[s*d.w+x] == cur {
+				gain++
+			}
+			if d.cells[y*d.w+x] == cur {
+				gain--
+			}
+		}
+	}
+	if gain < d.w {
+		return
+	}
+
+	seq := "\x1b[0m" + fmt.Sprintf("\x1b[%d;%dr", top+1, bottom)
+	if hint.delta > 0 {
+		seq += fmt.Sprintf("\x1b[%dS", n)
+	} else {
+		seq += fmt.Sprintf("\x1b[%dT", n)
+	}
+	seq += "\x1b[r"
+	if _, err := io.WriteString(d.out, seq); err != nil {
+		return
+	}
+
+	// 同步终端上的实际内容：平移后仍正确的单元格标记为干净，其余强制重画
+	shifted := make([]frameCell, (bottom-top)*d.w)
+	for y := top; y < bottom; y++ {
+		for x := 0; x < d.w; x++ {
+			cell := frameCell{" ", tcell.StyleDefault}
+			if s := src(y); s >= 0 {
+				cell = d.cells[s*d.w+x]
+			}
+			shifted[(y-top)*d.w+x] = cell
+			str, style, _ := cb.Get(x, y)
+			cb.SetDirty(x, y, cell != frameCell{str, style})
+		}
+	}
+	copy(d.cells[top*d.w:bottom*d.w], shifted)
+}
+
+// snapshot 在 Show 之后调用，记录终端上当前显示的内容
+func (d *damageTracker) snapshot(screen tcell.Screen) {
+	cs, ok := screen.(cellBufferScreen)
+	if !ok {
+		return
+	}
+	cs.Lock()
+	defer cs.Unlock()
+	cb := cs.GetCells()
+	d.w, d.h = cb.Size()
+	if cap(d.cells) < d.w*d.h {
+		d.cells = make([]frameCell, d.w*d.h)
+	}
+	d.cells = d.cells[:d.w*d.h]
+	for y := 0; y < d.h; y++ {
+		for x := 0; x < d.w; x++ {
+			str, style, _ := cb.Get(x, y)
+			d.cells[y*d.w+x] = frameCell{str, style}
+		}
+	}
+}
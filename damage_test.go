@@ -0,0 +1,75 @@
+package rego
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDamage_ScrollUsesTerminalScrollRegion(t *testing.T) {
+	app := func(c C) Node {
+		// 相邻行内容差异较大，如同真实日志
+		var lines []Node
+		for i := 0; i < 30; i++ {
+			lines = append(lines, Text(fmt.Sprintf("%02d %s", i, strings.Repeat(string(rune('a'+i%26)), 4+i*7%11))))
+		}
+		return VStack(
+			Text("header"),
+			ScrollBox(c.Child("log"), VStack(lines...)),
+		)
+	}
+
+	screen := newTestScreen(20, 6)
+	tr := NewTestRuntime(app, screen)
+	var out bytes.Buffer
+	tr.damage = &damageTracker{out: &out}
+	tr.Render()
+	if out.Len() != 0 {
+		t.Fatalf("unexpected output on first frame: %q", out.String())
+	}
+
+	tr.DispatchMouse(2, 3, tcell.WheelDown, tcell.ModNone)
+	tr.Render()
+
+	// 视口位于第 2~6 行，内容上移
+	if got := out.String(); !strings.HasPrefix(got, "\x1b[0m\x1b[2;6r\x1b[") || !strings.HasSuffix(got, "S\x1b[r") {
+		t.Fatalf("expected scroll region sequence, got %q", got)
+	}
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "header") || strings.Contains(content, "00 aaaa") {
+		t.Errorf("unexpected screen after scroll:\n%s", content)
+	}
+	// 只需重画新露出的行与滚动条，远少于整个视口
+	if cells := tr.Metrics().Cells; cells == 0 || cells >= 20*5 {
+		t.Errorf("expected only a few cells to be redrawn, got %d", cells)
+	}
+}
+
+func TestDamage_SkipsWhenContentIsReplaced(t *testing.T) {
+	var page *State[int]
+	app := func(c C) Node {
+		page = Use(c, "page", 0)
+		var lines []Node
+		for i := 0; i < 30; i++ {
+			lines = append(lines, Text(fmt.Sprintf("p%d %02d", page.Val, i)))
+		}
+		return ScrollBox(c.Child("log"), VStack(lines...))
+	}
+
+	screen := newTestScreen(20, 5)
+	tr := NewTestRuntime(app, screen)
+	var out bytes.Buffer
+	tr.damage = &damageTracker{out: &out}
+	tr.Render()
+
+	// 滚动的同时整页内容都变了，平移终端没有收益
+	page.Set(1)
+	tr.DispatchMouse(2, 2, tcell.WheelDown, tcell.ModNone)
+	tr.Render()
+	if out.Len() != 0 {
+		t.Errorf("expected no scroll sequence, got %q", out.String())
+	}
+}
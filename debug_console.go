@@ -0,0 +1,88 @@
+package rego
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// 调试控制台（F10）
+// =============================================================================
+
+// debugLogSize 调试控制台保留的日志条数
+const debugLogSize = 200
+
+// debugConsoleHeight 调试控制台的高度（含边框）
+const debugConsoleHeight = 12
+
+// debugLog 收集 Log 写入的日志
+var debugLog = &logBuffer{}
+
+// Log 记录一条日志，按 F10 打开的调试控制台中显示最近的日志
+func Log(level LogLevel, format string, args ...any) {
+	debugLog.add(LogEntry{Time: time.Now(), Level: level, Message: fmt.Sprintf(format, args...)}, debugLogSize)
+}
+
+var mouseEventNames = map[MouseEventType]string{
+	MouseEventPress:       "press",
+	MouseEventRelease:     "release",
+	MouseEventClick:       "click",
+	MouseEventMove:        "move",
+	MouseEventScrollUp:    "scroll-up",
+	MouseEventScrollDown:  "scroll-down",
+	MouseEventScrollLeft:  "scroll-left",
+	MouseEventScrollRight: "scroll-right",
+}
+
+// recordDebugEvent 记录最近的按键事件，供调试控制台显示
+func (r *Runtime) recordDebugEvent(event tcell.Event) {
+	if e, ok := event.(*tcell.EventKey); ok && e.Key() != tcell.KeyF10 {
+		r.lastKeyEvent = e.Name()
+	}
+}
+
+// recordMouseEvent 记录最近分发的鼠标事件，供调试控制台显示
+func (r *Runtime) recordMouseEvent(ev MouseEvent) {
+	r.lastMouseEvent = fmt.Sprintf("%s (%d,%d)", mouseEventNames[ev.Type], ev.X, ev.Y)
+}
+
+// addDebugConsole 在屏幕底部以浮层绘制调试控制台
+func (r *Runtime) addDebugConsole(width, height int) {
+	h := min(debugConsoleHeight, height)
+	if h < 3 {
+		return
+	}
+	m := r.metrics.snapshot()
+	rows := []Node{
+		Text(fmt.Sprintf("FPS %.1f · frame %v (p99 %v) · nodes %d · heap %d KB",
+			m.FPS, m.MeanFrame.Round(time.Microsecond), m.P99Frame.Round(time.Microsecond), m.Nodes, m.HeapAlloc/1024)).Bold(),
+		Text("focus: " + orDash(r.focusManager.Current())),
+		Text("key:   " + orDash(r.lastKeyEvent) + "    mouse: " + orDash(r.lastMouseEvent)),
+	}
+
+	records, _ := debugLog.snapshot()
+	room := h - 2 - len(rows)
+	if len(records) > room {
+		records = records[len(records)-room:]
+	}
+	for _, rec := range records {
+		rows = append(rows, HStack(
+			Text(rec.Time.Format("15:04:05")+" ").Dim(),
+			Text(fmt.Sprintf("%-5s ", rec.Level)).Color(rec.Level.color()),
+			Text(rec.Message),
+		))
+	}
+
+	console := Box(VStack(rows...)).Border(BorderRounded).BorderColor(Yellow).Padding(0, 1)
+	r.addOverlay(console, 0, height-h, width, h)
+}
+
+// orDash 空字符串显示为 "-"
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
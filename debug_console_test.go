@@ -0,0 +1,44 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDebugConsole(t *testing.T) {
+	debugLog = &logBuffer{}
+	t.Cleanup(func() { debugLog = &logBuffer{} })
+
+	app := func(c C) Node {
+		input := c.Child("input")
+		UseFocus(input)
+		return Text("main screen")
+	}
+
+	screen := newTestScreen(60, 14)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "FPS") {
+		t.Fatal("expected debug console to be hidden initially")
+	}
+
+	Log(LogWarn, "cache miss for %s", "users")
+	tr.DispatchKey(tcell.KeyRune, 'x', 0)
+	tr.DispatchKey(tcell.KeyF10, 0, 0)
+	tr.Render()
+
+	content := getScreenContent(screen)
+	for _, want := range []string{"FPS", "focus: root/input", "Rune[x]", "WARN", "cache miss for users"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected debug console to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	tr.DispatchKey(tcell.KeyF10, 0, 0)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "FPS") {
+		t.Error("expected F10 to close the debug console")
+	}
+}
@@ -0,0 +1,197 @@
+// Package demo 以脚本驱动应用并按固定帧率录制画面，用于生成可复现的演示（asciicast 或逐帧文件），
+// 不再依赖手工录屏：
+//
+//	d := demo.New(app, 80, 24, demo.Options{})
+//	d.Wait(time.Second).Type("买牛奶\n").Key(rego.KeyDown).Wait(500 * time.Millisecond).Screenshot("done")
+//	f, _ := os.Create("todo.cast")
+//	d.WriteCast(f) // 用 agg 等工具转换为 GIF
+package demo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/erweixin/rego"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Options 配置录制
+type Options struct {
+	FPS       int           // 每秒帧数，默认 10
+	TypeDelay time.Duration // Type 输入每个字符后的停顿，默认 80ms
+	Title     string        // 写入 asciicast 头部的标题
+}
+
+// Frame 是录制的一帧
+type Frame struct {
+	Time time.Duration // 相对于录制开始的时间
+	Text string        // 屏幕上的文字
+	ANSI string        // 带 ANSI 转义序列的屏幕内容
+}
+
+// Demo 在模拟屏幕上运行应用并记录画面
+//
+// 运行时使用 FakeClock，Spinner、动画等定时器只随 Wait 推进，因此同一脚本每次录制的结果相同
+// （后台协程返回的时机除外）。
+type Demo struct {
+	rt          *rego.Runtime
+	opts        Options
+	width       int
+	height      int
+	elapsed     time.Duration
+	frames      []Frame
+	screenshots map[string]Frame
+	names       []string // 截图的顺序
+}
+
+// New 创建录制并渲染第一帧
+func New(root func(rego.C) rego.Node, width, height int, opts Options) *Demo {
+	if opts.FPS <= 0 {
+		opts.FPS = 10
+	}
+	if opts.TypeDelay <= 0 {
+		opts.TypeDelay = 80 * time.Millisecond
+	}
+	screen := tcell.NewSimulationScreen("")
+	screen.Init()
+	screen.SetSize(width, height)
+	d := &Demo{
+		rt:          rego.NewTestRuntime(root, screen, rego.Options{Clock: rego.NewFakeClock(time.Now())}),
+		opts:        opts,
+		width:       width,
+		height:      height,
+		screenshots: map[string]Frame{},
+	}
+	d.frame()
+	return d
+}
+
+// frameInterval 相邻两帧之间的时间
+func (d *Demo) frameInterval() time.Duration {
+	return time.Second / time.Duration(d.opts.FPS)
+}
+
+// frame 渲染并记录当前画面，与上一帧相同时不记录
+func (d *Demo) frame() {
+	d.rt.Render()
+	f := Frame{Time: d.elapsed, Text: d.rt.CaptureScreen(), ANSI: d.rt.CaptureScreenANSI()}
+	if n := len(d.frames); n > 0 && d.frames[n-1].ANSI == f.ANSI {
+		return
+	}
+	d.frames = append(d.frames, f)
+}
+
+// Wait 按帧率推进时间 dur，期间每帧渲染一次
+func (d *Demo) Wait(dur time.Duration) *Demo {
+	step := d.frameInterval()
+	for dur > 0 {
+		s := min(step, dur)
+		d.rt.AdvanceTime(s)
+		d.elapsed += s
+		dur -= s
+		d.frame()
+	}
+	return d
+}
+
+// Key 发送一个按键：k 为 rune（如 'j'）或 rego.Key（如 rego.KeyEnter）
+func (d *Demo) Key(k any, mods ...rego.Modifiers) *Demo {
+	switch k := k.(type) {
+	case rune:
+		d.rt.DispatchKey(tcell.KeyRune, k, rego.TcellMods(mods...))
+	case rego.Key:
+		if tk, r, ok := rego.TcellKey(k); ok {
+			d.rt.DispatchKey(tk, r, rego.TcellMods(mods...))
+		} else {
+			panic(fmt.Sprintf("demo: unsupported key %d", k))
+		}
+	default:
+		panic(fmt.Sprintf("demo: unsupported key %v (%T), use a rune or rego.Key", k, k))
+	}
+	d.frame()
+	return d
+}
+
+// Type 逐字符输入文本，每个字符后停顿 TypeDelay，'\n' 作为 Enter 发送
+func (d *Demo) Type(text string) *Demo {
+	for _, r := range text {
+		if r == '\n' {
+			d.rt.DispatchKey(tcell.KeyEnter, 0, 0)
+		} else {
+			d.rt.DispatchKey(tcell.KeyRune, r, 0)
+		}
+		d.frame()
+		d.Wait(d.opts.TypeDelay)
+	}
+	return d
+}
+
+// Click 在 (x, y) 处单击鼠标左键
+func (d *Demo) Click(x, y int) *Demo {
+	d.rt.DispatchMouse(x, y, tcell.Button1, 0)
+	d.rt.DispatchMouse(x, y, tcell.ButtonNone, 0)
+	d.frame()
+	return d
+}
+
+// Screenshot 把当前画面记为名为 name 的截图
+func (d *Demo) Screenshot(name string) *Demo {
+	d.rt.Render()
+	if _, ok := d.screenshots[name]; !ok {
+		d.names = append(d.names, name)
+	}
+	d.screenshots[name] = Frame{Time: d.elapsed, Text: d.rt.CaptureScreen(), ANSI: d.rt.CaptureScreenANSI()}
+	return d
+}
+
+// Frames 返回录制的所有帧，内容不变的帧会被合并
+func (d *Demo) Frames() []Frame {
+	return d.frames
+}
+
+// Shot 返回名为 name 的截图
+func (d *Demo) Shot(name string) (Frame, bool) {
+	f, ok := d.screenshots[name]
+	return f, ok
+}
+
+// WriteCast 以 asciicast v2 格式写出录制结果，可用 asciinema 播放或用 agg 转换为 GIF
+func (d *Demo) WriteCast(w io.Writer) error {
+	header := map[string]any{"version": 2, "width": d.width, "height": d.height}
+	if d.opts.Title != "" {
+		header["title"] = d.opts.Title
+	}
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+	for _, f := range d.frames {
+		out := "\x1b[H\x1b[2J" + strings.ReplaceAll(f.ANSI, "\n", "\r\n")
+		if err := enc.Encode([]any{f.Time.Seconds(), "o", out}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveScreenshots 把截图写到 dir 下：<name>.txt 为文字，<name>.ans 带 ANSI 转义序列
+func (d *Demo) SaveScreenshots(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, name := range d.names {
+		f := d.screenshots[name]
+		if err := os.WriteFile(filepath.Join(dir, name+".txt"), []byte(f.Text+"\n"), 0o644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".ans"), []byte(f.ANSI+"\n"), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package demo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erweixin/rego"
+)
+
+func counterApp(c rego.C) rego.Node {
+	text := rego.Use(c, "text", "")
+	ticks := rego.Use(c, "ticks", 0)
+	rego.UseInterval(c, 500*time.Millisecond, func() { ticks.Set(ticks.Val + 1) })
+	rego.UseKey(c, func(key rego.Key, r rune) {
+		if r != 0 {
+			text.Set(text.Val + string(r))
+		}
+	})
+	return rego.VStack(
+		rego.Text("input: "+text.Val),
+		rego.Text("ticks: "+strings.Repeat("*", ticks.Val)),
+	)
+}
+
+func TestDemo_RecordAndExport(t *testing.T) {
+	d := New(counterApp, 20, 3, Options{FPS: 10, TypeDelay: 100 * time.Millisecond, Title: "counter"})
+	d.Type("hi").Wait(time.Second).Screenshot("end")
+
+	shot, ok := d.Shot("end")
+	if !ok {
+		t.Fatal("screenshot not recorded")
+	}
+	if want := "input: hi\nticks: **"; shot.Text != want {
+		t.Errorf("screenshot = %q, want %q", shot.Text, want)
+	}
+	if shot.Time != 1200*time.Millisecond {
+		t.Errorf("screenshot time = %v, want 1.2s", shot.Time)
+	}
+
+	// 内容不变的帧被合并：初始帧、两次输入、两次计时
+	if n := len(d.Frames()); n != 5 {
+		t.Errorf("frames = %d, want 5", n)
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteCast(&buf); err != nil {
+		t.Fatal(err)
+	}
+	sc := bufio.NewScanner(&buf)
+	sc.Scan()
+	var header map[string]any
+	if err := json.Unmarshal(sc.Bytes(), &header); err != nil || header["version"] != float64(2) || header["title"] != "counter" {
+		t.Fatalf("bad header %s (%v)", sc.Text(), err)
+	}
+	events := 0
+	for sc.Scan() {
+		var ev []any
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil || len(ev) != 3 || ev[1] != "o" {
+			t.Fatalf("bad event %s", sc.Text())
+		}
+		events++
+	}
+	if events != len(d.Frames()) {
+		t.Errorf("events = %d, want %d", events, len(d.Frames()))
+	}
+
+	dir := t.TempDir()
+	if err := d.SaveScreenshots(dir); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "end.txt"))
+	if err != nil || string(data) != shot.Text+"\n" {
+		t.Errorf("end.txt = %q (%v)", data, err)
+	}
+}
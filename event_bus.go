@@ -0,0 +1,68 @@
+package rego
+
+import "sync"
+
+// =============================================================================
+// EventBus - 跨组件事件总线
+// =============================================================================
+
+// EventBus 在彼此没有父子关系的组件之间传递事件
+type EventBus[T any] struct {
+	mu   sync.Mutex
+	subs map[int]func(T)
+	next int
+}
+
+// CreateEventBus 创建一个事件总线，通常定义为包级变量或通过 Context 下发
+func CreateEventBus[T any]() *EventBus[T] {
+	return &EventBus[T]{subs: make(map[int]func(T))}
+}
+
+// Emit 向所有订阅者发送事件，可在任意协程中调用
+func (b *EventBus[T]) Emit(v T) {
+	b.mu.Lock()
+	subs := make([]func(T), 0, len(b.subs))
+	for id := 0; id < b.next; id++ {
+		if fn, ok := b.subs[id]; ok {
+			subs = append(subs, fn)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(v)
+	}
+}
+
+func (b *EventBus[T]) subscribe(fn func(T)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.next
+	b.next++
+	b.subs[id] = fn
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+	}
+}
+
+// UseEventBus 在组件挂载期间订阅总线，handler 在 UI 循环中执行并触发刷新
+func UseEventBus[T any](c C, bus *EventBus[T], handler func(T)) {
+	ctx := c.(*componentContext)
+	latest := UseRef(c, handler)
+	latest.Current = handler
+
+	UseEffect(c, func() func() {
+		if bus == nil {
+			return nil
+		}
+		return bus.subscribe(func(v T) {
+			if ctx.runtime != nil {
+				ctx.runtime.post(func() { latest.Current(v) })
+			} else {
+				latest.Current(v)
+			}
+		})
+	}, bus)
+}
@@ -0,0 +1,43 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestEventBus_SiblingComponents(t *testing.T) {
+	bus := CreateEventBus[int]()
+	var total int
+
+	sender := func(c C) Node {
+		UseKey(c, func(key Key, r rune) {
+			if r == '+' {
+				bus.Emit(5)
+			}
+		})
+		return Text("sender")
+	}
+	receiver := func(c C) Node {
+		UseEventBus(c, bus, func(v int) { total += v })
+		return Text("receiver")
+	}
+	app := func(c C) Node {
+		return VStack(
+			sender(c.Child("sender")),
+			receiver(c.Child("receiver")),
+		)
+	}
+
+	screen := newTestScreen(10, 2)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyRune, '+', 0)
+	tr.DispatchKey(tcell.KeyRune, '+', 0)
+	tr.Render()
+
+	if total != 10 {
+		t.Errorf("expected receiver to get both events, got %d", total)
+	}
+}
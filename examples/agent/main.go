@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -14,12 +15,12 @@ import (
 
 func App(c rego.C) rego.Node {
 	activePanel := rego.Use(c, "activePanel", 0)
-	messages := rego.Use(c, "messages", []Message{
-		{Role: "system", Content: "欢迎使用 Rego Agent CLI! 这是一个多面板 Agent 界面示例。"},
+	messages := rego.Use(c, "messages", []rego.ChatMessage{
+		{ID: "welcome", Role: rego.RoleSystem, Content: "欢迎使用 Rego Agent CLI! 这是一个多面板 Agent 界面示例。", Time: time.Now()},
 	})
 	inputText := rego.Use(c, "inputText", "")
 	isThinking := rego.Use(c, "isThinking", false)
-	streamingText := rego.Use(c, "streamingText", "")
+	reply := rego.UseStream(c, "reply") // 正在生成的回复
 
 	// 处理键盘事件
 	rego.UseKey(c, func(key rego.Key, r rune) {
@@ -44,18 +45,18 @@ func App(c rego.C) rego.Node {
 		// Main content (3 panels)
 		rego.HStack(
 			// Left: Chat history
-			ChatPanel(c.Child("chat"), messages.Val, activePanel.Val == 0),
+			ChatPanel(c.Child("chat"), messages.Val, reply.String(), activePanel.Val == 0),
 
 			// Center: Input & thinking
-			InputPanel(c.Child("input"), inputText, isThinking.Val, streamingText.Val, activePanel.Val == 1, func(text string) {
+			InputPanel(c.Child("input"), inputText, isThinking.Val, activePanel.Val == 1, func(text string) {
 				// 发送消息
-				newMsg := Message{Role: "user", Content: text}
+				newMsg := rego.ChatMessage{ID: strconv.Itoa(len(messages.Val)), Role: rego.RoleUser, Content: text, Time: time.Now()}
 				messages.Set(append(messages.Val, newMsg))
 				inputText.Set("")
 				isThinking.Set(true)
 
 				// 模拟 AI 响应
-				go simulateResponse(c, messages, isThinking, streamingText)
+				go simulateResponse(c, messages, isThinking, reply)
 			}),
 
 			// Right: Context/Files
@@ -67,15 +68,6 @@ func App(c rego.C) rego.Node {
 	)
 }
 
-// =============================================================================
-// Message 类型
-// =============================================================================
-
-type Message struct {
-	Role    string // "user", "assistant", "system"
-	Content string
-}
-
 // =============================================================================
 // Header 组件
 // =============================================================================
@@ -94,19 +86,21 @@ func Header(c rego.C) rego.Node {
 // ChatPanel 组件 - 聊天历史
 // =============================================================================
 
-func ChatPanel(c rego.C, messages []Message, active bool) rego.Node {
-	// 最多显示最近 10 条
-	start := 0
-	if len(messages) > 10 {
-		start = len(messages) - 10
-	}
-	displayMessages := messages[start:]
-
+func ChatPanel(c rego.C, messages []rego.ChatMessage, streamingText string, active bool) rego.Node {
 	borderColor := rego.Gray
 	if active {
 		borderColor = rego.Green
 	}
 
+	// 正在生成的回复放在列表末尾
+	if streamingText != "" {
+		messages = append(messages[:len(messages):len(messages)], rego.ChatMessage{
+			Role:      rego.RoleAssistant,
+			Content:   streamingText,
+			Streaming: true,
+		})
+	}
+
 	return rego.Box(
 		rego.VStack(
 			rego.HStack(
@@ -114,47 +108,16 @@ func ChatPanel(c rego.C, messages []Message, active bool) rego.Node {
 				rego.When(active, rego.Text(" [活动]").Color(rego.Green)),
 			),
 			rego.Text(strings.Repeat("─", 30)),
-			rego.For(displayMessages, func(msg Message, i int) rego.Node {
-				return MessageItem(c.Child("msg", i), msg)
-			}),
-			rego.Spacer(),
+			rego.ChatList(c.Child("list"), messages).Flex(1),
 		),
 	).Width(35).Border(rego.BorderSingle).BorderColor(borderColor).Padding(1, 1).Flex(1)
 }
 
-// MessageItem 消息项
-func MessageItem(c rego.C, msg Message) rego.Node {
-	var prefix string
-	var color rego.Color
-
-	switch msg.Role {
-	case "user":
-		prefix = "👤 "
-		color = rego.Blue
-	case "assistant":
-		prefix = "🤖 "
-		color = rego.Cyan
-	case "system":
-		prefix = "💡 "
-		color = rego.Yellow
-	}
-
-	// 不再截断消息，使用 Markdown 渲染
-	content := msg.Content
-
-	return rego.VStack(
-		rego.Text(prefix).Bold().Color(color),
-		rego.Box(
-			rego.Markdown(content),
-		).Padding(0, 1),
-	)
-}
-
 // =============================================================================
 // InputPanel 组件 - 输入区域
 // =============================================================================
 
-func InputPanel(c rego.C, inputText *rego.State[string], thinking bool, streamingText string, active bool, onSubmit func(string)) rego.Node {
+func InputPanel(c rego.C, inputText *rego.State[string], thinking bool, active bool, onSubmit func(string)) rego.Node {
 	borderColor := rego.Gray
 	if active {
 		borderColor = rego.Green
@@ -189,15 +152,8 @@ func InputPanel(c rego.C, inputText *rego.State[string], thinking bool, streamin
 			rego.Text(strings.Repeat("─", 30)),
 			rego.Text(""),
 
-			// 思考/流式输出区域
-			rego.When(thinking,
-				rego.VStack(
-					rego.Text("🔄 思考中...").Color(rego.Yellow),
-					rego.When(len(streamingText) > 0,
-						rego.Text(streamingText).Color(rego.Cyan),
-					),
-				),
-			),
+			// 思考状态，回复内容在对话历史中流式显示
+			rego.When(thinking, rego.Text("🔄 思考中...").Color(rego.Yellow)),
 
 			rego.Spacer(),
 
@@ -277,27 +233,29 @@ func Footer(c rego.C) rego.Node {
 // 模拟 AI 响应
 // =============================================================================
 
-func simulateResponse(c rego.C, messages *rego.State[[]Message], isThinking *rego.State[bool], streamingText *rego.State[string]) {
+func simulateResponse(c rego.C, messages *rego.State[[]rego.ChatMessage], isThinking *rego.State[bool], reply *rego.Stream) {
 	// 模拟思考延迟
 	time.Sleep(500 * time.Millisecond)
 
 	// 模拟流式输出
 	response := "收到您的消息！\n\n### Rego 框架特点\n- **Hooks 风格**: 熟悉的状态管理\n- **声明式 UI**: 简单直观的布局\n\n```go\nfunc Hello(c rego.C) rego.Node {\n    return rego.Text(\"Hello Markdown!\")\n}\n```\n\n构建这类复杂 TUI 变得非常简单！"
 
-	for i := range response {
-		streamingText.Set(response[:i+1])
+	for _, r := range response {
+		reply.WriteString(string(r))
 		time.Sleep(30 * time.Millisecond)
 	}
 
 	// 完成响应
 	time.Sleep(200 * time.Millisecond)
 
-	messages.Set(append(messages.Val, Message{
-		Role:    "assistant",
+	messages.Set(append(messages.Val, rego.ChatMessage{
+		ID:      strconv.Itoa(len(messages.Val)),
+		Role:    rego.RoleAssistant,
 		Content: response,
+		Time:    time.Now(),
 	}))
 	isThinking.Set(false)
-	streamingText.Set("")
+	reply.Reset()
 }
 
 func main() {
@@ -23,11 +23,14 @@ type Handler interface {
 	Ask(Question) bool
 }
 
+// sleep 模拟耗时操作，测试中可替换为空实现
+var sleep = time.Sleep
+
 // Run 是业务主逻辑
 func Run(h Handler) {
 	state := AppState{Status: "准备中...", Logs: []string{"初始化系统接口..."}}
 	h.Update(state)
-	time.Sleep(1 * time.Second)
+	sleep(1 * time.Second)
 
 	// 第一阶段：扫描
 	state.Status = "正在扫描冗余文件..."
@@ -35,7 +38,7 @@ func Run(h Handler) {
 		state.Progress = i
 		state.Logs = append(state.Logs, fmt.Sprintf("扫描路径 /var/log/sys_%d.log", i))
 		h.Update(state)
-		time.Sleep(400 * time.Millisecond)
+		sleep(400 * time.Millisecond)
 	}
 
 	// 第二阶段：交互请求
@@ -54,7 +57,7 @@ func Run(h Handler) {
 		for i := 0; i <= 100; i += 10 {
 			state.Progress = i
 			h.Update(state)
-			time.Sleep(200 * time.Millisecond)
+			sleep(200 * time.Millisecond)
 		}
 		state.Status = "清理完成"
 		state.Logs = append(state.Logs, "系统优化已完成！")
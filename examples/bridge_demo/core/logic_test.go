@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erweixin/rego"
+	regotest "github.com/erweixin/rego/testing"
+)
+
+func TestRun_Confirmed(t *testing.T) {
+	sleep = func(time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+
+	driver := regotest.NewBridgeDriver[AppState, Question, bool](t, true)
+	driver.Run(func(h rego.Handle[AppState, Question, bool]) { Run(h) })
+
+	questions := driver.Questions()
+	if len(questions) != 1 || questions[0].Title != "清理确认" {
+		t.Fatalf("expected one confirmation question, got %+v", questions)
+	}
+	if last := driver.LastState(); last.Status != "清理完成" || last.Progress != 100 {
+		t.Errorf("unexpected final state: %+v", last)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	sleep = func(time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+
+	driver := regotest.NewBridgeDriver[AppState, Question, bool](t, false)
+	driver.Run(func(h rego.Handle[AppState, Question, bool]) { Run(h) })
+
+	if last := driver.LastState(); last.Status != "已取消" {
+		t.Errorf("expected cancelled state, got %+v", last)
+	}
+}
@@ -11,6 +11,9 @@ import (
 // Counter 示例 - 展示状态管理、按钮组件和多面板布局
 // =============================================================================
 
+// countBus 在计数器面板和历史记录面板之间传递计数变化
+var countBus = rego.CreateEventBus[int]()
+
 func App(c rego.C) rego.Node {
 	activePanel := rego.Use(c, "activePanel", 0) // 0: 计数器, 1: 历史记录
 
@@ -77,6 +80,12 @@ func CounterPanel(c rego.C, active bool) rego.Node {
 	count := rego.Use(c, "count", 0)
 	step := rego.Use(c, "step", 1)
 
+	// 计数变化时通知历史记录面板
+	rego.UseEffect(c, func() func() {
+		countBus.Emit(count.Val)
+		return nil
+	}, count.Val)
+
 	// 只在激活时处理面板特定的按键
 	if active {
 		rego.UseKey(c, func(key rego.Key, r rune) {
@@ -173,8 +182,12 @@ func HistoryPanel(c rego.C, active bool) rego.Node {
 	history := rego.Use(c, "history", []int{0})
 	selected := rego.Use(c, "selected", 0)
 
-	// 监听计数器变化（通过共享 context）
-	// 这里简化处理，只展示布局
+	// 监听计数器变化
+	rego.UseEventBus(c, countBus, func(v int) {
+		if last := history.Val[len(history.Val)-1]; last != v {
+			history.Set(append(history.Val, v))
+		}
+	})
 
 	if active {
 		rego.UseKey(c, func(key rego.Key, r rune) {
@@ -13,55 +13,10 @@ import (
 // 自定义 Hooks - 展示代码复用和组合的优雅
 // =============================================================================
 
-// useInterval 封装定时任务
-func useInterval(c rego.C, callback func(), delay time.Duration) {
-	rego.UseEffect(c, func() func() {
-		ticker := time.NewTicker(delay)
-		go func() {
-			for range ticker.C {
-				callback()
-			}
-		}()
-		return ticker.Stop
-	})
-}
-
-// useSystemStats 模拟系统数据抓取
-func useSystemStats(c rego.C) (cpu int, mem int, net int) {
-	cpuState := rego.Use(c, "cpu", 20)
-	memState := rego.Use(c, "mem", 45)
-	netState := rego.Use(c, "net", 10)
-
-	useInterval(c, func() {
-		cpuState.Update(func(v int) int {
-			delta := rand.Intn(11) - 5 // -5 to +5
-			newVal := v + delta
-			if newVal < 0 {
-				newVal = 0
-			}
-			if newVal > 100 {
-				newVal = 100
-			}
-			return newVal
-		})
-		memState.Update(func(v int) int {
-			delta := rand.Intn(5) - 2 // -2 to +2
-			newVal := v + delta
-			if newVal < 0 {
-				newVal = 0
-			}
-			if newVal > 100 {
-				newVal = 100
-			}
-			return newVal
-		})
-		netState.Update(func(v int) int {
-			return rand.Intn(100)
-		})
-		c.Refresh()
-	}, 800*time.Millisecond)
-
-	return cpuState.Val, memState.Val, netState.Val
+// useSystemStats 每 800ms 采样一次系统数据
+func useSystemStats(c rego.C) rego.PollState[systemStats] {
+	sampler := rego.UseRef(c, &statsSampler{})
+	return rego.UsePoll(c, 800*time.Millisecond, sampler.Current.sample)
 }
 
 // =============================================================================
@@ -90,14 +45,15 @@ func ProgressBar(label string, percent int, color rego.Color) rego.Node {
 // =============================================================================
 
 func App(c rego.C) rego.Node {
-	// 获取模拟数据
-	cpu, mem, net := useSystemStats(c)
+	// 获取系统数据
+	stats := useSystemStats(c)
+	cpu, mem := stats.Data.CPU, stats.Data.Mem
 	now := rego.Use(c, "now", time.Now())
 
 	// 更新时间
-	useInterval(c, func() {
+	rego.UseInterval(c, time.Second, func() {
 		now.Set(time.Now())
-	}, time.Second)
+	})
 
 	// 日志列表
 	logs := rego.Use(c, "logs", []string{
@@ -107,13 +63,13 @@ func App(c rego.C) rego.Node {
 	})
 
 	// 模拟日志增长
-	useInterval(c, func() {
+	rego.UseInterval(c, 2*time.Second, func() {
 		newLogs := append(logs.Val, fmt.Sprintf("[%s] Event detected: %d", time.Now().Format("15:04:05"), rand.Intn(1000)))
 		if len(newLogs) > 8 {
 			newLogs = newLogs[1:]
 		}
 		logs.Set(newLogs)
-	}, 2*time.Second)
+	})
 
 	return rego.VStack(
 		// 顶部：标题和时间
@@ -139,28 +95,36 @@ func App(c rego.C) rego.Node {
 						rego.Text(""),
 						ProgressBar("MEM", mem, rego.Green),
 						rego.Text(""),
-						ProgressBar("NET", net, rego.Blue),
+						rego.HStack(
+							rego.Text(fmt.Sprintf("%-6s", "NET")).Bold(),
+							rego.Text(fmt.Sprintf("%.1f KB/s", stats.Data.NetKBps)).Color(rego.Blue),
+						),
 						rego.Spacer(),
-						rego.Text("Status: ONLINE").Color(rego.Green).Dim(),
+						rego.WhenElse(stats.Err != nil,
+							rego.Text("Status: "+errString(stats.Err)).Color(rego.Red),
+							rego.Text("Status: ONLINE, updated "+stats.LastUpdated.Format("15:04:05")).Color(rego.Green).Dim(),
+						),
 					),
 				),
 			).Border(rego.BorderSingle).Padding(1, 2).Flex(1),
 
 			rego.Text("  "),
 
-			// 右侧：系统日志
-			rego.Box(
-				rego.ScrollBox(c.Child("logs-scroll"),
-					rego.VStack(
-						rego.Text("📜 SYSTEM LOGS").Bold().Underline(),
-						rego.Text(""),
-						rego.For(logs.Val, func(log string, i int) rego.Node {
-							return rego.Text("> " + log).Dim()
-						}),
-						rego.Spacer(),
+			// 右侧：系统日志（只在日志变化时重新构建，时钟每秒刷新不影响它）
+			rego.Memo(c, "logs-panel", func(c rego.C) rego.Node {
+				return rego.Box(
+					rego.ScrollBox(c.Child("logs-scroll"),
+						rego.VStack(
+							rego.Text("📜 SYSTEM LOGS").Bold().Underline(),
+							rego.Text(""),
+							rego.For(logs.Val, func(log string, i int) rego.Node {
+								return rego.Text("> " + log).Dim()
+							}),
+							rego.Spacer(),
+						),
 					),
-				),
-			).Border(rego.BorderSingle).Padding(1, 2).Flex(1),
+				).Border(rego.BorderSingle).Padding(1, 2).Flex(1)
+			}, logs.Val),
 		).Flex(1),
 
 		rego.Text(""),
@@ -177,6 +141,13 @@ func App(c rego.C) rego.Node {
 	)
 }
 
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
 func main() {
 	if err := rego.Run(App); err != nil {
 		fmt.Printf("Error: %v\n", err)
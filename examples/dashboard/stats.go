@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// systemStats 一次系统采样
+type systemStats struct {
+	CPU     int     // CPU 占用百分比
+	Mem     int     // 内存占用百分比
+	NetKBps float64 // 所有网卡的收发速率（KB/s）
+}
+
+// statsSampler 读取 /proc 中的系统数据；CPU 和网络速率需要与上一次采样做差
+type statsSampler struct {
+	busy, total uint64
+	netBytes    uint64
+	at          time.Time
+}
+
+var errStatsUnsupported = errors.New("system stats are only available on Linux")
+
+// sample 采样一次，首次调用时 CPU 和网络速率为 0
+func (s *statsSampler) sample(ctx context.Context) (systemStats, error) {
+	if err := ctx.Err(); err != nil {
+		return systemStats{}, err
+	}
+	busy, total, err := readCPU()
+	if err != nil {
+		return systemStats{}, err
+	}
+	mem, err := readMem()
+	if err != nil {
+		return systemStats{}, err
+	}
+	netBytes, err := readNet()
+	if err != nil {
+		return systemStats{}, err
+	}
+
+	now := time.Now()
+	stats := systemStats{Mem: mem}
+	if !s.at.IsZero() {
+		if dt := total - s.total; dt > 0 {
+			stats.CPU = int((busy - s.busy) * 100 / dt)
+		}
+		if secs := now.Sub(s.at).Seconds(); secs > 0 && netBytes >= s.netBytes {
+			stats.NetKBps = float64(netBytes-s.netBytes) / 1024 / secs
+		}
+	}
+	s.busy, s.total, s.netBytes, s.at = busy, total, netBytes, now
+	return stats, nil
+}
+
+// readCPU 读取 /proc/stat 中所有 CPU 的累计忙碌时间和总时间
+func readCPU() (busy, total uint64, err error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return 0, 0, errStatsUnsupported
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, fmt.Errorf("unexpected /proc/stat format")
+	}
+	for i, f := range fields[1:] {
+		v, err := strconv.ParseUint(f, 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		total += v
+		if i != 3 && i != 4 { // idle、iowait 不计入忙碌时间
+			busy += v
+		}
+	}
+	return busy, total, nil
+}
+
+// readMem 根据 /proc/meminfo 计算内存占用百分比
+func readMem() (int, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, errStatsUnsupported
+	}
+	defer f.Close()
+
+	var total, available uint64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		v, _ := strconv.ParseUint(fields[1], 10, 64)
+		switch fields[0] {
+		case "MemTotal:":
+			total = v
+		case "MemAvailable:":
+			available = v
+		}
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("unexpected /proc/meminfo format")
+	}
+	return int((total - available) * 100 / total), nil
+}
+
+// readNet 读取 /proc/net/dev 中所有网卡（不含 lo）累计收发的字节数
+func readNet() (uint64, error) {
+	data, err := os.ReadFile("/proc/net/dev")
+	if err != nil {
+		return 0, errStatsUnsupported
+	}
+	var sum uint64
+	for _, line := range strings.Split(string(data), "\n") {
+		name, rest, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "lo" {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) < 9 {
+			continue
+		}
+		rx, _ := strconv.ParseUint(fields[0], 10, 64)
+		tx, _ := strconv.ParseUint(fields[8], 10, 64)
+		sum += rx + tx
+	}
+	return sum, nil
+}
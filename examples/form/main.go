@@ -6,11 +6,25 @@ import (
 )
 
 func App(c rego.C) rego.Node {
-	username := rego.Use(c, "username", "")
-	password := rego.Use(c, "password", "")
 	bio := rego.Use(c, "bio", "这是一段很长很长很长很长很长很长很长很长的自我介绍，用来测试滚动。")
 	submitted := rego.Use(c, "submitted", false)
 
+	form := rego.UseForm(c, rego.FormSchema{
+		Fields: map[string]rego.FieldSchema{
+			"username": {Validators: []rego.Validator{
+				rego.Required("请输入用户名"),
+				rego.Pattern(`^[a-zA-Z0-9_]+$`, "用户名只能包含字母、数字和下划线"),
+			}},
+			"password": {Validators: []rego.Validator{
+				rego.Required("请输入密码"),
+				rego.MinLength(6, "密码至少 6 位"),
+			}},
+		},
+		OnSubmit: func(values map[string]string) {
+			submitted.Set(true)
+		},
+	})
+
 	rego.UseKey(c, func(key rego.Key, r rune) {
 		if r == 'q' {
 			c.Quit()
@@ -24,23 +38,19 @@ func App(c rego.C) rego.Node {
 			rego.Text(""),
 
 			// 输入框演示
-			rego.TextInput(c.Child("input-user"), rego.TextInputProps{
+			form.Field("username", rego.TextInputProps{
 				Label:       "用户名",
 				Placeholder: "请输入用户名...",
-				Value:       username.Val,
 				Width:       40,
-				OnChanged:   func(s string) { username.Set(s) },
 			}),
 
 			rego.Text(""),
 
-			rego.TextInput(c.Child("input-pwd"), rego.TextInputProps{
+			form.Field("password", rego.TextInputProps{
 				Label:       "密码",
 				Placeholder: "请输入密码...",
-				Value:       password.Val,
 				Width:       40,
 				Password:    true,
-				OnChanged:   func(s string) { password.Set(s) },
 			}),
 
 			rego.Text(""),
@@ -84,11 +94,11 @@ func App(c rego.C) rego.Node {
 			rego.Text(""),
 
 			Button(c.Child("submit"), "提交表单", func() {
-				submitted.Set(true)
+				form.Submit()
 			}),
 
 			rego.When(submitted.Val,
-				rego.Text(fmt.Sprintf("\n✅ 提交成功！欢迎，%s", username.Val)).Color(rego.Green),
+				rego.Text(fmt.Sprintf("\n✅ 提交成功！欢迎，%s", form.Value("username"))).Color(rego.Green),
 			),
 
 			rego.Spacer(),
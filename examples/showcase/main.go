@@ -368,8 +368,7 @@ func ColorPalette() rego.Node {
 // =============================================================================
 
 func StreamView(c rego.C) rego.Node {
-	text := rego.Use(c, "streamText", "")
-	charIndex := rego.Use(c, "charIndex", 0)
+	round := rego.Use(c, "round", 0) // 每一轮使用新的 Typewriter，从头开始显示
 	isTyping := rego.Use(c, "isTyping", true)
 
 	content := `## 🤖 AI Agent Streaming
@@ -400,25 +399,14 @@ func AgentUI(c rego.C) rego.Node {
 ---
 *This text is being streamed character by character...*`
 
-	rego.UseEffect(c, func() func() {
-		ticker := time.NewTicker(35 * time.Millisecond)
-		go func() {
-			for range ticker.C {
-				runes := []rune(content)
-				if charIndex.Val < len(runes) {
-					charIndex.Update(func(v int) int { return v + 1 })
-					text.Set(string(runes[:charIndex.Val]))
-					isTyping.Set(true)
-				} else {
-					isTyping.Set(false)
-					time.Sleep(3 * time.Second)
-					charIndex.Set(0)
-					text.Set("")
-				}
-			}
-		}()
-		return ticker.Stop
-	})
+	// 显示完后停顿 3 秒再重新开始
+	onDone := func() {
+		isTyping.Set(false)
+		time.AfterFunc(3*time.Second, func() {
+			round.Update(func(v int) int { return v + 1 })
+			isTyping.Set(true)
+		})
+	}
 
 	return rego.Box(
 		rego.VStack(
@@ -435,7 +423,8 @@ func AgentUI(c rego.C) rego.Node {
 			rego.TailBox(c.Child("stream-scroll"),
 				rego.Box(
 					rego.VStack(
-						rego.Markdown(text.Val+rego.If(isTyping.Val, "▍", "")),
+						rego.Typewriter(c.Child("typewriter", round.Val), content).
+							Speed(35*time.Millisecond).Cursor("▍").Markdown().OnDone(onDone),
 					),
 				).Padding(1, 1),
 			).Flex(1),
@@ -65,7 +65,7 @@ func App(c rego.C) rego.Node {
 					rego.For(messages.Val, func(msg string, i int) rego.Node {
 						return rego.VStack(
 							rego.Text(fmt.Sprintf("--- History Message #%d ---", i+1)).Dim(),
-							rego.Markdown(msg),
+							rego.Markdown(msg).CacheKey(fmt.Sprintf("history-%d", i)),
 							rego.Text(""),
 						)
 					}),
@@ -8,7 +8,7 @@ import (
 )
 
 // =============================================================================
-// Timer 示例 - 展示 UseEffect、UseMemo 和精美的 UI 布局
+// Timer 示例 - 展示 UseStopwatch、UseCountdown、UseMemo 和精美的 UI 布局
 // =============================================================================
 
 func App(c rego.C) rego.Node {
@@ -96,63 +96,51 @@ func TabBar(c rego.C, activeIndex int, setActive func(int)) rego.Node {
 // =============================================================================
 
 func StopwatchPanel(c rego.C) rego.Node {
-	seconds := rego.Use(c, "seconds", 0)
-	running := rego.Use(c, "running", false)
+	sw := rego.UseStopwatch(c)
 	laps := rego.Use(c, "laps", []int{})
+	seconds := int(sw.Elapsed / time.Second)
 
 	// 格式化时间
 	formattedTime := rego.UseMemo(c, func() string {
-		h := seconds.Val / 3600
-		m := (seconds.Val % 3600) / 60
-		s := seconds.Val % 60
+		h := seconds / 3600
+		m := (seconds % 3600) / 60
+		s := seconds % 60
 		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
-	}, seconds.Val)
+	}, seconds)
 
-	// UseEffect：创建定时器
-	rego.UseEffect(c, func() func() {
-		if !running.Val {
-			return nil
+	toggle := func() {
+		if sw.Running {
+			sw.Pause()
+		} else {
+			sw.Start()
 		}
-
-		ticker := time.NewTicker(time.Second)
-		done := make(chan bool)
-
-		go func() {
-			for {
-				select {
-				case <-done:
-					return
-				case <-ticker.C:
-					seconds.Update(func(s int) int { return s + 1 })
-				}
-			}
-		}()
-
-		return func() {
-			ticker.Stop()
-			close(done)
+	}
+	reset := func() {
+		sw.Reset()
+		laps.Set([]int{})
+	}
+	lap := func() {
+		if sw.Running {
+			laps.Set(append(laps.Val, seconds))
 		}
-	}, running.Val)
+	}
 
 	// 键盘事件
 	rego.UseKey(c, func(key rego.Key, r rune) {
 		switch r {
 		case ' ':
-			running.Set(!running.Val)
+			toggle()
 		case 'r':
-			seconds.Set(0)
-			laps.Set([]int{})
+			reset()
 		case 'l':
-			if running.Val {
-				laps.Set(append(laps.Val, seconds.Val))
-			}
+			lap()
 		}
 	})
 
 	// 状态文本
 	statusText := "▶ 运行中"
 	statusColor := rego.Green
-	if !running.Val {
+	if !sw.Running {
 		statusText = "⏸ 已暂停"
 		statusColor = rego.Yellow
 	}
@@ -177,26 +165,19 @@ func StopwatchPanel(c rego.C) rego.Node {
 				// 控制按钮
 				rego.HStack(
 					rego.Button(c.Child("btn-start"), rego.ButtonProps{
-						Label:   rego.If(running.Val, " ⏸ 暂停 ", " ▶ 开始 "),
-						Primary: !running.Val,
-						OnClick: func() { running.Set(!running.Val) },
+						Label:   rego.If(sw.Running, " ⏸ 暂停 ", " ▶ 开始 "),
+						Primary: !sw.Running,
+						OnClick: toggle,
 					}),
 					rego.Text(" "),
 					rego.Button(c.Child("btn-lap"), rego.ButtonProps{
 						Label:   " 📍 计圈 ",
-						OnClick: func() {
-							if running.Val {
-								laps.Set(append(laps.Val, seconds.Val))
-							}
-						},
+						OnClick: lap,
 					}),
 					rego.Text(" "),
 					rego.Button(c.Child("btn-reset"), rego.ButtonProps{
-						Label: " ↺ 重置 ",
-						OnClick: func() {
-							seconds.Set(0)
-							laps.Set([]int{})
-						},
+						Label:   " ↺ 重置 ",
+						OnClick: reset,
 					}),
 				),
 
@@ -244,9 +225,8 @@ func StopwatchPanel(c rego.C) rego.Node {
 
 func CountdownPanel(c rego.C) rego.Node {
 	totalSeconds := rego.Use(c, "total", 300) // 默认 5 分钟
-	remaining := rego.Use(c, "remaining", 300)
-	running := rego.Use(c, "running", false)
-	finished := rego.Use(c, "finished", false)
+	cd := rego.UseCountdown(c, time.Duration(totalSeconds.Val)*time.Second)
+	remaining := int((cd.Remaining + time.Second - 1) / time.Second) // 向上取整，归零前不显示 00:00
 
 	// 预设时间
 	presets := []struct {
@@ -261,86 +241,54 @@ func CountdownPanel(c rego.C) rego.Node {
 
 	// 格式化时间
 	formattedTime := rego.UseMemo(c, func() string {
-		m := remaining.Val / 60
-		s := remaining.Val % 60
+		m := remaining / 60
+		s := remaining % 60
 		return fmt.Sprintf("%02d:%02d", m, s)
-	}, remaining.Val)
+	}, remaining)
 
 	// 计算进度百分比
 	progress := rego.UseMemo(c, func() int {
 		if totalSeconds.Val == 0 {
 			return 0
 		}
-		return (remaining.Val * 100) / totalSeconds.Val
-	}, remaining.Val, totalSeconds.Val)
+		return (remaining * 100) / totalSeconds.Val
+	}, remaining, totalSeconds.Val)
 
-	// UseEffect：倒计时逻辑
-	rego.UseEffect(c, func() func() {
-		if !running.Val || remaining.Val <= 0 {
-			return nil
-		}
-
-		ticker := time.NewTicker(time.Second)
-		done := make(chan bool)
-
-		go func() {
-			for {
-				select {
-				case <-done:
-					return
-				case <-ticker.C:
-					remaining.Update(func(s int) int {
-						if s <= 1 {
-							running.Set(false)
-							finished.Set(true)
-							return 0
-						}
-						return s - 1
-					})
-				}
-			}
-		}()
-
-		return func() {
-			ticker.Stop()
-			close(done)
+	toggle := func() {
+		if cd.Running {
+			cd.Pause()
+		} else {
+			cd.Start()
 		}
-	}, running.Val)
+	}
+	setTotal := func(seconds int) {
+		totalSeconds.Set(seconds)
+		cd.Reset()
+	}
 
 	// 键盘事件
 	rego.UseKey(c, func(key rego.Key, r rune) {
 		switch r {
 		case ' ':
-			if remaining.Val > 0 {
-				running.Set(!running.Val)
-				finished.Set(false)
-			}
+			toggle()
 		case 'r':
-			remaining.Set(totalSeconds.Val)
-			running.Set(false)
-			finished.Set(false)
+			cd.Reset()
 		}
 		switch key {
 		case rego.KeyUp:
-			newTotal := totalSeconds.Val + 60
-			totalSeconds.Set(newTotal)
-			if !running.Val {
-				remaining.Set(newTotal)
+			if !cd.Running {
+				setTotal(totalSeconds.Val + 60)
 			}
 		case rego.KeyDown:
-			if totalSeconds.Val > 60 {
-				newTotal := totalSeconds.Val - 60
-				totalSeconds.Set(newTotal)
-				if !running.Val {
-					remaining.Set(newTotal)
-				}
+			if !cd.Running && totalSeconds.Val > 60 {
+				setTotal(totalSeconds.Val - 60)
 			}
 		}
 	})
 
 	// 状态和颜色
 	displayColor := rego.White
-	if finished.Val {
+	if cd.Finished {
 		displayColor = rego.Red
 	} else if progress < 20 {
 		displayColor = rego.Yellow
@@ -356,7 +304,7 @@ func CountdownPanel(c rego.C) rego.Node {
 			rego.Center(
 				rego.Box(
 					rego.VStack(
-						rego.When(finished.Val,
+						rego.When(cd.Finished,
 							rego.Text("🔔 时间到！").Bold().Color(rego.Red).Blink(),
 						),
 						rego.Text(formattedTime).Bold().Color(displayColor),
@@ -382,12 +330,7 @@ func CountdownPanel(c rego.C) rego.Node {
 						rego.Button(c.Child("preset", i), rego.ButtonProps{
 							Label:   p.label,
 							Primary: totalSeconds.Val == p.seconds,
-							OnClick: func() {
-								totalSeconds.Set(p.seconds)
-								remaining.Set(p.seconds)
-								running.Set(false)
-								finished.Set(false)
-							},
+							OnClick: func() { setTotal(p.seconds) },
 						}),
 						rego.Text(" "),
 					)
@@ -399,23 +342,14 @@ func CountdownPanel(c rego.C) rego.Node {
 			// 控制按钮
 			rego.HStack(
 				rego.Button(c.Child("btn-start"), rego.ButtonProps{
-					Label:   rego.If(running.Val, " ⏸ 暂停 ", " ▶ 开始 "),
-					Primary: !running.Val && remaining.Val > 0,
-					OnClick: func() {
-						if remaining.Val > 0 {
-							running.Set(!running.Val)
-							finished.Set(false)
-						}
-					},
+					Label:   rego.If(cd.Running, " ⏸ 暂停 ", " ▶ 开始 "),
+					Primary: !cd.Running && !cd.Finished,
+					OnClick: toggle,
 				}),
 				rego.Text(" "),
 				rego.Button(c.Child("btn-reset"), rego.ButtonProps{
-					Label: " ↺ 重置 ",
-					OnClick: func() {
-						remaining.Set(totalSeconds.Val)
-						running.Set(false)
-						finished.Set(false)
-					},
+					Label:   " ↺ 重置 ",
+					OnClick: cd.Reset,
 				}),
 			),
 
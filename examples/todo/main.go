@@ -34,21 +34,14 @@ func App(c rego.C) rego.Node {
 	activePanel := rego.Use(c, "activePanel", 0) // 0: 列表, 1: 输入
 
 	rego.UseKey(c, func(key rego.Key, r rune) {
-		switch key {
-		case rego.KeyTab:
+		if key == rego.KeyTab {
 			activePanel.Set((activePanel.Val + 1) % 2)
 		}
-		switch r {
-		case '1':
-			filter.Set(FilterAll)
-		case '2':
-			filter.Set(FilterActive)
-		case '3':
-			filter.Set(FilterCompleted)
-		case 'q':
-			c.Quit()
-		}
 	})
+	rego.UseShortcut(c, "1", func() { filter.Set(FilterAll) })
+	rego.UseShortcut(c, "2", func() { filter.Set(FilterActive) })
+	rego.UseShortcut(c, "3", func() { filter.Set(FilterCompleted) })
+	rego.UseShortcut(c, "q", c.Quit)
 
 	// 过滤后的任务列表
 	filteredTodos := rego.UseMemo(c, func() []Todo {
@@ -175,6 +168,7 @@ func FilterBar(c rego.C, current int, setFilter func(int)) rego.Node {
 
 func TodoList(c rego.C, filteredTodos []Todo, allTodos *rego.State[[]Todo], active bool) rego.Node {
 	selected := rego.Use(c, "selected", 0)
+	confirm := rego.UseConfirm(c)
 
 	// 处理键盘事件
 	if active {
@@ -196,12 +190,19 @@ func TodoList(c rego.C, filteredTodos []Todo, allTodos *rego.State[[]Todo], acti
 			}
 			switch r {
 			case 'd':
-				// 删除任务
+				// 删除任务（需要确认）
 				if len(filteredTodos) > 0 && selected.Val < len(filteredTodos) {
-					deleteTodo(allTodos, filteredTodos[selected.Val].Text)
-					if selected.Val >= len(filteredTodos)-1 && selected.Val > 0 {
-						selected.Set(selected.Val - 1)
-					}
+					text := filteredTodos[selected.Val].Text
+					last := selected.Val >= len(filteredTodos)-1 && selected.Val > 0
+					go func() {
+						if !<-confirm.Show(rego.ConfirmProps{Title: "删除任务？", Body: text, Danger: true, ConfirmLabel: "删除"}) {
+							return
+						}
+						deleteTodo(allTodos, text)
+						if last {
+							selected.Set(selected.Val - 1)
+						}
+					}()
 				}
 			case 'x':
 				// 清除已完成
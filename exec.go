@@ -0,0 +1,175 @@
+package rego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// UseExec / ExecView - 运行外部命令并流式显示输出
+// =============================================================================
+
+// execMaxOutput 保留的最大输出字节数，超出时丢弃最早的行
+const execMaxOutput = 1 << 20
+
+// ExecState 是 UseExec 返回的命令运行状态
+type ExecState struct {
+	Output   string // stdout 与 stderr 合并后的输出，保留 ANSI 转义序列
+	Running  bool
+	Done     bool  // 命令已结束
+	ExitCode int   // 退出码，命令结束后有效
+	Err      error // 启动失败或非正常退出时的错误
+
+	restart func()
+}
+
+// Restart 重新运行命令
+func (s ExecState) Restart() {
+	if s.restart != nil {
+		s.restart()
+	}
+}
+
+// execBuffer 在命令的输出协程和渲染之间共享状态
+type execBuffer struct {
+	mu    sync.Mutex
+	gen   int // 每次启动递增，用于丢弃上一次运行的迟到输出
+	out   strings.Builder
+	state ExecState
+}
+
+func (b *execBuffer) start() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gen++
+	b.out.Reset()
+	b.state = ExecState{Running: true}
+	return b.gen
+}
+
+func (b *execBuffer) write(gen int, p []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gen != b.gen {
+		return
+	}
+	b.out.Write(p)
+	if b.out.Len() > execMaxOutput {
+		s := b.out.String()
+		s = s[len(s)-execMaxOutput:]
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			s = s[i+1:]
+		}
+		b.out.Reset()
+		b.out.WriteString(s)
+	}
+}
+
+func (b *execBuffer) finish(gen int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gen != b.gen {
+		return
+	}
+	b.state.Running = false
+	b.state.Done = true
+	b.state.Err = err
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		b.state.ExitCode = 0
+	case errors.As(err, &exitErr):
+		b.state.ExitCode = exitErr.ExitCode()
+	default:
+		b.state.ExitCode = -1
+	}
+}
+
+func (b *execBuffer) snapshot() ExecState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.state
+	s.Output = b.out.String()
+	return s
+}
+
+// execWriter 将命令输出写入缓冲区并触发刷新
+type execWriter struct {
+	buf *execBuffer
+	gen int
+	c   C
+}
+
+func (w *execWriter) Write(p []byte) (int, error) {
+	w.buf.write(w.gen, p)
+	w.c.Refresh()
+	return len(p), nil
+}
+
+// UseExec 在组件挂载时运行 cmd，并将 stdout/stderr 流式写入状态
+//
+// cmd 仅作为模板（使用其 Path、Args、Dir、Env），每次运行都会复制一份新的命令，
+// 因此每帧重新构造相同的命令不会导致重复运行；命令行变化或调用 Restart 时重新运行。
+// 组件被释放时（见 UseEffect）终止仍在运行的进程；组件只是不再渲染时进程继续运行。
+func UseExec(c C, cmd *exec.Cmd) ExecState {
+	buf := UseRef(c, &execBuffer{})
+	runs := Use(c, "execRuns", 0)
+
+	cmdline := ""
+	if cmd != nil {
+		cmdline = cmd.String()
+	}
+
+	UseEffect(c, func() func() {
+		if cmd == nil {
+			return nil
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		proc := exec.CommandContext(ctx, cmd.Path, cmd.Args[1:]...)
+		proc.Dir = cmd.Dir
+		proc.Env = cmd.Env
+		proc.Stdin = cmd.Stdin
+
+		gen := buf.Current.start()
+		w := &execWriter{buf: buf.Current, gen: gen, c: c}
+		proc.Stdout = w
+		proc.Stderr = w
+
+		go func() {
+			err := proc.Run()
+			buf.Current.finish(gen, err)
+			c.Refresh()
+		}()
+		return cancel
+	}, cmdline, runs.Val)
+
+	state := buf.Current.snapshot()
+	state.restart = func() { runs.Update(func(v int) int { return v + 1 }) }
+	return state
+}
+
+// ExecView 以跟随滚动的方式显示命令输出，底部显示运行状态
+func ExecView(c C, state ExecState) Node {
+	var status Node
+	switch {
+	case state.Running:
+		status = Spinner(c.Child("spinner"), "running")
+	case state.Done && state.ExitCode == 0:
+		status = Text("✓ exited 0").Color(Green)
+	case state.Done && state.ExitCode > 0:
+		status = Text(fmt.Sprintf("✗ exited %d", state.ExitCode)).Color(Red)
+	case state.Err != nil:
+		status = Text("✗ " + state.Err.Error()).Color(Red)
+	default:
+		status = Text("not started").Dim()
+	}
+
+	return c.Wrap(VStack(
+		TailBox(c.Child("output"), Ansi(state.Output).Wrap(true)),
+		status,
+	))
+}
@@ -0,0 +1,37 @@
+package rego
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUseExec_StreamsOutputAndExitCode(t *testing.T) {
+	var state ExecState
+	app := func(c C) Node {
+		state = UseExec(c, exec.Command("sh", "-c", "echo hello; echo oops 1>&2; exit 3"))
+		return ExecView(c.Child("view"), state)
+	}
+
+	screen := newTestScreen(30, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !state.Done && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		tr.Render()
+	}
+	tr.Render()
+
+	if !state.Done || state.ExitCode != 3 {
+		t.Fatalf("expected command to exit with 3, got %+v", state)
+	}
+	if !strings.Contains(state.Output, "hello") || !strings.Contains(state.Output, "oops") {
+		t.Errorf("expected stdout and stderr in output, got %q", state.Output)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "✗ exited 3") {
+		t.Errorf("expected exit status to be shown, got:\n%s", content)
+	}
+}
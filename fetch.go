@@ -0,0 +1,188 @@
+package rego
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// UseFetch - 带缓存的 HTTP 请求
+// =============================================================================
+
+// FetchOpts 配置 UseFetch
+type FetchOpts[T any] struct {
+	// TTL 缓存的有效期：期内直接使用缓存不发请求；过期后先显示旧数据，同时在后台重新请求。
+	// 为 0 时每次都重新请求（仍会先显示缓存）
+	TTL time.Duration
+
+	// Decode 把响应体解码为 T，默认按 JSON 解码
+	Decode func(body []byte) (T, error)
+
+	// Client 发送请求使用的客户端，默认为 http.DefaultClient
+	Client *http.Client
+}
+
+// fetchEntry 是一个 URL 的缓存
+type fetchEntry struct {
+	url     string
+	body    []byte
+	at      time.Time     // 获取的时间，零值表示还没有成功获取过
+	pending chan struct{} // 正在进行的请求，完成时关闭
+	err     error         // 最近一次请求的错误
+}
+
+// fetchCacheSize 缓存的 URL 数，超出时淘汰最久未使用的
+const fetchCacheSize = 256
+
+// fetchCache 是进程内按 URL 共享的响应缓存（LRU）
+var fetchCache = struct {
+	mu      sync.Mutex
+	ll      *list.List // 元素为 *fetchEntry，最近使用的在前
+	entries map[string]*list.Element
+}{ll: list.New(), entries: map[string]*list.Element{}}
+
+// fetchEntryLocked 返回 url 的缓存并标记为最近使用，create 为 true 时不存在则创建；调用方需持有 fetchCache.mu
+func fetchEntryLocked(url string, create bool) *fetchEntry {
+	if el, ok := fetchCache.entries[url]; ok {
+		fetchCache.ll.MoveToFront(el)
+		return el.Value.(*fetchEntry)
+	}
+	if !create {
+		return nil
+	}
+	e := &fetchEntry{url: url}
+	fetchCache.entries[url] = fetchCache.ll.PushFront(e)
+	for fetchCache.ll.Len() > fetchCacheSize {
+		oldest := fetchCache.ll.Back()
+		fetchCache.ll.Remove(oldest)
+		delete(fetchCache.entries, oldest.Value.(*fetchEntry).url) // 正在进行的请求完成后仍会通知等待者
+	}
+	return e
+}
+
+// UseFetch 以 GET 请求 url 并解码响应，结果按 URL 缓存在进程内，多个组件共享同一份缓存
+//
+// 缓存过期（stale-while-revalidate）时先返回旧数据，Loading 为 true，后台请求完成后刷新；
+// 组件保持挂载时，缓存到期后也会自动重新请求。同一 URL 同时只有一个请求在进行，
+// 缓存最多保留 fetchCacheSize 个 URL。url 变化时重新请求；非 2xx 响应作为错误返回。
+//
+//	users := rego.UseFetch(c, api+"/users", rego.FetchOpts[[]User]{TTL: time.Minute})
+//	if users.Err != nil { ... }
+func UseFetch[T any](c C, url string, opts FetchOpts[T]) AsyncState[T] {
+	ctx := c.(*componentContext)
+	decode := opts.Decode
+	if decode == nil {
+		decode = func(body []byte) (T, error) {
+			var v T
+			err := json.Unmarshal(body, &v)
+			return v, err
+		}
+	}
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	// 缓存到期时递增，作为 UseAsync 的依赖触发重新请求
+	expired := Use(c, "expired", 0)
+	state := UseAsync(c, func() (T, error) {
+		body, err := fetchCached(client, url, opts.TTL, ctx.clock().Now)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return decode(body)
+	}, url, expired.Val)
+
+	body, at := peekFetchCache(url)
+	UseEffect(c, func() func() {
+		if at.IsZero() || opts.TTL <= 0 {
+			return nil
+		}
+		left := at.Add(opts.TTL).Sub(ctx.clock().Now())
+		if left <= 0 {
+			return nil // 已经过期：挂载或 url 变化时的请求会重新获取
+		}
+		return ctx.clock().AfterFunc(left, func() {
+			expired.Update(func(n int) int { return n + 1 })
+		})
+	}, url, at, opts.TTL)
+
+	// 加载期间显示缓存中的旧数据
+	cached := UseMemo(c, func() *T {
+		if at.IsZero() {
+			return nil
+		}
+		v, err := decode(body)
+		if err != nil {
+			return nil
+		}
+		return &v
+	}, url, at)
+	if state.Loading && cached != nil {
+		state.Data = *cached
+	}
+	return state
+}
+
+// peekFetchCache 返回 url 的缓存内容与获取时间，没有缓存时时间为零值
+func peekFetchCache(url string) ([]byte, time.Time) {
+	fetchCache.mu.Lock()
+	defer fetchCache.mu.Unlock()
+	if e := fetchEntryLocked(url, false); e != nil {
+		return e.body, e.at
+	}
+	return nil, time.Time{}
+}
+
+// fetchCached 返回 url 的响应体：缓存未过期时直接返回，否则发起请求（或等待正在进行的请求）
+func fetchCached(client *http.Client, url string, ttl time.Duration, now func() time.Time) ([]byte, error) {
+	fetchCache.mu.Lock()
+	e := fetchEntryLocked(url, true)
+	if !e.at.IsZero() && ttl > 0 && now().Sub(e.at) < ttl {
+		body := e.body
+		fetchCache.mu.Unlock()
+		return body, nil
+	}
+	if e.pending != nil {
+		done := e.pending
+		fetchCache.mu.Unlock()
+		<-done
+		fetchCache.mu.Lock()
+		defer fetchCache.mu.Unlock()
+		return e.body, e.err
+	}
+	done := make(chan struct{})
+	e.pending = done
+	fetchCache.mu.Unlock()
+
+	body, err := httpGet(client, url)
+
+	fetchCache.mu.Lock()
+	defer fetchCache.mu.Unlock()
+	e.err = err
+	if err == nil {
+		e.body, e.at = body, now()
+	}
+	e.pending = nil
+	close(done)
+	return e.body, err
+}
+
+// httpGet 发送 GET 请求并读取响应体
+func httpGet(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("rego: GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
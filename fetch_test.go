@@ -0,0 +1,152 @@
+package rego
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUseFetch_CacheAndRevalidate(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		if n > 1 {
+			<-release
+		}
+		fmt.Fprintf(w, `{"name":"v%d"}`, n)
+	}))
+	defer srv.Close()
+	url := srv.URL + "/user"
+
+	type user struct{ Name string }
+	view := func(c C) Node {
+		u := UseFetch(c, url, FetchOpts[user]{TTL: time.Minute})
+		return Text(fmt.Sprintf("%s loading=%v", u.Data.Name, u.Loading))
+	}
+	app := func(c C) Node {
+		return VStack(view(c.Child("a")), view(c.Child("b")))
+	}
+	waitFor := func(tr *Runtime, content func() string, want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			tr.Render()
+			if strings.Count(content(), want) == 2 {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("expected %q twice, got:\n%s", want, content())
+	}
+
+	// 两个组件共享同一个请求
+	screen := newTestScreen(30, 2)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	content := func() string { return getScreenContent(screen) }
+	waitFor(tr, content, "v1 loading=false")
+	if n := hits.Load(); n != 1 {
+		t.Fatalf("hits = %d, want 1", n)
+	}
+
+	// 有效期内重新挂载直接使用缓存
+	screen = newTestScreen(30, 2)
+	tr = NewTestRuntime(app, screen, withFakeClock())
+	waitFor(tr, content, "v1 loading=false")
+	if n := hits.Load(); n != 1 {
+		t.Fatalf("hits = %d, want cache hit", n)
+	}
+
+	// 过期后先显示旧数据，后台重新请求
+	screen = newTestScreen(30, 2)
+	tr = NewTestRuntime(app, screen, withFakeClock())
+	tr.AdvanceTime(2 * time.Minute)
+	waitFor(tr, content, "v1 loading=true")
+	release <- struct{}{}
+	waitFor(tr, content, "v2 loading=false")
+	if n := hits.Load(); n != 2 {
+		t.Errorf("hits = %d, want 2", n)
+	}
+}
+
+func TestUseFetch_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	app := func(c C) Node {
+		res := UseFetch(c, srv.URL+"/missing", FetchOpts[string]{
+			Decode: func(body []byte) (string, error) { return string(body), nil },
+		})
+		if res.Err != nil {
+			return Text("error: " + res.Err.Error())
+		}
+		return Text("ok")
+	}
+	screen := newTestScreen(80, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		tr.Render()
+		if strings.Contains(getScreenContent(screen), "404") {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Errorf("expected 404 error, got %q", getScreenContent(screen))
+}
+
+func TestUseFetch_RevalidatesWhileMounted(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `"v%d"`, hits.Add(1))
+	}))
+	defer srv.Close()
+
+	app := func(c C) Node {
+		res := UseFetch(c, srv.URL+"/status", FetchOpts[string]{TTL: time.Minute})
+		return Text(res.Data)
+	}
+	screen := newTestScreen(10, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			tr.Render()
+			if strings.HasPrefix(getScreenContent(screen), want) {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("expected %q, got %q", want, getScreenContent(screen))
+	}
+
+	waitFor("v1")
+	tr.AdvanceTime(30 * time.Second)
+	tr.Render()
+	if n := hits.Load(); n != 1 {
+		t.Fatalf("hits = %d before expiry, want 1", n)
+	}
+	// 不需要重新挂载，到期后自动重新请求
+	tr.AdvanceTime(31 * time.Second)
+	waitFor("v2")
+}
+
+func TestFetchCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	fetchCache.mu.Lock()
+	defer fetchCache.mu.Unlock()
+	first := fetchEntryLocked("test://evict/0", true)
+	for i := 1; i <= fetchCacheSize; i++ {
+		fetchEntryLocked(fmt.Sprintf("test://evict/%d", i), true)
+	}
+	if n := fetchCache.ll.Len(); n > fetchCacheSize {
+		t.Errorf("cache holds %d entries, want at most %d", n, fetchCacheSize)
+	}
+	if fetchEntryLocked(first.url, false) != nil {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+}
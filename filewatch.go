@@ -0,0 +1,97 @@
+package rego
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// =============================================================================
+// UseFileWatch - 监听文件变化
+// =============================================================================
+
+// FileWatch 是 UseFileWatch 返回的文件内容
+type FileWatch struct {
+	Content string
+	ModTime time.Time
+	Err     error // 读取或监听失败时的错误，文件被删除时为 os.ErrNotExist 类错误，内容保留旧值
+}
+
+// UseFileWatch 返回 path 的内容，文件在其他程序中被修改后自动重新读取并刷新组件
+//
+// 监听的是文件所在的目录，编辑器以"写临时文件再重命名"的方式保存也能收到变化。
+// path 变化时重新监听，运行时退出时停止监听。
+//
+//	doc := rego.UseFileWatch(c, "README.md")
+//	return rego.Markdown(doc.Content)
+func UseFileWatch(c C, path string) FileWatch {
+	ctx := c.(*componentContext)
+	state := Use(c, "filewatch", FileWatch{})
+
+	run := func(fn func()) {
+		if ctx.runtime != nil {
+			ctx.runtime.post(fn)
+		} else {
+			fn()
+		}
+	}
+
+	UseEffect(c, func() func() {
+		load := func() {
+			data, err := os.ReadFile(path)
+			var mod time.Time
+			if info, statErr := os.Stat(path); statErr == nil {
+				mod = info.ModTime()
+			}
+			run(func() {
+				if err != nil {
+					state.Set(FileWatch{Content: state.Val.Content, ModTime: state.Val.ModTime, Err: err})
+					return
+				}
+				state.Set(FileWatch{Content: string(data), ModTime: mod})
+			})
+		}
+		load()
+
+		watcher, err := fsnotify.NewWatcher()
+		if err == nil {
+			err = watcher.Add(filepath.Dir(path))
+		}
+		if err != nil {
+			run(func() {
+				state.Set(FileWatch{Content: state.Val.Content, ModTime: state.Val.ModTime, Err: err})
+			})
+			if watcher != nil {
+				watcher.Close()
+			}
+			return nil
+		}
+
+		name := filepath.Clean(path)
+		go func() {
+			for {
+				select {
+				case ev, ok := <-watcher.Events:
+					if !ok {
+						return
+					}
+					if filepath.Clean(ev.Name) == name && !ev.Has(fsnotify.Chmod) {
+						load()
+					}
+				case err, ok := <-watcher.Errors:
+					if !ok {
+						return
+					}
+					run(func() {
+						state.Set(FileWatch{Content: state.Val.Content, ModTime: state.Val.ModTime, Err: err})
+					})
+				}
+			}
+		}()
+		return func() { watcher.Close() }
+	}, path)
+
+	return state.Val
+}
@@ -0,0 +1,58 @@
+package rego
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUseFileWatch_ReloadsOnChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.md")
+	if err := os.WriteFile(path, []byte("first"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	app := func(c C) Node {
+		doc := UseFileWatch(c, path)
+		if doc.Err != nil {
+			return Text("error: " + doc.Err.Error())
+		}
+		return Text("doc: " + doc.Content)
+	}
+	screen := newTestScreen(40, 1)
+	tr := NewTestRuntime(app, screen)
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			tr.Render()
+			if strings.Contains(getScreenContent(screen), want) {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("expected %q, got %q", want, getScreenContent(screen))
+	}
+	waitFor("doc: first")
+
+	// 直接写入
+	if err := os.WriteFile(path, []byte("second"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("doc: second")
+
+	// 写临时文件再重命名
+	tmp := filepath.Join(dir, ".doc.md.swp")
+	if err := os.WriteFile(tmp, []byte("third"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("doc: third")
+
+	os.Remove(path)
+	waitFor("error: ")
+}
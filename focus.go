@@ -1,6 +1,9 @@
 package rego
 
-import "sync"
+import (
+	"strings"
+	"sync"
+)
 
 // =============================================================================
 // FocusState - 组件的焦点状态
@@ -59,6 +62,43 @@ func (fm *FocusManager) Register(key string, ctx *componentContext) {
 	}
 }
 
+// count 返回本帧已注册的可聚焦组件数量
+func (fm *FocusManager) count() int {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	return len(fm.focusable)
+}
+
+// registeredSince 返回第 from 个之后注册的可聚焦组件
+func (fm *FocusManager) registeredSince(from int) []focusEntry {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	var entries []focusEntry
+	for _, key := range fm.focusable[from:] {
+		entries = append(entries, focusEntry{key: key, ctx: fm.focusMap[key]})
+	}
+	return entries
+}
+
+// contextOf 返回 key 对应的组件上下文
+func (fm *FocusManager) contextOf(key string) *componentContext {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	return fm.focusMap[key]
+}
+
+// firstWithin 返回 key 为 prefix 或位于 prefix 之下、注册最早的可聚焦组件
+func (fm *FocusManager) firstWithin(prefix string) string {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	for _, key := range fm.focusable {
+		if key == prefix || strings.HasPrefix(key, prefix+"/") {
+			return key
+		}
+	}
+	return ""
+}
+
 // Unregister 注销可聚焦组件
 func (fm *FocusManager) Unregister(key string) {
 	fm.mu.Lock()
@@ -165,6 +205,33 @@ func (fm *FocusManager) Prev() {
 	fm.currentKey = fm.focusable[prevIdx]
 }
 
+// focusSnapshot 保存的注册列表，见 snapshot
+type focusSnapshot struct {
+	focusable []string
+	focusMap  map[string]*componentContext
+	orderMap  map[string]int
+	order     int
+}
+
+// snapshot 保存本帧的注册列表，以便临时重新注册后还原
+func (fm *FocusManager) snapshot() focusSnapshot {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	return focusSnapshot{
+		focusable: append([]string(nil), fm.focusable...),
+		focusMap:  fm.focusMap,
+		orderMap:  fm.orderMap,
+		order:     fm.order,
+	}
+}
+
+// restore 还原 snapshot 保存的注册列表，保留当前焦点
+func (fm *FocusManager) restore(s focusSnapshot) {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	fm.focusable, fm.focusMap, fm.orderMap, fm.order = s.focusable, s.focusMap, s.orderMap, s.order
+}
+
 // Reset 重置焦点管理器（每次渲染前调用）
 func (fm *FocusManager) Reset() {
 	fm.mu.Lock()
@@ -234,3 +301,32 @@ func (c *componentContext) focusKey() string {
 	}
 	return c.parent.focusKey() + "/" + c.key
 }
+
+// =============================================================================
+// FocusRing - 聚焦边框
+// =============================================================================
+
+// UseFocusRing 声明组件可聚焦，返回边框应使用的颜色：聚焦时为主题的 Primary，否则为 Border
+func UseFocusRing(c C) Color {
+	// 组件通常已经调用过 UseFocus 和 UseMouse，不覆盖它们设置的鼠标处理
+	ctx := c.(*componentContext)
+	handler := ctx.mouseHandler
+	focus := UseFocus(c)
+	if handler != nil {
+		ctx.mouseHandler = handler
+	}
+	theme := UseTheme(c)
+	return If(focus.IsFocused, theme.Primary, theme.Border)
+}
+
+// FocusRing 用主题的边框包裹 child，组件聚焦时边框高亮。与组件自己的 UseFocus 共用同一个焦点，
+// 返回的 Box 可以继续设置 Padding、Height 等，需要覆盖颜色（如校验出错）时再调用 BorderColor：
+//
+//	func Card(c rego.C, title string) rego.Node {
+//		rego.UseKey(c, onKey)
+//		return c.Wrap(rego.FocusRing(c, rego.Text(title)).Padding(0, 1))
+//	}
+func FocusRing(c C, child Node) *boxNode {
+	theme := UseTheme(c)
+	return Box(child).Border(theme.BorderStyle).BorderColor(UseFocusRing(c))
+}
@@ -0,0 +1,38 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFocusRing_FollowsFocus(t *testing.T) {
+	card := func(c C, title string) Node {
+		return c.Wrap(FocusRing(c, Text(title)))
+	}
+	app := func(c C) Node {
+		return VStack(card(c.Child("a"), "first"), card(c.Child("b"), "second"))
+	}
+	screen := newTestScreen(10, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	cornerColor := func(y int) tcell.Color {
+		r, _, style, _ := screen.GetContent(0, y)
+		if r != '┌' {
+			t.Fatalf("no border at row %d: %q", y, r)
+		}
+		fg, _, _ := style.Decompose()
+		return fg
+	}
+	primary, border := colorToTcell(DefaultTheme.Primary), colorToTcell(DefaultTheme.Border)
+	if cornerColor(0) != primary || cornerColor(3) != border {
+		t.Fatalf("initial ring colors = %v, %v", cornerColor(0), cornerColor(3))
+	}
+
+	tr.DispatchKey(tcell.KeyTab, 0, tcell.ModNone)
+	tr.Render()
+	if cornerColor(0) != border || cornerColor(3) != primary {
+		t.Errorf("ring did not follow focus: %v, %v", cornerColor(0), cornerColor(3))
+	}
+}
@@ -0,0 +1,260 @@
+package rego
+
+import (
+	"errors"
+	"regexp"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// Form - 表单状态与校验
+// =============================================================================
+
+// Validator 校验字段值，返回 nil 表示通过
+type Validator func(value string) error
+
+// Required 要求字段非空
+func Required(msg string) Validator {
+	return func(value string) error {
+		if value == "" {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// Pattern 要求字段匹配正则表达式（空值不校验，需配合 Required 使用）
+func Pattern(expr string, msg string) Validator {
+	re := regexp.MustCompile(expr)
+	return func(value string) error {
+		if value != "" && !re.MatchString(value) {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// MinLength 要求字段至少包含 n 个字符（空值不校验）
+func MinLength(n int, msg string) Validator {
+	return func(value string) error {
+		if value != "" && utf8.RuneCountInString(value) < n {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// FieldSchema 描述一个表单字段
+type FieldSchema struct {
+	Initial    string
+	Validators []Validator
+}
+
+// FormSchema 描述整个表单
+type FormSchema struct {
+	Fields   map[string]FieldSchema
+	OnSubmit func(values map[string]string)
+}
+
+// Form 是 UseForm 返回的表单句柄
+type Form struct {
+	c         *componentContext
+	schema    FormSchema
+	values    *State[map[string]string]
+	touched   *State[map[string]bool]
+	submitted *State[bool]
+	visited   *Ref[map[string]bool]
+}
+
+// UseForm 创建一个表单，管理字段值、touched/dirty 状态、校验和提交
+func UseForm(c C, schema FormSchema) *Form {
+	initial := make(map[string]string, len(schema.Fields))
+	for name, field := range schema.Fields {
+		initial[name] = field.Initial
+	}
+
+	return &Form{
+		c:         c.(*componentContext),
+		schema:    schema,
+		values:    Use(c, "form_values", initial),
+		touched:   Use(c, "form_touched", map[string]bool{}),
+		submitted: Use(c, "form_submitted", false),
+		visited:   UseRef(c, map[string]bool{}),
+	}
+}
+
+// Value 返回字段当前值
+func (f *Form) Value(name string) string {
+	return f.values.Val[name]
+}
+
+// Values 返回所有字段值的副本
+func (f *Form) Values() map[string]string {
+	out := make(map[string]string, len(f.values.Val))
+	for k, v := range f.values.Val {
+		out[k] = v
+	}
+	return out
+}
+
+// SetValue 设置字段值
+func (f *Form) SetValue(name, value string) {
+	next := f.Values()
+	next[name] = value
+	f.values.Set(next)
+}
+
+// Touch 将字段标记为已访问
+func (f *Form) Touch(name string) {
+	if f.touched.Val[name] {
+		return
+	}
+	next := make(map[string]bool, len(f.touched.Val)+1)
+	for k, v := range f.touched.Val {
+		next[k] = v
+	}
+	next[name] = true
+	f.touched.Set(next)
+}
+
+// Touched 字段是否已被访问过（获得焦点后又失去焦点）
+func (f *Form) Touched(name string) bool {
+	return f.touched.Val[name]
+}
+
+// Dirty 字段值是否与初始值不同
+func (f *Form) Dirty(name string) bool {
+	return f.Value(name) != f.schema.Fields[name].Initial
+}
+
+// IsDirty 是否有任意字段被修改
+func (f *Form) IsDirty() bool {
+	for name := range f.schema.Fields {
+		if f.Dirty(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Error 返回字段的第一条校验错误，通过时返回空字符串
+func (f *Form) Error(name string) string {
+	value := f.Value(name)
+	for _, v := range f.schema.Fields[name].Validators {
+		if err := v(value); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// Errors 返回所有未通过校验的字段及其错误
+func (f *Form) Errors() map[string]string {
+	out := make(map[string]string)
+	for name := range f.schema.Fields {
+		if err := f.Error(name); err != "" {
+			out[name] = err
+		}
+	}
+	return out
+}
+
+// Valid 表单是否全部通过校验
+func (f *Form) Valid() bool {
+	return len(f.Errors()) == 0
+}
+
+// VisibleError 返回应当展示给用户的错误：仅在字段被访问过或表单提交过后显示
+func (f *Form) VisibleError(name string) string {
+	if !f.Touched(name) && !f.submitted.Val {
+		return ""
+	}
+	return f.Error(name)
+}
+
+// Submit 提交表单：标记为已提交，校验通过时调用 OnSubmit，返回是否通过
+func (f *Form) Submit() bool {
+	f.submitted.Set(true)
+	if !f.Valid() {
+		return false
+	}
+	if f.schema.OnSubmit != nil {
+		f.schema.OnSubmit(f.Values())
+	}
+	return true
+}
+
+// Reset 恢复初始值并清除 touched/提交状态
+func (f *Form) Reset() {
+	initial := make(map[string]string, len(f.schema.Fields))
+	for name, field := range f.schema.Fields {
+		initial[name] = field.Initial
+	}
+	f.values.Set(initial)
+	f.touched.Set(map[string]bool{})
+	f.submitted.Set(false)
+	f.visited.Current = map[string]bool{}
+}
+
+// Field 渲染一个绑定到表单字段的 TextInput，校验错误通过 TextInputProps.Error 显示
+//
+// props 中的 Value、Controlled 和 OnChanged 由表单接管；单行输入未指定 OnSubmit 时回车提交表单。
+func (f *Form) Field(name string, props TextInputProps) Node {
+	fieldCtx := f.fieldContext(name)
+
+	onChanged := props.OnChanged
+	props.Value, props.Controlled = f.Value(name), true
+	props.OnChanged = func(v string) {
+		f.SetValue(name, v)
+		if onChanged != nil {
+			onChanged(v)
+		}
+	}
+	if props.OnSubmit == nil && !props.Multiline {
+		props.OnSubmit = func(string) { f.Submit() }
+	}
+	if props.Error == "" {
+		props.Error = f.VisibleError(name)
+	}
+
+	return TextInput(fieldCtx, props)
+}
+
+// FieldWith 使用自定义组件渲染表单字段（如选择器），错误显示方式与 Field 相同
+func (f *Form) FieldWith(name string, render func(c C, value string, onChange func(string)) Node) Node {
+	fieldCtx := f.fieldContext(name)
+	return VStack(
+		render(fieldCtx, f.Value(name), func(v string) { f.SetValue(name, v) }),
+		formError(f.VisibleError(name)),
+	)
+}
+
+// fieldContext 获取字段的子组件上下文，并根据焦点变化维护 touched 状态
+func (f *Form) fieldContext(name string) *componentContext {
+	fieldCtx := f.c.Child("field:" + name).(*componentContext)
+
+	if fm := f.focusManager(); fm != nil {
+		// 上一帧获得过焦点、现在失去焦点即视为 touched
+		if fm.IsFocused(fieldCtx.focusKey()) {
+			f.visited.Current[name] = true
+		} else if f.visited.Current[name] && !f.Touched(name) {
+			f.Touch(name)
+		}
+	}
+	return fieldCtx
+}
+
+func (f *Form) focusManager() *FocusManager {
+	if f.c.runtime == nil {
+		return nil
+	}
+	return f.c.runtime.focusManager
+}
+
+// formError 渲染字段下方的错误提示
+func formError(msg string) Node {
+	if msg == "" {
+		return Empty()
+	}
+	return Text("✗ " + msg).Color(Red)
+}
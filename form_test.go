@@ -0,0 +1,83 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       Validator
+		value   string
+		wantErr bool
+	}{
+		{"必填-空值", Required("required"), "", true},
+		{"必填-有值", Required("required"), "a", false},
+		{"正则-匹配", Pattern(`^\d+$`, "digits"), "123", false},
+		{"正则-不匹配", Pattern(`^\d+$`, "digits"), "12a", true},
+		{"正则-空值跳过", Pattern(`^\d+$`, "digits"), "", false},
+		{"最小长度-中文", MinLength(3, "short"), "你好", true},
+		{"最小长度-满足", MinLength(3, "short"), "你好啊", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.v(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("validator(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUseForm_SubmitAndErrors(t *testing.T) {
+	var submitted map[string]string
+	var form *Form
+
+	app := func(c C) Node {
+		form = UseForm(c, FormSchema{
+			Fields: map[string]FieldSchema{
+				"email": {Validators: []Validator{
+					Required("请输入邮箱"),
+					Pattern(`^[^@]+@[^@]+$`, "邮箱格式不正确"),
+				}},
+			},
+			OnSubmit: func(values map[string]string) { submitted = values },
+		})
+		return form.Field("email", TextInputProps{Width: 30})
+	}
+
+	screen := newTestScreen(40, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 未访问时不显示错误
+	if strings.Contains(getScreenContent(screen), "请输入邮箱") {
+		t.Fatalf("error should be hidden before touch/submit")
+	}
+
+	// 空表单回车提交：校验失败并显示错误
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	if submitted != nil {
+		t.Fatalf("invalid form should not submit")
+	}
+	if !strings.Contains(getScreenContent(screen), "请输入邮箱") {
+		t.Errorf("expected required error after submit, got:\n%s", getScreenContent(screen))
+	}
+
+	for _, r := range "a@b" {
+		tr.DispatchKey(tcell.KeyRune, r, 0)
+		tr.Render()
+	}
+	if !form.Dirty("email") || form.Value("email") != "a@b" {
+		t.Errorf("expected dirty field with value a@b, got %q", form.Value("email"))
+	}
+
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	if submitted["email"] != "a@b" {
+		t.Errorf("expected submit with email a@b, got %v", submitted)
+	}
+}
@@ -0,0 +1,513 @@
+package rego
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Graph - 有向图（DAG）
+// =============================================================================
+
+// GraphNode 图中的一个节点
+type GraphNode struct {
+	ID       string
+	Label    string // 为空时显示 ID
+	Color    Color  // 边框颜色（如用于表示任务状态），Default 使用主题的 Border
+	OnSelect func() // 点击节点或选中后按 Enter 时调用
+}
+
+// GraphEdge 一条从 From 指向 To 的边
+type GraphEdge struct {
+	From, To string
+}
+
+// graphZoom 各缩放级别的节点样式和间距
+type graphZoom struct {
+	boxH     int // 节点高度：1 为 "[label]"，3 为带边框的方框
+	maxLabel int // 标签的最大宽度，0 表示不限
+	hgap     int // 同层节点之间的列数
+	vgap     int // 层之间的行数（至少 2：一行走线，一行箭头）
+}
+
+var graphZooms = []graphZoom{
+	{boxH: 1, maxLabel: 12, hgap: 2, vgap: 2},
+	{boxH: 3, hgap: 3, vgap: 2},
+	{boxH: 3, hgap: 6, vgap: 4},
+}
+
+// graphDefaultZoom 默认缩放级别
+const graphDefaultZoom = 1
+
+// Graph 以分层布局绘制有向图，边用制表符连线，适合展示流水线和依赖关系
+//
+// 节点按最长路径分层（入口节点在最上方），同层节点按上层邻居的位置排序以减少交叉；形成环的边不绘制。
+// 图放在 Viewport 中，可以拖动平移。聚焦时方向键在节点之间移动选中，Enter 触发 OnSelect，
+// +/- 缩放（紧凑 / 标准 / 宽松）。
+//
+//	rego.Graph(c.Child("pipeline"), []rego.GraphNode{
+//		{ID: "build", Color: rego.Green}, {ID: "test", Color: rego.Yellow}, {ID: "deploy"},
+//	}, []rego.GraphEdge{{From: "build", To: "test"}, {From: "test", To: "deploy"}})
+func Graph(c C, nodes []GraphNode, edges []GraphEdge) Node {
+	focus := UseFocus(c)
+	theme := UseTheme(c)
+	selected := Use(c, "selected", "")
+	zoom := Use(c, "zoom", graphDefaultZoom)
+
+	layout := layoutGraph(nodes, edges, graphZooms[zoom.Val])
+	// current 返回选中的节点，尚未选择或节点已被移除时为第一个节点
+	current := func() string {
+		if _, ok := layout.boxes[selected.Val]; !ok && len(nodes) > 0 {
+			return nodes[0].ID
+		}
+		return selected.Val
+	}
+
+	canvas := &graphCanvasNode{layout: layout, selected: current(), focused: focus.IsFocused, theme: theme}
+	view, vc := useViewport(c.Child("view"), c.WrapContent(canvas), false)
+
+	choose := func(id string) {
+		selected.Set(id)
+		vc.reveal(layout.boxes[id])
+	}
+	activate := func(id string) {
+		for _, n := range nodes {
+			if n.ID == id && n.OnSelect != nil {
+				n.OnSelect()
+			}
+		}
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused || len(nodes) == 0 {
+			return
+		}
+		switch {
+		case key == KeyUp || key == KeyDown || key == KeyLeft || key == KeyRight:
+			if next := layout.neighbor(current(), key); next != "" {
+				choose(next)
+			}
+		case key == KeyEnter:
+			activate(current())
+		case r == '+' || r == '=':
+			zoom.Set(min(zoom.Val+1, len(graphZooms)-1))
+		case r == '-':
+			zoom.Set(max(zoom.Val-1, 0))
+		}
+	})
+
+	UseMouse(c, func(ev MouseEvent) {
+		if ev.Type != MouseEventClick || ev.Button != MouseButtonLeft || !ev.Hit {
+			return
+		}
+		focus.Focus()
+		x, y, _ := ev.In(c.ContentRect())
+		if id := layout.at(x, y); id != "" {
+			selected.Set(id)
+			activate(id)
+		}
+	})
+
+	return c.Wrap(view)
+}
+
+// =============================================================================
+// 分层布局
+// =============================================================================
+
+// graphLayout 布局结果，坐标相对于图的左上角
+type graphLayout struct {
+	zoom   graphZoom
+	nodes  map[string]GraphNode
+	boxes  map[string]Rect
+	layers [][]string
+	layer  map[string]int
+	edges  []graphRoute
+	width  int
+	height int
+}
+
+// graphRoute 一条边的折线路径
+type graphRoute struct {
+	from, to string
+	points   []graphPoint // 依次相连的拐点，最后一点为箭头位置
+}
+
+type graphPoint struct{ x, y int }
+
+// layoutGraph 计算节点的分层、顺序和位置，以及每条边的路径
+func layoutGraph(nodes []GraphNode, edges []GraphEdge, zoom graphZoom) *graphLayout {
+	l := &graphLayout{
+		zoom:  zoom,
+		nodes: make(map[string]GraphNode, len(nodes)),
+		boxes: make(map[string]Rect, len(nodes)),
+		layer: make(map[string]int, len(nodes)),
+	}
+	order := make(map[string]int, len(nodes))
+	for i, n := range nodes {
+		if _, dup := l.nodes[n.ID]; !dup {
+			l.nodes[n.ID] = n
+			order[n.ID] = i
+		}
+	}
+	// 1. 去掉环：按节点顺序深度优先遍历，指向遍历栈中节点的边（回边）不参与布局
+	out := map[string][]string{}
+	for _, e := range edges {
+		_, ok1 := l.nodes[e.From]
+		_, ok2 := l.nodes[e.To]
+		if ok1 && ok2 && e.From != e.To {
+			out[e.From] = append(out[e.From], e.To)
+		}
+	}
+	state := map[string]int{} // 1 表示遍历中，2 表示已完成
+	preds := map[string][]string{}
+	succs := map[string][]string{}
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = 1
+		for _, to := range out[id] {
+			if state[to] == 1 {
+				continue
+			}
+			preds[to] = append(preds[to], id)
+			succs[id] = append(succs[id], to)
+			if state[to] == 0 {
+				visit(to)
+			}
+		}
+		state[id] = 2
+	}
+	for _, n := range nodes {
+		if state[n.ID] == 0 {
+			visit(n.ID)
+		}
+	}
+
+	// 2. 分层：层号为从入口节点出发的最长路径
+	var depth func(id string) int
+	depth = func(id string) int {
+		if d, ok := l.layer[id]; ok {
+			return d
+		}
+		d := 0
+		for _, p := range preds[id] {
+			d = max(d, depth(p)+1)
+		}
+		l.layer[id] = d
+		return d
+	}
+	for _, n := range nodes {
+		if _, ok := order[n.ID]; ok {
+			d := depth(n.ID)
+			for len(l.layers) <= d {
+				l.layers = append(l.layers, nil)
+			}
+			if !slices.Contains(l.layers[d], n.ID) {
+				l.layers[d] = append(l.layers[d], n.ID)
+			}
+		}
+	}
+
+	// 3. 排序：按相邻层邻居的平均位置排序（重心法），先自上而下再自下而上
+	pos := map[string]float64{}
+	for _, layer := range l.layers {
+		for i, id := range layer {
+			pos[id] = float64(i)
+		}
+	}
+	sweep := func(layer []string, neighbors map[string][]string) {
+		key := make(map[string]float64, len(layer))
+		for _, id := range layer {
+			key[id] = pos[id]
+			if ns := neighbors[id]; len(ns) > 0 {
+				sum := 0.0
+				for _, n := range ns {
+					sum += pos[n]
+				}
+				key[id] = sum / float64(len(ns))
+			}
+		}
+		sort.SliceStable(layer, func(a, b int) bool { return key[layer[a]] < key[layer[b]] })
+		for i, id := range layer {
+			pos[id] = float64(i)
+		}
+	}
+	for pass := 0; pass < 2; pass++ {
+		for i := 1; i < len(l.layers); i++ {
+			sweep(l.layers[i], preds)
+		}
+		for i := len(l.layers) - 2; i >= 0; i-- {
+			sweep(l.layers[i], succs)
+		}
+	}
+
+	// 4. 位置：每层居中排列
+	layerW := make([]int, len(l.layers))
+	for i, layer := range l.layers {
+		for k, id := range layer {
+			if k > 0 {
+				layerW[i] += zoom.hgap
+			}
+			layerW[i] += l.boxWidth(id)
+		}
+		l.width = max(l.width, layerW[i])
+	}
+	for i, layer := range l.layers {
+		x := (l.width - layerW[i]) / 2
+		y := i * (zoom.boxH + zoom.vgap)
+		for _, id := range layer {
+			w := l.boxWidth(id)
+			l.boxes[id] = Rect{X: x, Y: y, W: w, H: zoom.boxH}
+			x += w + zoom.hgap
+		}
+	}
+	if len(l.layers) > 0 {
+		l.height = len(l.layers)*(zoom.boxH+zoom.vgap) - zoom.vgap
+	}
+
+	// 5. 路径：从源节点底部中点向下，在目标节点上方两行处横向走线，再向下到箭头
+	for _, e := range edges {
+		from, ok1 := l.boxes[e.From]
+		to, ok2 := l.boxes[e.To]
+		if !ok1 || !ok2 || l.layer[e.To] <= l.layer[e.From] {
+			continue
+		}
+		sx, tx := from.X+from.W/2, to.X+to.W/2
+		arrowY := to.Y - 1
+		midY := arrowY - 1
+		l.edges = append(l.edges, graphRoute{from: e.From, to: e.To, points: []graphPoint{
+			{sx, from.Y + from.H}, {sx, midY}, {tx, midY}, {tx, arrowY},
+		}})
+	}
+	return l
+}
+
+// label 返回节点显示的标签（按缩放级别截断）
+func (l *graphLayout) label(id string) string {
+	label := l.nodes[id].Label
+	if label == "" {
+		label = id
+	}
+	if l.zoom.maxLabel > 0 && runewidth.StringWidth(label) > l.zoom.maxLabel {
+		label = runewidth.Truncate(label, l.zoom.maxLabel, "…")
+	}
+	return label
+}
+
+// boxWidth 节点占用的宽度："[label]" 或 "│ label │"
+func (l *graphLayout) boxWidth(id string) int {
+	w := runewidth.StringWidth(l.label(id))
+	if l.zoom.boxH == 1 {
+		return w + 2
+	}
+	return w + 4
+}
+
+// at 返回 (x, y) 处的节点
+func (l *graphLayout) at(x, y int) string {
+	for id, r := range l.boxes {
+		if r.Contains(x, y) {
+			return id
+		}
+	}
+	return ""
+}
+
+// neighbor 返回从 id 按方向键移动到的节点：←/→ 为同层相邻节点，↑/↓ 为相邻层中水平距离最近的节点
+func (l *graphLayout) neighbor(id string, key Key) string {
+	d, ok := l.layer[id]
+	if !ok {
+		return ""
+	}
+	layer := l.layers[d]
+	i := slices.Index(layer, id)
+	switch key {
+	case KeyLeft:
+		if i > 0 {
+			return layer[i-1]
+		}
+		return ""
+	case KeyRight:
+		if i < len(layer)-1 {
+			return layer[i+1]
+		}
+		return ""
+	case KeyUp:
+		d--
+	case KeyDown:
+		d++
+	}
+	if d < 0 || d >= len(l.layers) {
+		return ""
+	}
+	r := l.boxes[id]
+	cx := r.X + r.W/2
+	best, bestDist := "", 0
+	for _, other := range l.layers[d] {
+		o := l.boxes[other]
+		dist := max(o.X+o.W/2-cx, cx-o.X-o.W/2)
+		if best == "" || dist < bestDist {
+			best, bestDist = other, dist
+		}
+	}
+	return best
+}
+
+// =============================================================================
+// graphCanvasNode - 图的渲染节点
+// =============================================================================
+
+type graphCanvasNode struct {
+	layout   *graphLayout
+	selected string
+	focused  bool
+	theme    Theme
+}
+
+// 连线单元格的方向位
+const (
+	lineUp = 1 << iota
+	lineDown
+	lineLeft
+	lineRight
+)
+
+var lineRunes = map[int]rune{
+	lineUp: '│', lineDown: '│', lineUp | lineDown: '│',
+	lineLeft: '─', lineRight: '─', lineLeft | lineRight: '─',
+	lineDown | lineRight: '┌', lineDown | lineLeft: '┐',
+	lineUp | lineRight: '└', lineUp | lineLeft: '┘',
+	lineUp | lineDown | lineRight: '├', lineUp | lineDown | lineLeft: '┤',
+	lineLeft | lineRight | lineDown: '┬', lineLeft | lineRight | lineUp: '┴',
+	lineUp | lineDown | lineLeft | lineRight: '┼',
+}
+
+func (g *graphCanvasNode) render(screen tcell.Screen, x, y, width, height int) int {
+	l := g.layout
+	if len(l.boxes) == 0 {
+		return 0
+	}
+
+	// 1. 连线：先合并所有边经过的方向，再转换为制表符；与选中节点相连的边高亮
+	type cell struct {
+		mask      int
+		highlight bool
+	}
+	cells := map[graphPoint]*cell{}
+	at := func(p graphPoint) *cell {
+		c := cells[p]
+		if c == nil {
+			c = &cell{}
+			cells[p] = c
+		}
+		return c
+	}
+	arrows := map[graphPoint]bool{}
+	for _, e := range l.edges {
+		hl := e.from == g.selected || e.to == g.selected
+		for k := 0; k+1 < len(e.points); k++ {
+			a, b := e.points[k], e.points[k+1]
+			for a != b {
+				next := a
+				var out, in int
+				switch {
+				case b.y > a.y:
+					next.y++
+					out, in = lineDown, lineUp
+				case b.y < a.y:
+					next.y--
+					out, in = lineUp, lineDown
+				case b.x > a.x:
+					next.x++
+					out, in = lineRight, lineLeft
+				default:
+					next.x--
+					out, in = lineLeft, lineRight
+				}
+				ca, cb := at(a), at(next)
+				ca.mask |= out
+				cb.mask |= in
+				ca.highlight = ca.highlight || hl
+				cb.highlight = cb.highlight || hl
+				a = next
+			}
+		}
+		at(e.points[0]).mask |= lineUp // 从节点底部引出
+		arrows[e.points[len(e.points)-1]] = true
+	}
+	lineStyle := tcell.StyleDefault.Foreground(colorToTcell(g.theme.Muted))
+	hlStyle := tcell.StyleDefault.Foreground(colorToTcell(g.theme.Primary))
+	for p, c := range cells {
+		style := If(c.highlight, hlStyle, lineStyle)
+		r := lineRunes[c.mask]
+		if arrows[p] {
+			r = '▼'
+		}
+		if r != 0 {
+			screen.SetContent(x+p.x, y+p.y, r, nil, style)
+		}
+	}
+
+	// 2. 节点
+	for id, r := range l.boxes {
+		g.drawBox(screen, x+r.X, y+r.Y, r.W, id)
+	}
+	return l.height
+}
+
+// drawBox 绘制一个节点
+func (g *graphCanvasNode) drawBox(screen tcell.Screen, x, y, w int, id string) {
+	l := g.layout
+	color := l.nodes[id].Color
+	if color == Default {
+		color = g.theme.Border
+	}
+	border := tcell.StyleDefault.Foreground(colorToTcell(color))
+	text := tcell.StyleDefault
+	if id == g.selected {
+		border = tcell.StyleDefault.Foreground(colorToTcell(g.theme.Primary)).Bold(true)
+		text = text.Bold(true)
+		if g.focused {
+			text = text.Background(colorToTcell(g.theme.Primary)).Foreground(colorToTcell(g.theme.OnColor))
+		}
+	}
+	put := func(col, row int, s string, style tcell.Style) int {
+		for _, r := range s {
+			screen.SetContent(col, row, r, nil, style)
+			col += runewidth.RuneWidth(r)
+		}
+		return col
+	}
+	label := l.label(id)
+	if l.zoom.boxH == 1 {
+		col := put(x, y, "[", border)
+		col = put(col, y, label, text)
+		put(col, y, "]", border)
+		return
+	}
+	inner := w - 2
+	bottom := []rune(strings.Repeat("─", inner))
+	// 有出边的节点在底边中点显示 ┬
+	for _, e := range l.edges {
+		if e.from == id {
+			bottom[w/2-1] = '┬'
+		}
+	}
+	put(x, y, "╭"+strings.Repeat("─", inner)+"╮", border)
+	col := put(x, y+1, "│ ", border)
+	col = put(col, y+1, label, text)
+	put(col, y+1, " │", border)
+	put(x, y+2, "╰"+string(bottom)+"╯", border)
+}
+
+func (g *graphCanvasNode) measureHeight(width int) int {
+	return g.layout.height
+}
+
+func (g *graphCanvasNode) measureWidth() int {
+	return g.layout.width
+}
@@ -0,0 +1,81 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestGraph_LayoutAndSelect(t *testing.T) {
+	var selected []string
+	onSelect := func(id string) func() { return func() { selected = append(selected, id) } }
+	nodes := []GraphNode{
+		{ID: "build", OnSelect: onSelect("build")},
+		{ID: "lint", OnSelect: onSelect("lint")},
+		{ID: "test", OnSelect: onSelect("test")},
+		{ID: "deploy", OnSelect: onSelect("deploy")},
+	}
+	edges := []GraphEdge{
+		{From: "build", To: "lint"},
+		{From: "build", To: "test"},
+		{From: "lint", To: "deploy"},
+		{From: "test", To: "deploy"},
+		{From: "deploy", To: "build"}, // 环上的边不绘制
+	}
+	app := func(c C) Node {
+		return Graph(c.Child("graph"), nodes, edges)
+	}
+	screen := newTestScreen(40, 14)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	content := getScreenContent(screen)
+	want := []string{
+		"     ╭───────╮",
+		"     │ build │",
+		"     ╰───┬───╯",
+		"    ┌────┴─────┐",
+		"    ▼          ▼",
+		"╭──────╮   ╭──────╮",
+		"│ lint │   │ test │",
+		"╰───┬──╯   ╰───┬──╯",
+		"    └────┬─────┘",
+		"         ▼",
+		"    ╭────────╮",
+		"    │ deploy │",
+		"    ╰────────╯",
+	}
+	lines := strings.Split(content, "\n")
+	for i, w := range want {
+		if got := strings.TrimRight(lines[i], " "); got != w {
+			t.Fatalf("line %d = %q, want %q\n%s", i, lines[i], w, content)
+		}
+	}
+	_, by := findText(screen, "build")
+	_, ly := findText(screen, "lint")
+	_, dy := findText(screen, "deploy")
+	if !(by < ly && ly < dy) {
+		t.Fatalf("expected layered layout build < lint < deploy:\n%s", content)
+	}
+
+	// 点击节点选中并回调
+	x, y := findText(screen, "test")
+	mouseClick(tr, x, y)
+	// 方向键移动选中，Enter 回调
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.DispatchKey(tcell.KeyUp, 0, 0)
+	tr.DispatchKey(tcell.KeyUp, 0, 0)
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	if got, want := strings.Join(selected, ","), "test,deploy,build"; got != want {
+		t.Errorf("selected = %s, want %s", got, want)
+	}
+
+	// 缩小为紧凑模式
+	tr.DispatchKey(tcell.KeyRune, '-', 0)
+	tr.Render()
+	if content = getScreenContent(screen); !strings.Contains(content, "[build]") {
+		t.Errorf("expected compact nodes after zooming out:\n%s", content)
+	}
+}
@@ -0,0 +1,75 @@
+package rego
+
+import (
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// =============================================================================
+// 字素簇（grapheme cluster）
+// =============================================================================
+
+// forEachGrapheme 按字素簇遍历 s，fn 收到簇的首字符、其余字符（组合符、ZWJ 序列等）与显示宽度，
+// 返回 false 时停止遍历
+//
+// 带 ZWJ 的 emoji（👨‍👩‍👧）、带组合符的字符（é = e + ◌́）都作为一个整体处理，
+// 不会被拆到两个单元格或在中间截断。
+func forEachGrapheme(s string, fn func(mainc rune, combc []rune, width int) bool) {
+	state := -1
+	for s != "" {
+		var cluster string
+		cluster, s, _, state = uniseg.FirstGraphemeClusterInString(s, state)
+		runes := []rune(cluster)
+		var combc []rune
+		if len(runes) > 1 {
+			combc = runes[1:]
+		}
+		if !fn(runes[0], combc, runewidth.StringWidth(cluster)) {
+			return
+		}
+	}
+}
+
+// graphemeBoundaries 返回 runes 中各字素簇的起始下标（按 rune 计），末尾包含 len(runes)
+func graphemeBoundaries(runes []rune) []int {
+	bounds := []int{0}
+	pos := 0
+	forEachGrapheme(string(runes), func(mainc rune, combc []rune, width int) bool {
+		pos += 1 + len(combc)
+		bounds = append(bounds, pos)
+		return true
+	})
+	return bounds
+}
+
+// prevGrapheme 返回 pos 之前一个字素簇的起始位置
+func prevGrapheme(runes []rune, pos int) int {
+	prev := 0
+	for _, b := range graphemeBoundaries(runes) {
+		if b >= pos {
+			break
+		}
+		prev = b
+	}
+	return prev
+}
+
+// nextGrapheme 返回 pos 之后一个字素簇的起始位置
+func nextGrapheme(runes []rune, pos int) int {
+	for _, b := range graphemeBoundaries(runes) {
+		if b > pos {
+			return b
+		}
+	}
+	return len(runes)
+}
+
+// isNewline 判断字素簇是否为换行（"\n" 或 "\r\n"）
+func isNewline(mainc rune, combc []rune) bool {
+	return mainc == '\n' || (mainc == '\r' && len(combc) == 1 && combc[0] == '\n')
+}
+
+// snapToGrapheme 将位于字素簇中间的 pos 移到该簇的起始位置
+func snapToGrapheme(runes []rune, pos int) int {
+	return prevGrapheme(runes, pos+1)
+}
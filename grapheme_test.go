@@ -0,0 +1,52 @@
+package rego
+
+import (
+	"testing"
+)
+
+func TestGraphemeBoundaries(t *testing.T) {
+	runes := []rune("a👨‍👩‍👧é")
+	got := graphemeBoundaries(runes)
+	want := []int{0, 1, 6, 8}
+	if len(got) != len(want) {
+		t.Fatalf("graphemeBoundaries = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("graphemeBoundaries = %v, want %v", got, want)
+		}
+	}
+	if p := prevGrapheme(runes, 8); p != 6 {
+		t.Errorf("prevGrapheme(8) = %d, want 6", p)
+	}
+	if n := nextGrapheme(runes, 1); n != 6 {
+		t.Errorf("nextGrapheme(1) = %d, want 6", n)
+	}
+	if s := snapToGrapheme(runes, 3); s != 1 {
+		t.Errorf("snapToGrapheme(3) = %d, want 1", s)
+	}
+}
+
+func TestText_CombiningMarks(t *testing.T) {
+	screen := newTestScreen(10, 1)
+	tr := NewTestRuntime(func(c C) Node {
+		return Text("éx")
+	}, screen)
+	tr.Render()
+
+	mainc, combc, _, _ := screen.GetContent(0, 0)
+	if mainc != 'e' || len(combc) != 1 || combc[0] != '́' {
+		t.Errorf("expected e with combining acute in the first cell, got %q %q", mainc, combc)
+	}
+	if next, _, _, _ := screen.GetContent(1, 0); next != 'x' {
+		t.Errorf("expected x in the second cell, got %q", next)
+	}
+}
+
+func TestMeasureNodeHeight_Graphemes(t *testing.T) {
+	// 每个 ZWJ 序列宽 2，宽度 4 时每行放两个
+	family := "👨‍👩‍👧"
+	if h := measureNodeHeight(Text(family+family+family).Wrap(true), 4); h != 2 {
+		t.Errorf("expected 2 lines, got %d", h)
+	}
+}
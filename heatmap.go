@@ -0,0 +1,218 @@
+package rego
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Heatmap - 热力图
+// =============================================================================
+
+// heatmapCellW 每个格子占用的列数（两列在多数终端字体中接近正方形）
+const heatmapCellW = 2
+
+// HeatmapPalette 是从低到高排列的颜色梯度
+type HeatmapPalette []Color
+
+var (
+	// PaletteGreens 与 GitHub 贡献图相同的绿色梯度
+	PaletteGreens = HeatmapPalette{RGB(0x16, 0x1b, 0x22), RGB(0x0e, 0x44, 0x29), RGB(0x00, 0x6d, 0x32), RGB(0x26, 0xa6, 0x41), RGB(0x39, 0xd3, 0x53)}
+	// PaletteThermal 由冷到热的梯度，适合没有真彩色的终端
+	PaletteThermal = HeatmapPalette{Blue, Cyan, Green, Yellow, Red}
+)
+
+type heatmapNode struct {
+	data      [][]float64
+	palette   HeatmapPalette
+	rowLabels []string
+	colLabels []string
+	lo, hi    float64
+	ranged    bool // 使用 Range 设置的取值范围
+	format    func(row, col int, v float64) string
+}
+
+// Heatmap 创建一个热力图，data[行][列] 的值按颜色梯度着色，NaN 表示空白格子
+//
+// 鼠标悬停在格子上时显示其行列标签与数值。列标签可以是稀疏的，空字符串不显示，
+// 与前一个标签重叠的标签也会跳过：
+//
+//	rego.Heatmap(commits).RowLabels("Mon", "", "Wed", "", "Fri", "", "").ColLabels(months...)
+func Heatmap(data [][]float64) *heatmapNode {
+	return &heatmapNode{data: data, palette: PaletteGreens}
+}
+
+// Palette 设置颜色梯度，默认为 PaletteGreens
+func (h *heatmapNode) Palette(p HeatmapPalette) *heatmapNode {
+	h.palette = p
+	return h
+}
+
+// RowLabels 设置显示在左侧的行标签
+func (h *heatmapNode) RowLabels(labels ...string) *heatmapNode {
+	h.rowLabels = labels
+	return h
+}
+
+// ColLabels 设置显示在上方的列标签
+func (h *heatmapNode) ColLabels(labels ...string) *heatmapNode {
+	h.colLabels = labels
+	return h
+}
+
+// Range 设置映射到梯度两端的取值，默认使用数据中的最小值与最大值
+func (h *heatmapNode) Range(lo, hi float64) *heatmapNode {
+	h.lo, h.hi, h.ranged = lo, hi, true
+	return h
+}
+
+// Format 设置悬停提示的内容
+func (h *heatmapNode) Format(fn func(row, col int, v float64) string) *heatmapNode {
+	h.format = fn
+	return h
+}
+
+// bounds 返回映射到梯度两端的取值
+func (h *heatmapNode) bounds() (float64, float64) {
+	if h.ranged {
+		return h.lo, h.hi
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, row := range h.data {
+		for _, v := range row {
+			if !math.IsNaN(v) {
+				lo, hi = min(lo, v), max(hi, v)
+			}
+		}
+	}
+	return lo, hi
+}
+
+// color 返回 v 在梯度中的颜色，NaN 没有颜色
+func (h *heatmapNode) color(v, lo, hi float64) (Color, bool) {
+	if math.IsNaN(v) || len(h.palette) == 0 {
+		return Default, false
+	}
+	i := len(h.palette) - 1
+	if hi > lo {
+		i = min(max(int((v-lo)/(hi-lo)*float64(len(h.palette))), 0), len(h.palette)-1)
+	}
+	return h.palette[i], true
+}
+
+// tooltip 返回格子的悬停提示
+func (h *heatmapNode) tooltip(row, col int) string {
+	v := h.data[row][col]
+	if h.format != nil {
+		return h.format(row, col, v)
+	}
+	label := strings.TrimSpace(labelAt(h.rowLabels, row) + " " + labelAt(h.colLabels, col))
+	if label == "" {
+		label = fmt.Sprintf("[%d, %d]", row, col)
+	}
+	return label + ": " + strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func labelAt(labels []string, i int) string {
+	if i < len(labels) {
+		return labels[i]
+	}
+	return ""
+}
+
+// labelWidth 行标签列的宽度（含与格子之间的一列空白）
+func (h *heatmapNode) labelWidth() int {
+	w := 0
+	for _, l := range h.rowLabels {
+		w = max(w, runewidth.StringWidth(l))
+	}
+	if w > 0 {
+		w++
+	}
+	return w
+}
+
+func (h *heatmapNode) columns() int {
+	n := 0
+	for _, row := range h.data {
+		n = max(n, len(row))
+	}
+	return n
+}
+
+func (h *heatmapNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	labelW := h.labelWidth()
+	gridX := x + labelW
+	label := tcell.StyleDefault.Foreground(colorToTcell(Gray))
+	drawLabel := func(text string, col, row, maxW int) int {
+		for _, r := range runewidth.Truncate(text, maxW, "") {
+			screen.SetContent(col, row, r, nil, label)
+			col += runewidth.RuneWidth(r)
+		}
+		return col
+	}
+
+	top := y
+	if len(h.colLabels) > 0 {
+		end := gridX
+		for i, l := range h.colLabels {
+			col := gridX + i*heatmapCellW
+			if l == "" || col < end || col >= x+width {
+				continue
+			}
+			end = drawLabel(l, col, y, x+width-col) + 1
+		}
+		top++
+	}
+
+	rows := min(len(h.data), y+height-top)
+	lo, hi := h.bounds()
+	for r := 0; r < rows; r++ {
+		if labelW > 0 {
+			drawLabel(labelAt(h.rowLabels, r), x, top+r, labelW-1)
+		}
+		for c, v := range h.data[r] {
+			col := gridX + c*heatmapCellW
+			if col+heatmapCellW > x+width {
+				break
+			}
+			style := tcell.StyleDefault
+			if color, ok := h.color(v, lo, hi); ok {
+				style = style.Background(colorToTcell(color))
+			}
+			for i := 0; i < heatmapCellW; i++ {
+				screen.SetContent(col+i, top+r, ' ', nil, style)
+			}
+		}
+	}
+
+	// 悬停提示
+	grid := Rect{X: gridX, Y: top, W: min(h.columns()*heatmapCellW, x+width-gridX), H: rows}
+	if mx, my, ok := hoverPoint(screen, grid); ok {
+		row, col := my-top, (mx-gridX)/heatmapCellW
+		if col < len(h.data[row]) && !math.IsNaN(h.data[row][col]) {
+			cell, _ := screenRect(screen, Rect{X: gridX + col*heatmapCellW, Y: my, W: heatmapCellW, H: 1})
+			showTooltip(screenRuntime(screen), screen, cell, h.tooltip(row, col))
+		}
+	}
+	return top - y + rows
+}
+
+func (h *heatmapNode) measureHeight(width int) int {
+	if len(h.colLabels) > 0 {
+		return len(h.data) + 1
+	}
+	return len(h.data)
+}
+
+func (h *heatmapNode) measureWidth() int {
+	return h.labelWidth() + h.columns()*heatmapCellW
+}
@@ -0,0 +1,79 @@
+package rego
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestHeatmap_ColorsAndLabels(t *testing.T) {
+	data := [][]float64{
+		{0, 1, 2, 3},
+		{4, math.NaN(), 4, 0},
+	}
+	app := func(c C) Node {
+		return Heatmap(data).
+			Palette(HeatmapPalette{Blue, Green, Red}).
+			RowLabels("Mon", "Tue").
+			ColLabels("Jan", "", "Feb", "")
+	}
+
+	screen := newTestScreen(20, 4)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(lines[0], "    Jan Feb") || !strings.HasPrefix(lines[1], "Mon") || !strings.HasPrefix(lines[2], "Tue") {
+		t.Fatalf("unexpected labels:\n%s", getScreenContent(screen))
+	}
+
+	// 0..4 均分为三档
+	bg := func(x, y int) tcell.Color {
+		_, _, style, _ := screen.GetContent(x, y)
+		_, b, _ := style.Decompose()
+		return b
+	}
+	for _, tc := range []struct {
+		x, y int
+		want tcell.Color
+	}{
+		{4, 1, colorToTcell(Blue)},
+		{6, 1, colorToTcell(Blue)},
+		{8, 1, colorToTcell(Green)},
+		{10, 1, colorToTcell(Red)},
+		{4, 2, colorToTcell(Red)},
+		{6, 2, tcell.ColorDefault},
+	} {
+		if got := bg(tc.x, tc.y); got != tc.want {
+			t.Errorf("cell (%d, %d) background = %v, want %v", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
+
+func TestHeatmap_HoverTooltip(t *testing.T) {
+	app := func(c C) Node {
+		return Heatmap([][]float64{{1, 2.5}}).RowLabels("cpu").ColLabels("a", "b")
+	}
+
+	screen := newTestScreen(20, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "2.5") {
+		t.Fatalf("tooltip shown without hover:\n%s", content)
+	}
+
+	tr.DispatchMouse(7, 1, tcell.ButtonNone, 0)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "cpu b: 2.5") {
+		t.Fatalf("expected tooltip for hovered cell:\n%s", content)
+	}
+
+	// 移出后提示消失
+	tr.DispatchMouse(15, 4, tcell.ButtonNone, 0)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "2.5") {
+		t.Errorf("tooltip still shown after leaving:\n%s", content)
+	}
+}
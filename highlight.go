@@ -0,0 +1,101 @@
+package rego
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// Highlight - 渲染时高亮匹配文字
+// =============================================================================
+
+// Highlight 在渲染时把 node 中与 query 匹配（不区分大小写）的文字改用 style 显示，
+// 不修改原始字符串，可用于搜索结果、过滤预览等场景。
+// style 中未设置的颜色沿用原样式，粗体/下划线等属性在原样式基础上叠加。
+//
+//	rego.Highlight(rego.Text(item.Name), query, rego.NewStyle().Background(rego.Yellow))
+func Highlight(node Node, query string, style Style) Node {
+	return &highlightNode{child: node, query: query, style: style}
+}
+
+type highlightNode struct {
+	child Node
+	query string
+	style Style
+}
+
+func (h *highlightNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if h.child == nil {
+		return 0
+	}
+	if h.query == "" {
+		return h.child.render(screen, x, y, width, height)
+	}
+
+	// 先把子树渲染到记录单元格的代理上，再覆盖匹配的单元格
+	capture := &searchCapture{originX: x, originY: y, rows: map[int]map[int]capturedCell{}}
+	proxy := &clipScreen{
+		Screen:  screen,
+		viewX:   x,
+		viewY:   y,
+		viewW:   width,
+		viewH:   height,
+		capture: capture,
+	}
+	used := h.child.render(proxy, x, y, width, height)
+	proxy.capture = nil
+
+	for _, m := range capture.find(h.query) {
+		for _, cell := range m.cells {
+			proxy.SetContent(x+cell.x, y+m.row, cell.mainc, cell.combc, h.style.overlay(cell.style))
+		}
+	}
+	return used
+}
+
+func (h *highlightNode) measureHeight(width int) int {
+	return measureNodeHeight(h.child, width)
+}
+
+func (h *highlightNode) measureWidth() int {
+	return (&hstackNode{}).measureWidth(h.child)
+}
+
+func (h *highlightNode) getFlex() int {
+	if fn, ok := h.child.(flexNode); ok {
+		return fn.getFlex()
+	}
+	return 0
+}
+
+func (h *highlightNode) getHeight() int {
+	if fn, ok := h.child.(flexNode); ok {
+		return fn.getHeight()
+	}
+	return 0
+}
+
+// overlay 把样式叠加到已有的单元格样式上：只覆盖设置过的颜色，文字属性取并集
+func (s Style) overlay(base tcell.Style) tcell.Style {
+	if s.fg != Default {
+		base = base.Foreground(colorToTcell(s.fg))
+	}
+	if s.bg != Default {
+		base = base.Background(colorToTcell(s.bg))
+	}
+	if s.bold {
+		base = base.Bold(true)
+	}
+	if s.italic {
+		base = base.Italic(true)
+	}
+	if s.underline {
+		base = base.Underline(true)
+	}
+	if s.dim {
+		base = base.Dim(true)
+	}
+	if s.blink {
+		base = base.Blink(true)
+	}
+	return base
+}
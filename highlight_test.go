@@ -0,0 +1,47 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestHighlight(t *testing.T) {
+	app := func(c C) Node {
+		return VStack(
+			Highlight(VStack(
+				Text("Error: disk full").Bold(),
+				HStack(Text("err"), Text("or")).Gap(1),
+			), "error", NewStyle().Background(Yellow)),
+			Text("error outside"),
+		)
+	}
+
+	screen := newTestScreen(30, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 匹配的单元格覆盖背景色，保留原有的粗体
+	want := tcell.StyleDefault.Foreground(tcell.ColorDefault).Bold(true).Background(colorToTcell(Yellow))
+	for x := 0; x < 5; x++ {
+		if _, _, style, _ := screen.GetContent(x, 0); style != want {
+			t.Errorf("cell (%d,0): expected highlighted style, got %v", x, style)
+		}
+	}
+	if _, _, style, _ := screen.GetContent(5, 0); style == want {
+		t.Errorf("cell (5,0): expected no highlight after match")
+	}
+
+	// 中间隔开的文字不算匹配
+	for x := 0; x < 6; x++ {
+		_, _, style, _ := screen.GetContent(x, 1)
+		if _, bg, _ := style.Decompose(); bg == colorToTcell(Yellow) {
+			t.Errorf("cell (%d,1): expected non-adjacent text not to match", x)
+		}
+	}
+
+	// Highlight 之外的文字不受影响
+	if _, _, style, _ := screen.GetContent(0, 2); style != tcell.StyleDefault.Foreground(tcell.ColorDefault) {
+		t.Errorf("expected text outside Highlight untouched, got %v", style)
+	}
+}
@@ -90,6 +90,10 @@ type effectSlot struct {
 
 // UseEffect 声明一个副作用
 // fn 返回清理函数，如果不需要清理返回 nil
+//
+// fn 在本帧整棵组件树执行完后按声明顺序执行；清理函数在 deps 变化、fn 重新执行之前，以及组件被释放时执行。组件只在应用退出、Panes 关闭窗格、
+// Router 丢弃页面、Tabs 移除标签页时被释放；只是不再渲染（被条件隐藏、切换到其他标签页）的组件
+// 保留状态，其副作用继续运行，需要停止时应由调用方改变 deps 或在 fn 中自行判断。
 func UseEffect(c C, fn func() func(), deps ...any) {
 	ctx := c.(*componentContext)
 
@@ -106,7 +110,10 @@ func UseEffect(c C, fn func() func(), deps ...any) {
 	// 检查依赖是否变化
 	shouldRun := !slot.ran || !depsEqual(slot.deps, deps)
 
-	if shouldRun {
+	if !shouldRun {
+		return
+	}
+	run := func() {
 		// 执行清理
 		if slot.cleanup != nil {
 			slot.cleanup()
@@ -118,6 +125,12 @@ func UseEffect(c C, fn func() func(), deps ...any) {
 		slot.deps = deps
 		slot.ran = true
 	}
+	// 构建组件树时推迟到整棵树执行完：被放弃的执行（如 Context 值变化后重新执行前的那一遍）不触发副作用
+	if r := ctx.runtime; r != nil && r.building {
+		r.effects = append(r.effects, run)
+		return
+	}
+	run()
 }
 
 // depsEqual 比较两个依赖数组是否相等
@@ -190,3 +203,43 @@ func UseMemo[T any](c C, fn func() T, deps ...any) T {
 
 	return slot.value.(T)
 }
+
+// UseChannel 订阅 Go channel：组件挂载时启动接收协程，ch 变化或组件被释放（见 UseEffect）时停止，
+// 每条消息的 onMsg 回调都在 UI 循环中执行并触发刷新，因此可以直接修改状态
+func UseChannel[T any](c C, ch <-chan T, onMsg func(T)) {
+	ctx := c.(*componentContext)
+	handler := UseRef(c, onMsg)
+	handler.Current = onMsg // 始终调用最新一次渲染传入的回调
+
+	UseEffect(c, func() func() {
+		if ch == nil {
+			return nil
+		}
+		stop := make(chan struct{})
+		deliver := func(v T) {
+			select {
+			case <-stop: // 已取消订阅，丢弃尚未执行的消息
+			default:
+				handler.Current(v)
+			}
+		}
+		go func() {
+			for {
+				select {
+				case v, ok := <-ch:
+					if !ok {
+						return
+					}
+					if ctx.runtime != nil {
+						ctx.runtime.post(func() { deliver(v) })
+					} else {
+						deliver(v)
+					}
+				case <-stop:
+					return
+				}
+			}
+		}()
+		return func() { close(stop) }
+	}, ch)
+}
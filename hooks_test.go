@@ -138,3 +138,25 @@ func TestUseRef(t *testing.T) {
 		t.Errorf("Expected 200, got %d", ref2.Current)
 	}
 }
+
+func TestUseChannel(t *testing.T) {
+	ch := make(chan string)
+	var received []string
+	app := func(c C) Node {
+		UseChannel(c, ch, func(msg string) { received = append(received, msg) })
+		return Text("")
+	}
+
+	screen := newTestScreen(10, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	ch <- "a"
+	ch <- "b"
+	ch <- "c" // 接收协程取走 c 时 a、b 已经投递
+	tr.Render()
+
+	if len(received) < 2 || received[0] != "a" || received[1] != "b" {
+		t.Errorf("expected messages to be delivered in order on render, got %v", received)
+	}
+}
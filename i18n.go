@@ -0,0 +1,275 @@
+package rego
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// i18n - 多语言文案
+// =============================================================================
+
+// Catalog 保存各语言的文案
+type Catalog struct {
+	// Fallback 当前语言缺少某条文案时使用的语言，默认为 "en"
+	Fallback string
+
+	messages map[string]map[string]message // locale -> key -> message
+}
+
+// message 是一条文案，plural 非空时按数量选择
+type message struct {
+	text   string
+	plural map[string]string // "zero"、"one"、"few"、"many"、"other"
+}
+
+// pluralCategories 合法的复数类别
+var pluralCategories = map[string]bool{"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true}
+
+// NewCatalog 从 fsys 根目录下的 <locale>.json / <locale>.toml 文件加载文案
+//
+// 文件内容为 key -> 文案，文案中的 {name} 为占位符；嵌套的表以 "." 连接为 key。
+// 值为仅包含复数类别（one、few、many、other 等）的表时，按参数 count 的值选择：
+//
+//	{"greeting": "你好，{name}", "files": {"one": "{count} file", "other": "{count} files"}}
+func NewCatalog(fsys fs.FS) (*Catalog, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	cat := &Catalog{Fallback: "en", messages: map[string]map[string]message{}}
+	for _, e := range entries {
+		ext := path.Ext(e.Name())
+		if e.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := parseConfig(e.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		locale := normalizeLocale(strings.TrimSuffix(e.Name(), ext))
+		msgs := cat.messages[locale]
+		if msgs == nil {
+			msgs = map[string]message{}
+			cat.messages[locale] = msgs
+		}
+		if err := flattenMessages(msgs, "", cfg); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+	}
+	return cat, nil
+}
+
+func flattenMessages(out map[string]message, prefix string, cfg map[string]any) error {
+	for k, v := range cfg {
+		key := prefix + k
+		switch v := v.(type) {
+		case string:
+			out[key] = message{text: v}
+		case map[string]any:
+			if plural, ok := pluralForms(v); ok {
+				out[key] = message{plural: plural}
+				continue
+			}
+			if err := flattenMessages(out, key+".", v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s: expected string or table, got %T", key, v)
+		}
+	}
+	return nil
+}
+
+// pluralForms 判断 m 是否为复数形式表
+func pluralForms(m map[string]any) (map[string]string, bool) {
+	if len(m) == 0 {
+		return nil, false
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		s, ok := v.(string)
+		if !ok || !pluralCategories[k] {
+			return nil, false
+		}
+		out[k] = s
+	}
+	return out, true
+}
+
+// Locales 返回已加载的语言，按字母排序
+func (cat *Catalog) Locales() []string {
+	out := make([]string, 0, len(cat.messages))
+	for l := range cat.messages {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// T 翻译 key，args 为交替的占位符名与值，如 T("zh-CN", "files", "count", 3)
+//
+// 查找顺序为 locale、其语言部分（"zh-CN" -> "zh"）、Fallback，均未找到时返回 key 本身。
+func (cat *Catalog) T(locale, key string, args ...any) string {
+	vars := make(map[string]any, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		vars[fmt.Sprint(args[i])] = args[i+1]
+	}
+
+	for _, l := range cat.lookupChain(locale) {
+		msg, ok := cat.messages[l][key]
+		if !ok {
+			continue
+		}
+		text := msg.text
+		if msg.plural != nil {
+			text = msg.plural[pluralCategory(l, vars["count"])]
+			if text == "" {
+				text = msg.plural["other"]
+			}
+		}
+		return interpolate(text, vars)
+	}
+	return interpolate(key, vars)
+}
+
+func (cat *Catalog) lookupChain(locale string) []string {
+	locale = normalizeLocale(locale)
+	chain := []string{locale}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		chain = append(chain, lang)
+	}
+	if cat.Fallback != "" {
+		chain = append(chain, normalizeLocale(cat.Fallback))
+	}
+	return chain
+}
+
+// interpolate 替换文案中的 {name} 占位符
+func interpolate(text string, vars map[string]any) string {
+	if len(vars) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	pairs := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// pluralCategory 按语言的复数规则返回 count 对应的类别（整数的常见规则）
+func pluralCategory(locale string, count any) string {
+	var n int64
+	switch v := count.(type) {
+	case int:
+		n = int64(v)
+	case int64:
+		n = v
+	case int32:
+		n = int64(v)
+	case uint:
+		n = int64(v)
+	case float64:
+		n = int64(v)
+	default:
+		return "other"
+	}
+	if n < 0 {
+		n = -n
+	}
+
+	lang, _, _ := strings.Cut(locale, "-")
+	switch lang {
+	case "zh", "ja", "ko", "vi", "th", "id":
+		return "other"
+	case "fr", "pt":
+		if n <= 1 {
+			return "one"
+		}
+	case "ru", "uk", "pl":
+		switch {
+		case n%10 == 1 && n%100 != 11 && lang != "pl":
+			return "one"
+		case n == 1:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	default:
+		if n == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
+
+// normalizeLocale 统一语言标记的写法："zh_CN.UTF-8" -> "zh-CN"
+func normalizeLocale(l string) string {
+	l, _, _ = strings.Cut(l, ".")
+	l, _, _ = strings.Cut(l, "@")
+	l = strings.ReplaceAll(l, "_", "-")
+	lang, region, ok := strings.Cut(l, "-")
+	if !ok {
+		return strings.ToLower(lang)
+	}
+	return strings.ToLower(lang) + "-" + strings.ToUpper(region)
+}
+
+// DetectLocale 从环境变量 LC_ALL、LC_MESSAGES、LANG 中检测语言，未设置时返回 "en"
+func DetectLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" && v != "C" && v != "POSIX" {
+			return normalizeLocale(v)
+		}
+	}
+	return "en"
+}
+
+// =============================================================================
+// Provide / UseT / UseLocale
+// =============================================================================
+
+type i18nValue struct {
+	catalog *Catalog
+	locale  *State[string]
+}
+
+var i18nContext = CreateContext(i18nValue{})
+
+// Provide 向 child 提供文案，初始语言取 DetectLocale，可通过 UseLocale 在运行时切换
+func (cat *Catalog) Provide(c C, child Node) Node {
+	locale := Use(c, "i18nLocale", DetectLocale())
+	return i18nContext.Provide(c, i18nValue{catalog: cat, locale: locale}, child)
+}
+
+// UseT 返回当前语言的翻译函数，不在 Catalog.Provide 内时仅替换占位符
+func UseT(c C) func(key string, args ...any) string {
+	v := UseContext(c, i18nContext)
+	if v.catalog == nil {
+		return func(key string, args ...any) string {
+			return (&Catalog{}).T("", key, args...)
+		}
+	}
+	return func(key string, args ...any) string {
+		return v.catalog.T(v.locale.Val, key, args...)
+	}
+}
+
+// UseLocale 返回当前语言及切换语言的函数
+func UseLocale(c C) (string, func(locale string)) {
+	v := UseContext(c, i18nContext)
+	if v.locale == nil {
+		return DetectLocale(), func(string) {}
+	}
+	return v.locale.Val, func(locale string) { v.locale.Set(normalizeLocale(locale)) }
+}
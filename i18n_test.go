@@ -0,0 +1,90 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func testCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	cat, err := NewCatalog(fstest.MapFS{
+		"en.json": {Data: []byte(`{"hello": "Hello, {name}", "files": {"one": "{count} file", "other": "{count} files"}, "menu": {"quit": "Quit"}}`)},
+		"zh-CN.toml": {Data: []byte(`hello = "你好，{name}"
+[files]
+other = "{count} 个文件"
+`)},
+		"ru.json": {Data: []byte(`{"files": {"one": "{count} файл", "few": "{count} файла", "many": "{count} файлов"}}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cat
+}
+
+func TestCatalog_T(t *testing.T) {
+	cat := testCatalog(t)
+	cases := []struct {
+		locale, key string
+		args        []any
+		want        string
+	}{
+		{"en", "hello", []any{"name", "Bob"}, "Hello, Bob"},
+		{"zh_CN.UTF-8", "hello", []any{"name", "Bob"}, "你好，Bob"},
+		{"en", "files", []any{"count", 1}, "1 file"},
+		{"en", "files", []any{"count", 3}, "3 files"},
+		{"zh-CN", "files", []any{"count", 1}, "1 个文件"},
+		{"ru", "files", []any{"count", 22}, "22 файла"},
+		{"ru", "files", []any{"count", 11}, "11 файлов"},
+		{"zh-CN", "menu.quit", nil, "Quit"}, // 回退到 en
+		{"en", "missing", nil, "missing"},
+	}
+	for _, tc := range cases {
+		if got := cat.T(tc.locale, tc.key, tc.args...); got != tc.want {
+			t.Errorf("T(%q, %q) = %q, want %q", tc.locale, tc.key, got, tc.want)
+		}
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "zh_CN.UTF-8")
+	if got := DetectLocale(); got != "zh-CN" {
+		t.Errorf("DetectLocale() = %q, want zh-CN", got)
+	}
+}
+
+func TestUseT_SwitchLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+	cat := testCatalog(t)
+
+	label := func(c C) Node {
+		tr := UseT(c)
+		_, setLocale := UseLocale(c)
+		UseKey(c, func(key Key, r rune) {
+			if r == 'z' {
+				setLocale("zh-CN")
+			}
+		})
+		return Text(tr("hello", "name", "Bob"))
+	}
+	app := func(c C) Node {
+		return cat.Provide(c, label(c.Child("label")))
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.HasPrefix(content, "Hello, Bob") {
+		t.Fatalf("expected English greeting, got %q", content)
+	}
+
+	tr.DispatchKey(tcell.KeyRune, 'z', 0)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "你好") {
+		t.Errorf("expected Chinese greeting after switching locale, got %q", content)
+	}
+}
@@ -0,0 +1,171 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// inlineScreen - 在光标下方原地绘制
+// =============================================================================
+
+// inlineScreen 在终端光标所在的位置原地绘制界面，不切换到备用屏幕（alternate screen）
+//
+// 组件树绘制在内嵌的模拟屏幕上，Show 时把其中的各行输出到终端，只占用 fit 设置的行数；
+// Fini 时光标移到最后一行之后，最后一帧留在终端的滚动历史中。
+type inlineScreen struct {
+	tcell.SimulationScreen
+	tty    tcell.Tty
+	input  tcell.InputProcessor
+	events chan tcell.Event
+	quit   chan struct{}
+
+	mu            sync.Mutex
+	width, height int // 终端尺寸，终端调整大小时在信号处理协程中更新
+
+	drawn int    // 上次输出的行数
+	row   int    // 终端光标相对绘制区域第一行的位置
+	last  string // 上一帧的内容和光标位置，没有变化时不再输出
+}
+
+func newInlineScreen(tty tcell.Tty) *inlineScreen {
+	return &inlineScreen{SimulationScreen: tcell.NewSimulationScreen(""), tty: tty}
+}
+
+func (s *inlineScreen) Init() error {
+	if err := s.SimulationScreen.Init(); err != nil {
+		return err
+	}
+	if err := s.tty.Start(); err != nil {
+		return err
+	}
+	s.events = make(chan tcell.Event, 64)
+	s.quit = make(chan struct{})
+	s.input = tcell.NewInputProcessor(s.events)
+	s.resize()
+	w, _ := s.termSize()
+	s.SimulationScreen.SetSize(w, 1)
+
+	s.tty.NotifyResize(func() {
+		s.resize()
+		w, h := s.termSize()
+		select {
+		case s.events <- tcell.NewEventResize(w, h):
+		case <-s.quit:
+		}
+	})
+	go func() {
+		buf := make([]byte, 128)
+		for {
+			n, err := s.tty.Read(buf)
+			if n > 0 {
+				s.input.ScanUTF8(buf[:n])
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// resize 读取终端尺寸
+func (s *inlineScreen) resize() {
+	ws, err := s.tty.WindowSize()
+	if err != nil {
+		ws = tcell.WindowSize{Width: 80, Height: 24}
+	}
+	s.mu.Lock()
+	s.width, s.height = ws.Width, ws.Height
+	s.mu.Unlock()
+}
+
+func (s *inlineScreen) termSize() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.width, s.height
+}
+
+// fit 把绘制区域设为终端宽度、rows 行高（不超过终端高度），应在绘制前调用
+func (s *inlineScreen) fit(rows int) {
+	w, h := s.termSize()
+	rows = max(1, min(rows, h))
+	if cw, ch := s.Size(); cw != w || ch != rows {
+		s.SimulationScreen.SetSize(w, rows)
+	}
+}
+
+func (s *inlineScreen) PollEvent() tcell.Event {
+	select {
+	case ev := <-s.events:
+		return ev
+	case <-s.quit:
+		return nil
+	}
+}
+
+// Show 从绘制区域的第一行开始重新输出各行，清除上一帧多出的行，再把光标移到输入位置
+func (s *inlineScreen) Show() {
+	_, h := s.Size()
+	lines := make([]string, h)
+	for y := range lines {
+		lines[y] = captureLine(s, y, true)
+	}
+	cx, cy, cursor := s.GetCursor()
+	frame := fmt.Sprint(lines, cx, cy, cursor)
+	if frame == s.last {
+		return
+	}
+	s.last = frame
+
+	var b strings.Builder
+	b.WriteString("\x1b[?25l\r")
+	if s.row > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", s.row)
+	}
+	b.WriteString(strings.Join(lines, "\x1b[K\r\n") + "\x1b[K")
+	if s.drawn > h {
+		b.WriteString("\x1b[J")
+	}
+	s.drawn, s.row = h, h-1
+	if cursor {
+		if cy < s.row {
+			fmt.Fprintf(&b, "\x1b[%dA", s.row-cy)
+		}
+		b.WriteString("\r")
+		if cx > 0 {
+			fmt.Fprintf(&b, "\x1b[%dC", cx)
+		}
+		b.WriteString("\x1b[?25h")
+		s.row = cy
+	}
+	s.tty.Write([]byte(b.String()))
+}
+
+func (s *inlineScreen) Sync() {
+	s.last = ""
+	s.Show()
+}
+
+// Fini 把光标移到绘制区域之后的新行并还原终端，最后一帧留在原处
+func (s *inlineScreen) Fini() {
+	close(s.quit)
+	var b strings.Builder
+	if s.drawn > 0 {
+		if down := s.drawn - 1 - s.row; down > 0 {
+			fmt.Fprintf(&b, "\x1b[%dB", down)
+		}
+		b.WriteString("\r\n")
+	}
+	b.WriteString("\x1b[0m\x1b[?25h")
+	s.tty.Write([]byte(b.String()))
+
+	s.tty.NotifyResize(nil)
+	s.tty.Drain()
+	s.tty.Stop()
+	s.tty.Close()
+	s.SimulationScreen.Fini()
+}
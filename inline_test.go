@@ -0,0 +1,52 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestInlineScreen_RedrawsInPlace(t *testing.T) {
+	tty := newScriptedTty("")
+	s := newInlineScreen(tty)
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	put := func(y int, text string) {
+		for x, r := range text {
+			s.SetContent(x, y, r, nil, tcell.StyleDefault)
+		}
+	}
+
+	s.fit(3)
+	put(0, "a")
+	put(1, "b")
+	put(2, "c")
+	s.Show()
+	if got, want := tty.out.String(), "\x1b[?25l\ra\x1b[K\r\nb\x1b[K\r\nc\x1b[K"; got != want {
+		t.Errorf("first frame = %q, want %q", got, want)
+	}
+
+	// 变矮时回到第一行重绘，并清除多出的行
+	tty.out.Reset()
+	s.fit(1)
+	s.Clear()
+	put(0, "x")
+	s.Show()
+	if got, want := tty.out.String(), "\x1b[?25l\r\x1b[2Ax\x1b[K\x1b[J"; got != want {
+		t.Errorf("second frame = %q, want %q", got, want)
+	}
+
+	// 内容没有变化时不再输出
+	tty.out.Reset()
+	s.Show()
+	if got := tty.out.String(); got != "" {
+		t.Errorf("unchanged frame wrote %q", got)
+	}
+
+	tty.out.Reset()
+	s.Fini()
+	if got, want := tty.out.String(), "\r\n\x1b[0m\x1b[?25h"; got != want {
+		t.Errorf("Fini wrote %q, want %q", got, want)
+	}
+}
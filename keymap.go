@@ -0,0 +1,216 @@
+package rego
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// Keymap - 可配置的键位
+// =============================================================================
+
+// Binding 是一个动作当前生效的键位
+type Binding struct {
+	Action      string
+	Description string
+	Keys        []string // 如 "ctrl+s"、"enter"、"q"
+	Overridden  bool     // 是否被用户配置覆盖
+}
+
+type keymapEntry struct {
+	description string
+	defaults    []string
+	overrides   []string // nil 表示未覆盖
+}
+
+type keymapRegistry struct {
+	mu      sync.RWMutex
+	actions map[string]*keymapEntry
+}
+
+var keymap = &keymapRegistry{actions: map[string]*keymapEntry{}}
+
+// DefineAction 注册一个动作及其默认键位，键位写法见 LoadKeymap
+//
+// 非法的键位写法会 panic，这属于程序错误而非用户配置错误。
+func DefineAction(action, description string, keys ...string) {
+	for _, k := range keys {
+		if _, err := parseKeySpec(k); err != nil {
+			panic(fmt.Sprintf("rego: DefineAction(%q): %v", action, err))
+		}
+	}
+	keymap.mu.Lock()
+	defer keymap.mu.Unlock()
+	e := keymap.actions[action]
+	if e == nil {
+		e = &keymapEntry{}
+		keymap.actions[action] = e
+	}
+	e.description = description
+	e.defaults = keys
+}
+
+// LoadKeymap 从 TOML 或 JSON 文件读取用户键位并覆盖默认键位，路径支持 ~ 开头
+//
+//	[keys]
+//	save = "ctrl+s"
+//	quit = ["q", "ctrl+q"]
+//
+// 键位写法：单个字符（"q"、"?"），按键名（enter、esc、tab、space、backspace、delete、insert、
+// up、down、left、right、home、end、pgup、pgdown、f1-f12），或 "ctrl+字母"。
+// 文件中的错误会全部收集后一起返回，合法的条目仍然生效。
+func LoadKeymap(path string) error {
+	cfg, err := readConfig(path)
+	if err != nil {
+		return err
+	}
+	if keys, ok := cfg["keys"].(map[string]any); ok {
+		cfg = keys
+	}
+
+	actions := make([]string, 0, len(cfg))
+	for action := range cfg {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	var errs []error
+	overrides := map[string][]string{}
+	for _, action := range actions {
+		keys, err := keymapValue(cfg[action])
+		if err == nil {
+			for _, k := range keys {
+				if _, err = parseKeySpec(k); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("keymap %s: action %q: %w", path, action, err))
+			continue
+		}
+		overrides[action] = keys
+	}
+
+	keymap.mu.Lock()
+	for action, keys := range overrides {
+		e := keymap.actions[action]
+		if e == nil {
+			e = &keymapEntry{}
+			keymap.actions[action] = e
+		}
+		e.overrides = keys
+	}
+	keymap.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// keymapValue 将配置值转换为键位列表，支持字符串或字符串数组
+func keymapValue(v any) ([]string, error) {
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		keys := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string, got %T", item)
+			}
+			keys = append(keys, s)
+		}
+		return keys, nil
+	}
+	return nil, fmt.Errorf("expected string or array of strings, got %T", v)
+}
+
+// Bindings 返回所有动作当前生效的键位，按动作名排序
+func Bindings() []Binding {
+	keymap.mu.RLock()
+	defer keymap.mu.RUnlock()
+	out := make([]Binding, 0, len(keymap.actions))
+	for action, e := range keymap.actions {
+		b := Binding{Action: action, Description: e.description, Keys: e.defaults}
+		if e.overrides != nil {
+			b.Keys = e.overrides
+			b.Overridden = true
+		}
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Action < out[j].Action })
+	return out
+}
+
+// MatchAction 判断按键是否触发了 action，在 UseKey 中使用：
+//
+//	UseKey(c, func(key rego.Key, r rune) {
+//		if rego.MatchAction("save", key, r) { ... }
+//	})
+func MatchAction(action string, key Key, r rune) bool {
+	keymap.mu.RLock()
+	e := keymap.actions[action]
+	var keys []string
+	if e != nil {
+		keys = e.defaults
+		if e.overrides != nil {
+			keys = e.overrides
+		}
+	}
+	keymap.mu.RUnlock()
+
+	for _, k := range keys {
+		spec, err := parseKeySpec(k)
+		if err == nil && spec.matches(key, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// keySpec 是解析后的键位
+type keySpec struct {
+	key  Key
+	rune rune
+}
+
+func (s keySpec) matches(key Key, r rune) bool {
+	if s.key != KeyNone {
+		// 空格键可能以 KeySpace 或字符 ' ' 的形式到达
+		return key == s.key || (s.key == KeySpace && r == ' ')
+	}
+	return r != 0 && r == s.rune
+}
+
+var keyNames = map[string]Key{
+	"up": KeyUp, "down": KeyDown, "left": KeyLeft, "right": KeyRight,
+	"enter": KeyEnter, "esc": KeyEsc, "escape": KeyEsc, "backspace": KeyBackspace,
+	"tab": KeyTab, "space": KeySpace, "home": KeyHome, "end": KeyEnd,
+	"pgup": KeyPageUp, "pgdown": KeyPageDown, "delete": KeyDelete, "insert": KeyInsert,
+	"f1": KeyF1, "f2": KeyF2, "f3": KeyF3, "f4": KeyF4, "f5": KeyF5, "f6": KeyF6,
+	"f7": KeyF7, "f8": KeyF8, "f9": KeyF9, "f10": KeyF10, "f11": KeyF11, "f12": KeyF12,
+}
+
+// parseKeySpec 解析键位写法
+func parseKeySpec(s string) (keySpec, error) {
+	if utf8.RuneCountInString(s) == 1 {
+		r, _ := utf8.DecodeRuneInString(s)
+		return keySpec{rune: r}, nil
+	}
+	lower := strings.ToLower(s)
+	if k, ok := keyNames[lower]; ok {
+		return keySpec{key: k}, nil
+	}
+	if letter, ok := strings.CutPrefix(lower, "ctrl+"); ok && len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' && letter != "m" {
+		k := KeyCtrlA + Key(letter[0]-'a')
+		if letter[0] > 'm' {
+			k-- // Ctrl+M 与 Enter 无法区分，没有对应的常量
+		}
+		return keySpec{key: k}, nil
+	}
+	return keySpec{}, fmt.Errorf("unknown key %q", s)
+}
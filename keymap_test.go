@@ -0,0 +1,84 @@
+package rego
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseKeySpec(t *testing.T) {
+	cases := map[string]keySpec{
+		"q":      {rune: 'q'},
+		"?":      {rune: '?'},
+		"enter":  {key: KeyEnter},
+		"Esc":    {key: KeyEsc},
+		"f5":     {key: KeyF5},
+		"ctrl+a": {key: KeyCtrlA},
+		"ctrl+l": {key: KeyCtrlL},
+		"ctrl+n": {key: KeyCtrlN},
+		"ctrl+z": {key: KeyCtrlZ},
+	}
+	for in, want := range cases {
+		got, err := parseKeySpec(in)
+		if err != nil || got != want {
+			t.Errorf("parseKeySpec(%q) = %+v, %v; want %+v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "ctrl+", "ctrl+m", "hyper+x"} {
+		if _, err := parseKeySpec(bad); err == nil {
+			t.Errorf("parseKeySpec(%q): expected error", bad)
+		}
+	}
+}
+
+func TestLoadKeymap(t *testing.T) {
+	keymap = &keymapRegistry{actions: map[string]*keymapEntry{}}
+	t.Cleanup(func() { keymap = &keymapRegistry{actions: map[string]*keymapEntry{}} })
+
+	DefineAction("save", "保存", "ctrl+s")
+	DefineAction("quit", "退出", "q")
+
+	path := filepath.Join(t.TempDir(), "keys.toml")
+	config := `# 用户键位
+[keys]
+save = ["ctrl+w", "f2"] # 覆盖默认
+quit = "hyper+q"
+`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := LoadKeymap(path)
+	if err == nil || !strings.Contains(err.Error(), `"quit"`) {
+		t.Fatalf("expected validation error for quit, got %v", err)
+	}
+
+	if !MatchAction("save", KeyF2, 0) || MatchAction("save", KeyCtrlS, 0) {
+		t.Error("expected save to be rebound to ctrl+w/f2")
+	}
+	if !MatchAction("quit", KeyNone, 'q') {
+		t.Error("expected invalid override to keep default binding")
+	}
+
+	bindings := Bindings()
+	if len(bindings) != 2 || bindings[1].Action != "save" || !bindings[1].Overridden {
+		t.Errorf("unexpected bindings: %+v", bindings)
+	}
+}
+
+func TestLoadKeymap_JSON(t *testing.T) {
+	keymap = &keymapRegistry{actions: map[string]*keymapEntry{}}
+	t.Cleanup(func() { keymap = &keymapRegistry{actions: map[string]*keymapEntry{}} })
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(path, []byte(`{"keys": {"help": "?"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadKeymap(path); err != nil {
+		t.Fatal(err)
+	}
+	if !MatchAction("help", KeyNone, '?') {
+		t.Error("expected help to be bound to ?")
+	}
+}
@@ -198,3 +198,62 @@ func convertTcellKey(e *tcell.EventKey) (Key, rune, Modifiers) {
 		return KeyNone, 0, mods
 	}
 }
+
+// tcellKeys rego 按键到 tcell 按键的映射（KeySpace 以外）
+var tcellKeys = map[Key]tcell.Key{
+	KeyUp:        tcell.KeyUp,
+	KeyDown:      tcell.KeyDown,
+	KeyLeft:      tcell.KeyLeft,
+	KeyRight:     tcell.KeyRight,
+	KeyEnter:     tcell.KeyEnter,
+	KeyEsc:       tcell.KeyEscape,
+	KeyBackspace: tcell.KeyBackspace2,
+	KeyTab:       tcell.KeyTab,
+	KeyHome:      tcell.KeyHome,
+	KeyEnd:       tcell.KeyEnd,
+	KeyPageUp:    tcell.KeyPgUp,
+	KeyPageDown:  tcell.KeyPgDn,
+	KeyDelete:    tcell.KeyDelete,
+	KeyInsert:    tcell.KeyInsert,
+}
+
+func init() {
+	for i := KeyF1; i <= KeyF12; i++ {
+		tcellKeys[i] = tcell.KeyF1 + tcell.Key(i-KeyF1)
+	}
+	// rego 中没有 Ctrl+M（与 Enter 相同），之后的字母需要跳过一位
+	for i := KeyCtrlA; i <= KeyCtrlZ; i++ {
+		offset := tcell.Key(i - KeyCtrlA)
+		if i >= KeyCtrlN {
+			offset++
+		}
+		tcellKeys[i] = tcell.KeyCtrlA + offset
+	}
+}
+
+// TcellKey 将 rego 按键转换为 tcell 按键和字符（convertTcellKey 的逆转换），用于测试和演示中注入按键；
+// KeySpace 转换为字符 ' '，不支持的按键返回 false
+func TcellKey(key Key) (tcell.Key, rune, bool) {
+	if key == KeySpace {
+		return tcell.KeyRune, ' ', true
+	}
+	k, ok := tcellKeys[key]
+	return k, 0, ok
+}
+
+// TcellMods 将 rego 修饰键转换为 tcell 修饰键
+func TcellMods(mods ...Modifiers) tcell.ModMask {
+	var m tcell.ModMask
+	for _, mod := range mods {
+		if mod&ModShift != 0 {
+			m |= tcell.ModShift
+		}
+		if mod&ModCtrl != 0 {
+			m |= tcell.ModCtrl
+		}
+		if mod&ModAlt != 0 {
+			m |= tcell.ModAlt
+		}
+	}
+	return m
+}
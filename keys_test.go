@@ -0,0 +1,24 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTcellKey_RoundTrip(t *testing.T) {
+	for key := KeyUp; key <= KeyCtrlZ; key++ {
+		k, r, ok := TcellKey(key)
+		if !ok {
+			t.Errorf("TcellKey(%d) unsupported", key)
+			continue
+		}
+		got, _, mods := convertTcellKey(tcell.NewEventKey(k, r, TcellMods(ModCtrl, ModAlt)))
+		if got != key || mods != ModCtrl|ModAlt {
+			t.Errorf("key %d: round trip = %d, mods %d", key, got, mods)
+		}
+	}
+	if _, _, ok := TcellKey(KeyNone); ok {
+		t.Error("KeyNone should not be supported")
+	}
+}
@@ -0,0 +1,92 @@
+package rego
+
+import (
+	"os/exec"
+	"runtime"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// 超链接（OSC 8）与可点击区域
+// =============================================================================
+
+// Link 将文本设为超链接：支持 OSC 8 的终端中可直接 Ctrl/Cmd+点击打开，并带下划线显示
+func (t *textNode) Link(url string) *textNode {
+	t.link = url
+	return t
+}
+
+// OnClick 设置鼠标左键点击文本时的回调，常与 Link 搭配使用：
+//
+//	Text("文档").Link(url).OnClick(func() { rego.OpenURL(url) })
+func (t *textNode) OnClick(fn func()) *textNode {
+	t.onClick = fn
+	return t
+}
+
+// OpenURL 使用系统默认程序打开 url（macOS 为 open，Windows 为 start，其余为 xdg-open）
+func OpenURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// clickRegion 是本帧中可点击的屏幕区域
+type clickRegion struct {
+	rect    Rect
+	onClick func()
+	window  *componentContext // 所在的浮动窗口，位于主界面时为 nil
+}
+
+// screenRuntime 从渲染用的 screen 中找到所属的运行时
+func screenRuntime(screen tcell.Screen) *Runtime {
+	for {
+		switch s := screen.(type) {
+		case *renderScreenProxy:
+			return s.runtime
+		case *clipScreen:
+			if s.runtime != nil {
+				return s.runtime
+			}
+			screen = s.Screen
+		default:
+			return nil
+		}
+	}
+}
+
+// addClickRegion 在本帧注册一个可点击区域，r 为渲染坐标
+func addClickRegion(screen tcell.Screen, r Rect, fn func()) {
+	rt := screenRuntime(screen)
+	if rt == nil {
+		return
+	}
+	_, visible := screenRect(screen, r)
+	if visible.W > 0 && visible.H > 0 {
+		rt.clickRegions = append(rt.clickRegions, clickRegion{rect: visible, onClick: fn, window: rt.windows.drawing})
+	}
+}
+
+// dispatchClick 将左键点击交给最上层命中的可点击区域，命中时返回 true
+func (r *Runtime) dispatchClick(ev MouseEvent) bool {
+	if ev.Type != MouseEventClick || ev.Button != MouseButtonLeft {
+		return false
+	}
+	for i := len(r.clickRegions) - 1; i >= r.clickFloor; i-- {
+		region := r.clickRegions[i]
+		if region.rect.Contains(ev.X, ev.Y) && r.pressedIn(region.rect) && region.window == r.windows.at(ev.X, ev.Y) {
+			r.clickRegions[i].onClick()
+			r.scheduleRefresh()
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestText_Link(t *testing.T) {
+	clicked := 0
+	app := func(c C) Node {
+		return VStack(
+			Text("header"),
+			HStack(Text("see "), Text("docs").Link("https://example.com/docs").OnClick(func() { clicked++ })),
+		)
+	}
+
+	screen := newTestScreen(20, 2)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	_, _, style, _ := screen.GetContent(4, 1)
+	want := tcell.StyleDefault.Foreground(tcell.ColorDefault).Url("https://example.com/docs").Underline(true)
+	if style != want {
+		t.Errorf("expected underlined OSC 8 link style, got %v", style)
+	}
+
+	tr.handleEvent(tcell.NewEventMouse(2, 1, tcell.Button1, 0))
+	tr.handleEvent(tcell.NewEventMouse(2, 1, tcell.ButtonNone, 0))
+	if clicked != 0 {
+		t.Error("expected click outside the link to be ignored")
+	}
+	tr.handleEvent(tcell.NewEventMouse(5, 1, tcell.Button1, 0))
+	if clicked != 0 {
+		t.Error("expected OnClick to wait for the button release")
+	}
+	tr.handleEvent(tcell.NewEventMouse(5, 1, tcell.ButtonNone, 0))
+	if clicked != 1 {
+		t.Errorf("expected link OnClick to be called once, got %d", clicked)
+	}
+}
@@ -0,0 +1,398 @@
+package rego
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// List - 列表（支持多选标记）
+// =============================================================================
+
+type ListProps struct {
+	Items    []string
+	Height   int // 0 表示按条目数自动计算
+	OnSelect func(index int)
+
+	// OnReorder 非空时可以用鼠标拖动或 Alt+↑/↓ 调整条目顺序，from 移到 to 之后由调用方更新 Items
+	OnReorder func(from, to int)
+
+	// Loading 数据加载中，显示为 Skeleton（行数取 Height，未指定时为 3）
+	Loading bool
+
+	// Striped 隔行使用主题的 Stripe 背景（斑马纹）
+	Striped bool
+
+	// 多选模式
+	MultiSelect     bool  // 是否在条目前显示标记
+	Marked          []int // 已标记的条目下标
+	OnMarkedChanged func(marked []int)
+}
+
+// listLoadingRows 未指定高度时加载中显示的占位行数
+const listLoadingRows = 3
+
+// listMarkWidth 标记列占用的宽度（"[x]" 加一个空格）
+const listMarkWidth = 4
+
+// List 创建一个单列列表
+//
+// 聚焦时 ↑/↓ 移动光标，Enter 触发 OnSelect，鼠标滚轮滚动，点击选中条目。
+//
+// 开启 MultiSelect 后 Space 标记/取消当前条目，a 全选/全不选，
+// v 进入可视模式：移动光标时从进入位置到光标之间的条目都显示为标记，
+// 再按 v 或 Space 把这段范围加入标记，Esc 放弃。Shift+点击标记上次标记的条目到点击条目之间的范围。
+// 标记的集合由调用方通过 Marked 和 OnMarkedChanged 保存。
+//
+// 设置 OnReorder 后 Alt+↑/↓ 把当前条目上移/下移一位，按住条目拖动时预览放下后的顺序，松开时触发 OnReorder。
+func List(c C, props ListProps) Node {
+	ctx := c.(*componentContext)
+	focus := UseFocus(c)
+	theme := UseTheme(c)
+	cursor := Use(c, "cursor", 0)
+	offset := Use(c, "offset", 0)
+	visual := Use(c, "visual", -1) // 可视模式的起点，-1 表示不在可视模式
+	anchor := Use(c, "anchor", -1) // 上次标记的条目，Shift+点击范围标记的起点
+	drag := Use(c, "drag", rowDrag{from: -1})
+
+	count := len(props.Items)
+
+	// 可见行数基于上一帧的布局
+	visibleRows := c.Rect().H
+	if visibleRows <= 0 {
+		visibleRows = count
+		if props.Height > 0 {
+			visibleRows = props.Height
+		}
+	}
+
+	marked := make(map[int]bool, len(props.Marked))
+	for _, i := range props.Marked {
+		marked[i] = true
+	}
+
+	emitMarked := func(next map[int]bool) {
+		if props.OnMarkedChanged == nil {
+			return
+		}
+		out := make([]int, 0, len(next))
+		for i, on := range next {
+			if on && i >= 0 && i < count {
+				out = append(out, i)
+			}
+		}
+		sort.Ints(out)
+		props.OnMarkedChanged(out)
+	}
+
+	moveTo := func(i int) {
+		if count == 0 {
+			return
+		}
+		i = max(0, min(i, count-1))
+		cursor.Set(i)
+		offset.Set(clampTableOffset(i, offset.Val, visibleRows))
+	}
+
+	toggle := func(i int) {
+		if i < 0 || i >= count {
+			return
+		}
+		next := make(map[int]bool, len(marked)+1)
+		for k, v := range marked {
+			next[k] = v
+		}
+		next[i] = !next[i]
+		anchor.Set(i)
+		emitMarked(next)
+	}
+
+	// markRange 标记 from 到 to 之间的所有条目（保留已有标记）
+	markRange := func(from, to int) {
+		next := make(map[int]bool, len(marked)+count)
+		for k, v := range marked {
+			next[k] = v
+		}
+		for i := max(min(from, to), 0); i <= max(from, to) && i < count; i++ {
+			next[i] = true
+		}
+		anchor.Set(to)
+		emitMarked(next)
+	}
+
+	// toggleAll 未全部标记时全选，否则全部取消
+	toggleAll := func() {
+		next := map[int]bool{}
+		if len(props.Marked) < count {
+			for i := 0; i < count; i++ {
+				next[i] = true
+			}
+		}
+		emitMarked(next)
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused {
+			return
+		}
+		if props.OnReorder != nil && ctx.keyMods()&ModAlt != 0 && (key == KeyUp || key == KeyDown) {
+			to := cursor.Val + If(key == KeyUp, -1, 1)
+			if count > 0 && to >= 0 && to < count {
+				props.OnReorder(cursor.Val, to)
+				moveTo(to)
+			}
+			return
+		}
+		if props.MultiSelect {
+			switch {
+			case visual.Val >= 0 && (r == 'v' || key == KeySpace || r == ' '):
+				markRange(visual.Val, cursor.Val)
+				visual.Set(-1)
+				return
+			case visual.Val >= 0 && key == KeyEsc:
+				visual.Set(-1)
+				return
+			case r == 'v' && count > 0:
+				visual.Set(cursor.Val)
+				return
+			case key == KeySpace || r == ' ':
+				toggle(cursor.Val)
+				return
+			case r == 'a':
+				toggleAll()
+				return
+			}
+		}
+		switch key {
+		case KeyUp:
+			moveTo(cursor.Val - 1)
+		case KeyDown:
+			moveTo(cursor.Val + 1)
+		case KeyPageUp:
+			moveTo(cursor.Val - visibleRows)
+		case KeyPageDown:
+			moveTo(cursor.Val + visibleRows)
+		case KeyHome:
+			moveTo(0)
+		case KeyEnd:
+			moveTo(count - 1)
+		case KeyEnter:
+			if props.OnSelect != nil && count > 0 {
+				props.OnSelect(cursor.Val)
+			}
+		}
+	})
+
+	UseMouse(c, func(ev MouseEvent) {
+		rect := c.Rect()
+		if props.OnReorder != nil && handleRowDrag(drag, ev, offset.Val+ev.Y-rect.Y, count, props.OnReorder, moveTo) {
+			return
+		}
+		if !rect.Contains(ev.X, ev.Y) {
+			return
+		}
+		switch ev.Type {
+		case MouseEventPress:
+			if props.OnReorder != nil && ev.Button == MouseButtonLeft && offset.Val+ev.Y-rect.Y < count {
+				drag.Set(rowDrag{from: offset.Val + ev.Y - rect.Y, to: offset.Val + ev.Y - rect.Y})
+			}
+		case MouseEventClick:
+			if ev.Button != MouseButtonLeft {
+				return
+			}
+			focus.Focus()
+			i := offset.Val + ev.Y - rect.Y
+			if i >= count {
+				return
+			}
+			if props.MultiSelect {
+				switch {
+				case ev.Mod&ModShift != 0 && anchor.Val >= 0 && anchor.Val < count:
+					markRange(anchor.Val, i)
+				case ev.X < rect.X+listMarkWidth:
+					toggle(i)
+				}
+			}
+			moveTo(i)
+		case MouseEventScrollUp:
+			moveTo(cursor.Val - 1)
+		case MouseEventScrollDown:
+			moveTo(cursor.Val + 1)
+		}
+	})
+
+	if props.Loading {
+		return c.Wrap(Skeleton(0, If(props.Height > 0, props.Height, listLoadingRows)))
+	}
+
+	cur := min(cursor.Val, count-1)
+	vis := visual.Val
+	if !props.MultiSelect || vis >= count {
+		vis = -1
+	}
+
+	// 拖动期间按放下后的顺序预览
+	items, dragRow := props.Items, -1
+	if d := drag.Val; d.from >= 0 && d.to != d.from && d.from < count && d.to < count {
+		order := reorderPreview(count, d.from, d.to)
+		items = make([]string, count)
+		previewMarked := make(map[int]bool, len(marked))
+		for i, src := range order {
+			items[i] = props.Items[src]
+			previewMarked[i] = marked[src]
+		}
+		marked, cur, dragRow, vis = previewMarked, d.to, d.to, -1
+	}
+
+	return c.Wrap(&listNode{
+		items:    items,
+		height:   props.Height,
+		offset:   clampTableOffset(cur, offset.Val, visibleRows),
+		cursor:   cur,
+		focused:  focus.IsFocused,
+		theme:    theme,
+		striped:  props.Striped,
+		multi:    props.MultiSelect,
+		marked:   marked,
+		visualLo: If(vis >= 0, min(vis, cur), -1),
+		visualHi: If(vis >= 0, max(vis, cur), -1),
+		dragRow:  dragRow,
+	})
+}
+
+// rowDrag 拖动调整行顺序的状态
+type rowDrag struct {
+	from, to int  // 被拖动的行和当前放下的位置，from 为 -1 表示没有在拖动
+	dropped  bool // 刚刚放下，忽略紧随松开的点击
+}
+
+// handleRowDrag 处理拖动中的移动和松开，row 是指针所在的行，返回 true 表示事件已被拖动消费
+//
+// 松开时如果位置发生了变化则调用 onReorder，随后把光标移到 to
+func handleRowDrag(drag *State[rowDrag], ev MouseEvent, row, count int, onReorder func(from, to int), moveTo func(int)) bool {
+	d := drag.Val
+	switch {
+	case d.dropped:
+		drag.Set(rowDrag{from: -1})
+		return ev.Type == MouseEventClick
+	case d.from < 0:
+		return false
+	case ev.Type == MouseEventMove && ev.Button == MouseButtonLeft:
+		drag.Set(rowDrag{from: d.from, to: max(0, min(row, count-1))})
+		return true
+	case ev.Type == MouseEventRelease:
+		if d.to == d.from || d.from >= count {
+			drag.Set(rowDrag{from: -1})
+			return false
+		}
+		drag.Set(rowDrag{from: -1, dropped: true})
+		onReorder(d.from, d.to)
+		moveTo(d.to)
+		return true
+	}
+	return false
+}
+
+// reorderPreview 返回把第 from 行移到 to 之后各位置对应的原始下标
+func reorderPreview(n, from, to int) []int {
+	order := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if i != from {
+			order = append(order, i)
+		}
+	}
+	order = append(order[:to], append([]int{from}, order[to:]...)...)
+	return order
+}
+
+// =============================================================================
+// listNode - 列表渲染节点
+// =============================================================================
+
+type listNode struct {
+	items   []string
+	height  int
+	offset  int
+	cursor  int
+	focused bool
+	theme   Theme
+	striped bool
+
+	multi              bool
+	marked             map[int]bool
+	visualLo, visualHi int // 可视模式选中的范围，-1 表示不在可视模式
+
+	dragRow int // 正在拖动的条目预览所在的行，-1 表示没有拖动
+}
+
+// isMarked 报告条目 i 是否显示为已标记（包括可视模式中的范围）
+func (l *listNode) isMarked(i int) bool {
+	return l.marked[i] || (l.visualLo >= 0 && i >= l.visualLo && i <= l.visualHi)
+}
+
+func (l *listNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	if l.height > 0 && l.height < height {
+		height = l.height
+	}
+	used := 0
+	for i := l.offset; i < len(l.items) && used < height; i++ {
+		rowY := y + used
+		style := tcell.StyleDefault
+		switch {
+		case i == l.dragRow:
+			style = style.Foreground(colorToTcell(l.theme.Primary)).Bold(true).Underline(true)
+		case i == l.cursor && l.focused:
+			style = style.Background(colorToTcell(l.theme.Primary)).Foreground(colorToTcell(l.theme.OnColor))
+		case i == l.cursor:
+			style = style.Reverse(true)
+		case l.multi && l.isMarked(i):
+			style = style.Foreground(colorToTcell(l.theme.Primary))
+		}
+		striped := l.striped && i%2 == 1 && i != l.cursor
+		if striped {
+			style = style.Background(colorToTcell(l.theme.Stripe))
+		}
+		if i == l.cursor || striped {
+			for col := x; col < x+width; col++ {
+				screen.SetContent(col, rowY, ' ', nil, style)
+			}
+		}
+		text := l.items[i]
+		if l.multi {
+			text = If(l.isMarked(i), "[x] ", "[ ] ") + text
+		}
+		if runewidth.StringWidth(text) > width {
+			text = runewidth.Truncate(text, width, "…")
+		}
+		col := x
+		for _, r := range text {
+			screen.SetContent(col, rowY, r, nil, style)
+			col += runewidth.RuneWidth(r)
+		}
+		used++
+	}
+	return used
+}
+
+func (l *listNode) measureHeight(width int) int {
+	if l.height > 0 {
+		return l.height
+	}
+	return len(l.items)
+}
+
+func (l *listNode) measureWidth() int {
+	w := 0
+	for _, item := range l.items {
+		w = max(w, runewidth.StringWidth(item))
+	}
+	if l.multi {
+		w += listMarkWidth
+	}
+	return w
+}
@@ -0,0 +1,150 @@
+package rego
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestList_Select(t *testing.T) {
+	selected := -1
+	app := func(c C) Node {
+		return List(c.Child("list"), ListProps{
+			Items:    []string{"a", "b", "c"},
+			OnSelect: func(i int) { selected = i },
+		})
+	}
+	screen := newTestScreen(20, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	if selected != 2 {
+		t.Errorf("selected = %d, want 2 (cursor clamped to last item)", selected)
+	}
+	if content := getScreenContent(screen); strings.Contains(content, "[ ]") {
+		t.Errorf("single-select list should not draw marks, got:\n%s", content)
+	}
+}
+
+func TestList_MultiSelect(t *testing.T) {
+	var got []int
+	app := func(c C) Node {
+		marked := Use(c, "marked", []int{})
+		return List(c.Child("list"), ListProps{
+			Items:       []string{"a", "b", "c", "d", "e"},
+			MultiSelect: true,
+			Marked:      marked.Val,
+			OnMarkedChanged: func(m []int) {
+				got = m
+				marked.Set(m)
+			},
+		})
+	}
+	screen := newTestScreen(20, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// Space 标记当前条目
+	tr.DispatchKey(tcell.KeyRune, ' ', 0)
+	tr.Render()
+	if !reflect.DeepEqual(got, []int{0}) {
+		t.Fatalf("marked = %v, want [0]", got)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "[x] a") || !strings.Contains(content, "[ ] b") {
+		t.Fatalf("expected marks to be drawn, got:\n%s", content)
+	}
+
+	// 可视模式：移动期间范围显示为标记，再按 v 加入标记
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyRune, 'v', 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "[x] d") {
+		t.Errorf("expected visual range to be shown as marked, got:\n%s", content)
+	}
+	tr.DispatchKey(tcell.KeyRune, 'v', 0)
+	tr.Render()
+	if !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Fatalf("marked = %v, want [0 2 3]", got)
+	}
+
+	// Esc 放弃可视模式
+	tr.DispatchKey(tcell.KeyRune, 'v', 0)
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyEsc, 0, 0)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "[x] e") {
+		t.Errorf("expected Esc to cancel visual range, got:\n%s", content)
+	}
+
+	// a 全选，再按一次全部取消
+	tr.DispatchKey(tcell.KeyRune, 'a', 0)
+	tr.Render()
+	if len(got) != 5 {
+		t.Errorf("marked = %v, want all", got)
+	}
+	tr.DispatchKey(tcell.KeyRune, 'a', 0)
+	tr.Render()
+	if len(got) != 0 {
+		t.Errorf("marked = %v, want none", got)
+	}
+}
+
+// moveItem 把 items 中的第 from 项移到 to
+func moveItem(items []string, from, to int) []string {
+	out := make([]string, 0, len(items))
+	for _, i := range reorderPreview(len(items), from, to) {
+		out = append(out, items[i])
+	}
+	return out
+}
+
+func TestList_Reorder(t *testing.T) {
+	app := func(c C) Node {
+		items := Use(c, "items", []string{"a", "b", "c", "d"})
+		return List(c.Child("list"), ListProps{
+			Items:     items.Val,
+			OnReorder: func(from, to int) { items.Set(moveItem(items.Val, from, to)) },
+		})
+	}
+	screen := newTestScreen(10, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	rows := func() string {
+		return strings.Join(strings.Fields(getScreenContent(screen)), "")
+	}
+
+	// Alt+↓ 把当前条目下移，光标跟随
+	tr.DispatchKey(tcell.KeyDown, 0, tcell.ModAlt)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyDown, 0, tcell.ModAlt)
+	tr.Render()
+	if got := rows(); got != "bcad" {
+		t.Fatalf("after Alt+Down twice: %q, want bcad", got)
+	}
+
+	// 拖动时预览放下后的顺序，松开后提交
+	tr.DispatchMouse(0, 3, tcell.Button1, 0)
+	tr.DispatchMouse(0, 0, tcell.Button1, 0)
+	tr.Render()
+	if got := rows(); got != "dbca" {
+		t.Errorf("drag preview: %q, want dbca", got)
+	}
+	_, _, style, _ := screen.GetContent(0, 0)
+	if _, _, attrs := style.Decompose(); attrs&tcell.AttrUnderline == 0 {
+		t.Errorf("expected dragged row to be underlined")
+	}
+	tr.DispatchMouse(0, 0, tcell.ButtonNone, 0)
+	tr.Render()
+	if got := rows(); got != "dbca" {
+		t.Errorf("after drop: %q, want dbca", got)
+	}
+}
@@ -0,0 +1,432 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// LogView - 日志查看组件
+// =============================================================================
+
+// LogLevel 日志级别
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+// String 返回日志级别的显示名称
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "DEBUG"
+	case LogInfo:
+		return "INFO"
+	case LogWarn:
+		return "WARN"
+	case LogError:
+		return "ERROR"
+	default:
+		return "?"
+	}
+}
+
+func (l LogLevel) color() Color {
+	switch l {
+	case LogDebug:
+		return Gray
+	case LogInfo:
+		return Cyan
+	case LogWarn:
+		return Yellow
+	case LogError:
+		return Red
+	default:
+		return Default
+	}
+}
+
+// LogEntry 是一条日志记录
+type LogEntry struct {
+	Time    time.Time
+	Level   LogLevel
+	Message string
+}
+
+type LogViewProps struct {
+	Source     <-chan LogEntry
+	MaxEntries int  // 最多保留的日志条数，0 表示默认 1000
+	Timestamps bool // 初始是否显示时间戳
+	Wrap       bool // 初始是否自动换行
+}
+
+// logRecord 是带序号的日志记录，序号用于暂停时截断视图
+type logRecord struct {
+	LogEntry
+	seq int
+}
+
+// logBuffer 在接收协程和渲染之间共享日志
+type logBuffer struct {
+	mu      sync.Mutex
+	records []logRecord
+	seq     int
+}
+
+func (b *logBuffer) add(e LogEntry, max int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	b.records = append(b.records, logRecord{LogEntry: e, seq: b.seq})
+	if len(b.records) > max {
+		b.records = b.records[len(b.records)-max:]
+	}
+}
+
+func (b *logBuffer) snapshot() ([]logRecord, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]logRecord, len(b.records))
+	copy(out, b.records)
+	return out, b.seq
+}
+
+// LogView 创建一个日志查看器：自动跟随、级别过滤、时间戳/换行切换、搜索高亮和暂停
+//
+// 快捷键（聚焦时）：
+//
+//	1-4  切换 DEBUG/INFO/WARN/ERROR 的显示
+//	t    切换时间戳
+//	w    切换自动换行
+//	p    暂停/继续
+//	f    恢复跟随并滚动到底部
+//	/    输入搜索词，Enter 确认，Esc 清除
+//	n/N  跳到下一个/上一个匹配行
+func LogView(c C, props LogViewProps) Node {
+	focus := UseFocus(c)
+	buf := UseRef(c, &logBuffer{})
+	hidden := Use(c, "hidden", map[LogLevel]bool{})
+	timestamps := Use(c, "timestamps", props.Timestamps)
+	wrap := Use(c, "wrap", props.Wrap)
+	paused := Use(c, "paused", false)
+	pausedSeq := Use(c, "pausedSeq", 0)
+	searching := Use(c, "searching", false)
+	query := Use(c, "query", "")
+	current := Use(c, "currentMatch", -1) // 当前跳到的匹配行（hits 下标）
+
+	maxEntries := props.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+
+	// 订阅日志来源
+	UseEffect(c, func() func() {
+		if props.Source == nil {
+			return nil
+		}
+		stop := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case e, ok := <-props.Source:
+					if !ok {
+						return
+					}
+					buf.Current.add(e, maxEntries)
+					c.Refresh()
+				case <-stop:
+					return
+				}
+			}
+		}()
+		return func() { close(stop) }
+	}, props.Source)
+
+	scrollCtx := c.Child("scroll")
+	scroll := UseScrollControl(scrollCtx)
+	records, seq := buf.Current.snapshot()
+
+	// 可见行及包含匹配的行下标（在下方构造，按键处理时使用）
+	var lines []Node
+	var hits []int
+
+	// 跳到下一个（dir=1）或上一个（dir=-1）匹配行，使其位于视口上部
+	jump := func(dir int) {
+		if len(hits) == 0 {
+			return
+		}
+		next := 0
+		if current.Val >= 0 {
+			next = (current.Val + dir + len(hits)) % len(hits)
+		} else if dir < 0 {
+			next = len(hits) - 1
+		}
+		current.Set(next)
+
+		width := max(1, scrollCtx.Rect().W-1) // 去掉滚动条
+		top := 0
+		for _, line := range lines[:hits[next]] {
+			top += measureNodeHeight(line, width)
+		}
+		view := UseScrollState(scrollCtx).ViewportHeight
+		scroll.ScrollTo(top - view/3)
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused {
+			return
+		}
+
+		// 搜索输入模式
+		if searching.Val {
+			switch key {
+			case KeyEnter:
+				searching.Set(false)
+			case KeyEsc:
+				searching.Set(false)
+				query.Set("")
+			case KeyBackspace:
+				if runes := []rune(query.Val); len(runes) > 0 {
+					query.Set(string(runes[:len(runes)-1]))
+				}
+			default:
+				if r != 0 {
+					query.Set(query.Val + string(r))
+				}
+			}
+			current.Set(-1)
+			return
+		}
+
+		switch {
+		case r >= '1' && r <= '4':
+			level := LogLevel(r - '1')
+			next := make(map[LogLevel]bool, len(hidden.Val)+1)
+			for k, v := range hidden.Val {
+				next[k] = v
+			}
+			next[level] = !next[level]
+			hidden.Set(next)
+		case r == 't':
+			timestamps.Set(!timestamps.Val)
+		case r == 'w':
+			wrap.Set(!wrap.Val)
+		case r == 'p':
+			if !paused.Val {
+				pausedSeq.Set(seq)
+				scroll.Pause()
+			}
+			paused.Set(!paused.Val)
+		case r == 'f':
+			paused.Set(false)
+			scroll.Follow()
+		case r == '/':
+			searching.Set(true)
+		case r == 'n' && query.Val != "":
+			jump(1)
+		case r == 'N' && query.Val != "":
+			jump(-1)
+		case key == KeyEsc:
+			query.Set("")
+			current.Set(-1)
+		}
+	})
+
+	// 构造可见行
+	matches := 0
+	for _, rec := range records {
+		if paused.Val && rec.seq > pausedSeq.Val {
+			break
+		}
+		if hidden.Val[rec.Level] {
+			continue
+		}
+		line, n := logLine(rec.LogEntry, timestamps.Val, wrap.Val, query.Val)
+		matches += n
+		if n > 0 {
+			hits = append(hits, len(lines))
+		}
+		lines = append(lines, line)
+	}
+
+	return c.Wrap(VStack(
+		TailBox(scrollCtx, VStack(lines...)),
+		logStatusBar(hidden.Val, paused.Val, searching.Val, query.Val, matches, current.Val, len(hits), focus.IsFocused),
+	).Flex(1))
+}
+
+// logLine 构造单行日志节点，返回节点和搜索命中次数
+func logLine(e LogEntry, showTime, wrap bool, query string) (Node, int) {
+	var spans []textSpan
+	if showTime {
+		spans = append(spans, textSpan{text: e.Time.Format("15:04:05") + " ", style: defaultStyle().Foreground(Gray)})
+	}
+	spans = append(spans, textSpan{
+		text:  fmt.Sprintf("%-5s ", e.Level),
+		style: defaultStyle().Foreground(e.Level.color()).Bold(),
+	})
+
+	plain := defaultStyle()
+	hit := defaultStyle().Background(Yellow).Foreground(Black)
+	ranges := findMatches(e.Message, query)
+	pos := 0
+	for _, rg := range ranges {
+		if rg[0] > pos {
+			spans = append(spans, textSpan{text: e.Message[pos:rg[0]], style: plain})
+		}
+		spans = append(spans, textSpan{text: e.Message[rg[0]:rg[1]], style: hit})
+		pos = rg[1]
+	}
+	if pos < len(e.Message) {
+		spans = append(spans, textSpan{text: e.Message[pos:], style: plain})
+	}
+
+	return &spanNode{spans: spans, wrap: wrap}, len(ranges)
+}
+
+// logStatusBar 底部状态栏：级别开关、跟随状态和搜索信息
+// current 为当前跳到的匹配行（-1 表示未跳转），hitLines 为包含匹配的行数
+func logStatusBar(hidden map[LogLevel]bool, paused, searching bool, query string, matches, current, hitLines int, focused bool) Node {
+	var items []Node
+	for l := LogDebug; l <= LogError; l++ {
+		label := Text(fmt.Sprintf("%d:%s", int(l)+1, l))
+		if hidden[l] {
+			label = label.Dim()
+		} else {
+			label = label.Color(l.color())
+		}
+		items = append(items, label)
+	}
+
+	items = append(items, Spacer())
+	if searching || query != "" {
+		search := Text("/" + query)
+		if searching {
+			search = search.Underline()
+		}
+		counter := fmt.Sprintf("(%d)", matches)
+		if current >= 0 && current < hitLines {
+			counter = fmt.Sprintf("(%d/%d)", current+1, hitLines)
+		}
+		items = append(items, search, Text(counter).Dim())
+	}
+	items = append(items, WhenElse(paused,
+		Text(" PAUSED ").Background(Yellow).Color(Black),
+		Text(" FOLLOW ").Background(If(focused, Green, Gray)).Color(Black),
+	))
+
+	return HStack(items...).Gap(1)
+}
+
+// findMatches 返回 query 在 s 中所有（不区分大小写的）匹配区间（字节偏移）
+func findMatches(s, query string) [][2]int {
+	if query == "" {
+		return nil
+	}
+	haystack, needle := s, query
+	// 仅当大小写转换不改变字节长度时才忽略大小写，保证偏移可用
+	if ls, lq := strings.ToLower(s), strings.ToLower(query); len(ls) == len(s) && len(lq) == len(query) {
+		haystack, needle = ls, lq
+	}
+
+	var out [][2]int
+	start := 0
+	for {
+		i := strings.Index(haystack[start:], needle)
+		if i < 0 {
+			break
+		}
+		begin := start + i
+		end := begin + len(needle)
+		out = append(out, [2]int{begin, end})
+		start = end
+	}
+	return out
+}
+
+// =============================================================================
+// spanNode - 多段样式文本（内部使用）
+// =============================================================================
+
+type textSpan struct {
+	text  string
+	style Style
+}
+
+type spanNode struct {
+	spans []textSpan
+	wrap  bool
+}
+
+func (n *spanNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if height <= 0 || width <= 0 {
+		return 0
+	}
+
+	col, row := x, y
+	lines := 1
+	for _, sp := range n.spans {
+		style := sp.style.toTcell()
+		for _, r := range sp.text {
+			w := runewidth.RuneWidth(r)
+			if r == '\n' || col+w > x+width {
+				if !n.wrap {
+					if r == '\n' {
+						continue
+					}
+					return 1
+				}
+				col = x
+				row++
+				lines++
+				if lines > height {
+					return height
+				}
+				if r == '\n' {
+					continue
+				}
+			}
+			screen.SetContent(col, row, r, nil, style)
+			col += w
+		}
+	}
+	return lines
+}
+
+func (n *spanNode) measureHeight(width int) int {
+	if !n.wrap || width <= 0 {
+		return 1
+	}
+	col, lines := 0, 1
+	for _, sp := range n.spans {
+		for _, r := range sp.text {
+			w := runewidth.RuneWidth(r)
+			if r == '\n' || col+w > width {
+				col = 0
+				lines++
+				if r == '\n' {
+					continue
+				}
+			}
+			col += w
+		}
+	}
+	return lines
+}
+
+func (n *spanNode) measureWidth() int {
+	total := 0
+	for _, sp := range n.spans {
+		total += runewidth.StringWidth(sp.text)
+	}
+	return total
+}
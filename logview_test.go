@@ -0,0 +1,138 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFindMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		query    string
+		expected [][2]int
+	}{
+		{name: "空查询", s: "hello", query: "", expected: nil},
+		{name: "单次命中", s: "hello world", query: "world", expected: [][2]int{{6, 11}}},
+		{name: "多次命中", s: "abab", query: "ab", expected: [][2]int{{0, 2}, {2, 4}}},
+		{name: "忽略大小写", s: "Error: ERROR", query: "error", expected: [][2]int{{0, 5}, {7, 12}}},
+		{name: "中文", s: "连接失败，重试连接", query: "连接", expected: [][2]int{{0, 6}, {21, 27}}},
+		{name: "无命中", s: "hello", query: "xyz", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findMatches(tt.s, tt.query)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("findMatches(%q, %q) = %v, want %v", tt.s, tt.query, got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("findMatches(%q, %q) = %v, want %v", tt.s, tt.query, got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestLogView_FiltersAndPause(t *testing.T) {
+	src := make(chan LogEntry)
+	app := func(c C) Node {
+		return LogView(c.Child("logs"), LogViewProps{Source: src})
+	}
+
+	screen := newTestScreen(60, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	src <- LogEntry{Time: time.Now(), Level: LogInfo, Message: "server started"}
+	src <- LogEntry{Time: time.Now(), Level: LogDebug, Message: "debug detail"}
+	src <- LogEntry{Time: time.Now(), Level: LogInfo, Message: "sync marker"}
+	tr.Render()
+
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "server started") || !strings.Contains(content, "debug detail") {
+		t.Fatalf("expected log lines on screen, got:\n%s", content)
+	}
+
+	// 隐藏 DEBUG
+	tr.DispatchKey(tcell.KeyRune, '1', 0)
+	tr.Render()
+	content = getScreenContent(screen)
+	if strings.Contains(content, "debug detail") {
+		t.Errorf("expected DEBUG line to be hidden, got:\n%s", content)
+	}
+
+	// 暂停后新日志不应出现
+	tr.DispatchKey(tcell.KeyRune, 'p', 0)
+	tr.Render()
+	src <- LogEntry{Time: time.Now(), Level: LogInfo, Message: "after pause"}
+	src <- LogEntry{Time: time.Now(), Level: LogInfo, Message: "sync marker 2"}
+	tr.Render()
+	content = getScreenContent(screen)
+	if strings.Contains(content, "after pause") || !strings.Contains(content, "PAUSED") {
+		t.Errorf("expected paused view, got:\n%s", content)
+	}
+
+	// 继续跟随
+	tr.DispatchKey(tcell.KeyRune, 'f', 0)
+	tr.Render()
+	content = getScreenContent(screen)
+	if !strings.Contains(content, "after pause") {
+		t.Errorf("expected follow to show new lines, got:\n%s", content)
+	}
+}
+
+func TestLogView_JumpBetweenMatches(t *testing.T) {
+	src := make(chan LogEntry)
+	app := func(c C) Node {
+		return LogView(c.Child("logs"), LogViewProps{Source: src})
+	}
+
+	screen := newTestScreen(60, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	for i := 0; i < 30; i++ {
+		msg := fmt.Sprintf("line %02d", i)
+		if i == 2 || i == 25 {
+			msg += " needle"
+		}
+		src <- LogEntry{Time: time.Now(), Level: LogInfo, Message: msg}
+	}
+	src <- LogEntry{Time: time.Now(), Level: LogInfo, Message: "sync marker"}
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyRune, '/', 0)
+	for _, r := range "needle" {
+		tr.DispatchKey(tcell.KeyRune, r, 0)
+	}
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyRune, 'n', 0)
+	tr.Render()
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "line 02 needle") || !strings.Contains(content, "(1/2)") {
+		t.Fatalf("expected first match in view with counter, got:\n%s", content)
+	}
+
+	tr.DispatchKey(tcell.KeyRune, 'n', 0)
+	tr.Render()
+	content = getScreenContent(screen)
+	if !strings.Contains(content, "line 25 needle") || !strings.Contains(content, "(2/2)") {
+		t.Fatalf("expected second match in view with counter, got:\n%s", content)
+	}
+
+	// N 回到上一个匹配
+	tr.DispatchKey(tcell.KeyRune, 'N', 0)
+	tr.Render()
+	content = getScreenContent(screen)
+	if !strings.Contains(content, "line 02 needle") || !strings.Contains(content, "(1/2)") {
+		t.Errorf("expected N to go back to first match, got:\n%s", content)
+	}
+}
@@ -1,7 +1,13 @@
 package rego
 
 import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -14,11 +20,18 @@ type markdownNode struct {
 	style   Style
 	theme   string // glamour theme: "dark", "light", "notty", etc.
 
+	codeTheme   string // 代码块的 chroma 高亮主题，为空时使用 glamour 主题自带的配色
+	defaultLang string // 未标注语言的代码块使用的语言
+
 	// 缓存机制，避免频繁调用 glamour 导致卡顿
 	lastContent string
 	lastWidth   int
 	lastHeight  int
 	lastOutput  string
+
+	// 跨帧缓存：设置 cacheKey 后，渲染结果保存在运行时的 LRU 中
+	cacheKey string
+	cache    *markdownCache
 }
 
 // Markdown 创建一个 Markdown 渲染节点
@@ -36,6 +49,25 @@ func (m *markdownNode) Theme(theme string) *markdownNode {
 	return m
 }
 
+// CodeTheme 设置代码块的 chroma 高亮主题（如 "monokai"、"dracula"、"github"）
+func (m *markdownNode) CodeTheme(name string) *markdownNode {
+	m.codeTheme = name
+	return m
+}
+
+// DefaultLanguage 设置未标注语言的代码块按哪种语言高亮（如 "go"）
+func (m *markdownNode) DefaultLanguage(lang string) *markdownNode {
+	m.defaultLang = lang
+	return m
+}
+
+// CacheKey 为内容块设置缓存键（如消息 ID），渲染结果按 (key, 宽度, 主题) 缓存在运行时中，
+// 适合聊天记录等不再变化的历史消息
+func (m *markdownNode) CacheKey(key string) *markdownNode {
+	m.cacheKey = key
+	return m
+}
+
 // Apply 应用样式
 func (m *markdownNode) Apply(s Style) *markdownNode {
 	m.style = s
@@ -57,23 +89,38 @@ func (m *markdownNode) getRenderedOutput(width int) string {
 		return m.lastOutput
 	}
 
+	var key markdownCacheKey
+	if m.cacheKey != "" && m.cache != nil {
+		key = m.cacheKeyFor(width)
+		if e, ok := m.cache.get(key); ok && e.content == m.content {
+			return m.useEntry(width, e)
+		}
+	}
+
+	// 终端尺寸变化期间沿用上一次的排版结果（超出部分由 renderAnsi 裁切），稳定后再重新排版
+	if m.cache != nil && m.cache.relayoutPaused() {
+		if e, ok := m.cache.get(m.latestKey()); ok && e.content == m.content {
+			return m.useEntry(width, e)
+		}
+	}
+
 	r, err := glamour.NewTermRenderer(
-		glamour.WithStandardStyle(m.theme),
+		m.styleOption(),
 		glamour.WithWordWrap(width),
 	)
 	if err != nil {
 		return m.content
 	}
 
-	out, err := r.Render(m.content)
+	source := m.content
+	if m.defaultLang != "" {
+		source = applyDefaultLanguage(source, m.defaultLang)
+	}
+	out, err := r.Render(source)
 	if err != nil {
 		return m.content
 	}
 
-	m.lastOutput = out
-	m.lastWidth = width
-	m.lastContent = m.content
-
 	// 计算并缓存高度
 	lines := 0
 	for _, r := range out {
@@ -81,9 +128,75 @@ func (m *markdownNode) getRenderedOutput(width int) string {
 			lines++
 		}
 	}
-	m.lastHeight = lines + 1
+	entry := markdownCacheEntry{content: m.content, output: out, height: lines + 1}
 
-	return out
+	if m.cache != nil {
+		if m.cacheKey != "" {
+			m.cache.put(key, entry)
+		}
+		m.cache.put(m.latestKey(), entry)
+	}
+
+	return m.useEntry(width, entry)
+}
+
+// useEntry 将渲染结果记录到节点自身的缓存中并返回输出
+func (m *markdownNode) useEntry(width int, e markdownCacheEntry) string {
+	m.lastOutput = e.output
+	m.lastWidth = width
+	m.lastContent = m.content
+	m.lastHeight = e.height
+	return e.output
+}
+
+// cacheKeyFor 返回指定宽度下的缓存键
+func (m *markdownNode) cacheKeyFor(width int) markdownCacheKey {
+	return markdownCacheKey{id: m.cacheKey, width: width, theme: m.theme, codeTheme: m.codeTheme, lang: m.defaultLang}
+}
+
+// latestKey 返回与宽度无关的"最近一次排版结果"的缓存键，未设置 CacheKey 时以内容作为标识
+func (m *markdownNode) latestKey() markdownCacheKey {
+	key := m.cacheKeyFor(0)
+	if key.id == "" {
+		key.id = m.content
+	}
+	key.latest = true
+	return key
+}
+
+// styleOption 返回 glamour 样式选项，设置了 CodeTheme 时替换代码块的高亮主题
+func (m *markdownNode) styleOption() glamour.TermRendererOption {
+	base, ok := styles.DefaultStyles[m.theme]
+	if m.codeTheme == "" || !ok {
+		return glamour.WithStandardStyle(m.theme)
+	}
+	cfg := *base
+	cfg.CodeBlock.Theme = m.codeTheme
+	cfg.CodeBlock.Chroma = nil // 主题自带的 Chroma 配色优先级更高，需要清除
+	return glamour.WithStyles(cfg)
+}
+
+// applyDefaultLanguage 为未标注语言的代码块补上语言标记，未闭合的代码块（流式输出中）同样适用
+func applyDefaultLanguage(content, lang string) string {
+	lines := strings.Split(content, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" {
+			for _, f := range []string{"```", "~~~"} {
+				if strings.HasPrefix(trimmed, f) {
+					fence = f
+					if strings.TrimLeft(trimmed, f[:1]) == "" {
+						lines[i] = line + lang
+					}
+					break
+				}
+			}
+		} else if strings.HasPrefix(trimmed, fence) && strings.TrimLeft(trimmed, fence[:1]) == "" {
+			fence = ""
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 // 实现 flexNode 接口
@@ -112,3 +225,94 @@ func measureMarkdownHeight(content string, width int, theme string) int {
 	m := Markdown(content).Theme(theme)
 	return m.measureHeight(width)
 }
+
+// =============================================================================
+// markdownCache - Markdown 渲染结果的 LRU 缓存
+// =============================================================================
+
+// markdownCacheSize 运行时默认缓存的 Markdown 块数量
+const markdownCacheSize = 256
+
+type markdownCacheKey struct {
+	id        string
+	width     int
+	theme     string
+	codeTheme string
+	lang      string
+	latest    bool // 最近一次排版结果，不区分宽度
+}
+
+type markdownCacheEntry struct {
+	content string // 渲染时的原文，同一 key 内容变化时视为未命中
+	output  string
+	height  int
+}
+
+type markdownCacheItem struct {
+	key   markdownCacheKey
+	entry markdownCacheEntry
+}
+
+type markdownCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[markdownCacheKey]*list.Element
+
+	// 在此之前宽度变化时不重新排版（终端尺寸调整的防抖）
+	pausedUntil time.Time
+	now         func() time.Time // 运行时的时钟
+}
+
+// markdownResizeDebounce 终端尺寸停止变化多久后重新排版 Markdown
+const markdownResizeDebounce = 150 * time.Millisecond
+
+// pauseRelayout 在 d 时间内暂停因宽度变化引起的重新排版
+func (c *markdownCache) pauseRelayout(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pausedUntil = c.now().Add(d)
+}
+
+// relayoutPaused 当前是否处于尺寸调整的防抖期
+func (c *markdownCache) relayoutPaused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now().Before(c.pausedUntil)
+}
+
+func newMarkdownCache(size int) *markdownCache {
+	return &markdownCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[markdownCacheKey]*list.Element),
+		now:   time.Now,
+	}
+}
+
+func (c *markdownCache) get(key markdownCacheKey) (markdownCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return markdownCacheEntry{}, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*markdownCacheItem).entry, true
+}
+
+func (c *markdownCache) put(key markdownCacheKey, entry markdownCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value.(*markdownCacheItem).entry = entry
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&markdownCacheItem{key: key, entry: entry})
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*markdownCacheItem).key)
+	}
+}
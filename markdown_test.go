@@ -0,0 +1,88 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMarkdownCache_LRU(t *testing.T) {
+	cache := newMarkdownCache(2)
+	a := markdownCacheKey{id: "a", width: 40, theme: "dark"}
+	b := markdownCacheKey{id: "b", width: 40, theme: "dark"}
+	c := markdownCacheKey{id: "c", width: 40, theme: "dark"}
+
+	cache.put(a, markdownCacheEntry{content: "A", output: "a"})
+	cache.put(b, markdownCacheEntry{content: "B", output: "b"})
+	cache.get(a) // a 变为最近使用
+	cache.put(c, markdownCacheEntry{content: "C", output: "c"})
+
+	if _, ok := cache.get(b); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	if _, ok := cache.get(a); !ok {
+		t.Error("expected recently used entry to be kept")
+	}
+}
+
+func TestMarkdown_CacheKey(t *testing.T) {
+	var node *markdownNode
+	content := "# Hello"
+	app := func(c C) Node {
+		node = Markdown(content).CacheKey("msg-1")
+		return node
+	}
+
+	screen := newTestScreen(40, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	key := markdownCacheKey{id: "msg-1", width: 40, theme: "dark"}
+	entry, ok := tr.markdownCache.get(key)
+	if !ok || entry.content != content {
+		t.Fatalf("expected rendered output to be cached, got %+v", entry)
+	}
+
+	// 内容变化时重新渲染并更新缓存
+	content = "# World"
+	tr.Render()
+	if entry, _ := tr.markdownCache.get(key); entry.content != content || entry.output != node.lastOutput {
+		t.Errorf("expected cache to be refreshed for new content, got %+v", entry)
+	}
+}
+
+func TestApplyDefaultLanguage(t *testing.T) {
+	in := "text\n```\nx := 1\n```\n\n```python\nprint(1)\n```\n```\nstreaming"
+	want := "text\n```go\nx := 1\n```\n\n```python\nprint(1)\n```\n```go\nstreaming"
+	if got := applyDefaultLanguage(in, "go"); got != want {
+		t.Errorf("applyDefaultLanguage() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdown_DebouncedRelayout(t *testing.T) {
+	var node *markdownNode
+	app := func(c C) Node {
+		node = Markdown("some words that will wrap differently when the terminal is narrower")
+		return node
+	}
+
+	screen := newTestScreen(60, 10)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+	wide := node.lastOutput
+
+	// 调整尺寸期间沿用旧的排版结果
+	screen.SetSize(30, 10)
+	tr.handleEvent(tcell.NewEventResize(30, 10))
+	tr.Render()
+	if node.lastOutput != wide {
+		t.Fatal("expected previous layout to be reused while resizing")
+	}
+
+	// 防抖结束后按新宽度重新排版
+	tr.AdvanceTime(markdownResizeDebounce)
+	tr.Render()
+	if node.lastOutput == wide {
+		t.Error("expected markdown to be re-laid out after the debounce")
+	}
+}
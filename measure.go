@@ -0,0 +1,99 @@
+package rego
+
+import (
+	"sync"
+)
+
+// =============================================================================
+// 并行测量 - 宽 VStack/HStack 的子节点由工作池并行测量高度
+// =============================================================================
+
+// parallelMeasureThreshold 子节点数达到该值的容器才并行测量，更小的容器调度开销大于收益
+const parallelMeasureThreshold = 32
+
+// measurePool 限制同时参与测量的协程数，属于开启了 ParallelMeasure 的运行时；
+// 构建后由 Runtime.bindNodes 绑定到新节点中的容器，未绑定（nil）时顺序测量
+type measurePool struct {
+	slots chan struct{} // 空闲的工作协程名额（调用方协程自身也参与测量，不占名额）
+}
+
+// newMeasurePool 创建参与测量的协程总数为 workers 的工作池，workers <= 1 时返回 nil
+func newMeasurePool(workers int) *measurePool {
+	if workers <= 1 {
+		return nil
+	}
+	return &measurePool{slots: make(chan struct{}, workers-1)}
+}
+
+// bindMeasurePool 把工作池绑定到节点树中会并行测量子节点的容器
+func bindMeasurePool(node Node, pool *measurePool) {
+	walkNodes(node, func(n Node) bool {
+		bindMeasure(n, pool)
+		return true
+	})
+}
+
+// bindMeasure 把工作池绑定到单个容器节点
+func bindMeasure(n Node, pool *measurePool) {
+	switch n := n.(type) {
+	case *vstackNode:
+		n.measure = pool
+	case *hstackNode:
+		n.measure = pool
+	case *columnsNode:
+		n.measure = pool
+	}
+}
+
+// each 对 [0, n) 中的每个下标执行 fn
+// p 非空且 n 达到阈值时分块交给工作池执行，fn 只能写入与下标对应的结果
+func (p *measurePool) each(n int, fn func(i int)) {
+	if p == nil || n < parallelMeasureThreshold {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	p.run(n, fn)
+}
+
+// run 把 [0, n) 分块：有空闲名额时交给新协程，否则在当前协程执行
+// 名额是非阻塞获取的，嵌套容器在工作协程中再次测量时不会死锁；测量中的 panic 转交给调用方
+func (p *measurePool) run(n int, fn func(i int)) {
+	chunk := (n + cap(p.slots)) / (cap(p.slots) + 1)
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		fault any
+	)
+	for start := 0; start < n; start += chunk {
+		end := min(start+chunk, n)
+		work := func() {
+			for i := start; i < end; i++ {
+				fn(i)
+			}
+		}
+		select {
+		case p.slots <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer func() {
+					if err := recover(); err != nil {
+						mu.Lock()
+						fault = err
+						mu.Unlock()
+					}
+					<-p.slots
+					wg.Done()
+				}()
+				work()
+			}()
+		default:
+			work()
+		}
+	}
+	wg.Wait()
+	if fault != nil {
+		panic(fault)
+	}
+}
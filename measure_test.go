@@ -0,0 +1,106 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMeasureEach_ParallelMatchesSequential(t *testing.T) {
+	root := LargeApp(&componentContext{children: make(map[string]*componentContext)})
+	want := measureNodeHeight(root, 200)
+
+	bindMeasurePool(root, newMeasurePool(4))
+	for i := 0; i < 3; i++ {
+		if got := measureNodeHeight(root, 200); got != want {
+			t.Fatalf("parallel height = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestMeasureEach_ParallelRender(t *testing.T) {
+	app := func(c C) Node {
+		var rows []Node
+		for i := 0; i < parallelMeasureThreshold+8; i++ {
+			rows = append(rows, Text(strings.Repeat("ab ", i%5+1)).Wrap(true))
+		}
+		return VStack(rows...)
+	}
+
+	sequential := newTestScreen(4, 80)
+	NewTestRuntime(app, sequential).Render()
+
+	parallel := newTestScreen(4, 80)
+	tr := NewTestRuntime(app, parallel)
+	tr.measurePool = newMeasurePool(4)
+	tr.Render()
+
+	if a, b := getScreenContent(sequential), getScreenContent(parallel); a != b {
+		t.Errorf("parallel render differs:\n%s\n---\n%s", a, b)
+	}
+}
+
+func TestMeasureEach_PoolIsPerRuntime(t *testing.T) {
+	app := func(c C) Node { return VStack(Text("a"), Text("b")) }
+
+	parallel := NewTestRuntime(app, newTestScreen(4, 2))
+	parallel.measurePool = newMeasurePool(4)
+	parallel.Render()
+	other := NewTestRuntime(app, newTestScreen(4, 2))
+	other.Render()
+
+	if v := parallel.lastNode.(*vstackNode); v.measure != parallel.measurePool {
+		t.Error("expected the runtime's pool to be bound to its nodes")
+	}
+	if v := other.lastNode.(*vstackNode); v.measure != nil {
+		t.Error("pool of one runtime leaked into another runtime")
+	}
+}
+
+func TestBindNodes_OnlyRebuiltSubtrees(t *testing.T) {
+	var counter *State[int]
+	var static, dynamic *vstackNode
+	app := func(c C) Node {
+		return Columns(2,
+			ScrollBox(c.Child("scroll"), Memo(c, "static", func(c C) Node {
+				static = VStack(Text("static"))
+				return static
+			})),
+			Memo(c, "dynamic", func(c C) Node {
+				counter = Use(c, "n", 0)
+				dynamic = VStack(Text(strings.Repeat("|", counter.Val)))
+				return dynamic
+			}),
+		)
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(20, 4))
+	tr.measurePool = newMeasurePool(4)
+	tr.Render()
+	if static.measure != tr.measurePool || dynamic.measure != tr.measurePool {
+		t.Fatal("expected the pool to be bound inside Columns and ScrollBox")
+	}
+
+	// 只单独重新执行 dynamic 时，不再遍历复用的 static 子树
+	static.measure = nil
+	counter.Set(1)
+	tr.Render()
+	if dynamic.measure != tr.measurePool {
+		t.Error("expected the pool to be bound to the rebuilt subtree")
+	}
+	if static.measure != nil {
+		t.Error("expected the reused subtree not to be walked again")
+	}
+}
+
+func TestMeasureEach_PanicPropagates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic from worker to reach caller")
+		}
+	}()
+	newMeasurePool(4).each(parallelMeasureThreshold, func(i int) {
+		if i == 0 { // 第一块交给工作协程执行
+			panic("boom")
+		}
+	})
+}
@@ -0,0 +1,242 @@
+package rego
+
+import (
+	"reflect"
+	"slices"
+)
+
+// =============================================================================
+// Memo - 跳过未变化子树的重新执行
+// =============================================================================
+
+// memoCache 记录 Memo 子树上一次执行的结果，以及复用时需要重放的副作用
+type memoCache struct {
+	node   *componentNode // 返回给父组件的节点，单独重新执行子树时原地替换其内容
+	render func(c C) Node
+	deps   []any
+	epoch  uint64        // 执行时的布局版本（终端尺寸变化后失效）
+	reads  []contextRead // 子树中的 UseContext 读取
+	focus  []focusEntry  // 子树中注册的可聚焦组件（按注册顺序）
+	frame  uint64        // 最近一次执行或复用所在的帧
+	bound  bool          // 节点已由 Runtime.bindNodes 绑定
+}
+
+// focusEntry 一次可聚焦组件注册
+type focusEntry struct {
+	key string
+	ctx *componentContext
+}
+
+// Memo 以 key 创建子组件并执行 render，返回其节点
+//
+// 默认情况下任何状态变化都会重新执行整棵组件树；用 Memo 包裹的子树在以下条件都满足时
+// 直接复用上一次的节点，不再执行 render 及其中的子组件：
+//
+//   - 子树内的组件自上次执行以来没有调用过 State.Set 或 Refresh
+//   - deps 与上次相同（reflect.DeepEqual）
+//   - 子树读取的 Context 值没有变化，焦点没有移入或移出子树，终端尺寸没有变化
+//
+// 子树内的状态变化时，运行时只重新执行这个子树，不再执行根组件和其他组件，
+// 并把新节点替换进上一帧的组件树；变化发生在 Memo 之外时才执行整棵组件树。
+//
+// render 中的子组件仍应通过 c.Child 创建；render 依赖的外部数据都要放进 deps，
+// 因为子树可能在父组件没有执行的帧中单独重新执行。
+//
+//	rego.Memo(c, "sidebar", func(c rego.C) rego.Node {
+//		return Sidebar(c, items)
+//	}, items)
+func Memo(c C, key string, render func(c C) Node, deps ...any) Node {
+	parent := c.(*componentContext)
+	ctx := parent.childContext(key)
+	r := ctx.runtime
+
+	if m := ctx.cached; m != nil && r != nil && m.reusable(ctx, deps) {
+		m.replay(ctx)
+		return m.node
+	}
+
+	if r == nil {
+		ctx.reset()
+		return render(ctx)
+	}
+
+	m := &memoCache{node: &componentNode{ctx: ctx}, render: render, deps: deps}
+	m.run(ctx)
+	ctx.cached = m
+	return m.node
+}
+
+// run 执行 render，记录其节点以及子树中的 Context 读取和焦点注册
+func (m *memoCache) run(ctx *componentContext) {
+	r := ctx.runtime
+	ctx.reset()
+	readsFrom := len(r.contextReads)
+	focusFrom := r.focusManager.count()
+	m.node.node = m.render(ctx)
+	m.epoch, m.frame, m.bound = r.layoutEpoch, r.frame, false
+	m.reads = append([]contextRead(nil), r.contextReads[readsFrom:]...)
+	m.focus = r.focusManager.registeredSince(focusFrom)
+}
+
+// reusable 判断能否复用上一次的节点
+func (m *memoCache) reusable(ctx *componentContext, deps []any) bool {
+	r := ctx.runtime
+	if ctx.dirty.Load() || ctx.childDirty.Load() || m.epoch != r.layoutEpoch || !depsEqual(m.deps, deps) {
+		return false
+	}
+	// 上一帧没有出现（被条件隐藏过）的子树需要重新执行，以便其中的组件重新注册
+	if m.frame != r.frame && m.frame+1 != r.frame {
+		return false
+	}
+	for _, read := range m.reads {
+		current, ok := read.consumer.lookupContext(read.key, false)
+		if !ok {
+			current = read.fallback
+		}
+		if read.changed(current) {
+			return false
+		}
+	}
+	return true
+}
+
+// replay 复用节点时补上子树本该在本帧产生的副作用：可见帧、Context 读取和焦点注册
+func (m *memoCache) replay(ctx *componentContext) {
+	r := ctx.runtime
+	ctx.markSeen(ctx.seenFrame, r.frame)
+	m.frame = r.frame
+	r.contextReads = append(r.contextReads, m.reads...)
+	for _, f := range m.focus {
+		r.focusManager.Register(f.key, f.ctx)
+	}
+}
+
+// rebuildDirty 只重新执行有变化的 Memo 子树，其余部分沿用上一帧的节点树，不执行根组件
+//
+// 变化不全在 Memo 子树中、终端尺寸变化、子树的祖先读取子树状态（如 Suspense），
+// 或重新执行的子树注册的可聚焦组件、读取的 Context 与上次不同（上一帧收集的焦点顺序和
+// Context 读取因此失效）时返回 false，需要完整构建组件树
+func (r *Runtime) rebuildDirty() bool {
+	if r.lastNode == nil || r.rebuild || r.lastEpoch != r.layoutEpoch {
+		return false
+	}
+	var memos []*componentContext
+	if !r.rootContext.dirtyMemos(r.frame-1, &memos) || len(memos) == 0 {
+		return false
+	}
+	for _, ctx := range memos {
+		if ctx.hasSubtreeReader() {
+			return false
+		}
+	}
+
+	r.buildPass++
+	r.contextPass = 1
+	r.contextChanges = r.contextChanges[:0]
+	r.effects = r.effects[:0]
+	focus, reads := r.focusManager.snapshot(), r.contextReads
+	same := true
+	for _, ctx := range memos {
+		m := ctx.cached
+		oldFocus, oldReads := m.focus, m.reads
+		r.focusManager.Reset()
+		r.contextReads = nil
+		m.run(ctx)
+		same = same && sameFocus(oldFocus, m.focus) && sameReads(oldReads, m.reads)
+	}
+	r.focusManager.restore(focus)
+	r.contextReads = reads
+	if !same || r.rebuild {
+		return false
+	}
+	r.rootContext.markSeen(r.frame-1, r.frame)
+	r.rebuilt = memos
+	return true
+}
+
+// dirtyMemos 收集上一帧出现过、需要重新执行的最内层 Memo 子树；
+// Memo 之外的组件有变化时返回 false
+func (c *componentContext) dirtyMemos(frame uint64, out *[]*componentContext) bool {
+	if c.seenFrame != frame {
+		return true // 上一帧没有出现的子树不影响本帧
+	}
+	dirty, childDirty := c.dirty.Load(), c.childDirty.Load()
+	if c.cached != nil && dirty {
+		*out = append(*out, c)
+		return true
+	}
+	if !childDirty {
+		return !dirty
+	}
+	var inner []*componentContext
+	ok := !dirty
+	for _, child := range c.children {
+		if !ok {
+			break
+		}
+		ok = child.dirtyMemos(frame, &inner)
+	}
+	switch {
+	case ok:
+		*out = append(*out, inner...)
+	case c.cached != nil:
+		*out = append(*out, c) // 变化在子树中不属于内层 Memo 的组件里，重新执行整个 Memo
+		ok = true
+	}
+	return ok
+}
+
+// hasSubtreeReader 祖先中是否有组件的结果取决于子树的状态（如 Suspense），
+// 这样的祖先在子树变化后也要重新执行，不能只替换子树的节点
+func (c *componentContext) hasSubtreeReader() bool {
+	for p := c.parent; p != nil; p = p.parent {
+		if p.readsSubtree {
+			return true
+		}
+	}
+	return false
+}
+
+func sameFocus(a, b []focusEntry) bool {
+	return slices.Equal(a, b)
+}
+
+func sameReads(a, b []contextRead) bool {
+	return slices.EqualFunc(a, b, func(x, y contextRead) bool {
+		return x.consumer == y.consumer && x.key == y.key && reflect.DeepEqual(x.value, y.value)
+	})
+}
+
+// markSeen 把子树中上次与 ctx 一同出现（seenFrame 为 prev）的组件标记为在 frame 中出现
+func (c *componentContext) markSeen(prev, frame uint64) {
+	if c.seenFrame != prev {
+		return
+	}
+	c.seenFrame = frame
+	for _, child := range c.children {
+		child.markSeen(prev, frame)
+	}
+}
+
+// markDirty 标记组件需要重新执行，并通知所有祖先其子树有变化
+func (c *componentContext) markDirty() {
+	c.dirty.Store(true)
+	for p := c.parent; p != nil; p = p.parent {
+		p.childDirty.Store(true)
+	}
+}
+
+// markFocusChange 焦点相对上次检查发生变化时，标记新旧焦点组件需要重新执行（其 IsFocused 已改变）
+// 在构建前后各调用一次：构建前捕获事件处理中的焦点移动，构建后捕获组件执行期间的焦点移动
+func (r *Runtime) markFocusChange() {
+	current := r.focusManager.Current()
+	if current == r.lastFocus {
+		return
+	}
+	for _, ctx := range []*componentContext{r.focusManager.contextOf(r.lastFocus), r.focusManager.contextOf(current)} {
+		if ctx != nil {
+			ctx.markDirty()
+		}
+	}
+	r.lastFocus = current
+}
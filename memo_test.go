@@ -0,0 +1,233 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMemo_SkipsCleanSiblings(t *testing.T) {
+	var counter *State[int]
+	label := "x"
+	counterPanel := func(c C) Node {
+		counter = Use(c, "n", 0)
+		return Text("n=" + strings.Repeat("|", counter.Val))
+	}
+	staticPanel := func(c C) Node {
+		c.Child("inner")
+		return Text("static " + label)
+	}
+	app := func(c C) Node {
+		return VStack(
+			Memo(c, "counter", counterPanel),
+			Memo(c, "static", staticPanel, label),
+		)
+	}
+
+	screen := newTestScreen(20, 2)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	counter.Set(2)
+	tr.Render()
+	if got := tr.RenderCount("counter"); got != 2 {
+		t.Errorf("counter renders = %d, want 2", got)
+	}
+	if got := tr.RenderCount("static"); got != 1 {
+		t.Errorf("static renders = %d, want 1", got)
+	}
+	if got := tr.RenderCount("static/inner"); got != 1 {
+		t.Errorf("static/inner renders = %d, want 1", got)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "n=||") || !strings.Contains(content, "static x") {
+		t.Errorf("unexpected screen:\n%s", content)
+	}
+
+	// deps 变化时重新执行
+	label = "y"
+	tr.Render()
+	if got := tr.RenderCount("static"); got != 2 {
+		t.Errorf("static renders after deps change = %d, want 2", got)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "static y") {
+		t.Errorf("expected updated label, got:\n%s", content)
+	}
+
+	// 终端尺寸变化时重新执行
+	tr.handleEvent(tcell.NewEventResize(20, 2))
+	tr.Render()
+	if got := tr.RenderCount("static"); got != 3 {
+		t.Errorf("static renders after resize = %d, want 3", got)
+	}
+}
+
+func TestMemo_NestedStateBubbles(t *testing.T) {
+	var leaf *State[string]
+	app := func(c C) Node {
+		return Memo(c, "outer", func(c C) Node {
+			inner := c.Child("inner")
+			leaf = Use(inner, "v", "a")
+			return Text(leaf.Val)
+		})
+	}
+
+	screen := newTestScreen(10, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	leaf.Set("b")
+	tr.Render()
+	if got := tr.RenderCount("outer"); got != 2 {
+		t.Errorf("outer renders = %d, want 2", got)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "b") {
+		t.Errorf("expected b, got:\n%s", content)
+	}
+}
+
+func TestMemo_RebuildsOnlyDirtySubtree(t *testing.T) {
+	var counter *State[int]
+	var extra *State[bool]
+	panel := func(c C) Node {
+		counter = Use(c, "n", 0)
+		extra = Use(c, "extra", false)
+		UseKey(c, func(key Key, r rune) {
+			if r == '+' {
+				counter.Set(counter.Val + 1)
+			}
+		})
+		rows := []Node{Text(fmt.Sprintf("n=%d", counter.Val))}
+		if extra.Val {
+			rows = append(rows, TextInput(c.Child("input"), TextInputProps{}))
+		}
+		return VStack(rows...)
+	}
+	app := func(c C) Node {
+		return VStack(
+			Text("header"),
+			Memo(c, "panel", panel),
+			Memo(c, "static", func(c C) Node { return Text("static") }),
+		)
+	}
+
+	screen := newTestScreen(20, 4)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 只有 panel 中的状态变化：根组件和其他 Memo 都不执行
+	tr.DispatchKey(tcell.KeyRune, '+', 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, '+', 0)
+	tr.Render()
+	if got := tr.RenderCount("root"); got != 1 {
+		t.Errorf("root renders = %d, want 1", got)
+	}
+	if got := tr.RenderCount("panel"); got != 3 {
+		t.Errorf("panel renders = %d, want 3", got)
+	}
+	if got := tr.RenderCount("static"); got != 1 {
+		t.Errorf("static renders = %d, want 1", got)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "header") || !strings.Contains(content, "n=2") || !strings.Contains(content, "static") {
+		t.Errorf("unexpected screen:\n%s", content)
+	}
+
+	// 子树中新出现了可聚焦组件：焦点顺序需要重新收集，执行整棵组件树
+	extra.Set(true)
+	tr.Render()
+	if got := tr.RenderCount("root"); got != 2 {
+		t.Errorf("root renders after focus change = %d, want 2", got)
+	}
+	if cur := tr.focusManager.Current(); cur != "root/panel/input" {
+		t.Errorf("focus = %q, want the new input", cur)
+	}
+}
+
+func TestMemo_ContextAndFocus(t *testing.T) {
+	theme := CreateContext("light")
+	var mode *State[string]
+	item := func(c C, name string) Node {
+		focus := UseFocus(c)
+		mark := " "
+		if focus.IsFocused {
+			mark = ">"
+		}
+		return Text(mark + name + ":" + UseContext(c, theme))
+	}
+	app := func(c C) Node {
+		mode = Use(c, "mode", "light")
+		return theme.Provide(c, mode.Val, VStack(
+			Memo(c, "a", func(c C) Node { return item(c, "a") }),
+			Memo(c, "b", func(c C) Node { return item(c, "b") }),
+		))
+	}
+
+	screen := newTestScreen(20, 2)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, ">a:light") || !strings.Contains(content, " b:light") {
+		t.Fatalf("unexpected initial screen:\n%s", content)
+	}
+
+	// 焦点移动：新旧焦点组件都重新执行，且复用的子树仍参与焦点循环
+	tr.focusManager.Next()
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, " a:light") || !strings.Contains(content, ">b:light") {
+		t.Errorf("focus not updated:\n%s", content)
+	}
+	tr.focusManager.Next()
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, ">a:light") {
+		t.Errorf("focus did not cycle back to a:\n%s", content)
+	}
+
+	// Context 变化时读取它的子树重新执行
+	mode.Set("dark")
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "a:dark") || !strings.Contains(content, "b:dark") {
+		t.Errorf("context change not applied:\n%s", content)
+	}
+}
+
+func TestMemo_SuspenseAncestorRebuildsAfterLoad(t *testing.T) {
+	release := make(chan struct{})
+	app := func(c C) Node {
+		return Suspense(c, Text("loading..."), Memo(c, "profile", func(c C) Node {
+			user := UseAsync(c, func() (string, error) {
+				<-release
+				return "alice", nil
+			})
+			return Text("user: " + user.Data)
+		}))
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "loading...") {
+		t.Fatalf("expected fallback while loading, got %q", content)
+	}
+
+	// 等结果投递到 UI 循环后只渲染一次：Suspense 所在的根组件必须重新执行
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		tr.tasksMu.Lock()
+		posted := len(tr.tasks) > 0
+		tr.tasksMu.Unlock()
+		if posted {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("async result was never posted")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "user: alice") {
+		t.Errorf("expected loaded content after one render, got %q", content)
+	}
+}
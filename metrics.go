@@ -0,0 +1,138 @@
+package rego
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	_ "net/http/pprof" // 注册 /debug/pprof 处理器
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// =============================================================================
+// 运行时指标（expvar / pprof）
+// =============================================================================
+
+// metricsWindow 统计帧耗时所用的最近帧数
+const metricsWindow = 256
+
+// DefaultDebugAddr Options.Debug 开启时 pprof/expvar HTTP 服务的默认监听地址
+const DefaultDebugAddr = "localhost:6060"
+
+// Metrics 是运行时的性能指标快照
+type Metrics struct {
+	Renders   uint64        // 累计渲染帧数
+	MeanFrame time.Duration // 最近帧的平均耗时
+	P99Frame  time.Duration // 最近帧耗时的 99 分位
+	Nodes     int           // 最近一帧的节点数
+	Cells     int           // 最近一帧输出到终端的单元格数（只统计真实终端）
+	FPS       float64       // 最近一秒内的渲染帧数
+
+	HeapAlloc  uint64 // 堆上仍在使用的字节数
+	TotalAlloc uint64 // 累计分配的字节数
+	Mallocs    uint64 // 累计分配次数
+	NumGC      uint32
+}
+
+type runtimeMetrics struct {
+	mu      sync.Mutex
+	renders uint64
+	frames  [metricsWindow]time.Duration
+	ends    [metricsWindow]time.Time // 各帧的结束时间，用于计算 FPS
+	nodes   int
+	cells   int
+}
+
+// record 记录一帧的耗时、节点数与输出的单元格数
+func (m *runtimeMetrics) record(d time.Duration, nodes, cells int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.frames[m.renders%metricsWindow] = d
+	m.ends[m.renders%metricsWindow] = time.Now()
+	m.renders++
+	m.nodes = nodes
+	m.cells = cells
+}
+
+func (m *runtimeMetrics) snapshot() Metrics {
+	m.mu.Lock()
+	n := int(min(m.renders, metricsWindow))
+	frames := make([]time.Duration, n)
+	copy(frames, m.frames[:n])
+	out := Metrics{Renders: m.renders, Nodes: m.nodes, Cells: m.cells}
+	since := time.Now().Add(-time.Second)
+	for _, t := range m.ends[:n] {
+		if t.After(since) {
+			out.FPS++
+		}
+	}
+	m.mu.Unlock()
+
+	if n > 0 {
+		var sum time.Duration
+		for _, d := range frames {
+			sum += d
+		}
+		out.MeanFrame = sum / time.Duration(n)
+		sort.Slice(frames, func(i, j int) bool { return frames[i] < frames[j] })
+		out.P99Frame = frames[(n*99-1)/100]
+	}
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	out.HeapAlloc = ms.HeapAlloc
+	out.TotalAlloc = ms.TotalAlloc
+	out.Mallocs = ms.Mallocs
+	out.NumGC = ms.NumGC
+	return out
+}
+
+// Metrics 返回运行时的性能指标
+func (r *Runtime) Metrics() Metrics {
+	return r.metrics.snapshot()
+}
+
+// countNodes 统计节点树中的节点数
+func countNodes(node Node) int {
+	n := 0
+	walkNodes(node, func(Node) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+var (
+	publishOnce    sync.Once
+	currentRuntime atomic.Pointer[Runtime]
+)
+
+// publishMetrics 以 "rego" 为名将当前运行时的指标发布到 expvar
+func (r *Runtime) publishMetrics() {
+	currentRuntime.Store(r)
+	publishOnce.Do(func() {
+		expvar.Publish("rego", expvar.Func(func() any {
+			if rt := currentRuntime.Load(); rt != nil {
+				return rt.Metrics()
+			}
+			return nil
+		}))
+	})
+}
+
+// startDebugServer 启动 pprof/expvar HTTP 服务，返回关闭函数
+func startDebugServer(addr string) func() {
+	if addr == "" {
+		addr = DefaultDebugAddr
+	}
+	srv := &http.Server{Addr: addr, Handler: http.DefaultServeMux}
+	go srv.ListenAndServe()
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}
+}
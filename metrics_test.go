@@ -0,0 +1,43 @@
+package rego
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuntimeMetrics(t *testing.T) {
+	tr := NewTestRuntime(func(c C) Node {
+		return VStack(Text("a"), Text("b"))
+	}, newTestScreen(10, 2))
+	for i := 0; i < 3; i++ {
+		tr.Render()
+	}
+
+	m := tr.Metrics()
+	if m.Renders != 3 {
+		t.Errorf("expected 3 renders, got %d", m.Renders)
+	}
+	if m.Nodes != 3 {
+		t.Errorf("expected 3 nodes, got %d", m.Nodes)
+	}
+	if m.MeanFrame <= 0 || m.P99Frame < m.MeanFrame {
+		t.Errorf("unexpected frame times: mean=%v p99=%v", m.MeanFrame, m.P99Frame)
+	}
+	if m.HeapAlloc == 0 {
+		t.Error("expected allocation stats to be filled")
+	}
+}
+
+func TestRuntimeMetrics_P99(t *testing.T) {
+	var m runtimeMetrics
+	for i := 1; i <= 100; i++ {
+		m.record(time.Duration(i)*time.Millisecond, 0, 0)
+	}
+	s := m.snapshot()
+	if s.P99Frame != 99*time.Millisecond {
+		t.Errorf("expected p99 of 99ms, got %v", s.P99Frame)
+	}
+	if s.MeanFrame != 50500*time.Microsecond {
+		t.Errorf("expected mean of 50.5ms, got %v", s.MeanFrame)
+	}
+}
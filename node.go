@@ -1,6 +1,8 @@
 package rego
 
 import (
+	"fmt"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
 )
@@ -19,6 +21,16 @@ type flexNode interface {
 	getHeight() int
 }
 
+// heightMeasurer 是能根据给定宽度自行测量高度的节点
+type heightMeasurer interface {
+	measureHeight(width int) int
+}
+
+// widthMeasurer 是能自行测量自然宽度的节点
+type widthMeasurer interface {
+	measureWidth() int
+}
+
 // =============================================================================
 // clipScreen - 一个包装 screen 的代理，用于实现裁切和滚动偏移
 // =============================================================================
@@ -29,9 +41,15 @@ type clipScreen struct {
 	viewW, viewH int // 视口宽高
 	offX, offY   int // 内容偏移量
 	runtime      *Runtime
+	capture      *searchCapture // 非空时记录裁切前的所有内容（用于查找）
+	fill         tcell.Color    // 非默认时作为未设置背景的内容的背景色（Row）
 }
 
 func (s *clipScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	if s.capture != nil {
+		s.capture.set(x, y, mainc, combc, style)
+	}
+
 	// 计算在视口中的实际坐标
 	realX := x + s.offX
 	realY := y + s.offY
@@ -39,10 +57,33 @@ func (s *clipScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.
 	// 只有在视口范围内的才真正渲染
 	if realX >= s.viewX && realX < s.viewX+s.viewW &&
 		realY >= s.viewY && realY < s.viewY+s.viewH {
+		if s.fill != tcell.ColorDefault {
+			if _, bg, attrs := style.Decompose(); bg == tcell.ColorDefault && attrs&tcell.AttrReverse == 0 {
+				style = style.Background(s.fill)
+			}
+		}
 		s.Screen.SetContent(realX, realY, mainc, combc, style)
 	}
 }
 
+// screenRect 将渲染坐标换算为终端坐标，并返回经过各层视口裁切后的可见部分
+func screenRect(screen tcell.Screen, r Rect) (Rect, Rect) {
+	abs := r
+	visible := r
+	for {
+		cs, ok := screen.(*clipScreen)
+		if !ok {
+			return abs, visible
+		}
+		abs.X += cs.offX
+		abs.Y += cs.offY
+		visible.X += cs.offX
+		visible.Y += cs.offY
+		visible = visible.Intersect(Rect{X: cs.viewX, Y: cs.viewY, W: cs.viewW, H: cs.viewH})
+		screen = cs.Screen
+	}
+}
+
 // 拦截光标显示
 func (s *clipScreen) ShowCursor(x, y int) {
 	realX := x + s.offX
@@ -58,13 +99,106 @@ func (s *clipScreen) ShowCursor(x, y int) {
 // =============================================================================
 
 type scrollNode struct {
-	ctx            *componentContext
-	child          Node
-	offY           int
-	contentHeight  int
-	autoScroll     bool // 是否自动滚动到底部
-	flex           int
-	scrollTopState *State[int]
+	ctx             *componentContext
+	child           Node
+	offY            int
+	contentHeight   int
+	autoScroll      bool // 是否自动滚动到底部
+	flex            int
+	scrollTopState  *State[int]
+	autoScrollState *State[bool]
+
+	// 滚动条样式
+	track         rune
+	thumb         rune
+	thumbColor    Color
+	hideScrollbar bool
+
+	// 贴底与新消息提示
+	stickThreshold int
+	showPill       bool
+	seen           *Ref[int] // 最近一次贴底时的内容条数，-1 表示尚未记录
+	lastTop        *Ref[int] // 上一帧渲染时的滚动偏移，用于让终端直接平移视口
+	pillRect       Rect      // 本帧新消息提示的位置，未显示时为空
+
+	// 嵌套滚动
+	nested bool          // 是否位于另一个 ScrollBox 的内容中
+	inner  []*scrollNode // 内容中直接嵌套的 ScrollBox
+
+	metricsState *State[ScrollState]
+
+	// 查找
+	searchable  bool
+	focus       FocusState
+	searchState *State[scrollSearch]
+	capture     *searchCapture
+	matches     []searchMatch
+	viewHeight  int
+}
+
+// ScrollState 描述 ScrollBox 当前的滚动情况
+type ScrollState struct {
+	Top            int  // 当前滚动偏移（行）
+	ContentHeight  int  // 内容总高度
+	ViewportHeight int  // 可视区域高度
+	AtBottom       bool // 是否已滚动到底部
+}
+
+// Progress 返回已阅读的比例（0~1），内容不足一屏时为 1
+func (s ScrollState) Progress() float64 {
+	maxTop := s.ContentHeight - s.ViewportHeight
+	if maxTop <= 0 {
+		return 1
+	}
+	return float64(min(s.Top, maxTop)) / float64(maxTop)
+}
+
+// UseScrollState 读取 ScrollBox 的滚动指标，c 需要与传给 ScrollBox 的上下文相同
+//
+// 指标在 ScrollBox 渲染时更新，变化后会触发重新渲染。
+func UseScrollState(c C) ScrollState {
+	return Use(c, "scrollMetrics", ScrollState{}).Val
+}
+
+// ScrollControl 控制 ScrollBox 的滚动位置和自动滚动，可以在事件处理中调用
+type ScrollControl struct {
+	ctx *componentContext
+}
+
+// UseScrollControl 返回控制 ScrollBox 的句柄，c 需要与传给 ScrollBox（或 TailBox）的上下文相同
+func UseScrollControl(c C) ScrollControl {
+	return ScrollControl{ctx: c.(*componentContext)}
+}
+
+// Following 报告是否开启了自动滚动（内容增加时保持在底部）
+func (s ScrollControl) Following() bool {
+	v, _ := s.ctx.getState("autoScroll")
+	following, _ := v.(bool)
+	return following
+}
+
+// Follow 开启自动滚动，下一帧滚动到底部
+func (s ScrollControl) Follow() {
+	Use(s.ctx, "autoScroll", true).Set(true)
+}
+
+// Pause 关闭自动滚动，停在当前位置
+func (s ScrollControl) Pause() {
+	Use(s.ctx, "autoScroll", false).Set(false)
+}
+
+// ScrollTo 关闭自动滚动并滚动到第 top 行
+func (s ScrollControl) ScrollTo(top int) {
+	s.Pause()
+	Use(s.ctx, "scrollTop", 0).Set(max(0, top))
+}
+
+// viewWidth 返回内容区宽度（显示滚动条时预留一列）
+func (s *scrollNode) viewWidth(width int) int {
+	if s.hideScrollbar {
+		return width
+	}
+	return width - 1
 }
 
 func (s *scrollNode) render(screen tcell.Screen, x, y, width, height int) int {
@@ -72,8 +206,18 @@ func (s *scrollNode) render(screen tcell.Screen, x, y, width, height int) int {
 		return 0
 	}
 
-	// 1. 重新计算内容总高度（基于当前宽度）
-	s.contentHeight = measureNodeHeight(s.child, width-1)
+	// 1. 标记内容中嵌套的 ScrollBox，再重新计算内容总高度（基于当前宽度）
+	s.inner = s.inner[:0]
+	walkNodes(s.child, func(n Node) bool {
+		if inner, ok := n.(*scrollNode); ok {
+			inner.nested = true
+			s.inner = append(s.inner, inner)
+			return false
+		}
+		return true
+	})
+	viewW := s.viewWidth(width)
+	s.contentHeight = measureNodeHeight(s.child, viewW)
 
 	// 2. 如果开启了自动滚动且内容高度超过视口高度，更新偏移量
 	if s.autoScroll && s.contentHeight > height {
@@ -89,17 +233,71 @@ func (s *scrollNode) render(screen tcell.Screen, x, y, width, height int) int {
 		Screen:  screen,
 		viewX:   x,
 		viewY:   y,
-		viewW:   width - 1, // 预留一列给滚动条
+		viewW:   viewW,
 		viewH:   height,
 		offY:    -s.offY,
 		runtime: s.ctx.runtime,
 	}
-	s.child.render(proxy, x, y, width-1, 1000)
+	if s.metricsState != nil {
+		s.metricsState.Set(ScrollState{
+			Top:            s.offY,
+			ContentHeight:  s.contentHeight,
+			ViewportHeight: height,
+			AtBottom:       s.offY >= s.contentHeight-height,
+		})
+	}
+
+	// 查找时记录全部内容，以便匹配视口之外的文字
+	searching := s.searchable && s.searchState != nil && (s.searchState.Val.Query != "" || s.searchState.Val.Typing)
+	s.capture = nil
+	if searching {
+		s.capture = &searchCapture{originX: x, originY: y, rows: map[int]map[int]capturedCell{}}
+		proxy.capture = s.capture
+	}
+	s.viewHeight = height
+	s.reportScroll(y, height)
+
+	// 按完整内容高度渲染，VStack 只渲染与视口相交的子节点（查找时需要完整内容）
+	if vs, ok := s.child.(*vstackNode); ok && vs.virtualizable() && !searching {
+		vs.renderWindow(proxy, x, y, viewW, s.offY, s.offY+height)
+	} else {
+		s.child.render(proxy, x, y, viewW, max(s.contentHeight, height))
+	}
+
+	if searching {
+		proxy.capture = nil
+		s.renderSearch(screen, proxy, x, y, viewW, height)
+	}
+
+	// 离开底部期间新增内容时，在视口底部显示悬浮提示（搜索栏显示时不显示）
+	s.pillRect = Rect{}
+	if s.seen != nil && !searching {
+		count := scrollItemCount(s.child, viewW)
+		if s.autoScroll || s.seen.Current < 0 || count < s.seen.Current {
+			s.seen.Current = count
+		} else if s.showPill && count > s.seen.Current && height > 0 {
+			s.renderPill(screen, x, y+height-1, viewW, count-s.seen.Current)
+		}
+	}
+
+	if s.hideScrollbar {
+		return height
+	}
 
 	// 3. 绘制滚动条背景轨道
+	track, thumb, thumbColor := s.track, s.thumb, s.thumbColor
+	if track == 0 {
+		track = '│'
+	}
+	if thumb == 0 {
+		thumb = '┃'
+	}
+	if thumbColor == Default {
+		thumbColor = Cyan
+	}
 	scrollbarX := x + width - 1
 	for i := 0; i < height; i++ {
-		screen.SetContent(scrollbarX, y+i, '│', nil, tcell.StyleDefault.Foreground(tcell.ColorGray))
+		screen.SetContent(scrollbarX, y+i, track, nil, tcell.StyleDefault.Foreground(tcell.ColorGray))
 	}
 
 	// 4. 计算并绘制滚动条滑块 (Thumb)
@@ -115,22 +313,108 @@ func (s *scrollNode) render(screen tcell.Screen, x, y, width, height int) int {
 		}
 
 		for i := 0; i < thumbHeight; i++ {
-			screen.SetContent(scrollbarX, y+thumbPos+i, '┃', nil, tcell.StyleDefault.Foreground(colorToTcell(Cyan)))
+			screen.SetContent(scrollbarX, y+thumbPos+i, thumb, nil, tcell.StyleDefault.Foreground(colorToTcell(thumbColor)))
 		}
 	}
 
 	return height
 }
 
+// reportScroll 滚动偏移变化时向运行时报告视口所在的行区间（嵌套的 ScrollBox 不在屏幕坐标上，不报告）
+func (s *scrollNode) reportScroll(y, height int) {
+	if s.lastTop == nil {
+		return
+	}
+	prev := s.lastTop.Current
+	s.lastTop.Current = s.offY
+	if prev < 0 || s.nested || s.ctx == nil || s.ctx.runtime == nil {
+		return
+	}
+	s.ctx.runtime.damage.addScrollHint(y, y+height, s.offY-prev)
+}
+
+// renderPill 在 (x, y) 这一行居中绘制"↓ N new messages"提示
+func (s *scrollNode) renderPill(screen tcell.Screen, x, y, width, n int) {
+	label := fmt.Sprintf(" ↓ %d new messages ", n)
+	if n == 1 {
+		label = " ↓ 1 new message "
+	}
+	w := runewidth.StringWidth(label)
+	if w > width {
+		return
+	}
+	px := x + (width-w)/2
+	style := tcell.StyleDefault.Background(colorToTcell(Cyan)).Foreground(colorToTcell(Black))
+	col := px
+	for _, r := range label {
+		screen.SetContent(col, y, r, nil, style)
+		col += runewidth.RuneWidth(r)
+	}
+	s.pillRect = Rect{X: px, Y: y, W: w, H: 1}
+}
+
+// scrollItemCount 统计滚动内容的条数：VStack 按子节点计数，其他内容按行计数
+func scrollItemCount(node Node, width int) int {
+	switch n := node.(type) {
+	case *componentNode:
+		return scrollItemCount(n.node, width)
+	case *vstackNode:
+		count := 0
+		for _, child := range n.children {
+			if child != nil {
+				count++
+			}
+		}
+		return count
+	}
+	return measureNodeHeight(node, width)
+}
+
 // ScrollBox 创建一个可滚动的容器
 func ScrollBox(c C, child Node) *componentNode {
 	scrollTop := Use(c, "scrollTop", 0)
 	autoScroll := Use(c, "autoScroll", false)
 	ctx := c.(*componentContext)
 
+	node := &scrollNode{
+		ctx:             ctx,
+		child:           child,
+		offY:            scrollTop.Val,
+		autoScroll:      autoScroll.Val,
+		scrollTopState:  scrollTop,
+		autoScrollState: autoScroll,
+		seen:            UseRef(c, -1),
+		lastTop:         UseRef(c, -1),
+		metricsState:    Use(c, "scrollMetrics", ScrollState{}),
+		searchState:     Use(c, "search", scrollSearch{Current: -1}),
+	}
+
+	// 跳到底部并恢复自动滚动
+	jumpToBottom := func() {
+		autoScroll.Set(true)
+	}
+
+	// 新消息提示显示时，Enter 跳到底部
+	UseKey(c, func(key Key, r rune) {
+		if node.handleSearchKey(key, r) {
+			return
+		}
+		if key == KeyEnter && node.pillRect.W > 0 {
+			jumpToBottom()
+		}
+	})
+
 	// 监听鼠标滚轮
 	UseMouse(c, func(ev MouseEvent) {
 		rect := c.Rect()
+		if ev.Type == MouseEventClick && node.pillRect.Contains(ev.X, ev.Y) {
+			jumpToBottom()
+			return
+		}
+		// 滚轮只交给鼠标下最内层的 ScrollBox
+		if node.hoveredInner(ev.X, ev.Y) {
+			return
+		}
 		if rect.Contains(ev.X, ev.Y) {
 			if ev.Type == MouseEventScrollUp {
 				autoScroll.Set(false) // 手动向上滚动时取消自动滚动
@@ -142,31 +426,25 @@ func ScrollBox(c C, child Node) *componentNode {
 				})
 			} else if ev.Type == MouseEventScrollDown {
 				// 获取子节点的高度
-				contentHeight := measureNodeHeight(child, rect.W-1)
+				contentHeight := measureNodeHeight(child, node.viewWidth(rect.W))
 				scrollTop.Update(func(v int) int {
 					maxScroll := contentHeight - rect.H
 					if maxScroll < 0 {
 						maxScroll = 0
 					}
 					if v < maxScroll {
-						return v + 1
-					} else {
-						// 滚到底部了，可以重新开启自动滚动
+						v++
+					}
+					// 滚到距底部 stickThreshold 行以内时，重新开启自动滚动
+					if v >= maxScroll-node.stickThreshold {
 						autoScroll.Set(true)
-						return v
 					}
+					return v
 				})
 			}
 		}
 	})
 
-	node := &scrollNode{
-		ctx:            ctx,
-		child:          child,
-		offY:           scrollTop.Val,
-		autoScroll:     autoScroll.Val,
-		scrollTopState: scrollTop,
-	}
 	return c.Wrap(node)
 }
 
@@ -179,9 +457,7 @@ func TailBox(c C, child Node) *componentNode {
 
 func (s *scrollNode) AutoScroll(auto bool) *scrollNode {
 	s.autoScroll = auto
-	// 同步回状态
-	autoScroll := Use(s.ctx, "autoScroll", false)
-	autoScroll.Set(auto)
+	s.autoScrollState.Set(auto) // 同步回状态
 	return s
 }
 
@@ -190,13 +466,60 @@ func (s *scrollNode) Flex(f int) *scrollNode {
 	return s
 }
 
+// ScrollbarStyle 设置滚动条的轨道字符、滑块字符和滑块颜色，传入零值时使用默认样式
+func (s *scrollNode) ScrollbarStyle(track, thumb rune, color Color) *scrollNode {
+	s.track = track
+	s.thumb = thumb
+	s.thumbColor = color
+	return s
+}
+
+// HideScrollbar 隐藏滚动条，内容占满整个宽度
+func (s *scrollNode) HideScrollbar() *scrollNode {
+	s.hideScrollbar = true
+	return s
+}
+
+// StickThreshold 设置贴底阈值：向下滚动到距底部 n 行以内时恢复自动滚动
+func (s *scrollNode) StickThreshold(n int) *scrollNode {
+	s.stickThreshold = n
+	return s
+}
+
+// NewMessagesPill 离开底部期间有新内容时，在底部显示"↓ N new messages"提示，点击或按 Enter 跳到底部
+func (s *scrollNode) NewMessagesPill(show bool) *scrollNode {
+	s.showPill = show
+	return s
+}
+
 func (s *scrollNode) getFlex() int {
 	if s.flex > 0 {
 		return s.flex
 	}
+	if s.nested {
+		return 0 // 嵌套时按内容高度参与外层布局
+	}
 	return 1 // ScrollBox 默认 flex=1
 }
 
+// measureHeight 顶层 ScrollBox 是弹性的，不占固定高度；嵌套时高度为其内容高度，由外层负责滚动
+func (s *scrollNode) measureHeight(width int) int {
+	if !s.nested || s.child == nil {
+		return 0
+	}
+	return measureNodeHeight(s.child, s.viewWidth(width))
+}
+
+// hoveredInner 鼠标是否位于某个嵌套的 ScrollBox 上
+func (s *scrollNode) hoveredInner(x, y int) bool {
+	for _, inner := range s.inner {
+		if inner.ctx.visible.Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *scrollNode) getHeight() int {
 	return 0
 }
@@ -206,18 +529,30 @@ func (s *scrollNode) getHeight() int {
 // =============================================================================
 
 type componentNode struct {
-	ctx  *componentContext
-	node Node
+	ctx     *componentContext
+	node    Node
+	content bool // 由 WrapContent 创建，只记录内容区域
 }
 
 func (cn *componentNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if cn.content {
+		usedHeight := 0
+		if cn.node != nil {
+			usedHeight = cn.node.render(screen, x, y, width, height)
+		}
+		cn.ctx.contentRect, _ = screenRect(screen, Rect{X: x, Y: y, W: width, H: usedHeight})
+		return usedHeight
+	}
+	if cn.ctx != nil && cn.ctx.runtime != nil {
+		defer cn.ctx.runtime.frameTrace.enterComponent(cn.ctx)()
+	}
 	usedHeight := 0
 	if cn.node != nil {
 		usedHeight = cn.node.render(screen, x, y, width, height)
 	}
 
-	// 记录布局位置（使用实际渲染的高度）
-	cn.ctx.rect = Rect{X: x, Y: y, W: width, H: usedHeight}
+	// 记录布局位置（使用实际渲染的高度），位于 ScrollBox 内时换算为屏幕坐标
+	cn.ctx.rect, cn.ctx.visible = screenRect(screen, Rect{X: x, Y: y, W: width, H: usedHeight})
 
 	return usedHeight
 }
@@ -250,6 +585,46 @@ func (cn *componentNode) Flex(f int) *componentNode {
 	return cn
 }
 
+// ScrollbarStyle 透传给 ScrollBox 内部的滚动节点
+func (cn *componentNode) ScrollbarStyle(track, thumb rune, color Color) *componentNode {
+	if sn, ok := cn.node.(*scrollNode); ok {
+		sn.ScrollbarStyle(track, thumb, color)
+	}
+	return cn
+}
+
+// HideScrollbar 透传给 ScrollBox 内部的滚动节点
+func (cn *componentNode) HideScrollbar() *componentNode {
+	if sn, ok := cn.node.(*scrollNode); ok {
+		sn.HideScrollbar()
+	}
+	return cn
+}
+
+// StickThreshold 透传给 ScrollBox 内部的滚动节点
+func (cn *componentNode) StickThreshold(n int) *componentNode {
+	if sn, ok := cn.node.(*scrollNode); ok {
+		sn.StickThreshold(n)
+	}
+	return cn
+}
+
+// NewMessagesPill 透传给 ScrollBox 内部的滚动节点
+func (cn *componentNode) NewMessagesPill(show bool) *componentNode {
+	if sn, ok := cn.node.(*scrollNode); ok {
+		sn.NewMessagesPill(show)
+	}
+	return cn
+}
+
+// Searchable 透传给 ScrollBox 内部的滚动节点
+func (cn *componentNode) Searchable() *componentNode {
+	if sn, ok := cn.node.(*scrollNode); ok {
+		sn.Searchable()
+	}
+	return cn
+}
+
 func (cn *componentNode) Padding(top, horizontal int) *componentNode {
 	// 暂时只支持透传给 vstackNode 等
 	type paddingSetter interface {
@@ -286,6 +661,9 @@ type textNode struct {
 	content string
 	style   Style
 	wrap    bool
+
+	link    string // OSC 8 超链接地址
+	onClick func()
 }
 
 // Text 创建一个文本节点
@@ -320,9 +698,12 @@ func (t *textNode) render(screen tcell.Screen, x, y, width, height int) int {
 	}
 
 	style := t.style.toTcell()
+	if t.link != "" {
+		style = style.Url(t.link).Underline(true)
+	}
 
 	if !t.wrap {
-		textWidth := runewidth.StringWidth(t.content)
+		textWidth := cachedStringWidth(t.content)
 		startX := x
 		switch t.style.align {
 		case AlignCenter:
@@ -336,13 +717,16 @@ func (t *textNode) render(screen tcell.Screen, x, y, width, height int) int {
 		}
 
 		col := startX
-		for _, r := range t.content {
-			charWidth := runewidth.RuneWidth(r)
+		forEachGrapheme(t.content, func(mainc rune, combc []rune, charWidth int) bool {
 			if col+charWidth > x+actualWidth {
-				break
+				return false
 			}
-			screen.SetContent(col, y, r, nil, style)
+			screen.SetContent(col, y, mainc, combc, style)
 			col += charWidth
+			return true
+		})
+		if t.onClick != nil {
+			addClickRegion(screen, Rect{X: startX, Y: y, W: col - startX, H: 1}, t.onClick)
 		}
 		return 1
 	}
@@ -352,34 +736,33 @@ func (t *textNode) render(screen tcell.Screen, x, y, width, height int) int {
 	currentY := y
 	lines := 1
 
-	for _, r := range t.content {
-		charWidth := runewidth.RuneWidth(r)
-
+	forEachGrapheme(t.content, func(mainc rune, combc []rune, charWidth int) bool {
 		// 如果当前行放不下，换行
 		if currentX+charWidth > x+width {
 			currentX = x
 			currentY++
 			lines++
 			if lines > height {
-				break
+				return false
 			}
 		}
 
 		// 处理显式的换行符
-		if r == '\n' {
+		if isNewline(mainc, combc) {
 			currentX = x
 			currentY++
 			lines++
-			if lines > height {
-				break
-			}
-			continue
+			return lines <= height
 		}
 
-		screen.SetContent(currentX, currentY, r, nil, style)
+		screen.SetContent(currentX, currentY, mainc, combc, style)
 		currentX += charWidth
-	}
+		return true
+	})
 
+	if t.onClick != nil {
+		addClickRegion(screen, Rect{X: x, Y: y, W: width, H: min(lines, height)}, t.onClick)
+	}
 	return lines
 }
 
@@ -443,6 +826,7 @@ type vstackNode struct {
 	style    Style
 	gap      int
 	justify  Align
+	measure  *measurePool
 }
 
 // VStack 创建一个垂直堆叠布局
@@ -516,13 +900,19 @@ func (v *vstackNode) measureHeight(width int) int {
 		return v.style.paddingTop + v.style.paddingBottom
 	}
 
+	heights := make([]int, len(v.children))
+	v.measure.each(len(v.children), func(i int) {
+		if v.children[i] != nil {
+			heights[i] = measureNodeHeight(v.children[i], innerWidth)
+		}
+	})
 	total := 0
 	count := 0
-	for _, child := range v.children {
+	for i, child := range v.children {
 		if child == nil {
 			continue
 		}
-		total += measureNodeHeight(child, innerWidth)
+		total += heights[i]
 		count++
 	}
 	if count > 1 {
@@ -540,6 +930,44 @@ func (v *vstackNode) Padding(top, horizontal int) *vstackNode {
 	return v
 }
 
+// virtualizable 是否可以按视口只渲染部分子节点（没有 flex 子节点且顶部对齐）
+func (v *vstackNode) virtualizable() bool {
+	if v.justify != AlignLeft {
+		return false
+	}
+	for _, child := range v.children {
+		if fn, ok := child.(flexNode); ok && fn.getFlex() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// renderWindow 只渲染与内容区间 [top, bottom) 相交的子节点，用于长列表滚动
+func (v *vstackNode) renderWindow(screen tcell.Screen, x, y, width, top, bottom int) {
+	innerW := width - (v.style.paddingLeft + v.style.paddingRight)
+	if innerW <= 0 {
+		return
+	}
+	pos := v.style.paddingTop
+	for _, child := range v.children {
+		if child == nil {
+			continue
+		}
+		h := 0
+		if pos < bottom {
+			h = measureNodeHeight(child, innerW)
+		}
+		if h > 0 && pos+h > top {
+			child.render(screen, x+v.style.paddingLeft, y+pos, innerW, h)
+		} else if cn, ok := child.(*componentNode); ok {
+			// 未渲染的组件清空位置，避免旧位置继续响应鼠标
+			cn.ctx.rect, cn.ctx.visible = Rect{}, Rect{}
+		}
+		pos += h + v.gap
+	}
+}
+
 func (v *vstackNode) render(screen tcell.Screen, x, y, width, height int) int {
 	if len(v.children) == 0 {
 		return 0
@@ -566,7 +994,14 @@ func (v *vstackNode) render(screen tcell.Screen, x, y, width, height int) int {
 		return 0
 	}
 
-	// 第一遍：计算固定高度和总 flex
+	// 第一遍：测量非 flex 子节点的高度，计算固定高度和总 flex
+	heights := make([]int, len(children))
+	v.measure.each(len(children), func(i int) {
+		if fn, ok := children[i].(flexNode); !ok || fn.getFlex() <= 0 {
+			heights[i] = measureNodeHeight(children[i], width)
+		}
+	})
+
 	fixedHeight := 0
 	totalFlex := 0
 	numGaps := len(children) - 1
@@ -575,11 +1010,11 @@ func (v *vstackNode) render(screen tcell.Screen, x, y, width, height int) int {
 	}
 	fixedHeight += numGaps * v.gap
 
-	for _, child := range children {
+	for i, child := range children {
 		if fn, ok := child.(flexNode); ok && fn.getFlex() > 0 {
 			totalFlex += fn.getFlex()
 		} else {
-			fixedHeight += measureNodeHeight(child, width)
+			fixedHeight += heights[i]
 		}
 	}
 
@@ -619,9 +1054,9 @@ func (v *vstackNode) render(screen tcell.Screen, x, y, width, height int) int {
 		}
 
 		// 计算子节点的高度
-		childHeight := measureNodeHeight(child, width)
+		childHeight := heights[i]
 		if fn, ok := child.(flexNode); ok && fn.getFlex() > 0 {
-			childHeight = flexUnitHeight * fn.getFlex()
+			childHeight = max(flexUnitHeight*fn.getFlex(), spacerMin(child))
 		}
 
 		remainingH := (y + height) - currentY
@@ -655,6 +1090,7 @@ type hstackNode struct {
 	style    Style
 	gap      int
 	justify  Align
+	measure  *measurePool
 }
 
 // HStack 创建一个水平排列布局
@@ -800,7 +1236,7 @@ func (h *hstackNode) render(screen tcell.Screen, x, y, width, height int) int {
 		flex := h.getChildFlex(child)
 
 		if flex > 0 {
-			childWidth = flexUnitWidth * flex
+			childWidth = max(flexUnitWidth*flex, spacerMin(child))
 		}
 
 		remainingW := (x + width) - currentX
@@ -846,7 +1282,7 @@ func (h *hstackNode) measureWidth(node Node) int {
 		if n.style.width > 0 {
 			total = n.style.width
 		} else {
-			total = runewidth.StringWidth(n.content)
+			total = cachedStringWidth(n.content)
 		}
 	case *boxNode:
 		if n.style.width > 0 {
@@ -908,7 +1344,11 @@ func (h *hstackNode) measureWidth(node Node) int {
 	case *emptyNode:
 		total = 0
 	default:
-		total = 10
+		if m, ok := node.(widthMeasurer); ok {
+			total = m.measureWidth()
+		} else {
+			total = 10
+		}
 	}
 	return total
 }
@@ -1006,13 +1446,29 @@ func For[T any](items []T, render func(item T, index int) Node) Node {
 // Spacer 节点（flex=1 的弹性空白）
 // =============================================================================
 
-type spacerNode struct{}
+type spacerNode struct {
+	min int
+}
 
 // Spacer 创建一个弹性空白节点（默认 flex=1）
 func Spacer() *spacerNode {
 	return &spacerNode{}
 }
 
+// Min 设置最小尺寸，空间不足时 Spacer 也至少保留 n 格
+func (s *spacerNode) Min(n int) *spacerNode {
+	s.min = n
+	return s
+}
+
+// spacerMin 返回 Spacer 的最小尺寸，其他节点返回 0
+func spacerMin(node Node) int {
+	if s, ok := node.(*spacerNode); ok {
+		return s.min
+	}
+	return 0
+}
+
 func (s *spacerNode) render(screen tcell.Screen, x, y, width, height int) int {
 	// Spacer 不渲染任何内容，只占据空间
 	return height
@@ -1097,22 +1553,73 @@ func StringWidth(s string) int {
 // =============================================================================
 
 type cursorNode struct {
-	runtime *Runtime
+	ctx *componentContext
 }
 
 // Cursor 创建一个光标标记节点
-// 需要传入 C 来访问 runtime
+// 需要传入 C 来访问 runtime。同时渲染了多个 Cursor 时，聚焦的组件（或其子组件）中的优先
 func Cursor(c C) Node {
 	ctx := c.(*componentContext)
-	return &cursorNode{runtime: ctx.runtime}
+	return &cursorNode{ctx: ctx}
 }
 
 func (n *cursorNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if !n.ctx.cursorAllowed() {
+		return 0
+	}
 	// 标记光标位置
+	r := n.ctx.runtime
+	if r != nil {
+		r.cursorFrom = n.ctx
+		defer func() { r.cursorFrom = nil }()
+	}
 	screen.ShowCursor(x, y)
 	return 0 // 不占用空间
 }
 
+// walkNodes 深度优先遍历节点树，fn 返回 false 时不再进入该节点的子节点
+func walkNodes(node Node, fn func(Node) bool) {
+	if node == nil || !fn(node) {
+		return
+	}
+	switch n := node.(type) {
+	case *componentNode:
+		walkNodes(n.node, fn)
+	case *boxNode:
+		walkNodes(n.child, fn)
+	case *vstackNode:
+		for _, child := range n.children {
+			walkNodes(child, fn)
+		}
+	case *hstackNode:
+		for _, child := range n.children {
+			walkNodes(child, fn)
+		}
+	case *whenNode:
+		if n.condition {
+			walkNodes(n.node, fn)
+		}
+	case *whenElseNode:
+		if n.condition {
+			walkNodes(n.trueNode, fn)
+		} else {
+			walkNodes(n.falseNode, fn)
+		}
+	case *tooltipNode:
+		walkNodes(n.child, fn)
+	case *highlightNode:
+		walkNodes(n.child, fn)
+	case *scrollNode:
+		walkNodes(n.child, fn)
+	case *columnsNode:
+		for _, child := range n.children {
+			walkNodes(child, fn)
+		}
+	case *rowNode:
+		walkNodes(n.stack, fn)
+	}
+}
+
 // measureNodeHeight 测量节点需要的高度
 func measureNodeHeight(node Node, width int) int {
 	switch n := node.(type) {
@@ -1121,33 +1628,20 @@ func measureNodeHeight(node Node, width int) int {
 			return 1
 		}
 		// 计算换行后的高度
-		currentX := 0
-		lines := 1
-		for _, r := range n.content {
-			charWidth := runewidth.RuneWidth(r)
-			if currentX+charWidth > width {
-				currentX = 0
-				lines++
-			}
-			if r == '\n' {
-				currentX = 0
-				lines++
-				continue
-			}
-			currentX += charWidth
-		}
-		return lines
+		return cachedWrapLines(n.content, width)
 	case *vstackNode:
 		return n.measureHeight(width)
 	case *hstackNode:
 		maxH := 0
 		// HStack 的子节点宽度分配比较复杂，这里简化处理
 		// 实际上 HStack 应该知道每个子节点的宽度分配
-		for _, child := range n.children {
-			if child == nil {
-				continue
+		heights := make([]int, len(n.children))
+		n.measure.each(len(n.children), func(i int) {
+			if n.children[i] != nil {
+				heights[i] = measureNodeHeight(n.children[i], width/len(n.children)) // 粗略估计
 			}
-			h := measureNodeHeight(child, width/len(n.children)) // 粗略估计
+		})
+		for _, h := range heights {
 			if h > maxH {
 				maxH = h
 			}
@@ -1192,7 +1686,7 @@ func measureNodeHeight(node Node, width int) int {
 	case *spacerNode:
 		return 0 // Spacer 不占固定高度，是弹性的
 	case *scrollNode:
-		return 0 // ScrollBox 应该是弹性的，占据所有可用高度
+		return n.measureHeight(width) // 顶层 ScrollBox 是弹性的，占据所有可用高度
 	case *emptyNode:
 		return 0
 	case *cursorNode:
@@ -1202,6 +1696,9 @@ func measureNodeHeight(node Node, width int) int {
 	case *componentNode:
 		return measureNodeHeight(n.node, width)
 	default:
+		if m, ok := node.(heightMeasurer); ok {
+			return m.measureHeight(width)
+		}
 		return 1
 	}
 }
@@ -0,0 +1,221 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSpacer_Min(t *testing.T) {
+	app := func(c C) Node {
+		return HStack(Text("Title"), Spacer().Min(2), Text("Status"))
+	}
+
+	screen := newTestScreen(10, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	if content := getScreenContent(screen); !strings.HasPrefix(content, "Title  Sta") {
+		t.Errorf("expected spacer to keep 2 columns, got %q", content)
+	}
+}
+
+func TestScrollBox_ScrollbarStyle(t *testing.T) {
+	lines := make([]Node, 10)
+	for i := range lines {
+		lines[i] = Text("line")
+	}
+
+	app := func(c C) Node {
+		return HStack(
+			Box(ScrollBox(c.Child("styled"), VStack(lines...)).ScrollbarStyle('.', '#', Green)).Width(6).Height(4),
+			Box(ScrollBox(c.Child("hidden"), VStack(lines...)).HideScrollbar()).Width(6).Height(4),
+		)
+	}
+
+	screen := newTestScreen(12, 4)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	rows := strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(rows[0], "line #") || !strings.HasPrefix(rows[3], "line .") {
+		t.Errorf("expected custom scrollbar characters, got %q", rows)
+	}
+	if strings.ContainsAny(rows[0][6:], "│┃") {
+		t.Errorf("expected hidden scrollbar, got %q", rows[0])
+	}
+}
+
+func TestTailBox_NewMessagesPill(t *testing.T) {
+	count := 8
+	app := func(c C) Node {
+		items := make([]Node, count)
+		for i := range items {
+			items[i] = Text(fmt.Sprintf("msg %d", i))
+		}
+		return Box(TailBox(c.Child("chat"), VStack(items...)).NewMessagesPill(true)).Height(4)
+	}
+
+	screen := newTestScreen(30, 4)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 向上滚动离开底部，再追加两条消息
+	tr.handleEvent(tcell.NewEventMouse(1, 1, tcell.WheelUp, 0))
+	tr.Render()
+	count += 2
+	tr.Render()
+
+	if content := getScreenContent(screen); !strings.Contains(content, "↓ 2 new messages") {
+		t.Fatalf("expected new messages pill, got:\n%s", content)
+	}
+
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	tr.Render()
+
+	content := getScreenContent(screen)
+	if strings.Contains(content, "new message") || !strings.Contains(content, "msg 9") {
+		t.Errorf("expected Enter to jump to bottom, got:\n%s", content)
+	}
+}
+
+func TestScrollBox_LongContent(t *testing.T) {
+	lines := make([]Node, 1500)
+	for i := range lines {
+		lines[i] = Text(fmt.Sprintf("row %d", i))
+	}
+	app := func(c C) Node {
+		return Box(TailBox(c.Child("log"), VStack(lines...))).Height(3)
+	}
+
+	screen := newTestScreen(20, 3)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.Render()
+
+	if content := getScreenContent(screen); !strings.Contains(content, "row 1499") {
+		t.Errorf("expected last row of long content to be visible, got:\n%s", content)
+	}
+}
+
+func TestScrollBox_Nested(t *testing.T) {
+	rows := func(prefix string, n int) []Node {
+		out := make([]Node, n)
+		for i := range out {
+			out[i] = Text(fmt.Sprintf("%s%d", prefix, i))
+		}
+		return out
+	}
+
+	app := func(c C) Node {
+		inner := Box(ScrollBox(c.Child("inner"), VStack(rows("in", 10)...))).Height(3)
+		content := append(rows("a", 2), inner)
+		content = append(content, rows("b", 5)...)
+		return Box(ScrollBox(c.Child("outer"), VStack(content...))).Height(6)
+	}
+
+	screen := newTestScreen(20, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 滚轮位于内层时只滚动内层
+	tr.handleEvent(tcell.NewEventMouse(1, 2, tcell.WheelDown, 0))
+	tr.Render()
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(lines[0], "a0") || !strings.HasPrefix(lines[2], "in1") {
+		t.Fatalf("expected only inner box to scroll, got %q", lines)
+	}
+
+	// 滚轮位于外层内容时滚动外层，外层内容高度包含内层盒子的高度
+	for i := 0; i < 10; i++ {
+		tr.handleEvent(tcell.NewEventMouse(1, 5, tcell.WheelDown, 0))
+		tr.Render()
+	}
+	lines = strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(lines[5], "b4") {
+		t.Errorf("expected outer box to scroll to its last row, got %q", lines)
+	}
+}
+
+func TestUseScrollControl(t *testing.T) {
+	var control ScrollControl
+	var state ScrollState
+	count := 10
+	app := func(c C) Node {
+		lines := make([]Node, count)
+		for i := range lines {
+			lines[i] = Text(fmt.Sprintf("row %d", i))
+		}
+		scroll := c.Child("scroll")
+		control = UseScrollControl(scroll)
+		state = UseScrollState(scroll)
+		return Box(TailBox(scroll, VStack(lines...))).Height(5)
+	}
+
+	screen := newTestScreen(20, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.Render()
+	if !control.Following() || !state.AtBottom {
+		t.Fatalf("TailBox should follow by default: following=%v %+v", control.Following(), state)
+	}
+
+	// 暂停后新内容不再把视口拉到底部
+	control.Pause()
+	count = 20
+	tr.Render()
+	tr.Render()
+	if control.Following() || state.AtBottom {
+		t.Errorf("paused TailBox followed new content: %+v", state)
+	}
+
+	control.ScrollTo(2)
+	tr.Render()
+	tr.Render()
+	if state.Top != 2 {
+		t.Errorf("Top = %d after ScrollTo(2)", state.Top)
+	}
+
+	control.Follow()
+	tr.Render()
+	tr.Render()
+	if !control.Following() || !state.AtBottom {
+		t.Errorf("Follow did not return to the bottom: %+v", state)
+	}
+}
+
+func TestUseScrollState(t *testing.T) {
+	lines := make([]Node, 20)
+	for i := range lines {
+		lines[i] = Text(fmt.Sprintf("row %d", i))
+	}
+
+	var state ScrollState
+	app := func(c C) Node {
+		scroll := c.Child("scroll")
+		state = UseScrollState(scroll)
+		return Box(ScrollBox(scroll, VStack(lines...))).Height(5)
+	}
+
+	screen := newTestScreen(20, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.Render()
+
+	if state.ContentHeight != 20 || state.ViewportHeight != 5 || state.AtBottom {
+		t.Fatalf("unexpected initial state: %+v", state)
+	}
+
+	for i := 0; i < 15; i++ {
+		tr.handleEvent(tcell.NewEventMouse(1, 1, tcell.WheelDown, 0))
+	}
+	tr.Render()
+	tr.Render()
+
+	if state.Top != 15 || !state.AtBottom || state.Progress() != 1 {
+		t.Errorf("expected to be at bottom, got %+v", state)
+	}
+}
@@ -0,0 +1,317 @@
+package rego
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Notification - 通知与通知中心
+// =============================================================================
+
+const (
+	toastDuration            = 4 * time.Second // toast 显示的时长
+	toastMaxVisible          = 3               // 同时显示的 toast 数量
+	toastMaxWidth            = 40
+	notificationHistoryLimit = 200 // 通知中心最多保留的条数
+)
+
+// Notification 是一条通知
+type Notification struct {
+	Level   LogLevel
+	Title   string
+	Message string
+	Silent  bool      // 只记入通知中心，不弹出 toast
+	Time    time.Time // 由 Notify 填写
+}
+
+// notificationRecord 是通知中心中的一条记录
+type notificationRecord struct {
+	Notification
+	id   int
+	read bool
+}
+
+// notificationStore 保存运行时的通知历史，Notify 可以在任意协程中调用
+type notificationStore struct {
+	mu       sync.Mutex
+	records  []notificationRecord // 从旧到新
+	next     int
+	watchers map[*componentContext]struct{} // 展示通知的组件，通知变化时刷新
+}
+
+func newNotificationStore() *notificationStore {
+	return &notificationStore{watchers: make(map[*componentContext]struct{})}
+}
+
+// Notify 发送一条通知：在右下角以 toast 显示几秒（Silent 时不显示），并记入通知中心
+//
+//	rego.Notify(c, rego.Notification{Level: rego.LogWarn, Title: "磁盘空间不足", Message: "剩余 2%"})
+func Notify(c C, n Notification) {
+	ctx := c.(*componentContext)
+	r := ctx.runtime
+	if r == nil {
+		return
+	}
+	clock := ctx.clock()
+	n.Time = clock.Now()
+
+	s := r.notifications
+	s.mu.Lock()
+	s.records = append(s.records, notificationRecord{Notification: n, id: s.next})
+	s.next++
+	if len(s.records) > notificationHistoryLimit {
+		s.records = append(s.records[:0], s.records[len(s.records)-notificationHistoryLimit:]...)
+	}
+	s.mu.Unlock()
+	s.changed()
+
+	if !n.Silent {
+		r.scheduleRefresh()
+		clock.AfterFunc(toastDuration, r.scheduleRefresh) // toast 到期后重绘以移除它
+	}
+}
+
+// watch 记录 ctx 展示了通知，之后通知变化时刷新它
+func (s *notificationStore) watch(ctx *componentContext) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchers[ctx] = struct{}{}
+}
+
+func (s *notificationStore) changed() {
+	s.mu.Lock()
+	watchers := make([]*componentContext, 0, len(s.watchers))
+	for ctx := range s.watchers {
+		watchers = append(watchers, ctx)
+	}
+	s.mu.Unlock()
+	for _, ctx := range watchers {
+		ctx.Refresh()
+	}
+}
+
+// snapshot 返回从新到旧排列的通知
+func (s *notificationStore) snapshot() []notificationRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]notificationRecord, len(s.records))
+	for i, rec := range s.records {
+		out[len(out)-1-i] = rec
+	}
+	return out
+}
+
+func (s *notificationStore) unread() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, rec := range s.records {
+		if !rec.read {
+			n++
+		}
+	}
+	return n
+}
+
+// update 修改满足 match 的记录，fn 返回 false 时删除该记录
+func (s *notificationStore) update(match func(notificationRecord) bool, fn func(*notificationRecord) bool) {
+	s.mu.Lock()
+	kept := s.records[:0]
+	for _, rec := range s.records {
+		if match(rec) && !fn(&rec) {
+			continue
+		}
+		kept = append(kept, rec)
+	}
+	s.records = kept
+	s.mu.Unlock()
+	s.changed()
+}
+
+// addToasts 把仍在显示期内的通知作为浮层放在屏幕右下角，最新的在最下方
+func (s *notificationStore) addToasts(r *Runtime, screenW, screenH int) {
+	now := r.clock.Now()
+	var toasts []Notification
+	s.mu.Lock()
+	for i := len(s.records) - 1; i >= 0 && len(toasts) < toastMaxVisible; i-- {
+		n := s.records[i].Notification
+		if now.Sub(n.Time) >= toastDuration {
+			break
+		}
+		if !n.Silent {
+			toasts = append(toasts, n)
+		}
+	}
+	s.mu.Unlock()
+
+	w := min(toastMaxWidth, screenW-2)
+	y := screenH - 1
+	for _, n := range toasts {
+		toast := toastNode(n)
+		h := measureNodeHeight(toast, w)
+		if w <= 0 || y-h < 0 {
+			return
+		}
+		y -= h
+		r.addOverlay(toast, screenW-w-1, y, w, h)
+	}
+}
+
+func toastNode(n Notification) Node {
+	color := n.Level.color()
+	return Box(VStack(
+		Text(n.Title).Bold().Color(color),
+		When(n.Message != "", Text(n.Message).Wrap(true)),
+	)).Border(BorderRounded).BorderColor(color).Padding(0, 1)
+}
+
+// UnreadNotifications 返回未读通知的数量
+func UnreadNotifications(c C) int {
+	ctx := c.(*componentContext)
+	if ctx.runtime == nil {
+		return 0
+	}
+	ctx.runtime.notifications.watch(ctx)
+	return ctx.runtime.notifications.unread()
+}
+
+// NotificationBadge 返回显示未读通知数量的徽标，没有未读通知时不显示，适合放在状态栏
+func NotificationBadge(c C) Node {
+	n := UnreadNotifications(c)
+	if n == 0 {
+		return Empty()
+	}
+	return Badge(strconv.Itoa(n))
+}
+
+// NotificationCenter 创建通知中心面板，按从新到旧列出所有通知，未读的以 ● 标记
+//
+// 快捷键（聚焦时）：
+//
+//	↑/↓    选择通知
+//	Enter  切换选中通知的已读状态
+//	a      全部标为已读
+//	d      删除选中的通知
+//	1-4    切换 DEBUG/INFO/WARN/ERROR 的显示
+func NotificationCenter(c C) Node {
+	ctx := c.(*componentContext)
+	focus := UseFocus(c)
+	hidden := Use(c, "hidden", map[LogLevel]bool{})
+	selected := Use(c, "selected", 0)
+
+	var store *notificationStore
+	var records []notificationRecord
+	if ctx.runtime != nil {
+		store = ctx.runtime.notifications
+		store.watch(ctx)
+		for _, rec := range store.snapshot() {
+			if !hidden.Val[rec.Level] {
+				records = append(records, rec)
+			}
+		}
+	}
+	sel := min(selected.Val, len(records)-1)
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused || store == nil {
+			return
+		}
+		byID := func(id int) func(notificationRecord) bool {
+			return func(rec notificationRecord) bool { return rec.id == id }
+		}
+		switch {
+		case key == KeyUp:
+			selected.Set(max(sel-1, 0))
+		case key == KeyDown:
+			selected.Set(max(min(sel+1, len(records)-1), 0))
+		case key == KeyEnter && sel >= 0:
+			store.update(byID(records[sel].id), func(rec *notificationRecord) bool {
+				rec.read = !rec.read
+				return true
+			})
+		case r == 'a':
+			store.update(func(notificationRecord) bool { return true }, func(rec *notificationRecord) bool {
+				rec.read = true
+				return true
+			})
+		case r == 'd' && sel >= 0:
+			store.update(byID(records[sel].id), func(*notificationRecord) bool { return false })
+		case r >= '1' && r <= '4':
+			level := LogLevel(r - '1')
+			next := make(map[LogLevel]bool, len(hidden.Val)+1)
+			for k, v := range hidden.Val {
+				next[k] = v
+			}
+			next[level] = !next[level]
+			hidden.Set(next)
+		}
+	})
+
+	unread := 0
+	if store != nil {
+		unread = store.unread()
+	}
+	header := HStack(
+		Text("通知").Bold(),
+		When(unread > 0, Text(fmt.Sprintf("%d 未读", unread)).Color(Cyan)),
+	).Gap(1)
+
+	var rows []Node
+	for i, rec := range records {
+		rows = append(rows, notificationRow(rec, focus.IsFocused && i == sel))
+	}
+	list := Node(Text("暂无通知").Dim())
+	if len(rows) > 0 {
+		list = VStack(rows...)
+	}
+
+	return c.Wrap(VStack(
+		header,
+		ScrollBox(c.Child("scroll"), list).Flex(1),
+		notificationFilterBar(hidden.Val),
+	).Flex(1))
+}
+
+// notificationRow 通知中心中的一条通知：标记、级别、时间、标题，以及缩进的正文
+func notificationRow(rec notificationRecord, selected bool) Node {
+	mark := Text("  ")
+	if !rec.read {
+		mark = Text("● ").Color(Cyan)
+	}
+	title := Text(rec.Title)
+	if !rec.read {
+		title = title.Bold()
+	}
+	line := HStack(
+		mark,
+		Text(fmt.Sprintf("%-5s ", rec.Level)).Color(rec.Level.color()).Bold(),
+		Text(rec.Time.Format("15:04:05")+" ").Dim(),
+		title,
+	)
+	if selected {
+		line = line.Background(Gray)
+	}
+	return VStack(
+		line,
+		When(rec.Message != "", Box(Text(rec.Message).Wrap(true).Dim()).Padding(0, 2)),
+	)
+}
+
+// notificationFilterBar 底部的级别开关
+func notificationFilterBar(hidden map[LogLevel]bool) Node {
+	var items []Node
+	for l := LogDebug; l <= LogError; l++ {
+		label := Text(fmt.Sprintf("%d:%s", int(l)+1, l))
+		if hidden[l] {
+			label = label.Dim()
+		} else {
+			label = label.Color(l.color())
+		}
+		items = append(items, label)
+	}
+	items = append(items, Spacer(), Text("a:全部已读 d:删除").Dim())
+	return HStack(items...).Gap(1)
+}
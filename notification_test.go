@@ -0,0 +1,89 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestNotify_ToastExpires(t *testing.T) {
+	var notify func(Notification)
+	app := func(c C) Node {
+		notify = func(n Notification) { Notify(c, n) }
+		return Text("main")
+	}
+
+	screen := newTestScreen(40, 8)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+
+	notify(Notification{Level: LogWarn, Title: "Disk almost full", Message: "2% left"})
+	notify(Notification{Level: LogInfo, Title: "quiet", Silent: true})
+	tr.Render()
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "Disk almost full") || !strings.Contains(content, "2% left") {
+		t.Fatalf("expected toast:\n%s", content)
+	}
+	if strings.Contains(content, "quiet") {
+		t.Errorf("silent notification shown as toast:\n%s", content)
+	}
+
+	tr.AdvanceTime(toastDuration)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "Disk almost full") {
+		t.Errorf("expected toast to expire:\n%s", content)
+	}
+}
+
+func TestNotificationCenter_ReadStateAndFilters(t *testing.T) {
+	var notify func(Notification)
+	app := func(c C) Node {
+		notify = func(n Notification) { Notify(c, n) }
+		return VStack(
+			HStack(Text("status"), NotificationBadge(c.Child("badge"))).Gap(1),
+			NotificationCenter(c.Child("center")),
+		)
+	}
+
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+	for _, n := range []Notification{
+		{Level: LogInfo, Title: "build ok", Silent: true},
+		{Level: LogError, Title: "deploy failed", Silent: true},
+	} {
+		notify(n)
+	}
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.Contains(lines[0], "status  2 ") || !strings.Contains(lines[1], "2 未读") {
+		t.Fatalf("unexpected badge/header:\n%s", getScreenContent(screen))
+	}
+	if !strings.Contains(lines[2], "● ERROR") || !strings.Contains(lines[2], "deploy failed") || !strings.Contains(lines[3], "build ok") {
+		t.Fatalf("expected newest first:\n%s", getScreenContent(screen))
+	}
+
+	// Enter 标记选中的（最新的）通知为已读
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	lines = strings.Split(getScreenContent(screen), "\n")
+	if strings.Contains(lines[2], "●") || !strings.Contains(lines[1], "1 未读") || !strings.Contains(lines[0], "status  1 ") {
+		t.Fatalf("expected one unread:\n%s", getScreenContent(screen))
+	}
+
+	// 隐藏 ERROR 后只剩 INFO
+	tr.DispatchKey(tcell.KeyRune, '4', 0)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "deploy failed") || !strings.Contains(content, "build ok") {
+		t.Fatalf("expected ERROR to be filtered:\n%s", content)
+	}
+
+	// 全部已读后徽标消失
+	tr.DispatchKey(tcell.KeyRune, 'a', 0)
+	tr.Render()
+	if lines = strings.Split(getScreenContent(screen), "\n"); strings.TrimSpace(lines[0]) != "status" {
+		t.Errorf("expected badge to disappear:\n%s", getScreenContent(screen))
+	}
+}
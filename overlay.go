@@ -0,0 +1,52 @@
+package rego
+
+import "github.com/gdamore/tcell/v2"
+
+// =============================================================================
+// Overlay - 浮层
+// =============================================================================
+
+// overlay 是在主界面渲染完成后绘制的浮层（如提示、弹窗）
+type overlay struct {
+	node       Node
+	x, y, w, h int
+}
+
+// addOverlay 在本帧添加一个浮层，通常由节点在 render 期间调用
+func (r *Runtime) addOverlay(node Node, x, y, w, h int) {
+	r.overlays = append(r.overlays, overlay{node: node, x: x, y: y, w: w, h: h})
+}
+
+// renderOverlays 按添加顺序绘制浮层，后添加的在上层
+func (r *Runtime) renderOverlays(screen tcell.Screen) {
+	// 浮层渲染期间可能继续添加浮层，因此按下标遍历
+	for i := 0; i < len(r.overlays); i++ {
+		ov := r.overlays[i]
+		if ov.w <= 0 || ov.h <= 0 {
+			continue
+		}
+		// 先清空区域，避免下方内容透出
+		for row := ov.y; row < ov.y+ov.h; row++ {
+			for col := ov.x; col < ov.x+ov.w; col++ {
+				screen.SetContent(col, row, ' ', nil, tcell.StyleDefault)
+			}
+		}
+		ov.node.render(screen, ov.x, ov.y, ov.w, ov.h)
+	}
+}
+
+// placeOverlay 计算浮层位置：优先放在锚点下方，超出屏幕时翻转到上方，并水平收回屏幕内
+func placeOverlay(anchor Rect, w, h, screenW, screenH int) (int, int) {
+	x := anchor.X
+	y := anchor.Y + anchor.H
+	if y+h > screenH && anchor.Y-h >= 0 {
+		y = anchor.Y - h
+	}
+	if x+w > screenW {
+		x = screenW - w
+	}
+	if x < 0 {
+		x = 0
+	}
+	return x, y
+}
@@ -0,0 +1,458 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Panes - 类 tmux 的窗格分割
+// =============================================================================
+
+// PaneSplit 窗格的分割方向
+type PaneSplit int
+
+const (
+	SplitHorizontal PaneSplit = iota // 左右排列
+	SplitVertical                    // 上下排列
+)
+
+// PaneDirection 在窗格间移动的方向
+type PaneDirection int
+
+const (
+	PaneLeft PaneDirection = iota
+	PaneRight
+	PaneUp
+	PaneDown
+)
+
+// 窗格快捷键的动作名：先按前缀键，再按动作键，键位可以通过 LoadKeymap 覆盖
+const (
+	ActionPanePrefix          = "pane.prefix"
+	ActionPaneSplitHorizontal = "pane.split-horizontal"
+	ActionPaneSplitVertical   = "pane.split-vertical"
+	ActionPaneClose           = "pane.close"
+	ActionPaneZoom            = "pane.zoom"
+	ActionPaneLeft            = "pane.left"
+	ActionPaneRight           = "pane.right"
+	ActionPaneUp              = "pane.up"
+	ActionPaneDown            = "pane.down"
+)
+
+// definePaneActions 在第一次使用 Panes 时注册默认键位，不用窗格的应用不会在 Bindings 中看到它们
+var definePaneActions = sync.OnceFunc(func() {
+	DefineAction(ActionPanePrefix, "窗格快捷键前缀", "ctrl+b")
+	DefineAction(ActionPaneSplitHorizontal, "左右分割当前窗格", "%")
+	DefineAction(ActionPaneSplitVertical, "上下分割当前窗格", "\"")
+	DefineAction(ActionPaneClose, "关闭当前窗格", "x")
+	DefineAction(ActionPaneZoom, "放大/还原当前窗格", "z")
+	DefineAction(ActionPaneLeft, "移到左侧窗格", "left", "h")
+	DefineAction(ActionPaneRight, "移到右侧窗格", "right", "l")
+	DefineAction(ActionPaneUp, "移到上方窗格", "up", "k")
+	DefineAction(ActionPaneDown, "移到下方窗格", "down", "j")
+})
+
+// paneTree 是分割树的节点：叶子持有一个窗格，内部节点按 split 把区域一分为二
+type paneTree struct {
+	id            int // 叶子的窗格 ID，内部节点为 0
+	content       Component
+	split         PaneSplit
+	first, second *paneTree
+	parent        *paneTree
+}
+
+// find 返回 ID 为 id 的叶子
+func (t *paneTree) find(id int) *paneTree {
+	if t.id != 0 {
+		if t.id == id {
+			return t
+		}
+		return nil
+	}
+	if leaf := t.first.find(id); leaf != nil {
+		return leaf
+	}
+	return t.second.find(id)
+}
+
+// ids 按从左到右、从上到下的顺序返回所有窗格 ID
+func (t *paneTree) ids(out []int) []int {
+	if t.id != 0 {
+		return append(out, t.id)
+	}
+	return t.second.ids(t.first.ids(out))
+}
+
+// layout 把 r 按分割树划分给各个窗格，前一半在奇数尺寸时多占一格
+func (t *paneTree) layout(r Rect, out map[int]Rect) {
+	if t.id != 0 {
+		out[t.id] = r
+		return
+	}
+	a, b := r, r
+	if t.split == SplitHorizontal {
+		a.W = r.W - r.W/2
+		b.X, b.W = r.X+a.W, r.W/2
+	} else {
+		a.H = r.H - r.H/2
+		b.Y, b.H = r.Y+a.H, r.H/2
+	}
+	t.first.layout(a, out)
+	t.second.layout(b, out)
+}
+
+// PaneManager 管理一组窗格的分割树与活动窗格
+type PaneManager struct {
+	ctx          *componentContext
+	root         *paneTree
+	active       int
+	nextID       int
+	zoomed       bool
+	prefix       bool   // 已按下前缀键，等待动作键
+	area         Rect   // 最近一次渲染的区域（屏幕坐标）
+	focusPending bool   // 活动窗格已切换，焦点还没移过去
+	seenFocus    string // 上一帧结束时的焦点，用于发现 Tab 或点击引起的焦点移动
+}
+
+// Panes 返回 c 下的窗格管理器，初始只有一个显示 initial 的窗格
+//
+// 每个窗格在自己的子上下文中运行内容组件，状态互相独立。按前缀键（默认 Ctrl+B）后：
+// % 左右分割，" 上下分割，x 关闭，z 放大/还原，方向键或 hjkl 移到相邻窗格。
+// 活动窗格与焦点保持同步：切换窗格时焦点移到其中第一个可聚焦组件，Tab 或点击把焦点移入某个窗格时它成为活动窗格。
+// 按键会广播给所有窗格，内容组件应通过 UseFocus 判断是否处理。
+//
+//	panes := rego.Panes(c.Child("panes"), Shell)
+//	return panes.View()
+func Panes(c C, initial Component) *PaneManager {
+	definePaneActions()
+	ref := UseRef[*PaneManager](c, nil)
+	if ref.Current == nil {
+		ref.Current = &PaneManager{root: &paneTree{id: 1, content: initial}, active: 1, nextID: 2}
+	}
+	pm := ref.Current
+	pm.ctx = c.(*componentContext)
+
+	UseKey(c, pm.handleKey)
+	UseMouse(c, func(ev MouseEvent) {
+		if ev.Type == MouseEventPress && ev.Button == MouseButtonLeft {
+			if id := pm.paneAt(ev.X, ev.Y); id != 0 {
+				pm.Activate(id)
+			}
+		}
+	})
+	return pm
+}
+
+// Active 返回活动窗格的 ID
+func (pm *PaneManager) Active() int {
+	return pm.active
+}
+
+// IDs 按从左到右、从上到下的顺序返回所有窗格 ID
+func (pm *PaneManager) IDs() []int {
+	return pm.root.ids(nil)
+}
+
+// Zoomed 活动窗格是否被放大到整个区域
+func (pm *PaneManager) Zoomed() bool {
+	return pm.zoomed
+}
+
+// Split 分割活动窗格，新窗格显示 content（为 nil 时与活动窗格相同）并成为活动窗格，返回新窗格的 ID
+func (pm *PaneManager) Split(split PaneSplit, content Component) int {
+	leaf := pm.root.find(pm.active)
+	if content == nil {
+		content = leaf.content
+	}
+	id := pm.nextID
+	pm.nextID++
+
+	// 原叶子变为内部节点，两侧分别是原窗格和新窗格
+	old := &paneTree{id: leaf.id, content: leaf.content, parent: leaf}
+	*leaf = paneTree{split: split, parent: leaf.parent, first: old, second: &paneTree{id: id, content: content, parent: leaf}}
+	pm.zoomed = false
+	pm.Activate(id)
+	return id
+}
+
+// Close 关闭活动窗格，相邻的窗格占据它的位置并成为活动窗格；只剩一个窗格时不关闭并返回 false
+func (pm *PaneManager) Close() bool {
+	leaf := pm.root.find(pm.active)
+	parent := leaf.parent
+	if parent == nil {
+		return false
+	}
+	sibling, next := parent.second, parent.second.ids(nil)[0]
+	if sibling == leaf {
+		ids := parent.first.ids(nil)
+		sibling, next = parent.first, ids[len(ids)-1]
+	}
+	sibling.parent = parent.parent
+	switch {
+	case parent.parent == nil:
+		pm.root = sibling
+	case parent.parent.first == parent:
+		parent.parent.first = sibling
+	default:
+		parent.parent.second = sibling
+	}
+
+	key := paneKey(leaf.id)
+	if pc, ok := pm.ctx.children[key]; ok {
+		pc.cleanup()
+		delete(pm.ctx.children, key)
+	}
+	pm.zoomed = false
+	pm.Activate(next)
+	return true
+}
+
+// Zoom 放大活动窗格到整个区域，再次调用还原
+func (pm *PaneManager) Zoom() {
+	pm.zoomed = !pm.zoomed
+	pm.ctx.Refresh()
+}
+
+// Activate 把 id 对应的窗格设为活动窗格
+func (pm *PaneManager) Activate(id int) bool {
+	if pm.root.find(id) == nil {
+		return false
+	}
+	if id != pm.active {
+		pm.active = id
+		pm.focusPending = true
+	}
+	pm.ctx.Refresh()
+	return true
+}
+
+// Focus 把活动窗格移到 dir 方向上最近的窗格，优先与活动窗格中线对齐的；该方向没有窗格时返回 false
+func (pm *PaneManager) Focus(dir PaneDirection) bool {
+	rects := make(map[int]Rect)
+	pm.root.layout(pm.area, rects)
+	a := rects[pm.active]
+
+	best, bestDist, bestOff := 0, 0, 0
+	for _, id := range pm.IDs() {
+		r := rects[id]
+		var dist, off int
+		switch dir {
+		case PaneLeft:
+			dist, off = a.X-(r.X+r.W), spanOffset(a.Y+a.H/2, r.Y, r.H)
+		case PaneRight:
+			dist, off = r.X-(a.X+a.W), spanOffset(a.Y+a.H/2, r.Y, r.H)
+		case PaneUp:
+			dist, off = a.Y-(r.Y+r.H), spanOffset(a.X+a.W/2, r.X, r.W)
+		case PaneDown:
+			dist, off = r.Y-(a.Y+a.H), spanOffset(a.X+a.W/2, r.X, r.W)
+		}
+		if id == pm.active || dist < 0 {
+			continue
+		}
+		if best == 0 || dist < bestDist || dist == bestDist && off < bestOff {
+			best, bestDist, bestOff = id, dist, off
+		}
+	}
+	if best == 0 {
+		return false
+	}
+	pm.zoomed = false
+	return pm.Activate(best)
+}
+
+// spanOffset 返回 p 到区间 [start, start+size) 的距离，p 在区间内时为 0
+func spanOffset(p, start, size int) int {
+	switch {
+	case p < start:
+		return start - p
+	case p >= start+size:
+		return p - (start + size - 1)
+	}
+	return 0
+}
+
+func (pm *PaneManager) handleKey(key Key, r rune) {
+	if !pm.prefix {
+		pm.prefix = MatchAction(ActionPanePrefix, key, r)
+		return
+	}
+	pm.prefix = false
+	switch {
+	case MatchAction(ActionPaneSplitHorizontal, key, r):
+		pm.Split(SplitHorizontal, nil)
+	case MatchAction(ActionPaneSplitVertical, key, r):
+		pm.Split(SplitVertical, nil)
+	case MatchAction(ActionPaneClose, key, r):
+		pm.Close()
+	case MatchAction(ActionPaneZoom, key, r):
+		pm.Zoom()
+	case MatchAction(ActionPaneLeft, key, r):
+		pm.Focus(PaneLeft)
+	case MatchAction(ActionPaneRight, key, r):
+		pm.Focus(PaneRight)
+	case MatchAction(ActionPaneUp, key, r):
+		pm.Focus(PaneUp)
+	case MatchAction(ActionPaneDown, key, r):
+		pm.Focus(PaneDown)
+	}
+}
+
+func paneKey(id int) string {
+	return fmt.Sprintf("pane[%d]", id)
+}
+
+// paneContext 返回窗格内容的上下文（不重置）
+func (pm *PaneManager) paneContext(id int) *componentContext {
+	return pm.ctx.childContext(paneKey(id))
+}
+
+// paneAt 返回最近一次渲染中 (x, y) 处的窗格 ID
+func (pm *PaneManager) paneAt(x, y int) int {
+	if pm.zoomed {
+		if pm.area.Contains(x, y) {
+			return pm.active
+		}
+		return 0
+	}
+	rects := make(map[int]Rect)
+	pm.root.layout(pm.area, rects)
+	for id, r := range rects {
+		if r.Contains(x, y) {
+			return id
+		}
+	}
+	return 0
+}
+
+// paneOf 返回焦点 key 所在的窗格 ID，不在任何窗格中时返回 0
+func (pm *PaneManager) paneOf(key string) int {
+	if key == "" {
+		return 0
+	}
+	for _, id := range pm.IDs() {
+		prefix := pm.paneContext(id).focusKey()
+		if key == prefix || strings.HasPrefix(key, prefix+"/") {
+			return id
+		}
+	}
+	return 0
+}
+
+// View 渲染窗格布局，放在 VStack 中时填满剩余高度
+func (pm *PaneManager) View() Node {
+	var fm *FocusManager
+	if pm.ctx.runtime != nil {
+		fm = pm.ctx.runtime.focusManager
+	}
+	// 焦点被 Tab 或点击移入其他窗格：那个窗格成为活动窗格
+	if fm != nil && fm.Current() != pm.seenFocus {
+		if id := pm.paneOf(fm.Current()); id != 0 {
+			pm.active = id
+		}
+	}
+
+	contents := make(map[int]Node)
+	for _, id := range pm.IDs() {
+		if pm.zoomed && id != pm.active {
+			continue
+		}
+		pc := pm.paneContext(id)
+		pc.reset()
+		contents[id] = pm.root.find(id).content(pc)
+	}
+
+	// 活动窗格切换后，焦点移到其中第一个可聚焦组件
+	if fm != nil {
+		if pm.focusPending && pm.paneOf(fm.Current()) != pm.active {
+			if key := fm.firstWithin(pm.paneContext(pm.active).focusKey()); key != "" {
+				fm.Focus(key)
+				pm.ctx.runtime.rebuild = true
+			}
+		}
+		pm.focusPending = false
+		pm.seenFocus = fm.Current()
+	}
+	return pm.ctx.Wrap(&panesNode{pm: pm, contents: contents})
+}
+
+// panesNode 按分割树绘制各个窗格，总是占满分配到的区域
+type panesNode struct {
+	pm       *PaneManager
+	contents map[int]Node
+}
+
+func (n *panesNode) render(screen tcell.Screen, x, y, width, height int) int {
+	pm := n.pm
+	area := Rect{X: x, Y: y, W: width, H: height}
+	pm.area, _ = screenRect(screen, area)
+
+	rects := make(map[int]Rect)
+	if pm.zoomed {
+		rects[pm.active] = area
+	} else {
+		pm.root.layout(area, rects)
+	}
+	for _, id := range pm.IDs() {
+		pc := pm.paneContext(id)
+		r, shown := rects[id]
+		if !shown {
+			// 放大时被隐藏的窗格不接收鼠标事件
+			pc.rect, pc.visible = Rect{}, Rect{}
+			continue
+		}
+		n.drawPane(screen, id, r)
+		pc.rect, pc.visible = screenRect(screen, r)
+	}
+	return height
+}
+
+// drawPane 绘制窗格边框（活动窗格高亮）与内容
+func (n *panesNode) drawPane(screen tcell.Screen, id int, r Rect) {
+	if r.W < 2 || r.H < 2 {
+		return
+	}
+	active := id == n.pm.active
+	color := Gray
+	if active {
+		color = Cyan
+	}
+	Box(nil).Border(BorderSingle).BorderColor(color).renderBorder(screen, r.X, r.Y, r.W, r.H)
+
+	title := fmt.Sprintf(" %d ", id)
+	if n.pm.zoomed {
+		title = fmt.Sprintf(" %d [Z] ", id)
+	}
+	style := tcell.StyleDefault.Foreground(colorToTcell(color)).Bold(active)
+	col := r.X + 1
+	for _, ch := range runewidth.Truncate(title, r.W-2, "") {
+		screen.SetContent(col, r.Y, ch, nil, style)
+		col += runewidth.RuneWidth(ch)
+	}
+
+	inner := Rect{X: r.X + 1, Y: r.Y + 1, W: r.W - 2, H: r.H - 2}
+	if child := n.contents[id]; child != nil && inner.W > 0 && inner.H > 0 {
+		clip := &clipScreen{Screen: screen, viewX: inner.X, viewY: inner.Y, viewW: inner.W, viewH: inner.H, runtime: n.pm.ctx.runtime}
+		child.render(clip, inner.X, inner.Y, inner.W, inner.H)
+	}
+}
+
+func (n *panesNode) measureHeight(width int) int {
+	return 0
+}
+
+func (n *panesNode) measureWidth() int {
+	return 0
+}
+
+func (n *panesNode) getFlex() int {
+	return 1
+}
+
+func (n *panesNode) getHeight() int {
+	return 0
+}
@@ -0,0 +1,132 @@
+package rego
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// paneItem 是可聚焦的窗格内容，聚焦时显示 >
+func paneItem(c C) Node {
+	mark := " "
+	if UseFocus(c).IsFocused {
+		mark = ">"
+	}
+	return Text(mark + "item")
+}
+
+// paneKeys 先按前缀键再按 r
+func paneKeys(tr *Runtime, key tcell.Key, r rune) {
+	tr.DispatchKey(tcell.KeyCtrlB, 0, 0)
+	tr.DispatchKey(key, r, 0)
+	tr.Render()
+}
+
+func TestPanes_SplitZoomClose(t *testing.T) {
+	var pm *PaneManager
+	app := func(c C) Node {
+		pm = Panes(c.Child("panes"), paneItem)
+		return VStack(Text("header"), pm.View())
+	}
+
+	screen := newTestScreen(20, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 左右分割：新窗格成为活动窗格，焦点随之移动
+	paneKeys(tr, tcell.KeyRune, '%')
+	if got := pm.IDs(); !reflect.DeepEqual(got, []int{1, 2}) || pm.Active() != 2 {
+		t.Fatalf("after split: ids=%v active=%d", got, pm.Active())
+	}
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.Contains(lines[1], " 1 ") || !strings.Contains(lines[1], " 2 ") || !strings.Contains(lines[2], " item") || !strings.Contains(lines[2], ">item") {
+		t.Fatalf("unexpected screen:\n%s", getScreenContent(screen))
+	}
+	if strings.Index(lines[2], ">item") < 10 {
+		t.Errorf("expected focus in the right pane:\n%s", getScreenContent(screen))
+	}
+
+	// 方向键移到左侧窗格
+	paneKeys(tr, tcell.KeyLeft, 0)
+	if pm.Active() != 1 {
+		t.Fatalf("active after left = %d", pm.Active())
+	}
+	if lines = strings.Split(getScreenContent(screen), "\n"); strings.Index(lines[2], ">item") > 10 {
+		t.Errorf("expected focus in the left pane:\n%s", getScreenContent(screen))
+	}
+
+	// 放大：只显示活动窗格
+	paneKeys(tr, tcell.KeyRune, 'z')
+	if content := getScreenContent(screen); !pm.Zoomed() || !strings.Contains(content, " 1 [Z] ") || strings.Contains(content, " 2 ") {
+		t.Fatalf("unexpected zoomed screen:\n%s", content)
+	}
+	paneKeys(tr, tcell.KeyRune, 'z')
+
+	// 关闭活动窗格，另一个窗格占据整个区域
+	paneKeys(tr, tcell.KeyRune, 'x')
+	if got := pm.IDs(); !reflect.DeepEqual(got, []int{2}) || pm.Active() != 2 {
+		t.Fatalf("after close: ids=%v active=%d", got, pm.Active())
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, ">item") || strings.Contains(content, " 1 ") {
+		t.Errorf("unexpected screen after close:\n%s", content)
+	}
+	if pm.Close() {
+		t.Error("expected the last pane to stay open")
+	}
+}
+
+func TestPanes_DirectionalFocusAndSync(t *testing.T) {
+	var pm *PaneManager
+	app := func(c C) Node {
+		pm = Panes(c.Child("panes"), paneItem)
+		return pm.View()
+	}
+
+	screen := newTestScreen(20, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 1 | 2
+	//   | 3
+	pm.Split(SplitHorizontal, nil)
+	pm.Split(SplitVertical, nil)
+	tr.Render()
+	if pm.Active() != 3 {
+		t.Fatalf("active = %d, want 3", pm.Active())
+	}
+
+	for _, step := range []struct {
+		dir  PaneDirection
+		want int
+		ok   bool
+	}{
+		{PaneUp, 2, true},
+		{PaneUp, 2, false},
+		{PaneLeft, 1, true},
+		{PaneRight, 3, true}, // 与 1 的中线对齐的是 3
+		{PaneUp, 2, true},
+	} {
+		if ok := pm.Focus(step.dir); ok != step.ok || pm.Active() != step.want {
+			t.Fatalf("Focus(%d) = %v, active %d; want %v, %d", step.dir, ok, pm.Active(), step.ok, step.want)
+		}
+		tr.Render()
+	}
+
+	// Tab 把焦点移入其他窗格时，活动窗格跟随
+	tr.DispatchKey(tcell.KeyTab, 0, 0)
+	tr.Render()
+	if pm.Active() != 3 {
+		t.Errorf("active after tab = %d, want 3", pm.Active())
+	}
+
+	// 点击窗格使其成为活动窗格
+	mouseClick(tr, 2, 6)
+	if pm.Active() != 1 {
+		t.Errorf("active after click = %d, want 1", pm.Active())
+	}
+	if lines := strings.Split(getScreenContent(screen), "\n"); !strings.HasPrefix(lines[1], "│>item") {
+		t.Errorf("expected focus in the clicked pane:\n%s", getScreenContent(screen))
+	}
+}
@@ -0,0 +1,325 @@
+package rego
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// PatchPrompt - 按块确认补丁
+// =============================================================================
+
+// Hunk 补丁中的一个修改块
+type Hunk struct {
+	File   string   // 修改的文件（取自 +++ 行，去掉 b/ 前缀）
+	Header string   // 块头，如 "@@ -12,7 +12,9 @@ func main()"
+	Lines  []string // 块中以 ' '、'+'、'-' 或 '\' 开头的行
+
+	fileHeader []string // 块所属文件的头部（diff --git、index、---、+++ 等行）
+	file       int      // 块所属文件的序号
+}
+
+// ParsePatch 解析统一格式（unified diff）的补丁，如 git diff 的输出；块之外无法识别的行被忽略
+func ParsePatch(patch string) []Hunk {
+	var (
+		hunks    []Hunk
+		header   []string
+		file     string
+		fileNum  = -1
+		cur      *Hunk
+		oldLeft  int
+		newLeft  int
+		sawPlus  bool
+		inHeader bool
+	)
+	startFile := func(line string) {
+		header, file, sawPlus, inHeader = []string{line}, "", false, true
+		fileNum++
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		if cur != nil && (oldLeft > 0 || newLeft > 0 || strings.HasPrefix(line, `\`)) {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, " "), line == "":
+				// 一些编辑器会去掉空上下文行行首的空格
+				line = " " + strings.TrimPrefix(line, " ")
+				oldLeft--
+				newLeft--
+			case strings.HasPrefix(line, `\`):
+			default:
+				cur = nil // 行数与块头不符，放弃这个块剩余的部分
+				continue
+			}
+			cur.Lines = append(cur.Lines, line)
+			continue
+		}
+		cur = nil
+
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			startFile(line)
+		case strings.HasPrefix(line, "--- ") && (!inHeader || sawPlus):
+			startFile(line)
+		case strings.HasPrefix(line, "@@ "):
+			if fileNum < 0 {
+				startFile("--- a/" + file)
+				header = nil
+			}
+			oldN, newN, ok := parseHunkCounts(line)
+			if !ok {
+				continue
+			}
+			inHeader = false
+			hunks = append(hunks, Hunk{File: file, Header: line, fileHeader: header, file: fileNum})
+			cur = &hunks[len(hunks)-1]
+			oldLeft, newLeft = oldN, newN
+		case inHeader:
+			header = append(header, line)
+			if name, ok := strings.CutPrefix(line, "+++ "); ok {
+				sawPlus = true
+				name, _, _ = strings.Cut(name, "\t")
+				file = strings.TrimPrefix(name, "b/")
+			}
+		}
+	}
+	return hunks
+}
+
+// parseHunkRange 解析块头中的 "-a,b +c,d"，省略的行数为 1
+func parseHunkRange(header string) (oldStart, oldN, newStart, newN int, rest string, ok bool) {
+	fields := strings.SplitN(header, " ", 5)
+	if len(fields) < 4 || fields[0] != "@@" || fields[3] != "@@" {
+		return 0, 0, 0, 0, "", false
+	}
+	parse := func(s, sign string) (int, int, bool) {
+		s, found := strings.CutPrefix(s, sign)
+		if !found {
+			return 0, 0, false
+		}
+		start, count, hasCount := strings.Cut(s, ",")
+		a, err := strconv.Atoi(start)
+		if err != nil {
+			return 0, 0, false
+		}
+		n := 1
+		if hasCount {
+			if n, err = strconv.Atoi(count); err != nil {
+				return 0, 0, false
+			}
+		}
+		return a, n, true
+	}
+	oldStart, oldN, ok1 := parse(fields[1], "-")
+	newStart, newN, ok2 := parse(fields[2], "+")
+	if len(fields) == 5 {
+		rest = fields[4]
+	}
+	return oldStart, oldN, newStart, newN, rest, ok1 && ok2
+}
+
+func parseHunkCounts(header string) (int, int, bool) {
+	_, oldN, _, newN, _, ok := parseHunkRange(header)
+	return oldN, newN, ok
+}
+
+// PatchSelection 是 PatchPrompt 的结果
+type PatchSelection struct {
+	Hunks    []Hunk
+	Accepted []bool // 与 Hunks 一一对应
+}
+
+// AcceptedCount 返回接受的块数
+func (s PatchSelection) AcceptedCount() int {
+	n := 0
+	for _, ok := range s.Accepted {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// Patch 返回只包含接受的块的补丁，可以直接交给 git apply；拒绝的块之后的块头行号已相应调整
+func (s PatchSelection) Patch() string {
+	var b strings.Builder
+	file, delta, wrote := -1, 0, false
+	for i, h := range s.Hunks {
+		if h.file != file {
+			file, delta, wrote = h.file, 0, false
+		}
+		oldStart, oldN, newStart, newN, rest, ok := parseHunkRange(h.Header)
+		if !ok {
+			continue
+		}
+		if i >= len(s.Accepted) || !s.Accepted[i] {
+			delta -= newN - oldN
+			continue
+		}
+		if !wrote {
+			for _, line := range h.fileHeader {
+				b.WriteString(line + "\n")
+			}
+			wrote = true
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@", oldStart, oldN, newStart+delta, newN)
+		if rest != "" {
+			b.WriteString(" " + rest)
+		}
+		b.WriteString("\n")
+		for _, line := range h.Lines {
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// PatchPrompt 显示带颜色的补丁，逐块选择接受或拒绝，确认后把结果交给 onDone
+//
+// 适合编码助手一类的工具在应用修改前征求同意。默认所有块都被接受；
+// ↑/↓（或 k/j）在块之间移动，Space 切换当前块，y/n 接受/拒绝当前块并移到下一块，
+// a/d 全部接受/全部拒绝，Enter 确认，点击块头切换该块。补丁放在 Viewport 中，可以拖动或滚动查看。
+//
+// onDone 可以直接是 Bridge 的 Submit，把选择作为 Ask 的回答交还给 Core；也可以写入 channel：
+//
+//	if b.HasInteraction() {
+//		return rego.PatchPrompt(c.Child("patch"), b.Interaction(), b.Submit) // Bridge[S, string, rego.PatchSelection]
+//	}
+//	rego.PatchPrompt(c.Child("patch"), diff, func(s rego.PatchSelection) { ch <- s })
+func PatchPrompt(c C, patch string, onDone func(PatchSelection)) Node {
+	focus := UseFocus(c)
+	theme := UseTheme(c)
+	hunks := UseMemo(c, func() []Hunk { return ParsePatch(patch) }, patch)
+	accepted := Use(c, "accepted", []bool(nil))
+	cursor := Use(c, "cursor", 0)
+
+	if len(accepted.Val) != len(hunks) {
+		all := make([]bool, len(hunks))
+		for i := range all {
+			all[i] = true
+		}
+		accepted.Val = all // 补丁变化时重新全部接受，不需要额外一帧
+	}
+	cur := max(0, min(cursor.Val, len(hunks)-1))
+
+	// 各块在内容中的起始行
+	tops := make([]int, len(hunks)+1)
+	for i, h := range hunks {
+		tops[i+1] = tops[i] + 1 + len(h.Lines)
+	}
+
+	content := VStack(patchRows(hunks, accepted.Val, cur, focus.IsFocused, theme)...)
+	view, vc := useViewport(c.Child("view"), c.WrapContent(content), false)
+
+	moveTo := func(i int) {
+		if i < 0 || i >= len(hunks) {
+			return
+		}
+		cursor.Set(i)
+		vc.reveal(Rect{Y: tops[i], W: 1, H: tops[i+1] - tops[i]})
+		vc.reveal(Rect{Y: tops[i], W: 1, H: 1}) // 块比视口高时保证块头可见
+	}
+	set := func(i int, ok bool) {
+		next := append([]bool(nil), accepted.Val...)
+		next[i] = ok
+		accepted.Set(next)
+	}
+	setAll := func(ok bool) {
+		next := make([]bool, len(hunks))
+		for i := range next {
+			next[i] = ok
+		}
+		accepted.Set(next)
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused {
+			return
+		}
+		if key == KeyEnter {
+			if onDone != nil {
+				onDone(PatchSelection{Hunks: hunks, Accepted: append([]bool(nil), accepted.Val...)})
+			}
+			return
+		}
+		if len(hunks) == 0 {
+			return
+		}
+		switch {
+		case key == KeyUp || r == 'k':
+			moveTo(cur - 1)
+		case key == KeyDown || r == 'j':
+			moveTo(cur + 1)
+		case key == KeySpace || r == ' ':
+			set(cur, !accepted.Val[cur])
+		case r == 'y' || r == 'n':
+			set(cur, r == 'y')
+			moveTo(cur + 1)
+		case r == 'a' || r == 'd':
+			setAll(r == 'a')
+		}
+	})
+
+	UseMouse(c, func(ev MouseEvent) {
+		if ev.Type != MouseEventClick || ev.Button != MouseButtonLeft || !ev.Hit {
+			return
+		}
+		focus.Focus()
+		_, y, ok := ev.In(c.ContentRect())
+		if !ok {
+			return
+		}
+		for i := range hunks {
+			if y == tops[i] {
+				cursor.Set(i)
+				set(i, !accepted.Val[i])
+			}
+		}
+	})
+
+	n := PatchSelection{Accepted: accepted.Val}.AcceptedCount()
+	footer := Text(fmt.Sprintf("%d/%d hunks · space toggle · y/n accept/reject · a/d all/none · enter apply", n, len(hunks))).Color(theme.Muted)
+	return c.Wrap(VStack(view, footer))
+}
+
+// patchRows 返回补丁各行的节点：每个块一行块头，随后是带颜色的修改行
+func patchRows(hunks []Hunk, accepted []bool, cur int, focused bool, theme Theme) []Node {
+	var rows []Node
+	for i, h := range hunks {
+		mark, markColor := "✓", theme.Success
+		if !accepted[i] {
+			mark, markColor = "✗", theme.Error
+		}
+		head := Row(
+			Text(" "+mark+" ").Color(markColor).Bold(),
+			Text(h.File+" ").Bold(),
+			Text(h.Header).Color(theme.Primary),
+		)
+		if i == cur {
+			head.Background(If(focused, theme.Stripe, Default))
+			head = Row(Text(If(focused, "▌", " ")).Color(theme.Primary), head)
+		} else {
+			head = Row(Text(" "), head)
+		}
+		rows = append(rows, head)
+
+		for _, line := range h.Lines {
+			t := Text("  " + line)
+			switch {
+			case !accepted[i]:
+				t = t.Color(theme.Muted)
+			case strings.HasPrefix(line, "+"):
+				t = t.Color(theme.Success)
+			case strings.HasPrefix(line, "-"):
+				t = t.Color(theme.Error)
+			case strings.HasPrefix(line, `\`):
+				t = t.Color(theme.Muted)
+			}
+			rows = append(rows, t)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,97 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const testPatch = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@ package main
+ package main
++import "fmt"
+ 
+ func a() {}
+@@ -10,2 +11,3 @@ func b() {
+ 	x := 1
++	y := 2
+ }
+diff --git a/util.go b/util.go
+--- a/util.go
++++ b/util.go
+@@ -5,2 +5,1 @@
+-// old
+ func c() {}
+`
+
+func TestParsePatch(t *testing.T) {
+	hunks := ParsePatch(testPatch)
+	if len(hunks) != 3 {
+		t.Fatalf("got %d hunks, want 3", len(hunks))
+	}
+	if hunks[0].File != "main.go" || hunks[2].File != "util.go" {
+		t.Errorf("files = %q, %q", hunks[0].File, hunks[2].File)
+	}
+	if len(hunks[0].Lines) != 4 || hunks[0].Lines[2] != " " {
+		t.Errorf("hunk 0 lines = %q", hunks[0].Lines)
+	}
+}
+
+func TestPatchSelection_RecountsAfterRejectedHunk(t *testing.T) {
+	sel := PatchSelection{Hunks: ParsePatch(testPatch), Accepted: []bool{false, true, false}}
+	want := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -10,2 +10,3 @@ func b() {
+ 	x := 1
++	y := 2
+ }
+`
+	if got := sel.Patch(); got != want {
+		t.Errorf("Patch() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPatchPrompt_ToggleHunks(t *testing.T) {
+	var result *PatchSelection
+	app := func(c C) Node {
+		return PatchPrompt(c.Child("patch"), testPatch, func(s PatchSelection) { result = &s })
+	}
+	screen := newTestScreen(50, 16)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyRune, 'n', 0) // 拒绝第一块并移到第二块
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, 'j', 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, ' ', 0) // 拒绝第三块
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "1/3 hunks") {
+		t.Errorf("expected 1/3 hunks accepted:\n%s", content)
+	}
+
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	if result == nil {
+		t.Fatal("onDone not called")
+	}
+	if got := result.Accepted; got[0] || !got[1] || got[2] {
+		t.Errorf("accepted = %v, want [false true false]", got)
+	}
+}
+
+func TestPrompt_Patch(t *testing.T) {
+	withPromptInput(t, "dy\r")
+	sel, err := Prompt.Patch(testPatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sel.AcceptedCount() != 1 || !sel.Accepted[0] {
+		t.Errorf("accepted = %v, want only the first hunk", sel.Accepted)
+	}
+}
@@ -2,6 +2,7 @@ package rego
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
@@ -77,3 +78,16 @@ func BenchmarkTextWrapping(b *testing.B) {
 		measureNodeHeight(node, 40)
 	}
 }
+
+// BenchmarkLayoutOnlyParallel 与 BenchmarkLayoutOnly 相同，但开启并行测量
+func BenchmarkLayoutOnlyParallel(b *testing.B) {
+	root := LargeApp(&componentContext{
+		children: make(map[string]*componentContext),
+	})
+	bindMeasurePool(root, newMeasurePool(runtime.GOMAXPROCS(0)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		measureNodeHeight(root, 200)
+	}
+}
@@ -0,0 +1,119 @@
+package rego
+
+import (
+	"context"
+	"time"
+)
+
+// =============================================================================
+// UsePoll - 周期性获取数据
+// =============================================================================
+
+// PollState 是 UsePoll 返回的轮询状态
+type PollState[T any] struct {
+	Data        T         // 最近一次成功获取的数据，获取失败时保留旧值
+	Err         error     // 最近一次获取的错误，成功后清空
+	LastUpdated time.Time // 最近一次成功获取的时间
+	Loading     bool      // 是否正在获取
+	Refresh     func()    // 立即重新获取
+}
+
+// pollResult 是 PollState 中存入状态的部分（不含函数，便于比较）
+type pollResult[T any] struct {
+	Data        T
+	Err         error
+	LastUpdated time.Time
+	Loading     bool
+}
+
+// pollControl 记录轮询的运行情况，只在 UI 循环中读写
+type pollControl struct {
+	inFlight bool   // 是否有请求尚未返回（不会并发获取）
+	stale    bool   // 隐藏期间错过了轮询，重新显示时立即获取
+	start    func() // 发起一次获取
+}
+
+// UsePoll 挂载时立即调用 fetch，之后每隔 interval 调用一次；结果在 UI 循环中写回并触发刷新
+//
+// 组件在某一帧没有被渲染（例如切换到了其他页面）时暂停轮询，再次渲染时立即补一次获取。
+// interval 变化或组件被释放（见 UseEffect）时传给 fetch 的 ctx 会被取消。
+func UsePoll[T any](c C, interval time.Duration, fetch func(ctx context.Context) (T, error)) PollState[T] {
+	ctx := c.(*componentContext)
+	state := Use(c, "poll", pollResult[T]{Loading: true})
+	fetchRef := UseRef(c, fetch)
+	fetchRef.Current = fetch // 始终使用最新一次渲染传入的 fetch
+	ctl := UseRef(c, &pollControl{})
+
+	run := func(fn func()) {
+		if ctx.runtime != nil {
+			ctx.runtime.post(fn)
+		} else {
+			fn()
+		}
+	}
+	visible := func() bool {
+		return ctx.runtime == nil || ctx.seenFrame == ctx.runtime.frame
+	}
+
+	UseEffect(c, func() func() {
+		base, cancel := context.WithCancel(context.Background())
+
+		ctl.Current.start = func() {
+			if ctl.Current.inFlight || base.Err() != nil {
+				return
+			}
+			ctl.Current.inFlight = true
+			prev := state.Val
+			state.Set(pollResult[T]{Data: prev.Data, Err: prev.Err, LastUpdated: prev.LastUpdated, Loading: true})
+
+			fetch := fetchRef.Current
+			go func() {
+				data, err := fetch(base)
+				run(func() {
+					ctl.Current.inFlight = false
+					if base.Err() != nil {
+						return
+					}
+					prev := state.Val
+					if err != nil {
+						state.Set(pollResult[T]{Data: prev.Data, Err: err, LastUpdated: prev.LastUpdated})
+					} else {
+						state.Set(pollResult[T]{Data: data, LastUpdated: ctx.clock().Now()})
+					}
+				})
+			}()
+		}
+		ctl.Current.start()
+
+		stop := ctx.clock().Every(interval, func() {
+			run(func() {
+				if visible() {
+					ctl.Current.start()
+				} else {
+					ctl.Current.stale = true
+				}
+			})
+		})
+
+		return func() {
+			cancel()
+			stop()
+		}
+	}, interval)
+
+	// 本帧被渲染说明重新可见，补上隐藏期间错过的获取
+	if ctl.Current.stale {
+		ctl.Current.stale = false
+		ctl.Current.start()
+	}
+
+	return PollState[T]{
+		Data:        state.Val.Data,
+		Err:         state.Val.Err,
+		LastUpdated: state.Val.LastUpdated,
+		Loading:     state.Val.Loading,
+		Refresh: func() {
+			run(func() { ctl.Current.start() })
+		},
+	}
+}
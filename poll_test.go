@@ -0,0 +1,120 @@
+package rego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// renderUntil 反复渲染直到屏幕内容包含 want
+func renderUntil(t *testing.T, tr *Runtime, screen tcell.SimulationScreen, want string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		tr.Render()
+		if strings.Contains(getScreenContent(screen), want) {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected %q on screen, got:\n%s", want, getScreenContent(screen))
+}
+
+func TestUsePoll_FetchesPeriodically(t *testing.T) {
+	var calls atomic.Int32
+	fail := atomic.Bool{}
+	app := func(c C) Node {
+		poll := UsePoll(c, 20*time.Millisecond, func(ctx context.Context) (int, error) {
+			n := int(calls.Add(1))
+			if fail.Load() {
+				return 0, errors.New("boom")
+			}
+			return n, nil
+		})
+		msg := fmt.Sprintf("data=%d", poll.Data)
+		if poll.Err != nil {
+			msg += " err=" + poll.Err.Error()
+		}
+		return Text(msg)
+	}
+
+	screen := newTestScreen(30, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	renderUntil(t, tr, screen, "data=1")
+
+	// 时间未到不会再次获取
+	tr.AdvanceTime(10 * time.Millisecond)
+	tr.Render()
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 fetch before interval elapsed, got %d", got)
+	}
+	tr.AdvanceTime(10 * time.Millisecond)
+	renderUntil(t, tr, screen, "data=2")
+
+	// 出错时保留旧数据
+	fail.Store(true)
+	tr.AdvanceTime(20 * time.Millisecond)
+	renderUntil(t, tr, screen, "err=boom")
+	if strings.Contains(getScreenContent(screen), "data=0") {
+		t.Errorf("expected previous data kept on error, got %q", getScreenContent(screen))
+	}
+}
+
+func TestUsePoll_PausesWhenHidden(t *testing.T) {
+	var calls atomic.Int32
+	show := true
+	panel := func(c C) Node {
+		poll := UsePoll(c, 10*time.Millisecond, func(ctx context.Context) (int, error) {
+			return int(calls.Add(1)), nil
+		})
+		return Text(fmt.Sprintf("data=%d", poll.Data))
+	}
+	app := func(c C) Node {
+		if !show {
+			return Text("hidden")
+		}
+		return panel(c.Child("panel"))
+	}
+
+	screen := newTestScreen(30, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	renderUntil(t, tr, screen, "data=1")
+
+	show = false
+	tr.Render()
+	for i := 0; i < 5; i++ {
+		tr.AdvanceTime(10 * time.Millisecond)
+		tr.Render() // 执行隐藏期间的轮询任务
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected polling paused while hidden, got %d fetches", got)
+	}
+
+	// 重新显示时立即获取
+	show = true
+	renderUntil(t, tr, screen, "data=2")
+}
+
+func TestUsePoll_Refresh(t *testing.T) {
+	var calls atomic.Int32
+	var refresh func()
+	app := func(c C) Node {
+		poll := UsePoll(c, time.Hour, func(ctx context.Context) (int, error) {
+			return int(calls.Add(1)), nil
+		})
+		refresh = poll.Refresh
+		return Text(fmt.Sprintf("data=%d", poll.Data))
+	}
+
+	screen := newTestScreen(30, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	renderUntil(t, tr, screen, "data=1")
+	refresh()
+	renderUntil(t, tr, screen, "data=2")
+}
@@ -0,0 +1,166 @@
+package rego
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// Prompt - 无需编写 App 的一次性交互
+// =============================================================================
+
+// ErrPromptCancelled 用户按 Esc 或 Ctrl+C 取消了输入
+var ErrPromptCancelled = errors.New("rego: prompt cancelled")
+
+// newPromptTty 打开 Prompt 使用的终端（测试中替换为模拟终端）
+var newPromptTty = tcell.NewDevTty
+
+type promptHelpers struct{}
+
+// Prompt 提供一次性的交互式提问，适合在脚本中直接使用：
+//
+//	ok, err := rego.Prompt.Confirm("删除 3 个文件？")
+//	name, err := rego.Prompt.Input("名称：")
+//	i, err := rego.Prompt.Select("选择区域", []string{"cn", "us", "eu"})
+//	sel, err := rego.Prompt.Patch(diff)
+//
+// 每次调用启动一个独立的运行时，在光标下方原地绘制，只占用提问需要的行数，不切换到备用屏幕；
+// 得到回答后还原终端，最后一帧（包括回答）留在滚动历史中，之后的输出从下一行开始。
+var Prompt promptHelpers
+
+// Confirm 询问是/否，y/n 直接回答，←/→ 切换后 Enter 确认
+func (promptHelpers) Confirm(message string) (bool, error) {
+	var answer bool
+	err := runPrompt(func(c C, done func()) Node {
+		yes := Use(c, "yes", true)
+		UseKey(c, func(key Key, r rune) {
+			switch {
+			case r == 'y' || r == 'Y' || r == 'n' || r == 'N':
+				answer = r == 'y' || r == 'Y'
+				yes.Set(answer) // 留在滚动历史中的最后一帧显示回答
+				done()
+			case key == KeyLeft || key == KeyRight:
+				yes.Set(!yes.Val)
+			case key == KeyEnter:
+				answer = yes.Val
+				done()
+			}
+		})
+		option := func(label string, on bool) Node {
+			t := Text(" " + label + " ")
+			if on {
+				t = t.Background(Cyan).Color(Black).Bold()
+			}
+			return t
+		}
+		return c.Wrap(HStack(
+			Text(message+" ").Bold(),
+			option("是", yes.Val),
+			Text(" "),
+			option("否", !yes.Val),
+		))
+	})
+	return answer, err
+}
+
+// Input 读取一行文本，Enter 提交
+func (promptHelpers) Input(label string) (string, error) {
+	var answer string
+	err := runPrompt(func(c C, done func()) Node {
+		return c.Wrap(TextInput(c.Child("input"), TextInputProps{
+			Label: label,
+			OnSubmit: func(v string) {
+				answer = v
+				done()
+			},
+		}))
+	})
+	return answer, err
+}
+
+// Select 从 options 中选择一项，↑/↓ 移动，Enter 确认，返回选中项的下标
+func (promptHelpers) Select(title string, options []string) (int, error) {
+	answer := -1
+	err := runPrompt(func(c C, done func()) Node {
+		cursor := Use(c, "cursor", 0)
+		UseKey(c, func(key Key, r rune) {
+			switch key {
+			case KeyUp:
+				if cursor.Val > 0 {
+					cursor.Set(cursor.Val - 1)
+				}
+			case KeyDown:
+				if cursor.Val < len(options)-1 {
+					cursor.Set(cursor.Val + 1)
+				}
+			case KeyEnter:
+				if len(options) > 0 {
+					answer = cursor.Val
+					done()
+				}
+			}
+		})
+		rows := []Node{Text(title).Bold()}
+		for i, opt := range options {
+			if i == cursor.Val {
+				rows = append(rows, Text("> "+opt).Color(Cyan).Bold())
+			} else {
+				rows = append(rows, Text("  "+opt))
+			}
+		}
+		return c.Wrap(VStack(rows...))
+	})
+	return answer, err
+}
+
+// Patch 显示补丁并逐块确认（见 PatchPrompt），Enter 确认后返回选择结果
+func (promptHelpers) Patch(patch string) (PatchSelection, error) {
+	var answer PatchSelection
+	err := runPrompt(func(c C, done func()) Node {
+		// Viewport 会占满剩余高度，按补丁的行数（加上底部说明）限定高度，超出终端高度时在其中滚动
+		rows := 1
+		for _, h := range ParsePatch(patch) {
+			rows += 1 + len(h.Lines)
+		}
+		return c.Wrap(Box(PatchPrompt(c.Child("patch"), patch, func(s PatchSelection) {
+			answer = s
+			done()
+		})).Height(rows))
+	})
+	return answer, err
+}
+
+// runPrompt 在光标下方运行一个最小的运行时，绘制区域的高度随 root 的节点变化；
+// root 调用 done 表示得到回答，再绘制一帧后退出；未调用 done 即退出（Esc / Ctrl+C）时返回 ErrPromptCancelled
+func runPrompt(root func(c C, done func()) Node) error {
+	tty, err := newPromptTty()
+	if err != nil {
+		return err
+	}
+	screen := newInlineScreen(tty)
+
+	answered := false
+	var r *Runtime
+	r = newRuntime(func(c C) Node {
+		UseKey(c, func(key Key, _ rune) {
+			if key == KeyEsc {
+				r.quit()
+			}
+		})
+		node := root(c.Child("prompt"), func() {
+			answered = true
+			r.post(r.quit) // 退出前再绘制一帧，让回答显示在最后一帧中
+		})
+		w, _ := screen.Size()
+		screen.fit(measureNodeHeight(node, w))
+		return node
+	})
+	if err := r.runOn(screen); err != nil {
+		return err
+	}
+	if !answered {
+		return ErrPromptCancelled
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package rego
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// scriptedTty 模拟终端：读取时一次返回预先给定的输入，记录写出的内容
+type scriptedTty struct {
+	in   chan []byte
+	stop chan struct{}
+	out  strings.Builder
+	once sync.Once
+}
+
+func (t *scriptedTty) Start() error { return nil }
+func (t *scriptedTty) Drain() error { return nil }
+func (t *scriptedTty) Close() error { return nil }
+func (t *scriptedTty) Stop() error {
+	t.once.Do(func() { close(t.stop) })
+	return nil
+}
+func (t *scriptedTty) NotifyResize(func()) {}
+func (t *scriptedTty) WindowSize() (tcell.WindowSize, error) {
+	return tcell.WindowSize{Width: 40, Height: 10}, nil
+}
+func (t *scriptedTty) Read(b []byte) (int, error) {
+	select {
+	case data := <-t.in:
+		return copy(b, data), nil
+	case <-t.stop:
+		return 0, io.EOF
+	}
+}
+func (t *scriptedTty) Write(b []byte) (int, error) { return t.out.Write(b) }
+
+func newScriptedTty(input string) *scriptedTty {
+	t := &scriptedTty{in: make(chan []byte, 1), stop: make(chan struct{})}
+	t.in <- []byte(input)
+	return t
+}
+
+// withPromptInput 让下一次 Prompt 从模拟终端读取 input（终端的原始字节，如 "\x1b[B" 表示 ↓）
+func withPromptInput(t *testing.T, input string) *scriptedTty {
+	t.Helper()
+	tty := newScriptedTty(input)
+	orig := newPromptTty
+	newPromptTty = func() (tcell.Tty, error) { return tty, nil }
+	t.Cleanup(func() { newPromptTty = orig })
+	return tty
+}
+
+func TestPrompt_Confirm(t *testing.T) {
+	withPromptInput(t, "\x1b[C\r")
+	ok, err := Prompt.Confirm("Delete?")
+	if err != nil || ok {
+		t.Errorf("Confirm = %v, %v; want false, nil", ok, err)
+	}
+
+	withPromptInput(t, "y")
+	ok, err = Prompt.Confirm("Delete?")
+	if err != nil || !ok {
+		t.Errorf("Confirm = %v, %v; want true, nil", ok, err)
+	}
+}
+
+func TestPrompt_Input(t *testing.T) {
+	withPromptInput(t, "bob\r")
+	name, err := Prompt.Input("Name:")
+	if err != nil || name != "bob" {
+		t.Errorf("Input = %q, %v; want \"bob\", nil", name, err)
+	}
+}
+
+func TestPrompt_Select(t *testing.T) {
+	withPromptInput(t, "\x1b[B\x1b[B\r")
+	i, err := Prompt.Select("Region", []string{"cn", "us", "eu"})
+	if err != nil || i != 2 {
+		t.Errorf("Select = %d, %v; want 2, nil", i, err)
+	}
+}
+
+func TestPrompt_Cancel(t *testing.T) {
+	withPromptInput(t, "\x1b")
+	_, err := Prompt.Select("Region", []string{"cn"})
+	if !errors.Is(err, ErrPromptCancelled) {
+		t.Errorf("expected ErrPromptCancelled, got %v", err)
+	}
+}
+
+func TestPrompt_RendersInline(t *testing.T) {
+	tty := withPromptInput(t, "\x1b[B\r")
+	if _, err := Prompt.Select("Region", []string{"cn", "us", "eu"}); err != nil {
+		t.Fatal(err)
+	}
+	out := tty.out.String()
+	if strings.Contains(out, "\x1b[?1049h") || strings.Contains(out, "\x1b[2J") {
+		t.Errorf("prompt took over the screen: %q", out)
+	}
+	if !strings.HasSuffix(out, "\r\n\x1b[0m\x1b[?25h") {
+		t.Errorf("cursor was not left on the line after the prompt: %q", out)
+	}
+
+	// 最后一帧只占用提问需要的 4 行，并显示选中的回答
+	last := out[strings.LastIndex(out, "\x1b[?25l"):]
+	last = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`).ReplaceAllString(last, "")
+	want := []string{"Region", "  cn", "> us", "  eu", ""}
+	if got := strings.Split(strings.TrimPrefix(last, "\r"), "\r\n"); !slices.Equal(got, want) {
+		t.Errorf("last frame = %q, want %q", got, want)
+	}
+}
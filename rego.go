@@ -1,7 +1,13 @@
 // Package rego 提供 React Hooks 风格的 CLI/TUI 开发体验
 package rego
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // Run 启动应用
 func Run(root func(C) Node) error {
@@ -9,10 +15,80 @@ func Run(root func(C) Node) error {
 	return runtime.Run()
 }
 
-// NewTestRuntime 创建一个用于测试的运行时
-func NewTestRuntime(root func(C) Node, screen tcell.Screen) *Runtime {
+// Options 运行时选项
+type Options struct {
+	// ColorMode 颜色模式，默认 ColorAuto（遵循 NO_COLOR 环境变量）
+	ColorMode ColorMode
+
+	// CursorStyle 默认光标样式，组件可通过 UseCursorStyle 在获得焦点时覆盖
+	CursorStyle CursorStyle
+
+	// Debug 开启后在 DebugAddr（默认 DefaultDebugAddr）上提供 /debug/pprof 与 /debug/vars，
+	// 运行时指标以 "rego" 为名发布在 expvar 中
+	Debug     bool
+	DebugAddr string
+
+	// ParallelMeasure 开启后，子节点较多的 VStack/HStack 由工作池（GOMAXPROCS 个协程）并行测量子节点高度，
+	// 适合上千节点的大型界面；自定义节点的 measureHeight 需要能被并发调用
+	ParallelMeasure bool
+
+	// OnQuitRequest 在按下 Ctrl+C 或组件调用 Quit 时调用，返回 false 取消这次退出。
+	// 例如有未保存的修改时返回 false 并弹出确认框，确认后标记为可以退出再调用 Quit。
+	// 组件 panic 后显示错误界面时不调用，Ctrl+C 总是退出
+	OnQuitRequest func() bool
+
+	// JankThreshold 大于 0 时，耗时超过该值的帧以 LogWarn 写入日志（F10 调试控制台中可见），
+	// 并附上自身耗时最长的几个组件路径，用于找出组件函数或绘制中意外执行的同步操作；
+	// 通常设为 DefaultJankThreshold
+	JankThreshold time.Duration
+
+	// Clock 组件获取时间和调度定时器所用的时钟，默认为系统时钟；
+	// 测试中可传入 NewFakeClock，定时器只在 AdvanceTime 时触发
+	Clock Clock
+
+	// Session 开启会话持久化：通过 UsePersist 声明的状态在退出时写入 Session.Path，下次启动时按组件路径恢复
+	Session *SessionOptions
+}
+
+// DefaultJankThreshold 约为 30 FPS 的一帧，超过时用户能感觉到卡顿
+const DefaultJankThreshold = 33 * time.Millisecond
+
+// RunWithOptions 以指定选项启动应用
+func RunWithOptions(root func(C) Node, opts Options) error {
+	runtime := newRuntime(root)
+	runtime.apply(opts)
+	return runtime.Run()
+}
+
+// apply 应用运行时选项
+func (r *Runtime) apply(opts Options) {
+	r.colorMode = opts.ColorMode.resolve()
+	r.cursorStyle = opts.CursorStyle
+	r.debug = opts.Debug
+	r.debugAddr = opts.DebugAddr
+	r.onQuitRequest = opts.OnQuitRequest
+	r.jankThreshold = opts.JankThreshold
+	if opts.Clock != nil {
+		r.clock = opts.Clock
+	}
+	if opts.Session != nil {
+		r.session = loadSession(*opts.Session)
+	}
+	if opts.ParallelMeasure {
+		r.measurePool = newMeasurePool(runtime.GOMAXPROCS(0))
+	}
+}
+
+// NewTestRuntime 创建一个用于测试的运行时，可选传入一个 Options
+//
+// 默认使用系统时钟；需要控制 Spinner、UseInterval 等定时器时传入 Options{Clock: NewFakeClock(...)}，
+// 再用 AdvanceTime 推进时间。
+func NewTestRuntime(root func(C) Node, screen tcell.Screen, opts ...Options) *Runtime {
 	r := newRuntime(root)
 	r.screen = screen
+	for _, o := range opts {
+		r.apply(o)
+	}
 	r.rootContext = newComponentContext("root", nil, r)
 	return r
 }
@@ -27,3 +103,37 @@ func (r *Runtime) DispatchKey(key tcell.Key, r_rune rune, mod tcell.ModMask) {
 	ev := tcell.NewEventKey(key, r_rune, mod)
 	r.handleEvent(ev)
 }
+
+// RenderCount 返回组件累计执行的次数（用于测试），path 为组件路径，
+// 即各级 c.Child 的 key 以 "/" 连接，如 "list/item[3]"（可带或不带开头的 "root/"）；
+// 组件不存在时返回 0
+func (r *Runtime) RenderCount(path string) int {
+	if ctx := r.findContext(path); ctx != nil {
+		return ctx.renders
+	}
+	return 0
+}
+
+// findContext 按路径查找组件上下文
+func (r *Runtime) findContext(path string) *componentContext {
+	keys := strings.Split(strings.Trim(path, "/"), "/")
+	if len(keys) > 0 && keys[0] == "root" {
+		keys = keys[1:]
+	}
+	ctx := r.rootContext
+	for _, key := range keys {
+		if ctx == nil {
+			return nil
+		}
+		if key != "" {
+			ctx = ctx.children[key]
+		}
+	}
+	return ctx
+}
+
+// DispatchMouse 分发鼠标事件（用于测试）
+func (r *Runtime) DispatchMouse(x, y int, buttons tcell.ButtonMask, mod tcell.ModMask) {
+	ev := tcell.NewEventMouse(x, y, buttons, mod)
+	r.handleEvent(ev)
+}
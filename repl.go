@@ -0,0 +1,352 @@
+package rego
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Repl - 交互式命令行
+// =============================================================================
+
+// replMenuRows 补全菜单最多显示的行数
+const replMenuRows = 6
+
+// ReplProps 是 Repl 的属性
+type ReplProps struct {
+	Prompt       string // 提示符，默认 "> "
+	Continuation string // 续行的提示符，默认为与 Prompt 等宽的 ". "
+	// OnSubmit 提交一行（或多行）输入，写入 out 的内容显示在这条输入之后；
+	// out 可以交给其他协程继续写入，适合流式输出
+	OnSubmit func(input string, out io.Writer)
+	// Completer 返回光标前内容的补全候选，选中的候选替换光标前的最后一个词
+	Completer func(input string) []string
+	// Highlighter 返回一行输入中每个字符的样式，用于语法高亮
+	Highlighter func(line string) []Style
+	// Incomplete 输入未完成时 Enter 插入换行而不是提交；默认光标前以 \ 结尾时去掉 \ 并续行
+	Incomplete func(input string) bool
+}
+
+// replEntry 记录中的一条输入及其输出
+type replEntry struct {
+	input string
+	out   *Stream
+}
+
+// replEditor 是 Repl 的编辑状态，只在 UI 循环中读写
+type replEditor struct {
+	ctx        *componentContext
+	input      []rune
+	cursor     int
+	transcript []replEntry
+
+	history []string
+	histIdx int    // 正在浏览的历史记录，等于 len(history) 表示正在编辑的新输入
+	draft   string // 浏览历史前正在编辑的输入
+
+	completions []string // 补全菜单中的候选，为空表示菜单未打开
+	selected    int
+}
+
+// Repl 创建一个交互式命令行：输入记录、历史、补全、多行续行与滚动的输出记录
+//
+// 快捷键（聚焦时）：
+//
+//	Enter      提交；补全菜单打开时选中当前候选
+//	Tab        补全（唯一候选直接补全，多个候选时打开菜单并在其中循环）
+//	↑/↓        浏览历史；补全菜单打开时移动选中项
+//	Esc        关闭补全菜单
+//	Ctrl+L     清空输出记录
+//
+//	rego.Repl(c.Child("repl"), rego.ReplProps{
+//		Prompt: "sql> ",
+//		OnSubmit: func(input string, out io.Writer) {
+//			go runQuery(input, out)
+//		},
+//		Incomplete: func(input string) bool { return !strings.HasSuffix(strings.TrimSpace(input), ";") },
+//	})
+func Repl(c C, props ReplProps) Node {
+	ctx := c.(*componentContext)
+	focus := UseFocus(c)
+	theme := UseTheme(c)
+	UseCursorStyle(c, CursorStyle{Shape: CursorBar, Blink: true})
+	ed := UseRef(c, &replEditor{}).Current
+	ed.ctx = ctx
+	ctx.capturesTab = props.Completer != nil
+	ctx.acceptsText = true
+
+	prompt := props.Prompt
+	if prompt == "" {
+		prompt = "> "
+	}
+	continuation := props.Continuation
+	if continuation == "" {
+		w := runewidth.StringWidth(prompt)
+		continuation = strings.Repeat(".", max(w-1, 0)) + strings.Repeat(" ", min(w, 1))
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused {
+			return
+		}
+		if ed.handleKey(key, r, props) {
+			ctx.Refresh()
+		}
+	})
+
+	lineNode := func(line, lead string, cursor int) Node {
+		return replLine(c, lead, line, cursor, props.Highlighter, theme)
+	}
+
+	var rows []Node
+	for _, e := range ed.transcript {
+		for i, line := range strings.Split(e.input, "\n") {
+			rows = append(rows, lineNode(line, If(i == 0, prompt, continuation), -1))
+		}
+		e.out.rendered()
+		if out := strings.TrimSuffix(e.out.String(), "\n"); out != "" {
+			rows = append(rows, Text(out).Wrap(true))
+		}
+	}
+
+	// 正在编辑的输入，光标所在行显示光标
+	offset := 0
+	for i, line := range strings.Split(string(ed.input), "\n") {
+		n := len([]rune(line))
+		cursor := -1
+		if focus.IsFocused && ed.cursor >= offset && ed.cursor <= offset+n {
+			cursor = ed.cursor - offset
+		}
+		rows = append(rows, lineNode(line, If(i == 0, prompt, continuation), cursor))
+		offset += n + 1
+	}
+	rows = append(rows, ed.menu(theme, runewidth.StringWidth(prompt))...)
+
+	return c.Wrap(TailBox(c.Child("scroll"), VStack(rows...)).Flex(1))
+}
+
+// replLine 一行输入：提示符与按 Highlighter 着色的内容，cursor >= 0 时在该位置显示光标
+func replLine(c C, lead, line string, cursor int, highlight func(string) []Style, theme Theme) Node {
+	parts := []Node{Text(lead).Color(theme.Primary).Bold()}
+	runes := []rune(line)
+	var styles []Style
+	if highlight != nil {
+		styles = highlight(line)
+	}
+	styleAt := func(i int) Style {
+		if i < len(styles) {
+			return styles[i]
+		}
+		return defaultStyle()
+	}
+
+	// 相同样式的连续字符合并为一个 Text，光标处断开
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && i != cursor && styleAt(i) == styleAt(start) {
+			continue
+		}
+		if start == cursor {
+			parts = append(parts, Cursor(c))
+		}
+		parts = append(parts, Text(string(runes[start:i])).Apply(styleAt(start)))
+		start = i
+	}
+	if cursor == len(runes) {
+		parts = append(parts, Cursor(c))
+	}
+	return HStack(parts...)
+}
+
+// menu 补全菜单，与输入内容左对齐
+func (ed *replEditor) menu(theme Theme, indent int) []Node {
+	if len(ed.completions) == 0 {
+		return nil
+	}
+	first := min(max(ed.selected-replMenuRows+1, 0), max(len(ed.completions)-replMenuRows, 0))
+	var rows []Node
+	for i := first; i < len(ed.completions) && i < first+replMenuRows; i++ {
+		item := Text(" " + ed.completions[i] + " ")
+		if i == ed.selected {
+			item = item.Background(theme.Primary).Color(theme.OnColor)
+		} else {
+			item = item.Color(theme.Muted)
+		}
+		rows = append(rows, HStack(Text(strings.Repeat(" ", indent)), item))
+	}
+	return rows
+}
+
+// handleKey 处理一次按键，返回是否需要重绘
+func (ed *replEditor) handleKey(key Key, r rune, props ReplProps) bool {
+	if len(ed.completions) > 0 {
+		switch key {
+		case KeyTab, KeyDown:
+			ed.selected = (ed.selected + 1) % len(ed.completions)
+			return true
+		case KeyUp:
+			ed.selected = (ed.selected + len(ed.completions) - 1) % len(ed.completions)
+			return true
+		case KeyEnter:
+			ed.complete(ed.completions[ed.selected])
+			ed.completions = nil
+			return true
+		case KeyEsc:
+			ed.completions = nil
+			return true
+		}
+		ed.completions = nil // 其他按键关闭菜单后照常处理
+	}
+
+	switch key {
+	case KeyEnter:
+		ed.enter(props)
+	case KeyTab:
+		if props.Completer != nil {
+			ed.openCompletions(props.Completer(string(ed.input[:ed.cursor])))
+		}
+	case KeyBackspace:
+		if ed.cursor > 0 {
+			prev := prevGrapheme(ed.input, ed.cursor)
+			ed.input = append(ed.input[:prev], ed.input[ed.cursor:]...)
+			ed.cursor = prev
+		}
+	case KeyDelete:
+		if ed.cursor < len(ed.input) {
+			next := nextGrapheme(ed.input, ed.cursor)
+			ed.input = append(ed.input[:ed.cursor], ed.input[next:]...)
+		}
+	case KeyLeft:
+		if ed.cursor > 0 {
+			ed.cursor = prevGrapheme(ed.input, ed.cursor)
+		}
+	case KeyRight:
+		if ed.cursor < len(ed.input) {
+			ed.cursor = nextGrapheme(ed.input, ed.cursor)
+		}
+	case KeyHome:
+		for ed.cursor > 0 && ed.input[ed.cursor-1] != '\n' {
+			ed.cursor--
+		}
+	case KeyEnd:
+		for ed.cursor < len(ed.input) && ed.input[ed.cursor] != '\n' {
+			ed.cursor++
+		}
+	case KeyUp:
+		if !strings.ContainsRune(string(ed.input[:ed.cursor]), '\n') {
+			ed.browse(-1)
+		} else {
+			ed.cursor = snapToGrapheme(ed.input, findPosAbove(ed.input, ed.cursor))
+		}
+	case KeyDown:
+		if !strings.ContainsRune(string(ed.input[ed.cursor:]), '\n') {
+			ed.browse(1)
+		} else {
+			ed.cursor = snapToGrapheme(ed.input, findPosBelow(ed.input, ed.cursor))
+		}
+	case KeyCtrlL:
+		ed.transcript = nil
+	default:
+		if r == 0 {
+			return false
+		}
+		ed.insert(string(r))
+	}
+	return true
+}
+
+func (ed *replEditor) insert(s string) {
+	ins := []rune(s)
+	input := make([]rune, 0, len(ed.input)+len(ins))
+	input = append(input, ed.input[:ed.cursor]...)
+	input = append(input, ins...)
+	ed.input = append(input, ed.input[ed.cursor:]...)
+	ed.cursor += len(ins)
+}
+
+// enter 输入未完成时续行，否则提交
+func (ed *replEditor) enter(props ReplProps) {
+	input := string(ed.input)
+	if props.Incomplete != nil {
+		if props.Incomplete(input) {
+			ed.insert("\n")
+			return
+		}
+	} else if ed.cursor > 0 && ed.input[ed.cursor-1] == '\\' {
+		ed.input = append(ed.input[:ed.cursor-1], ed.input[ed.cursor:]...)
+		ed.cursor--
+		ed.insert("\n")
+		return
+	}
+
+	entry := replEntry{input: input, out: &Stream{ctx: ed.ctx}}
+	ed.transcript = append(ed.transcript, entry)
+	if strings.TrimSpace(input) != "" && (len(ed.history) == 0 || ed.history[len(ed.history)-1] != input) {
+		ed.history = append(ed.history, input)
+	}
+	ed.histIdx = len(ed.history)
+	ed.input, ed.cursor, ed.draft = nil, 0, ""
+	if props.OnSubmit != nil {
+		props.OnSubmit(input, entry.out)
+	}
+}
+
+// browse 在历史记录中移动 delta 条
+func (ed *replEditor) browse(delta int) {
+	next := ed.histIdx + delta
+	if next < 0 || next > len(ed.history) {
+		return
+	}
+	if ed.histIdx == len(ed.history) {
+		ed.draft = string(ed.input)
+	}
+	ed.histIdx = next
+	if next == len(ed.history) {
+		ed.input = []rune(ed.draft)
+	} else {
+		ed.input = []rune(ed.history[next])
+	}
+	ed.cursor = len(ed.input)
+}
+
+// word 返回光标前最后一个词的起始位置
+func (ed *replEditor) word() int {
+	start := ed.cursor
+	for start > 0 && !strings.ContainsRune(" \t\n", ed.input[start-1]) {
+		start--
+	}
+	return start
+}
+
+// complete 用 s 替换光标前的最后一个词
+func (ed *replEditor) complete(s string) {
+	start := ed.word()
+	ed.input = append(ed.input[:start:start], ed.input[ed.cursor:]...)
+	ed.cursor = start
+	ed.insert(s)
+}
+
+// openCompletions 只有一个候选时直接补全；多个候选时先补全公共前缀，再打开菜单
+func (ed *replEditor) openCompletions(cands []string) {
+	switch len(cands) {
+	case 0:
+		return
+	case 1:
+		ed.complete(cands[0])
+		return
+	}
+	prefix := cands[0]
+	for _, s := range cands[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	if len([]rune(prefix)) > ed.cursor-ed.word() {
+		ed.complete(prefix)
+	}
+	ed.completions, ed.selected = cands, 0
+}
@@ -0,0 +1,149 @@
+package rego
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func replType(tr *Runtime, s string) {
+	for _, r := range s {
+		tr.DispatchKey(tcell.KeyRune, r, 0)
+	}
+	tr.Render()
+}
+
+func TestRepl_SubmitHistoryAndContinuation(t *testing.T) {
+	var submitted []string
+	app := func(c C) Node {
+		return Repl(c.Child("repl"), ReplProps{
+			OnSubmit: func(input string, out io.Writer) {
+				submitted = append(submitted, input)
+				fmt.Fprintf(out, "echo: %s", input)
+			},
+		})
+	}
+	screen := newTestScreen(30, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	replType(tr, "one")
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	tr.Render()
+
+	// 以 \ 结尾时续行，提交的内容不含 \
+	replType(tr, `two\`)
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	replType(tr, "lines")
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	tr.Render()
+
+	if want := []string{"one", "two\nlines"}; fmt.Sprint(submitted) != fmt.Sprint(want) {
+		t.Fatalf("submitted = %q, want %q", submitted, want)
+	}
+	content := getScreenContent(screen)
+	for _, want := range []string{"> one", "echo: one", "> two", ". lines"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in transcript, got:\n%s", want, content)
+		}
+	}
+
+	// ↑ 浏览历史；光标不在首行时先在多行输入内移动
+	tr.DispatchKey(tcell.KeyUp, 0, 0)
+	tr.DispatchKey(tcell.KeyUp, 0, 0)
+	tr.DispatchKey(tcell.KeyUp, 0, 0)
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	if len(submitted) != 3 || submitted[2] != "one" {
+		t.Errorf("submitted = %q, want history entry resubmitted", submitted)
+	}
+}
+
+func TestRepl_Completion(t *testing.T) {
+	words := []string{"select", "set", "show"}
+	var submitted string
+	app := func(c C) Node {
+		return Repl(c.Child("repl"), ReplProps{
+			Completer: func(input string) []string {
+				fields := strings.Fields(input)
+				var out []string
+				for _, w := range words {
+					if len(fields) > 0 && strings.HasPrefix(w, fields[len(fields)-1]) {
+						out = append(out, w)
+					}
+				}
+				return out
+			},
+			OnSubmit: func(input string, out io.Writer) { submitted = input },
+		})
+	}
+	screen := newTestScreen(30, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 唯一候选直接补全，Tab 不切换焦点
+	replType(tr, "sh")
+	tr.DispatchKey(tcell.KeyTab, 0, 0)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "> show") {
+		t.Fatalf("expected single completion, got:\n%s", content)
+	}
+
+	// 多个候选时补全公共前缀并打开菜单，Tab 在菜单中循环，Enter 选中
+	replType(tr, " s")
+	tr.DispatchKey(tcell.KeyTab, 0, 0)
+	tr.Render()
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "select") || !strings.Contains(content, "set") {
+		t.Fatalf("expected completion menu, got:\n%s", content)
+	}
+	tr.DispatchKey(tcell.KeyTab, 0, 0)
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	if submitted != "show set" {
+		t.Errorf("submitted = %q, want %q", submitted, "show set")
+	}
+}
+
+func TestRepl_Highlighter(t *testing.T) {
+	app := func(c C) Node {
+		return Repl(c.Child("repl"), ReplProps{
+			Highlighter: func(line string) []Style {
+				styles := make([]Style, len([]rune(line)))
+				for i := range styles {
+					if i < 2 {
+						styles[i] = NewStyle().Foreground(Red)
+					} else {
+						styles[i] = NewStyle()
+					}
+				}
+				return styles
+			},
+		})
+	}
+	screen := newTestScreen(20, 3)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	replType(tr, "abcd")
+
+	red := colorToTcell(Red)
+	fg := func(s tcell.Style) tcell.Color {
+		c, _, _ := s.Decompose()
+		return c
+	}
+	if _, _, style, _ := screen.GetContent(2, 0); fg(style) != red {
+		t.Errorf("highlighted cell fg = %v, want red", fg(style))
+	}
+	if _, _, style, _ := screen.GetContent(4, 0); fg(style) == red {
+		t.Errorf("plain cell should not be highlighted")
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "> abcd") {
+		t.Errorf("expected input line, got:\n%s", content)
+	}
+}
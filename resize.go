@@ -0,0 +1,103 @@
+package rego
+
+import "github.com/gdamore/tcell/v2"
+
+// =============================================================================
+// 可拖动边框调整大小的 Box
+// =============================================================================
+
+// Resizable 设置是否可以用鼠标拖动右边框或下边框调整大小，需要配合 OnResize 保存新的大小：
+//
+//	width := rego.Use(c, "sidebar", 24)
+//	rego.Box(sidebar).Width(width.Val).Border(rego.BorderSingle).
+//		Resizable(true).OnResize(func(w, h int) { width.Set(w) })
+func (b *boxNode) Resizable(on bool) *boxNode {
+	b.resizable = on
+	return b
+}
+
+// OnResize 设置拖动边框时的回调，参数为新的宽度和高度（包括边框），没有拖动的方向保持当前大小
+func (b *boxNode) OnResize(fn func(width, height int)) *boxNode {
+	b.onResize = fn
+	return b
+}
+
+// resizeMin 拖动调整大小时的最小宽高
+func (b *boxNode) resizeMin() int {
+	if b.style.border != BorderNone {
+		return 3
+	}
+	return 1
+}
+
+// resizeRegion 是本帧中可拖动调整大小的 Box
+type resizeRegion struct {
+	rect     Rect // Box 的完整区域
+	visible  Rect // 屏幕上可见的部分
+	minSize  int
+	onResize func(width, height int)
+	window   *componentContext // 所在的浮动窗口，位于主界面时为 nil
+}
+
+// resizeDrag 是正在进行的边框拖动
+type resizeDrag struct {
+	region         resizeRegion
+	horizontal     bool // 拖动右边框，改变宽度
+	vertical       bool // 拖动下边框，改变高度
+	startX, startY int
+	released       bool // 已经松开，等待吞掉紧随的点击
+}
+
+// addResizeRegion 在本帧注册一个可拖动边框的 Box，r 为渲染坐标
+func addResizeRegion(screen tcell.Screen, r Rect, minSize int, fn func(width, height int)) {
+	rt := screenRuntime(screen)
+	if rt == nil || fn == nil {
+		return
+	}
+	abs, visible := screenRect(screen, r)
+	if visible.W > 0 && visible.H > 0 {
+		rt.resizeRegions = append(rt.resizeRegions, resizeRegion{rect: abs, visible: visible, minSize: minSize, onResize: fn, window: rt.windows.drawing})
+	}
+}
+
+// dispatchResize 处理在可调整大小的 Box 边框上的拖动，事件被拖动消费时返回 true
+func (r *Runtime) dispatchResize(ev MouseEvent) bool {
+	if d := r.resizing; d != nil {
+		if d.released {
+			r.resizing = nil
+			return ev.Type == MouseEventClick
+		}
+		switch {
+		case ev.Type == MouseEventMove && ev.Button == MouseButtonLeft:
+			w, h := d.region.rect.W, d.region.rect.H
+			if d.horizontal {
+				w = max(w+ev.X-d.startX, d.region.minSize)
+			}
+			if d.vertical {
+				h = max(h+ev.Y-d.startY, d.region.minSize)
+			}
+			d.region.onResize(w, h)
+			r.scheduleRefresh()
+		case ev.Type == MouseEventRelease:
+			d.released = true
+		}
+		return true
+	}
+	if ev.Type != MouseEventPress || ev.Button != MouseButtonLeft {
+		return false
+	}
+	for i := len(r.resizeRegions) - 1; i >= 0; i-- {
+		region := r.resizeRegions[i]
+		if !region.visible.Contains(ev.X, ev.Y) || region.window != r.windows.at(ev.X, ev.Y) {
+			continue
+		}
+		right := ev.X == region.rect.X+region.rect.W-1
+		bottom := ev.Y == region.rect.Y+region.rect.H-1
+		if !right && !bottom {
+			continue
+		}
+		r.resizing = &resizeDrag{region: region, horizontal: right, vertical: bottom, startX: ev.X, startY: ev.Y}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBox_Resizable(t *testing.T) {
+	var size [2]int
+	clicks := 0
+	app := func(c C) Node {
+		width := Use(c, "width", 10)
+		height := Use(c, "height", 3)
+		return HStack(
+			Box(Text("side").OnClick(func() { clicks++ })).Width(width.Val).Height(height.Val).Border(BorderSingle).
+				Resizable(true).OnResize(func(w, h int) {
+				size = [2]int{w, h}
+				width.Set(w)
+				height.Set(h)
+			}),
+			Text("main"),
+		)
+	}
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 拖动右边框改变宽度，高度保持不变
+	mouseDrag(tr, 9, 1, 14, 1)
+	tr.Render()
+	if size != [2]int{15, 3} {
+		t.Fatalf("size = %v, want [15 3]", size)
+	}
+	if x, _ := findText(screen, "main"); x != 15 {
+		t.Errorf("main at x=%d, want 15 after resize:\n%s", x, getScreenContent(screen))
+	}
+
+	// 拖动右下角同时改变宽高，不能小于最小尺寸
+	mouseDrag(tr, 14, 2, 0, 5)
+	tr.Render()
+	if size != [2]int{3, 6} {
+		t.Errorf("size = %v, want [3 6]", size)
+	}
+
+	// 边框以外的点击照常分发
+	mouseClick(tr, 1, 1)
+	if clicks != 1 {
+		t.Errorf("clicks = %d, want 1:\n%s", clicks, getScreenContent(screen))
+	}
+	if strings.Count(getScreenContent(screen), "main") != 1 {
+		t.Errorf("unexpected layout:\n%s", getScreenContent(screen))
+	}
+}
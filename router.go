@@ -0,0 +1,167 @@
+package rego
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Router - 页面路由
+// =============================================================================
+
+// Component 是一个组件函数
+type Component func(c C) Node
+
+// Navigator 是 UseNavigate 返回的导航句柄
+type Navigator struct {
+	history *State[[]string]
+}
+
+// Path 返回当前路径
+func (n *Navigator) Path() string {
+	if n == nil || n.history == nil || len(n.history.Val) == 0 {
+		return ""
+	}
+	return n.history.Val[len(n.history.Val)-1]
+}
+
+// Push 跳转到 path，并记入历史
+func (n *Navigator) Push(path string) {
+	if n == nil || n.history == nil {
+		return
+	}
+	next := append(append([]string{}, n.history.Val...), path)
+	n.history.Set(next)
+}
+
+// Replace 用 path 替换当前页面，不产生新的历史记录
+func (n *Navigator) Replace(path string) {
+	if n == nil || n.history == nil || len(n.history.Val) == 0 {
+		return
+	}
+	next := append([]string{}, n.history.Val...)
+	next[len(next)-1] = path
+	n.history.Set(next)
+}
+
+// CanBack 是否可以返回上一页
+func (n *Navigator) CanBack() bool {
+	return n != nil && n.history != nil && len(n.history.Val) > 1
+}
+
+// Back 返回上一页，已在第一页时返回 false
+func (n *Navigator) Back() bool {
+	if !n.CanBack() {
+		return false
+	}
+	n.history.Set(append([]string{}, n.history.Val[:len(n.history.Val)-1]...))
+	return true
+}
+
+// routerValue 通过 Context 下发给页面
+type routerValue struct {
+	nav    *Navigator
+	params map[string]string
+}
+
+var routerContext = CreateContext[*routerValue](nil)
+
+// Router 根据当前路径渲染匹配的页面
+//
+// 路由模式中以 ":" 开头的段为参数，如 "/users/:id"，页面中通过 UseParams 读取；
+// 静态段优先于参数段匹配。initial 为初始路径，默认为 "/"。
+// 每个路径的页面拥有独立的组件上下文，返回上一页时其状态得以保留；
+// 不再位于历史记录中的页面（如返回时弹出的页面）的上下文随即释放。
+func Router(c C, routes map[string]Component, initial ...string) Node {
+	start := "/"
+	if len(initial) > 0 {
+		start = initial[0]
+	}
+	history := Use(c, "routerHistory", []string{start})
+	nav := &Navigator{history: history}
+	path := nav.Path()
+
+	pattern, params, ok := matchRoute(routes, path)
+	if !ok {
+		return c.Wrap(Text("404: " + path).Color(Red))
+	}
+
+	page := routes[pattern](c.Child("route:" + path))
+	releaseRoutes(c.(*componentContext), history.Val)
+	return c.Wrap(routerContext.Provide(c, &routerValue{nav: nav, params: params}, page))
+}
+
+// releaseRoutes 清理并删除不在历史记录中的页面上下文
+func releaseRoutes(ctx *componentContext, history []string) {
+	for key, child := range ctx.children {
+		path, ok := strings.CutPrefix(key, "route:")
+		if ok && !slices.Contains(history, path) {
+			child.cleanup()
+			delete(ctx.children, key)
+		}
+	}
+}
+
+// UseNavigate 返回最近的 Router 的导航句柄，不在 Router 内时所有操作都不生效
+func UseNavigate(c C) *Navigator {
+	if v := UseContext(c, routerContext); v != nil {
+		return v.nav
+	}
+	return &Navigator{}
+}
+
+// UseParams 返回当前路由匹配到的路径参数
+func UseParams(c C) map[string]string {
+	if v := UseContext(c, routerContext); v != nil {
+		return v.params
+	}
+	return map[string]string{}
+}
+
+// matchRoute 找出与 path 匹配的路由模式，静态段越多优先级越高
+func matchRoute(routes map[string]Component, path string) (string, map[string]string, bool) {
+	patterns := make([]string, 0, len(routes))
+	for p := range routes {
+		patterns = append(patterns, p)
+	}
+	sort.Strings(patterns)
+
+	best, bestStatic := "", -1
+	var bestParams map[string]string
+	segs := splitPath(path)
+	for _, p := range patterns {
+		psegs := splitPath(p)
+		if len(psegs) != len(segs) {
+			continue
+		}
+		params := map[string]string{}
+		static := 0
+		matched := true
+		for i, ps := range psegs {
+			switch {
+			case strings.HasPrefix(ps, ":"):
+				params[ps[1:]] = segs[i]
+			case ps == segs[i]:
+				static++
+			default:
+				matched = false
+			}
+			if !matched {
+				break
+			}
+		}
+		if matched && static > bestStatic {
+			best, bestStatic, bestParams = p, static, params
+		}
+	}
+	return best, bestParams, bestStatic >= 0
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
@@ -0,0 +1,127 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMatchRoute(t *testing.T) {
+	routes := map[string]Component{
+		"/":            nil,
+		"/users/:id":   nil,
+		"/users/new":   nil,
+		"/posts/:p/:c": nil,
+	}
+	cases := []struct {
+		path    string
+		pattern string
+		params  map[string]string
+	}{
+		{"/", "/", map[string]string{}},
+		{"/users/42", "/users/:id", map[string]string{"id": "42"}},
+		{"/users/new", "/users/new", map[string]string{}},
+		{"/posts/1/2", "/posts/:p/:c", map[string]string{"p": "1", "c": "2"}},
+	}
+	for _, tc := range cases {
+		pattern, params, ok := matchRoute(routes, tc.path)
+		if !ok || pattern != tc.pattern || len(params) != len(tc.params) {
+			t.Errorf("matchRoute(%q) = %q %v, want %q %v", tc.path, pattern, params, tc.pattern, tc.params)
+			continue
+		}
+		for k, v := range tc.params {
+			if params[k] != v {
+				t.Errorf("matchRoute(%q) param %s = %q, want %q", tc.path, k, params[k], v)
+			}
+		}
+	}
+	if _, _, ok := matchRoute(routes, "/missing"); ok {
+		t.Error("expected no match for unknown path")
+	}
+}
+
+func TestRouter_NavigateAndBack(t *testing.T) {
+	home := func(c C) Node {
+		nav := UseNavigate(c)
+		UseKey(c, func(key Key, r rune) {
+			if r == 'u' {
+				nav.Push("/users/7")
+			}
+		})
+		return Text("home")
+	}
+	user := func(c C) Node {
+		nav := UseNavigate(c)
+		params := UseParams(c)
+		UseKey(c, func(key Key, r rune) {
+			if key == KeyEsc {
+				nav.Back()
+			}
+		})
+		return Text("user " + params["id"])
+	}
+	app := func(c C) Node {
+		return Router(c.Child("router"), map[string]Component{"/": home, "/users/:id": user})
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyRune, 'u', 0)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.HasPrefix(content, "user 7") {
+		t.Fatalf("expected user page, got %q", content)
+	}
+
+	tr.DispatchKey(tcell.KeyEscape, 0, 0)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.HasPrefix(content, "home") {
+		t.Errorf("expected to go back home, got %q", content)
+	}
+}
+
+func TestRouter_ReleasesPoppedPages(t *testing.T) {
+	clicks := 0
+	home := func(c C) Node {
+		return Button(c.Child("open"), ButtonProps{Label: "Open", OnClick: func() { clicks++ }})
+	}
+	user := func(c C) Node {
+		return Text("user " + UseParams(c)["id"])
+	}
+	var nav *Navigator
+	app := func(c C) Node {
+		return Router(c.Child("router"), map[string]Component{
+			"/":          func(c C) Node { nav = UseNavigate(c); return home(c) },
+			"/users/:id": func(c C) Node { nav = UseNavigate(c); return user(c) },
+		})
+	}
+
+	screen := newTestScreen(20, 3)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	nav.Push("/users/1")
+	tr.Render()
+	// 离开的首页不再响应原位置的点击
+	tr.DispatchMouse(1, 0, tcell.Button1, 0)
+	tr.DispatchMouse(1, 0, tcell.ButtonNone, 0)
+	tr.Render()
+	if clicks != 0 {
+		t.Errorf("page navigated away from received a click (clicks = %d)", clicks)
+	}
+
+	nav.Back()
+	tr.Render()
+	nav.Push("/users/2")
+	tr.Render()
+
+	router := tr.rootContext.children["router"]
+	if _, ok := router.children["route:/users/1"]; ok {
+		t.Error("expected the popped /users/1 page context to be released")
+	}
+	if _, ok := router.children["route:/"]; !ok {
+		t.Error("expected the home page context to be kept in history")
+	}
+}
@@ -0,0 +1,70 @@
+package rego
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// Row - 整行背景
+// =============================================================================
+
+type rowNode struct {
+	stack *hstackNode
+	bg    Color
+}
+
+// Row 创建一个水平排列的行，与 HStack 相同，但 Background 会填满整行宽度，
+// 子节点中没有设置背景的文字也使用这个背景，适合自绘列表的斑马纹和整行选中高亮：
+//
+//	rego.Row(rego.Text(name), rego.Spacer(), rego.Text(size)).
+//		Background(rego.If(i == cursor, theme.Primary, rego.If(i%2 == 1, theme.Stripe, rego.Default)))
+func Row(children ...Node) *rowNode {
+	return &rowNode{stack: HStack(children...)}
+}
+
+// Background 设置整行的背景色，Default 表示不填充
+func (r *rowNode) Background(c Color) *rowNode {
+	r.bg = c
+	return r
+}
+
+// Gap 设置子节点之间的间距
+func (r *rowNode) Gap(g int) *rowNode {
+	r.stack.gap = g
+	return r
+}
+
+// Justify 设置主轴对齐方式
+func (r *rowNode) Justify(a Align) *rowNode {
+	r.stack.justify = a
+	return r
+}
+
+func (r *rowNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	h := min(r.measureHeight(width), height)
+	if r.bg == Default {
+		r.stack.render(screen, x, y, width, h)
+		return h
+	}
+	bg := colorToTcell(r.bg)
+	fill := tcell.StyleDefault.Background(bg)
+	for row := y; row < y+h; row++ {
+		for col := x; col < x+width; col++ {
+			screen.SetContent(col, row, ' ', nil, fill)
+		}
+	}
+	proxy := &clipScreen{Screen: screen, viewX: x, viewY: y, viewW: width, viewH: h, fill: bg}
+	r.stack.render(proxy, x, y, width, h)
+	return h
+}
+
+func (r *rowNode) measureHeight(width int) int {
+	return max(measureNodeHeight(r.stack, width), 1)
+}
+
+func (r *rowNode) measureWidth() int {
+	return r.stack.measureWidth(r.stack)
+}
@@ -0,0 +1,56 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRow_FillsFullWidthBackground(t *testing.T) {
+	app := func(c C) Node {
+		return VStack(
+			Row(Text("a"), Spacer(), Text("b").Background(Red)).Background(Blue),
+			Row(Text("plain")),
+		)
+	}
+	screen := newTestScreen(12, 2)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	blue := colorToTcell(Blue)
+	for x := 0; x < 11; x++ {
+		if _, _, style, _ := screen.GetContent(x, 0); bgOf(style) != blue {
+			t.Fatalf("cell %d: background = %v, want blue", x, bgOf(style))
+		}
+	}
+	// 子节点自己设置的背景保留
+	if _, _, style, _ := screen.GetContent(11, 0); bgOf(style) != colorToTcell(Red) {
+		t.Errorf("explicit child background overridden: %v", bgOf(style))
+	}
+	// 不设置背景时与 HStack 相同
+	if _, _, style, _ := screen.GetContent(8, 1); bgOf(style) != tcell.ColorDefault {
+		t.Errorf("row without background filled: %v", bgOf(style))
+	}
+}
+
+func TestList_Striped(t *testing.T) {
+	app := func(c C) Node {
+		return List(c.Child("list"), ListProps{Items: []string{"one", "two", "three"}, Striped: true})
+	}
+	screen := newTestScreen(10, 3)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	stripe := colorToTcell(DefaultTheme.Stripe)
+	if _, _, style, _ := screen.GetContent(9, 1); bgOf(style) != stripe {
+		t.Errorf("odd row not striped across full width: %v", bgOf(style))
+	}
+	if _, _, style, _ := screen.GetContent(9, 2); bgOf(style) != tcell.ColorDefault {
+		t.Errorf("even row striped: %v", bgOf(style))
+	}
+}
+
+func bgOf(style tcell.Style) tcell.Color {
+	_, bg, _ := style.Decompose()
+	return bg
+}
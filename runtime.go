@@ -3,6 +3,9 @@ package rego
 import (
 	"fmt"
 	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -20,6 +23,123 @@ type Runtime struct {
 	// 光标位置（用于 IME 输入定位）
 	cursorX, cursorY int
 	showCursor       bool
+	cursorFocused    bool              // 当前光标来自聚焦的组件，其他组件的光标不再覆盖它
+	cursorFrom       *componentContext // 正在设置光标的组件
+
+	// 本帧收集到的浮层，在主界面之后绘制
+	overlays []overlay
+
+	// 本帧可点击的区域（如带 OnClick 的链接），模态浮层打开时只有 clickFloor 之后的区域可以点击
+	clickRegions []clickRegion
+	clickFloor   int
+
+	// 本帧可拖动边框调整大小的 Box，以及正在进行的拖动
+	resizeRegions []resizeRegion
+	resizing      *resizeDrag
+
+	// 本帧的悬停区域（如 Heatmap），鼠标在其中移动时需要重绘
+	hoverRegions []Rect
+
+	// 按 CacheKey 缓存的 Markdown 渲染结果
+	markdownCache *markdownCache
+
+	// 并行测量的工作池，未开启 ParallelMeasure 时为 nil
+	measurePool *measurePool
+
+	// 渲染帧序号，本帧组件读取过的 Context 值，以及本轮新提供或有变化的 Provide
+	frame          uint64
+	contextPass    int
+	contextReads   []contextRead
+	contextChanges []contextChange
+
+	// 构建组件树时推迟执行的副作用，组件树构建完成后按声明顺序执行（见 UseEffect）
+	building bool
+	effects  []func()
+
+	// 累计执行组件树的轮数（每帧可能多于一轮），用于统计组件执行次数
+	buildPass uint64
+
+	// 终端尺寸变化的次数，变化后所有 Memo 都需要重新执行（组件可能依据 Rect 构建）
+	layoutEpoch uint64
+
+	// 上一帧的焦点，焦点变化时新旧焦点组件需要重新执行
+	lastFocus string
+
+	// 上一帧构建的节点树及构建时的布局版本，只有 Memo 子树变化时复用（见 rebuildDirty）
+	lastNode  Node
+	lastEpoch uint64
+
+	// 本帧由 rebuildDirty 单独重新执行的 Memo 子树
+	rebuilt []*componentContext
+
+	// 构建中移动了焦点（如切换窗格），本帧需要再执行一遍组件树
+	rebuild bool
+
+	// 浮动窗口的层叠顺序
+	windows *WindowManager
+
+	// 通知历史，未过期的通知以 toast 浮层显示
+	notifications *notificationStore
+
+	// 等待回答的确认框，打开期间独占按键和鼠标
+	confirms *confirmStore
+
+	// 上一帧的终端内容与本帧的滚动区域，仅在真实终端上启用
+	damage *damageTracker
+
+	// 正在分发的按键的修饰键
+	keyMods Modifiers
+
+	// 当前按住的鼠标按钮及其按下位置
+	mouseDown      MouseButton
+	pressX, pressY int
+
+	// 最近一次鼠标事件的位置，mouseIn 为 false 时还没有收到过鼠标事件
+	mouseX, mouseY int
+	mouseIn        bool
+
+	// 等待在 UI 循环中执行的任务（来自其他协程）
+	tasksMu sync.Mutex
+	tasks   []func()
+
+	// 颜色模式（高对比度 / 单色）
+	colorMode ColorMode
+
+	// 性能指标，Options.Debug 开启时启动的 pprof 服务
+	metrics   runtimeMetrics
+	debugAddr string
+	debug     bool
+
+	// 退出前的确认，返回 false 取消退出
+	onQuitRequest func() bool
+
+	// 会话持久化，未开启时为 nil
+	session *sessionStore
+
+	// 当前帧的追踪信息，未开启 runtime/trace 且 jankThreshold 为 0 时为 nil
+	frameTrace    *frameTrace
+	jankThreshold time.Duration // 超过该耗时的帧写入日志
+
+	// 调试控制台（F10）及其显示的最近事件
+	debugConsole   bool
+	lastKeyEvent   string
+	lastMouseEvent string
+
+	// 默认光标样式，以及最近一次写出的样式
+	cursorStyle   CursorStyle
+	appliedCursor *CursorStyle
+
+	// 当前终端标题
+	title string
+
+	// 组件使用的时钟，默认为系统时钟，见 Options.Clock
+	clock Clock
+
+	// 已安排 Skeleton 高光的下一帧重绘
+	skeletonTick atomic.Bool
+
+	// 无障碍输出，未开启时为 nil
+	a11y *announcer
 
 	// 错误处理
 	lastPanic  any
@@ -28,12 +148,21 @@ type Runtime struct {
 
 // newRuntime 创建运行时
 func newRuntime(root func(C) Node) *Runtime {
-	return &Runtime{
-		root:         root,
-		focusManager: newFocusManager(),
-		refreshChan:  make(chan struct{}, 1),
-		quitChan:     make(chan struct{}),
+	r := &Runtime{
+		root:          root,
+		focusManager:  newFocusManager(),
+		markdownCache: newMarkdownCache(markdownCacheSize),
+		a11y:          newAnnouncerFromEnv(),
+		colorMode:     ColorAuto.resolve(),
+		clock:         realClock{},
+		notifications: newNotificationStore(),
+		confirms:      &confirmStore{},
+		refreshChan:   make(chan struct{}, 1),
+		quitChan:      make(chan struct{}),
 	}
+	r.windows = &WindowManager{runtime: r}
+	r.markdownCache.now = func() time.Time { return r.clock.Now() }
+	return r
 }
 
 // Run 启动运行时
@@ -43,6 +172,11 @@ func (r *Runtime) Run() error {
 	if err != nil {
 		return err
 	}
+	return r.runOn(screen)
+}
+
+// runOn 在指定的 screen 上运行，退出时还原终端
+func (r *Runtime) runOn(screen tcell.Screen) error {
 	if err := screen.Init(); err != nil {
 		return err
 	}
@@ -52,10 +186,17 @@ func (r *Runtime) Run() error {
 			r.rootContext.cleanup()
 		}
 		screen.Fini()
+		r.closeAccessibility()
 	}()
 
 	r.screen = screen
 	r.rootContext = newComponentContext("root", nil, r)
+	r.damage = newDamageTracker(screen)
+
+	r.publishMetrics()
+	if r.debug {
+		defer startDebugServer(r.debugAddr)()
+	}
 
 	// 启用粘贴模式（改善 IME 支持）
 	screen.EnablePaste()
@@ -85,7 +226,7 @@ func (r *Runtime) Run() error {
 	for {
 		select {
 		case <-r.quitChan:
-			return nil
+			return r.saveSession()
 
 		case <-r.refreshChan:
 			r.render()
@@ -107,6 +248,12 @@ func (r *Runtime) render() {
 		return
 	}
 
+	start := time.Now()
+	r.frameTrace = beginFrameTrace("rego.frame", r.jankThreshold > 0)
+	defer func() {
+		r.frameTrace.end()
+		r.frameTrace = nil
+	}()
 	defer func() {
 		if err := recover(); err != nil {
 			r.lastPanic = err
@@ -117,16 +264,39 @@ func (r *Runtime) render() {
 		}
 	}()
 
-	r.rootContext.reset()
-
-	// 重置焦点管理器（每次渲染前）
-	r.focusManager.Reset()
+	// 先执行其他协程投递的任务，使其状态变化体现在本帧
+	r.runTasks()
+	r.markFocusChange()
+	if r.damage != nil {
+		r.damage.hints = r.damage.hints[:0]
+	}
 
-	// 重置光标状态（每次渲染前）
+	// 重置光标状态，清空上一帧的浮层
 	r.showCursor = false
-
-	// 调用根组件
-	node := r.root(r.rootContext)
+	r.cursorFocused = false
+	r.overlays = r.overlays[:0]
+
+	r.frame++
+	endBuild := r.frameTrace.region("build")
+	// 只有 Memo 子树有变化时单独重新执行这些子树，否则执行整棵组件树
+	node := r.lastNode
+	r.building = true
+	if r.rebuildDirty() {
+		for _, ctx := range r.rebuilt {
+			r.bindNodes(ctx.cached.node)
+		}
+	} else {
+		node = r.build()
+		r.lastNode, r.lastEpoch = node, r.layoutEpoch
+		r.bindNodes(node)
+	}
+	r.building = false
+	r.runEffects()
+	endBuild()
+	r.frameTrace.endBuild()
+	r.markFocusChange()
+	r.announceFocus()
+	nodes := countNodes(node)
 
 	// 准备渲染屏幕代理（拦截光标设置）
 	renderScreen := &renderScreenProxy{
@@ -135,19 +305,74 @@ func (r *Runtime) render() {
 	}
 
 	// 渲染到屏幕
+	endRender := r.frameTrace.region("render")
+	r.clickRegions = r.clickRegions[:0]
+	r.clickFloor = 0
+	r.resizeRegions = r.resizeRegions[:0]
+	r.hoverRegions = r.hoverRegions[:0]
 	width, height := r.screen.Size()
 	if node != nil {
 		node.render(renderScreen, 0, 0, width, height)
 	}
 
+	// 绘制浮层（覆盖在主界面之上）
+	if r.debugConsole {
+		r.addDebugConsole(width, height)
+	}
+	r.windows.render(renderScreen)
+	r.notifications.addToasts(r, width, height)
+	r.confirms.addDialog(r, width, height)
+	r.renderOverlays(renderScreen)
+	endRender()
+
 	// 设置光标位置（用于 IME 输入定位）
 	if r.showCursor {
+		r.applyCursorStyle()
 		r.screen.ShowCursor(r.cursorX, r.cursorY)
 	} else {
 		r.screen.HideCursor()
 	}
 
+	cells := 0
+	if r.damage != nil {
+		cells = r.damage.flush(r.screen)
+	}
 	r.screen.Show()
+	if r.damage != nil {
+		r.damage.snapshot(r.screen)
+	}
+	elapsed := time.Since(start)
+	r.metrics.record(elapsed, nodes, cells)
+	r.reportJank(elapsed)
+}
+
+// build 执行整棵组件树，返回根节点
+func (r *Runtime) build() Node {
+	var node Node
+	for pass := 1; ; pass++ {
+		r.buildPass++
+		r.rootContext.reset()
+
+		// 重置焦点管理器（每次渲染前）
+		r.focusManager.Reset()
+
+		r.contextPass = pass
+		r.contextReads = r.contextReads[:0]
+		r.contextChanges = r.contextChanges[:0]
+		r.effects = r.effects[:0]
+
+		// 调用根组件
+		node = r.root(r.rootContext)
+
+		// 子组件可能先于 Provide 执行而读到旧值，此时再执行一遍，保证同一帧内 Context 值一致；
+		// 构建中移动了焦点时同样再执行一遍
+		rebuild := r.rebuild
+		r.rebuild = false
+		if pass >= maxContextPasses || !rebuild && !r.contextChanged() {
+			break
+		}
+	}
+	return node
 }
 
 // renderScreenProxy 代理 tcell.Screen 以拦截光标设置
@@ -156,6 +381,13 @@ type renderScreenProxy struct {
 	runtime *Runtime
 }
 
+func (p *renderScreenProxy) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	if p.runtime != nil && p.runtime.colorMode != ColorNormal {
+		style = p.runtime.colorMode.filter(style)
+	}
+	p.Screen.SetContent(x, y, mainc, combc, style)
+}
+
 func (p *renderScreenProxy) ShowCursor(x, y int) {
 	if p.runtime != nil {
 		p.runtime.setCursor(x, y)
@@ -170,16 +402,37 @@ func (p *renderScreenProxy) HideCursor() {
 
 // handleEvent 处理事件
 func (r *Runtime) handleEvent(event tcell.Event) {
+	ft := beginFrameTrace("rego.event", false)
+	defer ft.end()
+	defer ft.region(eventKind(event))()
+
+	r.recordDebugEvent(event)
+
 	switch e := event.(type) {
 	case *tcell.EventKey:
 		// Ctrl+C 退出
 		if e.Key() == tcell.KeyCtrlC {
-			r.quit()
+			r.requestQuit()
+			return
+		}
+
+		// 确认框打开时按键只交给确认框
+		if r.confirms.active() != nil {
+			key, ru, _ := convertTcellKey(e)
+			r.confirms.handleKey(key, ru)
+			r.scheduleRefresh()
 			return
 		}
 
-		// Tab/Shift+Tab 焦点导航
-		if e.Key() == tcell.KeyTab {
+		// F10 切换调试控制台
+		if e.Key() == tcell.KeyF10 {
+			r.debugConsole = !r.debugConsole
+			r.scheduleRefresh()
+			return
+		}
+
+		// Tab/Shift+Tab 焦点导航（聚焦的组件要求自己处理 Tab 时除外）
+		if e.Key() == tcell.KeyTab && !r.focusCapturesTab(e.Modifiers()) {
 			if e.Modifiers()&tcell.ModShift != 0 {
 				r.focusManager.Prev()
 			} else {
@@ -190,21 +443,116 @@ func (r *Runtime) handleEvent(event tcell.Event) {
 		}
 
 		// 转换按键
-		key, ru, _ := convertTcellKey(e)
+		key, ru, mods := convertTcellKey(e)
+		r.keyMods = mods
 
-		// 分发给组件树
+		// 焦点所在子树的快捷键优先，其次广播给组件树
+		if r.dispatchShortcut(key, ru) {
+			return
+		}
 		r.rootContext.dispatchKeyEvent(key, ru)
 
 	case *tcell.EventMouse:
-		ev := convertTcellMouseEvent(e)
-		r.rootContext.dispatchMouseEvent(ev)
+		r.trackHover(e.Position())
+		modal := r.confirms.active() != nil
+		for _, ev := range r.trackMouse(convertTcellMouseEvent(e)) {
+			r.recordMouseEvent(ev)
+			if !modal && r.dispatchResize(ev) {
+				continue
+			}
+			if r.dispatchClick(ev) || modal {
+				continue
+			}
+			r.rootContext.dispatchMouseEvent(ev)
+		}
 
 	case *tcell.EventResize:
+		r.layoutEpoch++
+		// 尺寸调整期间 Markdown 沿用旧排版，停止调整后再刷新一次完成重新排版
+		r.markdownCache.pauseRelayout(markdownResizeDebounce)
+		r.clock.AfterFunc(markdownResizeDebounce, r.scheduleRefresh)
 		r.scheduleRefresh()
 	}
 }
 
+// runEffects 执行构建中推迟的副作用
+func (r *Runtime) runEffects() {
+	effects := r.effects
+	r.effects = nil
+	for _, run := range effects {
+		run()
+	}
+}
+
+// bindNodes 把 Markdown 缓存和测量工作池绑定到新构建的节点，
+// 跳过复用的 Memo 子树（其节点在执行时已经绑定过）
+func (r *Runtime) bindNodes(node Node) {
+	walkNodes(node, func(n Node) bool {
+		switch n := n.(type) {
+		case *componentNode:
+			if m := n.ctx.cached; m != nil && m.node == n {
+				if m.bound {
+					return false
+				}
+				m.bound = true
+			}
+		case *markdownNode:
+			n.cache = r.markdownCache
+		}
+		if r.measurePool != nil {
+			bindMeasure(n, r.measurePool)
+		}
+		return true
+	})
+}
+
+// focusCapturesTab 报告不带 Shift 的 Tab 是否交给当前聚焦的组件处理
+func (r *Runtime) focusCapturesTab(mods tcell.ModMask) bool {
+	if mods&tcell.ModShift != 0 {
+		return false
+	}
+	ctx := r.focusManager.CurrentContext()
+	return ctx != nil && ctx.capturesTab
+}
+
+// focusAcceptsText 报告当前聚焦的组件是否在接收文字输入
+func (r *Runtime) focusAcceptsText() bool {
+	ctx := r.focusManager.CurrentContext()
+	return ctx != nil && ctx.acceptsText
+}
+
+// trackMouse 根据按钮状态的变化把 tcell 报告的按钮状态转换为事件序列：
+// 按下时产生 Press；按住移动时产生带 Button 的 Move（拖动）；
+// 松开时产生 Release，随后产生 Click（是否算作某个组件的点击由其区域是否同时包含按下与松开的位置决定）
+func (r *Runtime) trackMouse(ev MouseEvent) []MouseEvent {
+	switch {
+	case ev.Type != MouseEventPress && ev.Type != MouseEventMove:
+		return []MouseEvent{ev} // 滚轮
+	case ev.Button != MouseButtonNone && ev.Button != r.mouseDown:
+		r.mouseDown = ev.Button
+		r.pressX, r.pressY = ev.X, ev.Y
+		return []MouseEvent{ev}
+	case ev.Button != MouseButtonNone:
+		ev.Type = MouseEventMove
+		return []MouseEvent{ev}
+	case r.mouseDown != MouseButtonNone:
+		ev.Button = r.mouseDown
+		r.mouseDown = MouseButtonNone
+		release, click := ev, ev
+		release.Type = MouseEventRelease
+		click.Type = MouseEventClick
+		return []MouseEvent{release, click}
+	}
+	return []MouseEvent{ev}
+}
+
+// pressedIn 当前点击的按下位置是否也在 rect 内
+func (r *Runtime) pressedIn(rect Rect) bool {
+	return rect.Contains(r.pressX, r.pressY)
+}
+
 // convertTcellMouseEvent 将 tcell 鼠标事件转换为 rego 鼠标事件
+// 按住任意按钮时类型为 MouseEventPress，由 Runtime.trackMouse 再区分按下、拖动与松开
 func convertTcellMouseEvent(e *tcell.EventMouse) MouseEvent {
 	x, y := e.Position()
 	button := MouseButtonNone
@@ -213,13 +561,13 @@ func convertTcellMouseEvent(e *tcell.EventMouse) MouseEvent {
 	b := e.Buttons()
 	if b&tcell.Button1 != 0 {
 		button = MouseButtonLeft
-		eventType = MouseEventClick
+		eventType = MouseEventPress
 	} else if b&tcell.Button3 != 0 {
 		button = MouseButtonRight
-		eventType = MouseEventClick
+		eventType = MouseEventPress
 	} else if b&tcell.Button2 != 0 {
 		button = MouseButtonMiddle
-		eventType = MouseEventClick
+		eventType = MouseEventPress
 	}
 
 	// 处理滚轮
@@ -227,6 +575,21 @@ func convertTcellMouseEvent(e *tcell.EventMouse) MouseEvent {
 		eventType = MouseEventScrollUp
 	} else if b&tcell.WheelDown != 0 {
 		eventType = MouseEventScrollDown
+	} else if b&tcell.WheelLeft != 0 {
+		eventType = MouseEventScrollLeft
+	} else if b&tcell.WheelRight != 0 {
+		eventType = MouseEventScrollRight
+	}
+
+	var mods Modifiers
+	if e.Modifiers()&tcell.ModShift != 0 {
+		mods |= ModShift
+	}
+	if e.Modifiers()&tcell.ModCtrl != 0 {
+		mods |= ModCtrl
+	}
+	if e.Modifiers()&tcell.ModAlt != 0 {
+		mods |= ModAlt
 	}
 
 	return MouseEvent{
@@ -234,6 +597,7 @@ func convertTcellMouseEvent(e *tcell.EventMouse) MouseEvent {
 		Y:      y,
 		Button: button,
 		Type:   eventType,
+		Mod:    mods,
 	}
 }
 
@@ -246,16 +610,63 @@ func (r *Runtime) scheduleRefresh() {
 	}
 }
 
-// quit 退出应用
+// post 将 fn 投递到 UI 循环，在下一次渲染前执行，可在任意协程中调用
+func (r *Runtime) post(fn func()) {
+	r.tasksMu.Lock()
+	r.tasks = append(r.tasks, fn)
+	r.tasksMu.Unlock()
+	r.scheduleRefresh()
+}
+
+// runTasks 按投递顺序执行所有待处理任务
+func (r *Runtime) runTasks() {
+	r.tasksMu.Lock()
+	tasks := r.tasks
+	r.tasks = nil
+	r.tasksMu.Unlock()
+	for _, fn := range tasks {
+		fn()
+	}
+}
+
+// requestQuit 先询问 OnQuitRequest，允许时退出；显示错误界面时直接退出，
+// 因为错误界面提示按 Ctrl+C 退出，其上也无法显示确认框
+func (r *Runtime) requestQuit() {
+	if r.onQuitRequest != nil && r.lastPanic == nil && !r.onQuitRequest() {
+		return
+	}
+	r.quit()
+}
+
+// quit 退出应用，可重复调用
 func (r *Runtime) quit() {
-	close(r.quitChan)
+	select {
+	case <-r.quitChan:
+	default:
+		close(r.quitChan)
+	}
 }
 
-// setCursor 设置光标位置
+// setCursor 设置光标位置；聚焦的组件（或其子组件）设置的光标优先，同等情况下后设置的生效
 func (r *Runtime) setCursor(x, y int) {
+	focused := r.cursorFrom != nil && r.cursorFrom.withinFocus()
+	if r.showCursor && r.cursorFocused && !focused {
+		return
+	}
 	r.cursorX = x
 	r.cursorY = y
 	r.showCursor = true
+	r.cursorFocused = focused
+}
+
+// setCursorFrom 以组件 c 的名义设置光标位置，c 调用过 HideCursor 时忽略
+func (r *Runtime) setCursorFrom(c *componentContext, x, y int) {
+	if !c.cursorAllowed() {
+		return
+	}
+	r.cursorFrom = c
+	r.setCursor(x, y)
+	r.cursorFrom = nil
 }
 
 // drawErrorScreen 绘制错误界面
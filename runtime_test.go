@@ -1,6 +1,8 @@
 package rego
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
@@ -60,3 +62,177 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRuntime_RenderCount(t *testing.T) {
+	showB := true
+	app := func(c C) Node {
+		c.Child("a")
+		c.Child("a") // 同一轮中重复获取不重复计数
+		nodes := []Node{Text("a")}
+		if showB {
+			list := c.Child("list")
+			nodes = append(nodes, Text("b"))
+			list.Child("item", 3)
+		}
+		return VStack(nodes...)
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(10, 2))
+	tr.Render()
+	tr.Render()
+	showB = false
+	tr.Render()
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"", 3},
+		{"root", 3},
+		{"a", 3},
+		{"root/a", 3},
+		{"list/item[3]", 2},
+		{"/root/list/item[3]", 2},
+		{"missing", 0},
+		{"missing/item", 0},
+	}
+	for _, tt := range tests {
+		if got := tr.RenderCount(tt.path); got != tt.want {
+			t.Errorf("RenderCount(%q) = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRuntime_MouseButtonTransitions(t *testing.T) {
+	var events []string
+	app := func(c C) Node {
+		target := c.Child("target")
+		UseMouse(target, func(ev MouseEvent) {
+			if ev.Type == MouseEventMove && ev.Button == MouseButtonNone {
+				return
+			}
+			events = append(events, fmt.Sprintf("%s@%d,%d", mouseEventNames[ev.Type], ev.X, ev.Y))
+		})
+		return VStack(
+			target.Wrap(Text("target")),
+			Text("outside"),
+		)
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(10, 2))
+	tr.Render()
+
+	// 按下、拖动、松开：只在松开时产生一次点击
+	tr.DispatchMouse(1, 0, tcell.Button1, 0)
+	tr.DispatchMouse(2, 0, tcell.Button1, 0)
+	tr.DispatchMouse(3, 0, tcell.Button1, 0)
+	tr.DispatchMouse(3, 0, tcell.ButtonNone, 0)
+	want := []string{"press@1,0", "move@2,0", "move@3,0", "release@3,0", "click@3,0"}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+
+	// 在区域外松开不算点击
+	events = nil
+	tr.DispatchMouse(1, 0, tcell.Button1, 0)
+	tr.DispatchMouse(1, 1, tcell.ButtonNone, 0)
+	want = []string{"press@1,0", "release@1,1", "move@1,1"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
+
+func TestRuntime_MouseLocalCoordinates(t *testing.T) {
+	var got []string
+	app := func(c C) Node {
+		target := c.Child("target")
+		UseMouse(target, func(ev MouseEvent) {
+			if ev.Type == MouseEventClick || ev.Type == MouseEventRelease {
+				got = append(got, fmt.Sprintf("%s@%d,%d hit=%v", mouseEventNames[ev.Type], ev.LocalX, ev.LocalY, ev.Hit))
+			}
+		})
+		return VStack(
+			Text("header"),
+			HStack(Text(">> "), target.Wrap(Box(Text("target")).Border(BorderSingle))),
+		)
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(20, 4))
+	tr.Render()
+
+	// 区域从 (3,1) 开始，点击 (5,2) 对应局部坐标 (2,1)
+	tr.DispatchMouse(5, 2, tcell.Button1, 0)
+	tr.DispatchMouse(5, 2, tcell.ButtonNone, 0)
+	// 区域外松开：Release 照常送达但 Hit 为 false
+	tr.DispatchMouse(1, 0, tcell.Button1, 0)
+	tr.DispatchMouse(1, 0, tcell.ButtonNone, 0)
+	want := []string{"release@2,1 hit=true", "click@2,1 hit=true", "release@-2,-1 hit=false"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	ev := MouseEvent{X: 4, Y: 3}
+	if x, y, ok := ev.In(Rect{X: 2, Y: 3, W: 2, H: 1}); x != 2 || y != 0 || ok {
+		t.Errorf("In() = %d, %d, %v, want 2, 0, false", x, y, ok)
+	}
+}
+
+func TestRuntime_OnQuitRequest(t *testing.T) {
+	allow := false
+	asked := 0
+	var quit func()
+	tr := NewTestRuntime(func(c C) Node {
+		quit = c.Quit
+		return Text("app")
+	}, newTestScreen(10, 1))
+	tr.apply(Options{OnQuitRequest: func() bool {
+		asked++
+		return allow
+	}})
+	tr.Render()
+
+	quitting := func() bool {
+		select {
+		case <-tr.quitChan:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// Ctrl+C 与 Quit 都先询问，返回 false 时不退出
+	tr.DispatchKey(tcell.KeyCtrlC, 0, 0)
+	quit()
+	if asked != 2 || quitting() {
+		t.Fatalf("asked=%d quitting=%v, want 2 vetoed requests", asked, quitting())
+	}
+
+	allow = true
+	quit()
+	if !quitting() {
+		t.Error("expected quit after the request was allowed")
+	}
+}
+
+func TestRuntime_OnQuitRequestSkippedOnErrorScreen(t *testing.T) {
+	asked := 0
+	tr := NewTestRuntime(func(c C) Node {
+		panic("oops")
+	}, newTestScreen(40, 10))
+	tr.apply(Options{OnQuitRequest: func() bool {
+		asked++
+		return false
+	}})
+	tr.Render()
+
+	// 错误界面提示按 Ctrl+C 退出，不能被 OnQuitRequest 拦下
+	tr.DispatchKey(tcell.KeyCtrlC, 0, 0)
+	select {
+	case <-tr.quitChan:
+	default:
+		t.Error("Ctrl+C on the error screen did not quit")
+	}
+	if asked != 0 {
+		t.Errorf("OnQuitRequest called %d times on the error screen", asked)
+	}
+}
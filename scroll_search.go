@@ -0,0 +1,250 @@
+package rego
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// ScrollBox 查找（/ 搜索，n/N 跳转）
+// =============================================================================
+
+// scrollSearch 是 ScrollBox 的搜索状态
+type scrollSearch struct {
+	Query   string
+	Typing  bool // 正在输入搜索词
+	Current int  // 当前匹配的下标，-1 表示尚未跳转
+}
+
+// searchMatch 是内容中的一处匹配，row/col 为内容坐标，cells 为匹配覆盖的单元格
+type searchMatch struct {
+	row, col int
+	cells    []capturedCell
+}
+
+// capturedCell 是渲染内容时记录下的单元格
+type capturedCell struct {
+	x     int
+	mainc rune
+	combc []rune
+	style tcell.Style
+}
+
+// searchCapture 记录内容区（裁切前）绘制的所有单元格，用于在渲染结果中查找文字
+type searchCapture struct {
+	originX, originY int
+	rows             map[int]map[int]capturedCell
+}
+
+func (c *searchCapture) set(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	row := y - c.originY
+	if c.rows[row] == nil {
+		c.rows[row] = map[int]capturedCell{}
+	}
+	c.rows[row][x-c.originX] = capturedCell{x: x - c.originX, mainc: mainc, combc: combc, style: style}
+}
+
+// find 返回 query 在各行中的所有匹配（不区分大小写），按行列排序
+func (c *searchCapture) find(query string) []searchMatch {
+	needle := []rune(strings.ToLower(query))
+	if len(needle) == 0 {
+		return nil
+	}
+
+	rows := make([]int, 0, len(c.rows))
+	for row := range c.rows {
+		rows = append(rows, row)
+	}
+	sort.Ints(rows)
+
+	var out []searchMatch
+	for _, row := range rows {
+		cells := make([]capturedCell, 0, len(c.rows[row]))
+		for _, cell := range c.rows[row] {
+			cells = append(cells, cell)
+		}
+		sort.Slice(cells, func(i, j int) bool { return cells[i].x < cells[j].x })
+
+		// 每个单元格按首字符比较（组合字符随首字符一起高亮），匹配的单元格必须相邻
+		for start := 0; start+len(needle) <= len(cells); start++ {
+			ok := true
+			for i, r := range needle {
+				cell := cells[start+i]
+				if unicode.ToLower(cell.mainc) != r {
+					ok = false
+					break
+				}
+				if i > 0 {
+					prev := cells[start+i-1]
+					if cell.x != prev.x+max(1, runewidth.RuneWidth(prev.mainc)) {
+						ok = false
+						break
+					}
+				}
+			}
+			if ok {
+				out = append(out, searchMatch{row: row, col: cells[start].x, cells: cells[start : start+len(needle)]})
+				start += len(needle) - 1
+			}
+		}
+	}
+	return out
+}
+
+// Searchable 开启查找：ScrollBox 可通过 Tab 聚焦，聚焦时按 / 输入搜索词，Enter 确认，
+// n/N 在匹配间跳转，Esc 退出；匹配在渲染结果中高亮，底部显示匹配计数
+func (s *scrollNode) Searchable() *scrollNode {
+	s.searchable = true
+	// UseFocus 会替换鼠标处理器，这里保留 ScrollBox 的滚轮处理
+	scroll := s.ctx.mouseHandler
+	s.focus = UseFocus(s.ctx)
+	if focus := s.ctx.mouseHandler; scroll != nil && focus != nil {
+		s.ctx.mouseHandler = func(ev MouseEvent) {
+			focus(ev)
+			scroll(ev)
+		}
+	}
+	return s
+}
+
+// handleSearchKey 处理查找相关的按键，返回是否已处理
+func (s *scrollNode) handleSearchKey(key Key, r rune) bool {
+	if !s.searchable || !s.focus.IsFocused || s.searchState == nil {
+		return false
+	}
+	st := s.searchState.Val
+
+	if st.Typing {
+		switch key {
+		case KeyEnter:
+			st.Typing = false
+			st.Current = -1
+			s.searchState.Set(st)
+			s.jumpToMatch(1)
+		case KeyEsc:
+			s.searchState.Set(scrollSearch{Current: -1})
+		case KeyBackspace:
+			if runes := []rune(st.Query); len(runes) > 0 {
+				st.Query = string(runes[:len(runes)-1])
+				s.searchState.Set(st)
+			}
+		default:
+			if r != 0 {
+				st.Query += string(r)
+				s.searchState.Set(st)
+			}
+		}
+		return true
+	}
+
+	switch {
+	case r == '/':
+		s.searchState.Set(scrollSearch{Typing: true, Current: -1})
+	case r == 'n' && st.Query != "":
+		s.jumpToMatch(1)
+	case r == 'N' && st.Query != "":
+		s.jumpToMatch(-1)
+	case key == KeyEsc && st.Query != "":
+		s.searchState.Set(scrollSearch{Current: -1})
+	default:
+		return false
+	}
+	return true
+}
+
+// jumpToMatch 跳到下一个（dir=1）或上一个（dir=-1）匹配，并滚动使其位于视口上部
+func (s *scrollNode) jumpToMatch(dir int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	st := s.searchState.Val
+	if st.Current < 0 {
+		// 首次跳转：从当前视口顶部开始找
+		st.Current = 0
+		for i, m := range s.matches {
+			if m.row >= s.offY {
+				st.Current = i
+				break
+			}
+		}
+		if dir < 0 {
+			st.Current = (st.Current - 1 + len(s.matches)) % len(s.matches)
+		}
+	} else {
+		st.Current = (st.Current + dir + len(s.matches)) % len(s.matches)
+	}
+	s.searchState.Set(st)
+
+	top := s.matches[st.Current].row - s.viewHeight/3
+	top = max(0, min(top, s.contentHeight-s.viewHeight))
+	s.autoScrollState.Set(false)
+	s.scrollTopState.Set(top)
+}
+
+// renderSearch 高亮视口内的匹配，并在视口底部绘制搜索栏
+func (s *scrollNode) renderSearch(screen, content tcell.Screen, x, y, width, height int) {
+	st := s.searchState.Val
+	s.matches = nil
+	if s.capture != nil {
+		s.matches = s.capture.find(st.Query)
+	}
+
+	hit := tcell.StyleDefault.Background(colorToTcell(Yellow)).Foreground(colorToTcell(Black))
+	current := tcell.StyleDefault.Background(colorToTcell(Cyan)).Foreground(colorToTcell(Black)).Bold(true)
+	for i, m := range s.matches {
+		if m.row < s.offY || m.row >= s.offY+height {
+			continue
+		}
+		style := hit
+		if i == st.Current {
+			style = current
+		}
+		for _, cell := range m.cells {
+			content.SetContent(x+cell.x, y+m.row, cell.mainc, cell.combc, style)
+		}
+	}
+
+	if height <= 0 {
+		return
+	}
+	label := "/" + st.Query
+	if st.Typing {
+		label += "█"
+	}
+	counter := "no matches"
+	switch {
+	case st.Query == "":
+		counter = ""
+	case len(s.matches) > 0 && st.Current >= 0:
+		counter = fmt.Sprintf("%d/%d", st.Current+1, len(s.matches))
+	case len(s.matches) > 0:
+		counter = fmt.Sprintf("%d matches", len(s.matches))
+	}
+
+	bar := tcell.StyleDefault.Reverse(true)
+	row := y + height - 1
+	for col := x; col < x+width; col++ {
+		screen.SetContent(col, row, ' ', nil, bar)
+	}
+	col := x
+	for _, r := range label {
+		w := runewidth.RuneWidth(r)
+		if col+w > x+width {
+			break
+		}
+		screen.SetContent(col, row, r, nil, bar)
+		col += w
+	}
+	if cw := runewidth.StringWidth(counter); cw > 0 && col+1+cw <= x+width {
+		col = x + width - cw
+		for _, r := range counter {
+			screen.SetContent(col, row, r, nil, bar)
+			col += runewidth.RuneWidth(r)
+		}
+	}
+}
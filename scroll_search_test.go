@@ -0,0 +1,99 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestScrollBox_Search(t *testing.T) {
+	app := func(c C) Node {
+		var lines []Node
+		for i := 0; i < 40; i++ {
+			msg := fmt.Sprintf("row %02d", i)
+			if i == 5 || i == 30 {
+				msg += " Target"
+			}
+			lines = append(lines, Text(msg))
+		}
+		return ScrollBox(c.Child("scroll"), VStack(lines...)).Searchable()
+	}
+
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyRune, '/', 0)
+	for _, r := range "target" {
+		tr.DispatchKey(tcell.KeyRune, r, 0)
+	}
+	tr.Render()
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "/target") || !strings.Contains(content, "2 matches") {
+		t.Fatalf("expected search bar with match count, got:\n%s", content)
+	}
+
+	// Enter 跳到第一个匹配
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	content = getScreenContent(screen)
+	if !strings.Contains(content, "row 05 Target") || !strings.Contains(content, "1/2") {
+		t.Fatalf("expected first match with counter, got:\n%s", content)
+	}
+
+	// 当前匹配高亮
+	lines := strings.Split(content, "\n")
+	row := -1
+	for i, line := range lines {
+		if strings.Contains(line, "row 05 Target") {
+			row = i
+		}
+	}
+	col := strings.Index(lines[row], "Target")
+	mainc, _, style, _ := screen.GetContent(col, row)
+	want := tcell.StyleDefault.Background(colorToTcell(Cyan)).Foreground(colorToTcell(Black)).Bold(true)
+	if mainc != 'T' || style != want {
+		t.Errorf("expected current match highlighted, got %q %v", mainc, style)
+	}
+
+	// n 跳到视口之外的下一个匹配
+	tr.DispatchKey(tcell.KeyRune, 'n', 0)
+	tr.Render()
+	content = getScreenContent(screen)
+	if !strings.Contains(content, "row 30 Target") || !strings.Contains(content, "2/2") {
+		t.Fatalf("expected second match with counter, got:\n%s", content)
+	}
+
+	// N 回绕到上一个匹配
+	tr.DispatchKey(tcell.KeyRune, 'N', 0)
+	tr.Render()
+	content = getScreenContent(screen)
+	if !strings.Contains(content, "row 05 Target") || !strings.Contains(content, "1/2") {
+		t.Errorf("expected N to go back, got:\n%s", content)
+	}
+
+	// Esc 退出查找
+	tr.DispatchKey(tcell.KeyEsc, 0, 0)
+	tr.Render()
+	content = getScreenContent(screen)
+	if strings.Contains(content, "/target") {
+		t.Errorf("expected search bar to close, got:\n%s", content)
+	}
+}
+
+func TestScrollBox_SearchNotEnabledByDefault(t *testing.T) {
+	app := func(c C) Node {
+		return ScrollBox(c.Child("scroll"), Text("hello"))
+	}
+
+	screen := newTestScreen(40, 5)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, '/', 0)
+	tr.Render()
+	if strings.Contains(getScreenContent(screen), "/") {
+		t.Errorf("expected / to be ignored without Searchable")
+	}
+}
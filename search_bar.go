@@ -0,0 +1,139 @@
+package rego
+
+import (
+	"fmt"
+	"time"
+)
+
+// =============================================================================
+// SearchBar - 搜索/过滤栏
+// =============================================================================
+
+// DefaultSearchDebounce 停止输入多久后把查询交给 OnChange
+const DefaultSearchDebounce = 200 * time.Millisecond
+
+type SearchProps struct {
+	Value       string             // 当前生效的查询
+	OnChange    func(query string) // 停止输入 Debounce 后调用；Enter、Esc 立即调用
+	Placeholder string
+	Hotkey      rune          // 在任意位置按下时聚焦搜索栏，默认 '/'；焦点在输入框中时不触发
+	Debounce    time.Duration // 0 表示 DefaultSearchDebounce
+
+	// Total 大于 0 且查询非空时在右侧显示 "Matches/Total"
+	Matches int
+	Total   int
+}
+
+// SearchBar 创建一行搜索栏，搭配 List、Table、LogView 等按 Value 过滤的组件使用
+//
+// 按 Hotkey 聚焦后直接输入，Backspace 删除，Ctrl+U 清空；Enter 立即提交并把焦点交给下一个组件，
+// Esc 清空查询，查询为空时再按 Esc 离开搜索栏。
+//
+//	rego.SearchBar(c.Child("search"), rego.SearchProps{
+//		Value:    query.Val,
+//		OnChange: query.Set,
+//		Matches:  len(filtered),
+//		Total:    len(items),
+//	})
+func SearchBar(c C, props SearchProps) Node {
+	ctx := c.(*componentContext)
+	focus := UseFocus(c)
+	theme := UseTheme(c)
+	UseCursorStyle(c, CursorStyle{Shape: CursorBar, Blink: true})
+	ctx.acceptsText = true
+
+	draft := Use(c, "draft", props.Value)
+	lastValue := UseRef(c, props.Value)
+	onChange := UseRef(c, props.OnChange)
+	onChange.Current = props.OnChange // 始终使用最新一次渲染传入的回调
+	stop := UseRef(c, func() {})
+
+	// 调用方修改了查询（如清空过滤）时同步到输入
+	if props.Value != lastValue.Current {
+		lastValue.Current = props.Value
+		draft.Set(props.Value)
+	}
+
+	UseEffect(c, func() func() {
+		return func() { stop.Current() }
+	})
+
+	hotkey := props.Hotkey
+	if hotkey == 0 {
+		hotkey = '/'
+	}
+	debounce := props.Debounce
+	if debounce <= 0 {
+		debounce = DefaultSearchDebounce
+	}
+
+	commit := func(q string) {
+		stop.Current()
+		if q != lastValue.Current && onChange.Current != nil {
+			onChange.Current(q)
+		}
+	}
+	edit := func(q string) {
+		draft.Set(q)
+		stop.Current()
+		stop.Current = ctx.clock().AfterFunc(debounce, func() {
+			apply := func() {
+				if draft.Val == q {
+					commit(q)
+				}
+			}
+			if ctx.runtime != nil {
+				ctx.runtime.post(apply)
+			} else {
+				apply()
+			}
+		})
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused {
+			if r == hotkey && ctx.runtime != nil && !ctx.runtime.focusAcceptsText() {
+				focus.Focus()
+			}
+			return
+		}
+		runes := []rune(draft.Val)
+		switch {
+		case key == KeyEnter:
+			commit(draft.Val)
+			focus.Blur()
+		case key == KeyEsc && draft.Val != "":
+			draft.Set("")
+			commit("")
+		case key == KeyEsc:
+			focus.Blur()
+		case key == KeyBackspace:
+			if len(runes) > 0 {
+				edit(string(runes[:prevGrapheme(runes, len(runes))]))
+			}
+		case key == KeyCtrlU:
+			edit("")
+		case r != 0:
+			edit(draft.Val + string(r))
+		}
+	})
+
+	iconColor := If(focus.IsFocused, theme.Primary, theme.Muted)
+	input := Node(Text(draft.Val))
+	if draft.Val == "" {
+		input = Text(props.Placeholder).Color(theme.Muted)
+	}
+	count := Node(nil)
+	if props.Total > 0 && props.Value != "" {
+		count = Text(fmt.Sprintf("%d/%d", props.Matches, props.Total)).Color(If(props.Matches == 0, theme.Error, theme.Muted))
+	}
+
+	return c.Wrap(HStack(
+		Text(string(hotkey)+" ").Color(iconColor).Bold(),
+		When(focus.IsFocused && draft.Val == "", Cursor(c)),
+		input,
+		When(focus.IsFocused && draft.Val != "", Cursor(c)),
+		Spacer(),
+		count,
+	))
+}
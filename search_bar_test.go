@@ -0,0 +1,77 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSearchBar_HotkeyAndDebounce(t *testing.T) {
+	items := []string{"apple", "banana", "cherry"}
+	var queries []string
+	app := func(c C) Node {
+		query := Use(c, "query", "")
+		var matches []string
+		for _, it := range items {
+			if strings.Contains(it, query.Val) {
+				matches = append(matches, it)
+			}
+		}
+		return VStack(
+			TextInput(c.Child("name"), TextInputProps{}),
+			SearchBar(c.Child("search"), SearchProps{
+				Value:       query.Val,
+				OnChange:    func(q string) { queries = append(queries, q); query.Set(q) },
+				Placeholder: "filter",
+				Matches:     len(matches),
+				Total:       len(items),
+			}),
+			List(c.Child("list"), ListProps{Items: matches}),
+		)
+	}
+
+	screen := newTestScreen(30, 8)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+
+	// 焦点在输入框中时 / 是普通输入
+	tr.DispatchKey(tcell.KeyRune, '/', 0)
+	tr.Render()
+	if !strings.Contains(getScreenContent(screen), "│ /") {
+		t.Fatalf("expected / typed into the input:\n%s", getScreenContent(screen))
+	}
+
+	tr.DispatchKey(tcell.KeyTab, 0, 0)
+	tr.DispatchKey(tcell.KeyTab, 0, 0) // 焦点移到列表
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, '/', 0)
+	tr.Render()
+	for _, r := range "an" {
+		tr.DispatchKey(tcell.KeyRune, r, 0)
+		tr.Render()
+	}
+	if len(queries) != 0 {
+		t.Fatalf("query dispatched before debounce: %v", queries)
+	}
+
+	tr.AdvanceTime(DefaultSearchDebounce)
+	tr.Render()
+	tr.Render()
+	if len(queries) != 1 || queries[0] != "an" {
+		t.Fatalf("queries = %v, want [an]", queries)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "/ an") || !strings.Contains(content, "1/3") {
+		t.Errorf("unexpected search bar:\n%s", content)
+	}
+
+	// Esc 立即清空
+	tr.DispatchKey(tcell.KeyEscape, 0, 0)
+	tr.Render()
+	if len(queries) != 2 || queries[1] != "" {
+		t.Errorf("queries = %v, want [an ]", queries)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "/ filter") {
+		t.Errorf("expected placeholder after clearing:\n%s", content)
+	}
+}
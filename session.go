@@ -0,0 +1,149 @@
+package rego
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// =============================================================================
+// 会话持久化
+// =============================================================================
+
+// SessionOptions 配置会话持久化，见 Options.Session
+type SessionOptions struct {
+	// Path 会话文件路径（JSON），支持以 ~ 开头
+	Path string
+
+	// Version 当前状态格式的版本，状态的类型或含义发生变化时递增
+	Version int
+
+	// Migrate 在文件中的版本与 Version 不同时调用，把旧版本的状态（以 "组件路径:key" 为键）转换为当前版本；
+	// 为 nil 或返回错误时丢弃旧的会话
+	Migrate func(from int, states map[string]json.RawMessage) (map[string]json.RawMessage, error)
+}
+
+// sessionFile 是会话文件的内容
+type sessionFile struct {
+	Version int                        `json:"version"`
+	States  map[string]json.RawMessage `json:"states"`
+}
+
+// sessionStore 保存启动时读到的会话与本次运行中登记的状态
+type sessionStore struct {
+	opts    SessionOptions
+	mu      sync.Mutex
+	loaded  map[string]json.RawMessage
+	tracked map[string]func() any
+}
+
+// loadSession 读取会话文件，文件不存在、无法解析或无法迁移时从空会话开始
+func loadSession(opts SessionOptions) *sessionStore {
+	s := &sessionStore{opts: opts, loaded: map[string]json.RawMessage{}, tracked: map[string]func() any{}}
+	data, err := os.ReadFile(expandHome(opts.Path))
+	if err != nil {
+		return s
+	}
+	var file sessionFile
+	if json.Unmarshal(data, &file) != nil || file.States == nil {
+		return s
+	}
+	if file.Version != opts.Version {
+		if opts.Migrate == nil {
+			return s
+		}
+		states, err := opts.Migrate(file.Version, file.States)
+		if err != nil || states == nil {
+			return s
+		}
+		file.States = states
+	}
+	s.loaded = file.States
+	return s
+}
+
+// restore 把会话中 id 对应的值解码到 out，没有或无法解码时返回 false
+func (s *sessionStore) restore(id string, out any) bool {
+	s.mu.Lock()
+	raw, ok := s.loaded[id]
+	s.mu.Unlock()
+	return ok && json.Unmarshal(raw, out) == nil
+}
+
+// track 登记 id 对应的状态，保存时调用 get 读取当前值
+func (s *sessionStore) track(id string, get func() any) {
+	s.mu.Lock()
+	s.tracked[id] = get
+	s.mu.Unlock()
+}
+
+// save 写入会话文件；本次没有渲染到的组件沿用启动时读到的值
+func (s *sessionStore) save() error {
+	s.mu.Lock()
+	file := sessionFile{Version: s.opts.Version, States: make(map[string]json.RawMessage, len(s.loaded)+len(s.tracked))}
+	for id, raw := range s.loaded {
+		file.States[id] = raw
+	}
+	for id, get := range s.tracked {
+		raw, err := json.Marshal(get())
+		if err != nil {
+			s.mu.Unlock()
+			return err
+		}
+		file.States[id] = raw
+	}
+	s.mu.Unlock()
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := expandHome(s.opts.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// saveSession 在退出时保存会话，未开启会话持久化时什么也不做
+func (r *Runtime) saveSession() error {
+	if r.session == nil {
+		return nil
+	}
+	return r.session.save()
+}
+
+// UsePersist 与 Use 相同，但开启 Options.Session 时状态会在退出时写入会话文件，
+// 下次启动时恢复。状态以组件路径和 key 标识，值需要能用 encoding/json 编解码：
+//
+//	draft := rego.UsePersist(c, "draft", "")
+//	tab := rego.UsePersist(c, "tab", 0)
+func UsePersist[T any](c C, key string, initial T) *State[T] {
+	ctx := c.(*componentContext)
+	var session *sessionStore
+	if ctx.runtime != nil {
+		session = ctx.runtime.session
+	}
+	if session == nil {
+		return Use(c, key, initial)
+	}
+
+	id := ctx.focusKey() + ":" + key
+	if _, ok := ctx.getState(key); !ok {
+		var saved T
+		if session.restore(id, &saved) {
+			initial = saved
+		}
+	}
+	state := Use(c, key, initial)
+	session.track(id, func() any {
+		v, _ := ctx.getState(key)
+		return v
+	})
+	return state
+}
@@ -0,0 +1,94 @@
+package rego
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestUsePersist_SaveAndRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	opts := Options{Session: &SessionOptions{Path: path, Version: 1}}
+	editor := func(c C) Node {
+		draft := UsePersist(c, "draft", "")
+		UseKey(c, func(key Key, r rune) {
+			if r != 0 {
+				draft.Set(draft.Val + string(r))
+			}
+		})
+		return Text("draft: " + draft.Val)
+	}
+	app := func(c C) Node {
+		return editor(c.Child("editor"))
+	}
+
+	screen := newTestScreen(20, 2)
+	tr := NewTestRuntime(app, screen)
+	tr.apply(opts)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, 'h', 0)
+	tr.DispatchKey(tcell.KeyRune, 'i', 0)
+	tr.Render()
+	if err := tr.saveSession(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 下次启动时恢复
+	screen = newTestScreen(20, 2)
+	tr = NewTestRuntime(app, screen)
+	tr.apply(opts)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "draft: hi") {
+		t.Fatalf("expected restored draft, got:\n%s", content)
+	}
+
+	// 版本不同且没有迁移函数时丢弃旧会话
+	screen = newTestScreen(20, 2)
+	tr = NewTestRuntime(app, screen)
+	tr.apply(Options{Session: &SessionOptions{Path: path, Version: 2}})
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "hi") {
+		t.Errorf("expected stale session to be dropped, got:\n%s", content)
+	}
+}
+
+func TestUsePersist_Migrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	app := func(c C) Node {
+		tab := UsePersist(c.Child("tabs"), "tab", 0)
+		return Text("tab " + string(rune('0'+tab.Val)))
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(20, 2))
+	tr.apply(Options{Session: &SessionOptions{Path: path, Version: 1}})
+	tr.Render()
+	tr.session.track("root/tabs:tab", func() any { return "second" }) // 第 1 版用名字保存
+	if err := tr.saveSession(); err != nil {
+		t.Fatal(err)
+	}
+
+	var from int
+	screen := newTestScreen(20, 2)
+	tr = NewTestRuntime(app, screen)
+	tr.apply(Options{Session: &SessionOptions{
+		Path:    path,
+		Version: 2,
+		Migrate: func(v int, states map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+			from = v
+			if string(states["root/tabs:tab"]) == `"second"` {
+				states["root/tabs:tab"] = json.RawMessage("1")
+			}
+			return states, nil
+		},
+	}})
+	tr.Render()
+	if from != 1 {
+		t.Errorf("Migrate called with version %d, want 1", from)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "tab 1") {
+		t.Errorf("expected migrated tab, got:\n%s", content)
+	}
+}
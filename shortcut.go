@@ -0,0 +1,76 @@
+package rego
+
+import "fmt"
+
+// =============================================================================
+// UseShortcut - 组件快捷键
+// =============================================================================
+
+// shortcut 组件注册的一个快捷键
+type shortcut struct {
+	action  string  // 在 keymap 中定义的动作，为空时使用 spec
+	spec    keySpec // 直接写出的键位
+	handler func()
+}
+
+func (s shortcut) matches(key Key, r rune) bool {
+	if s.action != "" {
+		return MatchAction(s.action, key, r)
+	}
+	return s.spec.matches(key, r)
+}
+
+// UseShortcut 注册一个只在组件的焦点子树内生效的快捷键
+//
+// keys 可以是 DefineAction 定义的动作名（使用其当前键位，包括用户在 LoadKeymap 中的覆盖），
+// 也可以直接写键位（写法见 LoadKeymap），非法的键位会 panic。
+//
+// 焦点位于组件自身或其后代时快捷键生效；没有组件获得焦点时只有根组件的快捷键生效。
+// 多个组件注册了同一按键时，离焦点最近（最内层）的组件优先，触发的快捷键会消费这次按键，
+// 不再广播给 UseKey。单个字符的快捷键会拦截子树中输入框的输入，通常应使用 ctrl+ 组合键。
+//
+//	rego.UseShortcut(c, "ctrl+s", save)
+//	rego.UseShortcut(c, "quit", c.Quit) // 先 DefineAction("quit", "退出", "q")
+func UseShortcut(c C, keys string, handler func()) {
+	ctx := c.(*componentContext)
+	s := shortcut{handler: handler}
+	if actionDefined(keys) {
+		s.action = keys
+	} else {
+		spec, err := parseKeySpec(keys)
+		if err != nil {
+			panic(fmt.Sprintf("rego: UseShortcut(%q): %v", keys, err))
+		}
+		s.spec = spec
+	}
+	ctx.shortcuts = append(ctx.shortcuts, s)
+}
+
+// actionDefined 报告 keymap 中是否有名为 action 的动作
+func actionDefined(action string) bool {
+	keymap.mu.RLock()
+	defer keymap.mu.RUnlock()
+	_, ok := keymap.actions[action]
+	return ok
+}
+
+// dispatchShortcut 从当前焦点所在的组件向上查找匹配的快捷键，找到时执行并返回 true
+func (r *Runtime) dispatchShortcut(key Key, ru rune) bool {
+	ctx := r.focusManager.CurrentContext()
+	if ctx == nil {
+		ctx = r.rootContext
+	}
+	for ; ctx != nil; ctx = ctx.parent {
+		if ctx.seenFrame != r.frame {
+			continue // 本帧没有渲染的组件
+		}
+		for _, s := range ctx.shortcuts {
+			if s.matches(key, ru) {
+				s.handler()
+				r.scheduleRefresh()
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestUseShortcut_InnermostScopeWins(t *testing.T) {
+	var got []string
+	var focusB func()
+	field := func(c C, name string) Node {
+		focus := UseFocus(c)
+		if name == "b" {
+			focusB = focus.Focus
+			UseShortcut(c, "ctrl+s", func() { got = append(got, "b:save") })
+		}
+		return Text(name)
+	}
+	keys := 0
+	app := func(c C) Node {
+		UseShortcut(c, "ctrl+s", func() { got = append(got, "root:save") })
+		UseShortcut(c, "q", func() { got = append(got, "root:q") })
+		UseKey(c, func(Key, rune) { keys++ })
+		return VStack(field(c.Child("a"), "a"), field(c.Child("b"), "b"))
+	}
+	tr := NewTestRuntime(app, newTestScreen(10, 2))
+	tr.Render()
+
+	// 焦点在 a：只有根组件的快捷键在作用域内
+	tr.DispatchKey(tcell.KeyCtrlS, 0, 0)
+	focusB()
+	tr.Render()
+	// 焦点在 b：b 的快捷键优先，没有冲突的按键仍由外层处理
+	tr.DispatchKey(tcell.KeyCtrlS, 0, 0)
+	tr.DispatchKey(tcell.KeyRune, 'q', 0)
+	tr.DispatchKey(tcell.KeyRune, 'x', 0)
+
+	want := []string{"root:save", "b:save", "root:q"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if keys != 1 {
+		t.Errorf("UseKey received %d keys, want only the unhandled one", keys)
+	}
+}
+
+func TestUseShortcut_Action(t *testing.T) {
+	DefineAction("test.shortcut.refresh", "刷新", "f5")
+	fired := 0
+	app := func(c C) Node {
+		UseShortcut(c, "test.shortcut.refresh", func() { fired++ })
+		return Text("x")
+	}
+	tr := NewTestRuntime(app, newTestScreen(5, 1))
+	tr.Render()
+	tr.DispatchKey(tcell.KeyF5, 0, 0)
+	if fired != 1 {
+		t.Errorf("fired = %d, want action key to trigger shortcut", fired)
+	}
+}
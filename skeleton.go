@@ -0,0 +1,91 @@
+package rego
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// Skeleton - 加载占位
+// =============================================================================
+
+const (
+	skeletonFrame = 80 * time.Millisecond // 高光每帧移动一列
+	skeletonBand  = 6                     // 高光宽度
+)
+
+type skeletonNode struct {
+	width int
+	lines int
+}
+
+// Skeleton 创建 lines 行、宽 width 的占位块，带有从左到右扫过的高光，用于数据加载完成前占位；
+// width 为 0 时占满可用宽度，多行时最后一行较短，看起来像一段文字
+func Skeleton(width, lines int) Node {
+	return &skeletonNode{width: width, lines: max(lines, 1)}
+}
+
+func (s *skeletonNode) render(screen tcell.Screen, x, y, width, height int) int {
+	w := width
+	if s.width > 0 {
+		w = min(s.width, width)
+	}
+	if w <= 0 || height <= 0 {
+		return 0
+	}
+	phase := skeletonPhase(screen)
+	n := min(s.lines, height)
+	for i := 0; i < n; i++ {
+		lineW := w
+		if s.lines > 1 && i == s.lines-1 {
+			lineW = max(w*3/5, 1)
+		}
+		drawSkeleton(screen, x, y+i, lineW, phase-i)
+	}
+	return n
+}
+
+func (s *skeletonNode) measureHeight(width int) int {
+	return s.lines
+}
+
+func (s *skeletonNode) measureWidth() int {
+	if s.width > 0 {
+		return s.width
+	}
+	return 10
+}
+
+// skeletonPhase 返回当前的动画帧，并请求下一帧重绘
+func skeletonPhase(screen tcell.Screen) int {
+	r := screenRuntime(screen)
+	if r == nil {
+		return 0
+	}
+	if r.skeletonTick.CompareAndSwap(false, true) {
+		r.clock.AfterFunc(skeletonFrame, func() {
+			r.skeletonTick.Store(false)
+			r.scheduleRefresh()
+		})
+	}
+	return int(r.clock.Now().UnixMilli() / skeletonFrame.Milliseconds())
+}
+
+// drawSkeleton 绘制一行宽 w 的占位块，phase 决定高光的位置
+func drawSkeleton(screen tcell.Screen, x, y, w, phase int) {
+	if w <= 0 {
+		return
+	}
+	base := tcell.StyleDefault.Foreground(colorToTcell(Gray))
+	shine := tcell.StyleDefault.Foreground(colorToTcell(White))
+	period := w + skeletonBand*2
+	band := (phase%period+period)%period - skeletonBand
+	for i := 0; i < w; i++ {
+		if i >= band && i < band+skeletonBand {
+			screen.SetContent(x+i, y, '▒', nil, shine)
+		} else {
+			screen.SetContent(x+i, y, '░', nil, base)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSkeleton_Shimmer(t *testing.T) {
+	app := func(c C) Node {
+		return VStack(Skeleton(20, 2), Text("after"))
+	}
+	screen := newTestScreen(30, 3)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if got := strings.TrimRight(lines[0], " "); len([]rune(got)) != 20 || strings.Trim(got, "░▒") != "" {
+		t.Fatalf("first line = %q, want 20 skeleton cells", got)
+	}
+	if got := strings.TrimRight(lines[1], " "); len([]rune(got)) != 12 {
+		t.Errorf("last line = %q, want 12 cells", got)
+	}
+	if !strings.HasPrefix(lines[2], "after") {
+		t.Errorf("expected content after skeleton, got %q", lines[2])
+	}
+
+	// 高光随时间移动：一个周期内画面会变化
+	seen := map[string]bool{lines[0]: true}
+	for i := 0; i < 20+skeletonBand*2; i++ {
+		tr.AdvanceTime(skeletonFrame)
+		tr.Render()
+		seen[strings.Split(getScreenContent(screen), "\n")[0]] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("expected shimmer to move, got %v", seen)
+	}
+}
+
+func TestTableAndList_Loading(t *testing.T) {
+	loading := true
+	app := func(c C) Node {
+		return VStack(
+			Table(c.Child("table"), TableProps{
+				Columns: []TableColumn{{Title: "name", Width: 8}, {Title: "size", Width: 6}},
+				Rows:    [][]string{{"a.txt", "1K"}},
+				Loading: loading,
+			}),
+			List(c.Child("list"), ListProps{Items: []string{"first"}, Height: 2, Loading: loading}),
+		)
+	}
+	screen := newTestScreen(30, 8)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+
+	content := getScreenContent(screen)
+	lines := strings.Split(content, "\n")
+	if !strings.HasPrefix(lines[0], "name") {
+		t.Errorf("expected header while loading, got %q", lines[0])
+	}
+	if strings.Contains(content, "a.txt") || strings.Contains(content, "first") {
+		t.Errorf("expected data hidden while loading:\n%s", content)
+	}
+	// 表头 2 行 + 3 行占位，然后是列表的 2 行占位
+	for _, y := range []int{2, 3, 4, 5, 6} {
+		if !strings.ContainsAny(lines[y], "░▒") {
+			t.Errorf("line %d = %q, want skeleton", y, lines[y])
+		}
+	}
+
+	loading = false
+	tr.AdvanceTime(time.Second)
+	tr.Render()
+	content = getScreenContent(screen)
+	if !strings.Contains(content, "a.txt") || !strings.Contains(content, "first") || strings.ContainsAny(content, "░▒") {
+		t.Errorf("expected data after loading:\n%s", content)
+	}
+}
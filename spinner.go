@@ -13,17 +13,10 @@ var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "
 func Spinner(c C, label string) Node {
 	frame := Use(c, "frame", 0)
 
-	UseEffect(c, func() func() {
-		ticker := time.NewTicker(100 * time.Millisecond)
-		go func() {
-			for range ticker.C {
-				frame.Update(func(v int) int {
-					return (v + 1) % len(spinnerFrames)
-				})
-				c.Refresh()
-			}
-		}()
-		return func() { ticker.Stop() }
+	UseInterval(c, 100*time.Millisecond, func() {
+		frame.Update(func(v int) int {
+			return (v + 1) % len(spinnerFrames)
+		})
 	})
 
 	return HStack(
@@ -0,0 +1,116 @@
+package rego
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// UseStream - 流式文本
+// =============================================================================
+
+// Stream 是 UseStream 返回的文本缓冲，实现了 io.Writer，可以在任意协程中写入
+type Stream struct {
+	ctx      *componentContext
+	mu       sync.Mutex
+	buf      strings.Builder
+	closed   bool
+	notified bool // 上次渲染之后已经请求过刷新，同一帧内的多次写入只刷新一次
+}
+
+// UseStream 返回组件中名为 key 的流式文本缓冲，写入的内容追加到缓冲末尾并自动刷新组件
+//
+// 同一帧内的多次写入合并为一次刷新，适合直接接到 LLM SDK 的流式回调上：
+//
+//	reply := rego.UseStream(c, "reply")
+//	go client.Stream(ctx, prompt, func(token string) { reply.WriteString(token) })
+//	rego.Markdown(reply.String())
+func UseStream(c C, key string) *Stream {
+	ctx := c.(*componentContext)
+	stateKey := "stream:" + key
+	s, ok := ctx.getState(stateKey)
+	if !ok {
+		s = &Stream{ctx: ctx}
+		ctx.setState(stateKey, s)
+	}
+	stream := s.(*Stream)
+
+	stream.rendered()
+	return stream
+}
+
+// rendered 标记本次渲染会读到目前为止写入的全部内容，之后的写入重新请求刷新
+func (s *Stream) rendered() {
+	s.mu.Lock()
+	s.notified = false
+	s.mu.Unlock()
+}
+
+// Write 追加 p，流已关闭时返回 io.ErrClosedPipe
+func (s *Stream) Write(p []byte) (int, error) {
+	return s.WriteString(string(p))
+}
+
+// WriteString 追加 str，流已关闭时返回 io.ErrClosedPipe
+func (s *Stream) WriteString(str string) (int, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return 0, io.ErrClosedPipe
+	}
+	s.buf.WriteString(str)
+	s.mu.Unlock()
+	s.notify()
+	return len(str), nil
+}
+
+// String 返回目前为止写入的全部内容
+func (s *Stream) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+// Len 返回已写入内容的字节数
+func (s *Stream) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Len()
+}
+
+// Close 标记流已结束，之后的写入返回错误
+func (s *Stream) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.notify()
+	return nil
+}
+
+// Closed 报告流是否已经结束
+func (s *Stream) Closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Reset 清空内容并重新打开流，用于开始下一轮输出
+func (s *Stream) Reset() {
+	s.mu.Lock()
+	s.buf.Reset()
+	s.closed = false
+	s.mu.Unlock()
+	s.notify()
+}
+
+// notify 在本帧第一次变化时刷新组件
+func (s *Stream) notify() {
+	s.mu.Lock()
+	first := !s.notified
+	s.notified = true
+	s.mu.Unlock()
+	if first {
+		s.ctx.Refresh()
+	}
+}
@@ -0,0 +1,71 @@
+package rego
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUseStream_WritesRefreshMemoizedComponent(t *testing.T) {
+	var reply *Stream
+	renders := 0
+	app := func(c C) Node {
+		return Memo(c, "reply", func(c C) Node {
+			renders++
+			reply = UseStream(c, "reply")
+			return Text(reply.String())
+		})
+	}
+
+	screen := newTestScreen(40, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fmt.Fprint(reply, "ab")
+		}()
+	}
+	wg.Wait()
+	tr.Render()
+
+	if got := strings.TrimSpace(getScreenContent(screen)); got != strings.Repeat("ab", 10) {
+		t.Errorf("content = %q, want 10 tokens", got)
+	}
+	if renders != 2 {
+		t.Errorf("renders = %d, want writes within a frame coalesced into one", renders)
+	}
+}
+
+func TestUseStream_CloseAndReset(t *testing.T) {
+	var reply *Stream
+	app := func(c C) Node {
+		reply = UseStream(c, "reply")
+		return Text(reply.String())
+	}
+	tr := NewTestRuntime(app, newTestScreen(20, 1))
+	tr.Render()
+
+	reply.WriteString("done")
+	reply.Close()
+	if !reply.Closed() {
+		t.Fatal("expected stream to be closed")
+	}
+	if _, err := reply.WriteString("more"); err != io.ErrClosedPipe {
+		t.Errorf("write after close: err = %v, want io.ErrClosedPipe", err)
+	}
+	if reply.String() != "done" {
+		t.Errorf("content = %q, want %q", reply.String(), "done")
+	}
+
+	reply.Reset()
+	tr.Render()
+	if reply.Closed() || reply.Len() != 0 {
+		t.Errorf("after reset: closed=%v len=%d", reply.Closed(), reply.Len())
+	}
+}
@@ -1,6 +1,12 @@
 package rego
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // Color 表示颜色
 type Color int
@@ -19,6 +25,27 @@ const (
 	Gray
 )
 
+// colorRGBFlag 标记由 RGB 构造的真彩色，低 24 位为 0xRRGGBB
+const colorRGBFlag Color = 1 << 24
+
+// RGB 创建一个真彩色
+func RGB(r, g, b uint8) Color {
+	return colorRGBFlag | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// Hex 解析 "#rrggbb" 或 "#rgb" 形式的颜色
+func Hex(s string) (Color, error) {
+	h := strings.TrimPrefix(s, "#")
+	if len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if len(h) != 6 || err != nil {
+		return Default, fmt.Errorf("invalid hex color %q", s)
+	}
+	return colorRGBFlag | Color(v), nil
+}
+
 // Style 表示样式
 type Style struct {
 	fg        Color
@@ -181,6 +208,9 @@ func (s Style) toTcell() tcell.Style {
 }
 
 func colorToTcell(c Color) tcell.Color {
+	if c&colorRGBFlag != 0 {
+		return tcell.NewHexColor(int32(c &^ colorRGBFlag))
+	}
 	switch c {
 	case Black:
 		return tcell.ColorBlack
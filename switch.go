@@ -0,0 +1,80 @@
+package rego
+
+import "github.com/mattn/go-runewidth"
+
+// =============================================================================
+// Switch - 开关组件
+// =============================================================================
+
+type SwitchProps struct {
+	On        bool
+	OnChanged func(bool)
+	Labels    [2]string // 关闭/开启时显示的文字，默认 "OFF"/"ON"
+	Label     string    // 开关右侧的说明文字
+}
+
+// Switch 创建一个胶囊样式的开关，颜色取自主题
+//
+// 聚焦时 Space/Enter 切换，← 关闭，→ 开启；鼠标点击切换。
+func Switch(c C, props SwitchProps) Node {
+	focus := UseFocus(c)
+	theme := UseTheme(c)
+
+	set := func(on bool) {
+		if on != props.On && props.OnChanged != nil {
+			props.OnChanged(on)
+		}
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused {
+			return
+		}
+		switch {
+		case key == KeyEnter || key == KeySpace || r == ' ':
+			set(!props.On)
+		case key == KeyLeft:
+			set(false)
+		case key == KeyRight:
+			set(true)
+		}
+	})
+
+	UseMouse(c, func(ev MouseEvent) {
+		if ev.Type == MouseEventClick && ev.Button == MouseButtonLeft && c.Rect().Contains(ev.X, ev.Y) {
+			focus.Focus()
+			set(!props.On)
+		}
+	})
+
+	off, on := props.Labels[0], props.Labels[1]
+	if off == "" && on == "" {
+		off, on = "OFF", "ON"
+	}
+	// 两种状态的文字补齐到同一宽度，避免切换时跳动
+	w := runewidth.StringWidth(off)
+	if ow := runewidth.StringWidth(on); ow > w {
+		w = ow
+	}
+
+	var pill *textNode
+	if props.On {
+		pill = Text(" " + runewidth.FillLeft(on, w) + " ● ").Background(theme.Primary).Color(theme.OnColor)
+	} else {
+		pill = Text(" ● " + runewidth.FillRight(off, w) + " ").Background(theme.Muted).Color(theme.OnColor)
+	}
+	if focus.IsFocused {
+		pill = pill.Bold()
+	}
+
+	label := Text(props.Label)
+	if focus.IsFocused {
+		label = label.Color(theme.Primary)
+	}
+
+	return c.Wrap(Box(HStack(
+		pill,
+		When(props.Label != "", Text(" ")),
+		When(props.Label != "", label),
+	)).Padding(0, 1))
+}
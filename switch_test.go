@@ -0,0 +1,99 @@
+package rego
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSwitch_Keys(t *testing.T) {
+	var changes []bool
+	app := func(c C) Node {
+		on := Use(c, "on", false)
+		return Switch(c.Child("switch"), SwitchProps{
+			On:        on.Val,
+			OnChanged: func(v bool) { changes = append(changes, v); on.Set(v) },
+			Label:     "Wi-Fi",
+		})
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "● OFF") || !strings.Contains(content, "Wi-Fi") {
+		t.Fatalf("unexpected initial switch: %q", content)
+	}
+
+	tr.DispatchKey(tcell.KeyRune, ' ', 0)
+	tr.Render()
+	for _, key := range []tcell.Key{tcell.KeyEnter, tcell.KeyLeft, tcell.KeyRight, tcell.KeyRight} {
+		tr.DispatchKey(key, 0, 0)
+		tr.Render()
+	}
+	// ← 在关闭时、→ 在开启时不触发 OnChanged
+	if want := []bool{true, false, true}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("OnChanged values = %v, want %v", changes, want)
+	}
+	if content := getScreenContent(screen); !strings.Contains(content, "ON ●") {
+		t.Errorf("expected switch on: %q", content)
+	}
+}
+
+func TestSwitch_ClickAndLabels(t *testing.T) {
+	var changes []bool
+	app := func(c C) Node {
+		on := Use(c, "on", false)
+		return Switch(c.Child("switch"), SwitchProps{
+			On:        on.Val,
+			OnChanged: func(v bool) { changes = append(changes, v); on.Set(v) },
+			Labels:    [2]string{"no", "yes"},
+		})
+	}
+	click := func(tr *Runtime) {
+		tr.DispatchMouse(3, 0, tcell.Button1, 0)
+		tr.DispatchMouse(3, 0, tcell.ButtonNone, 0)
+		tr.Render()
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	// 两种状态补齐到同一宽度
+	if content := getScreenContent(screen); !strings.HasPrefix(content, "  ● no  ") {
+		t.Fatalf("unexpected off label: %q", content)
+	}
+
+	click(tr)
+	if content := getScreenContent(screen); !strings.HasPrefix(content, "  yes ● ") {
+		t.Errorf("unexpected on label: %q", content)
+	}
+	click(tr)
+	if want := []bool{true, false}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("OnChanged values = %v, want %v", changes, want)
+	}
+}
+
+func TestSwitch_ThemeColors(t *testing.T) {
+	theme := DefaultTheme
+	theme.Primary = Green
+	theme.Muted = Blue
+	on := false
+	app := func(c C) Node {
+		return ThemeContext.Provide(c, theme, Switch(c.Child("switch"), SwitchProps{On: on}))
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if _, _, style, _ := screen.GetContent(2, 0); bgOf(style) != colorToTcell(Blue) {
+		t.Errorf("off pill background = %v, want theme Muted", bgOf(style))
+	}
+
+	on = true
+	tr.Render()
+	if _, _, style, _ := screen.GetContent(2, 0); bgOf(style) != colorToTcell(Green) {
+		t.Errorf("on pill background = %v, want theme Primary", bgOf(style))
+	}
+}
@@ -0,0 +1,855 @@
+package rego
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Table - 表格组件（支持冻结列和横向滚动）
+// =============================================================================
+
+// TableColumn 描述表格的一列
+type TableColumn struct {
+	Title string
+	Width int // 0 表示按内容自动计算
+	Align Align
+}
+
+type TableProps struct {
+	Columns       []TableColumn
+	Rows          [][]string
+	Source        TableSource // 非空时按需从 Source 读取可见的行，忽略 Rows
+	FrozenColumns int         // 固定在左侧、不参与横向滚动的列数
+	Height        int         // 0 表示按行数自动计算
+	OnSelect      func(row int)
+	OnReorder     func(from, to int) // 非空时可以用鼠标拖动或 Alt+↑/↓ 调整行的顺序
+	Loading       bool               // 数据加载中：保留表头，数据行显示为 Skeleton
+	Striped       bool               // 数据行隔行使用主题的 Stripe 背景（斑马纹）
+
+	// 编辑模式
+	Editable   bool                             // 是否允许编辑单元格
+	OnCellEdit func(row, col int, value string) // 单元格编辑提交时回调
+	CellEditor func(c C, cell CellEdit) Node    // 自定义编辑器，nil 使用内置单行编辑器
+
+	// 多选模式
+	Selectable         bool  // 是否在最左侧显示勾选列
+	Selected           []int // 已勾选的行下标
+	OnSelectionChanged func(selected []int)
+}
+
+// CellEdit 描述一次正在进行的单元格编辑，传给 CellEditor
+type CellEdit struct {
+	Row, Col int
+	Value    string             // 当前编辑中的值
+	OnChange func(value string) // 编辑器在值变化时调用
+	Commit   func()             // 提交当前值
+	Cancel   func()             // 放弃编辑
+}
+
+// tableHeaderHeight 表头 + 分隔线占用的行数
+const tableHeaderHeight = 2
+
+// Table 创建一个表格
+//
+// 聚焦时 ↑/↓ 选择行，←/→ 横向滚动非冻结列，Enter 触发 OnSelect。
+// 鼠标滚轮纵向滚动，Shift+滚轮横向滚动。
+//
+// 开启 Editable 后 ←/→ 在单元格之间移动，Enter 进入编辑，
+// 编辑器中 Enter 或失去焦点时提交并触发 OnCellEdit，Esc 放弃。
+//
+// 开启 Selectable 后 Space 勾选当前行，Ctrl+A 全选/全不选，
+// 点击勾选列切换该行，Shift+点击勾选从上次勾选的行到点击行之间的所有行。
+//
+// 设置 OnReorder 后 Alt+↑/↓ 把选中行上移/下移一位，按住行拖动时预览放下后的顺序，松开时触发 OnReorder。
+//
+// 设置 Source 后每帧只读取可见窗口内的行，点击表头按该列排序，再次点击切换升序/降序。
+func Table(c C, props TableProps) Node {
+	ctx := c.(*componentContext)
+	focus := UseFocus(c)
+	selected := Use(c, "selected", 0)
+	selectedCol := Use(c, "selectedCol", 0)
+	rowOffset := Use(c, "rowOffset", 0)
+	scrollCol := Use(c, "scrollCol", 0)
+	editing := Use(c, "editing", false)
+	editText := Use(c, "editText", "")
+	anchor := Use(c, "anchor", -1) // 上次勾选的行，Shift+点击范围选择的起点
+	drag := Use(c, "drag", rowDrag{from: -1})
+	sortCol := Use(c, "sortCol", -1)
+	sortDir := Use(c, "sortDir", SortAsc)
+	theme := UseTheme(c)
+	ctx.acceptsText = editing.Val
+
+	// 数据行：使用 Source 时只包含可见窗口内的行，base 为 rows[0] 的行号
+	rows, base, rowCount := props.Rows, 0, len(props.Rows)
+	var sourceErr error
+	if props.Source != nil {
+		rowCount, sourceErr = props.Source.Count()
+	}
+	frozen := max(0, min(props.FrozenColumns, len(props.Columns)))
+	maxScrollCol := len(props.Columns) - frozen - 1
+	if maxScrollCol < 0 {
+		maxScrollCol = 0
+	}
+
+	// 可见数据行数基于上一帧的布局
+	visibleRows := c.Rect().H - tableHeaderHeight
+	if visibleRows <= 0 {
+		visibleRows = rowCount
+		if props.Height > 0 {
+			visibleRows = props.Height - tableHeaderHeight
+		} else if props.Source != nil {
+			visibleRows = min(rowCount, tableSourcePage)
+		}
+	}
+
+	if props.Source != nil && sourceErr == nil {
+		base = clampTableOffset(max(min(selected.Val, rowCount-1), 0), rowOffset.Val, visibleRows)
+		rows, sourceErr = props.Source.Rows(base, visibleRows)
+	}
+
+	cellValue := func(row, col int) string {
+		row -= base
+		if row >= 0 && row < len(rows) && col >= 0 && col < len(rows[row]) {
+			return rows[row][col]
+		}
+		return ""
+	}
+
+	commit := func() {
+		if !editing.Val {
+			return
+		}
+		editing.Set(false)
+		if props.OnCellEdit != nil {
+			props.OnCellEdit(selected.Val, selectedCol.Val, editText.Val)
+		}
+	}
+	cancel := func() {
+		editing.Set(false)
+	}
+
+	selectRow := func(row int) {
+		if rowCount == 0 {
+			return
+		}
+		if row < 0 {
+			row = 0
+		}
+		if row >= rowCount {
+			row = rowCount - 1
+		}
+		selected.Set(row)
+		rowOffset.Set(clampTableOffset(row, rowOffset.Val, visibleRows))
+	}
+
+	// sortBy 按 col 排序：与当前排序列相同时切换方向，否则升序
+	sortBy := func(col int) {
+		dir := SortAsc
+		if col == sortCol.Val && sortDir.Val == SortAsc {
+			dir = SortDesc
+		}
+		props.Source.Sort(col, dir)
+		sortCol.Set(col)
+		sortDir.Set(dir)
+		selectRow(0)
+	}
+
+	scrollColumns := func(delta int) {
+		scrollCol.Update(func(v int) int {
+			v += delta
+			if v < 0 {
+				v = 0
+			}
+			if v > maxScrollCol {
+				v = maxScrollCol
+			}
+			return v
+		})
+	}
+
+	// selectColumn 移动活动单元格所在列，并横向滚动使其可见
+	selectColumn := func(col int) {
+		if col < 0 || col >= len(props.Columns) {
+			return
+		}
+		selectedCol.Set(col)
+		if col < frozen {
+			return
+		}
+		probe := &tableNode{columns: props.Columns, rows: rows, frozen: frozen, scrollCol: scrollCol.Val, selectable: props.Selectable, sortable: props.Source != nil}
+		if col-frozen < probe.scrollCol {
+			probe.scrollCol = col - frozen
+		}
+		for probe.scrollCol < col-frozen && !probe.fullyVisible(col, c.Rect().W) {
+			probe.scrollCol++
+		}
+		scrollCol.Set(probe.scrollCol)
+	}
+
+	startEdit := func() {
+		if rowCount == 0 || len(props.Columns) == 0 {
+			return
+		}
+		editText.Set(cellValue(selected.Val, selectedCol.Val))
+		editing.Set(true)
+	}
+
+	checked := make(map[int]bool, len(props.Selected))
+	for _, i := range props.Selected {
+		checked[i] = true
+	}
+
+	emitSelection := func(next map[int]bool) {
+		if props.OnSelectionChanged == nil {
+			return
+		}
+		out := make([]int, 0, len(next))
+		for i, on := range next {
+			if on && i >= 0 && i < rowCount {
+				out = append(out, i)
+			}
+		}
+		sort.Ints(out)
+		props.OnSelectionChanged(out)
+	}
+
+	toggleRow := func(row int) {
+		if row < 0 || row >= rowCount {
+			return
+		}
+		next := make(map[int]bool, len(checked)+1)
+		for k, v := range checked {
+			next[k] = v
+		}
+		next[row] = !next[row]
+		anchor.Set(row)
+		emitSelection(next)
+	}
+
+	// selectRange 勾选 anchor 到 row 之间的所有行（保留已有勾选）
+	selectRange := func(row int) {
+		from := anchor.Val
+		if from < 0 || from >= rowCount {
+			toggleRow(row)
+			return
+		}
+		next := make(map[int]bool, len(checked)+rowCount)
+		for k, v := range checked {
+			next[k] = v
+		}
+		for i := min(from, row); i <= max(from, row); i++ {
+			next[i] = true
+		}
+		emitSelection(next)
+	}
+
+	// toggleAll 未全部勾选时全选，否则全部取消
+	toggleAll := func() {
+		next := map[int]bool{}
+		if len(props.Selected) < rowCount {
+			for i := 0; i < rowCount; i++ {
+				next[i] = true
+			}
+		}
+		emitSelection(next)
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		// 编辑期间按键交给编辑器处理
+		if !focus.IsFocused || editing.Val {
+			return
+		}
+		if props.OnReorder != nil && ctx.keyMods()&ModAlt != 0 && (key == KeyUp || key == KeyDown) {
+			to := selected.Val + If(key == KeyUp, -1, 1)
+			if rowCount > 0 && to >= 0 && to < rowCount {
+				props.OnReorder(selected.Val, to)
+				selectRow(to)
+			}
+			return
+		}
+		if props.Selectable {
+			switch {
+			case key == KeySpace || r == ' ':
+				toggleRow(selected.Val)
+				return
+			case key == KeyCtrlA:
+				toggleAll()
+				return
+			}
+		}
+		switch key {
+		case KeyUp:
+			selectRow(selected.Val - 1)
+		case KeyDown:
+			selectRow(selected.Val + 1)
+		case KeyPageUp:
+			selectRow(selected.Val - visibleRows)
+		case KeyPageDown:
+			selectRow(selected.Val + visibleRows)
+		case KeyHome:
+			selectRow(0)
+		case KeyEnd:
+			selectRow(rowCount - 1)
+		case KeyLeft:
+			if props.Editable {
+				selectColumn(selectedCol.Val - 1)
+			} else {
+				scrollColumns(-1)
+			}
+		case KeyRight:
+			if props.Editable {
+				selectColumn(selectedCol.Val + 1)
+			} else {
+				scrollColumns(1)
+			}
+		case KeyEnter:
+			if props.Editable {
+				startEdit()
+			} else if props.OnSelect != nil && rowCount > 0 {
+				props.OnSelect(selected.Val)
+			}
+		}
+	})
+
+	UseMouse(c, func(ev MouseEvent) {
+		rect := c.Rect()
+		if props.OnReorder != nil && handleRowDrag(drag, ev, rowOffset.Val+ev.Y-rect.Y-tableHeaderHeight, rowCount, props.OnReorder, selectRow) {
+			return
+		}
+		if !rect.Contains(ev.X, ev.Y) {
+			return
+		}
+		switch ev.Type {
+		case MouseEventPress:
+			row := rowOffset.Val + ev.Y - rect.Y - tableHeaderHeight
+			if props.OnReorder != nil && ev.Button == MouseButtonLeft && !editing.Val && row >= rowOffset.Val && row < rowCount {
+				drag.Set(rowDrag{from: row, to: row})
+			}
+		case MouseEventClick:
+			if ev.Button != MouseButtonLeft {
+				return
+			}
+			focus.Focus()
+			row := ev.Y - rect.Y - tableHeaderHeight
+			inCheck := props.Selectable && ev.X < rect.X+tableCheckWidth
+			if inCheck && row == -tableHeaderHeight {
+				toggleAll()
+				return
+			}
+			if props.Source != nil && row == -tableHeaderHeight {
+				probe := &tableNode{columns: props.Columns, rows: rows, frozen: frozen, scrollCol: scrollCol.Val, selectable: props.Selectable, sortable: props.Source != nil}
+				if col := probe.columnAt(rect.X, rect.W, ev.X); col >= 0 {
+					sortBy(col)
+				}
+				return
+			}
+			if row < 0 {
+				return
+			}
+			if props.Selectable && rowOffset.Val+row < rowCount {
+				if ev.Mod&ModShift != 0 {
+					selectRange(rowOffset.Val + row)
+				} else if inCheck {
+					toggleRow(rowOffset.Val + row)
+				} else {
+					anchor.Set(rowOffset.Val + row)
+				}
+			}
+			probe := &tableNode{columns: props.Columns, rows: rows, frozen: frozen, scrollCol: scrollCol.Val, selectable: props.Selectable, sortable: props.Source != nil}
+			col := probe.columnAt(rect.X, rect.W, ev.X)
+			if editing.Val && (rowOffset.Val+row != selected.Val || col != selectedCol.Val) {
+				commit()
+			}
+			selectRow(rowOffset.Val + row)
+			if props.Editable && col >= 0 {
+				selectedCol.Set(col)
+			}
+		case MouseEventScrollUp:
+			if ev.Mod&ModShift != 0 {
+				scrollColumns(-1)
+			} else {
+				selectRow(selected.Val - 1)
+			}
+		case MouseEventScrollDown:
+			if ev.Mod&ModShift != 0 {
+				scrollColumns(1)
+			} else {
+				selectRow(selected.Val + 1)
+			}
+		case MouseEventScrollLeft:
+			scrollColumns(-1)
+		case MouseEventScrollRight:
+			scrollColumns(1)
+		}
+	})
+
+	// 失去焦点时提交编辑
+	if editing.Val && !focus.IsFocused {
+		commit()
+	}
+
+	sel := selected.Val
+	if sel >= rowCount {
+		sel = rowCount - 1
+	}
+
+	// 每帧都获取编辑器上下文，避免退出编辑后旧的按键处理器残留
+	editorCtx := c.Child("editor")
+	var editor Node
+	if editing.Val {
+		cell := CellEdit{
+			Row:      sel,
+			Col:      selectedCol.Val,
+			Value:    editText.Val,
+			OnChange: editText.Set,
+			Commit:   commit,
+			Cancel:   cancel,
+		}
+		if props.CellEditor != nil {
+			editor = props.CellEditor(editorCtx, cell)
+		} else {
+			editor = tableCellInput(editorCtx, cell)
+		}
+	}
+
+	activeCol := -1
+	if props.Editable {
+		activeCol = selectedCol.Val
+	}
+
+	if props.Loading {
+		return c.Wrap(&tableNode{
+			columns:   props.Columns,
+			frozen:    frozen,
+			scrollCol: scrollCol.Val,
+			height:    props.Height,
+			loading:   If(props.Height > 0, props.Height-tableHeaderHeight, tableLoadingRows),
+			sortable:  props.Source != nil,
+			sortCol:   -1,
+		})
+	}
+
+	if sourceErr != nil {
+		return c.Wrap(Text("⚠ " + sourceErr.Error()).Color(Red))
+	}
+
+	// 拖动期间按放下后的顺序预览
+	dragRow := -1
+	if d := drag.Val; props.Source == nil && d.from >= 0 && d.to != d.from && d.from < rowCount && d.to < rowCount {
+		order := reorderPreview(rowCount, d.from, d.to)
+		rows = make([][]string, rowCount)
+		previewChecked := make(map[int]bool, len(checked))
+		for i, src := range order {
+			rows[i] = props.Rows[src]
+			previewChecked[i] = checked[src]
+		}
+		checked, sel, dragRow = previewChecked, d.to, d.to
+	}
+
+	return c.Wrap(&tableNode{
+		columns:   props.Columns,
+		rows:      rows,
+		frozen:    frozen,
+		scrollCol: scrollCol.Val,
+		rowOffset: clampTableOffset(sel, rowOffset.Val, visibleRows),
+		base:      base,
+		total:     rowCount,
+		dragRow:   dragRow,
+		sortable:  props.Source != nil,
+		sortCol:   If(props.Source != nil, sortCol.Val, -1),
+		sortDir:   sortDir.Val,
+		selected:  sel,
+		activeCol: activeCol,
+		editor:    editor,
+		focused:   focus.IsFocused,
+		height:    props.Height,
+		stripe:    If(props.Striped, theme.Stripe, Default),
+
+		selectable: props.Selectable,
+		checked:    checked,
+	})
+}
+
+// tableCellInput 内置的单行单元格编辑器
+func tableCellInput(c C, cell CellEdit) Node {
+	cursor := Use(c, "cursor", len([]rune(cell.Value)))
+
+	// 切换到新的单元格时光标回到末尾
+	UseEffect(c, func() func() {
+		cursor.Set(len([]rune(cell.Value)))
+		return nil
+	}, cell.Row, cell.Col)
+
+	// value 保存最新的文本：每帧取 cell.Value，两帧之间的连续按键基于上一次修改的结果继续编辑，
+	// 避免快速输入丢字（与 TextInput 相同）
+	value := UseRef(c, cell.Value)
+	value.Current = cell.Value
+	setValue := func(v string) {
+		value.Current = v
+		cell.OnChange(v)
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		runes := []rune(value.Current)
+		pos := min(cursor.Val, len(runes))
+		switch key {
+		case KeyEnter:
+			cell.Commit()
+		case KeyEsc:
+			cell.Cancel()
+		case KeyLeft:
+			if pos > 0 {
+				cursor.Set(pos - 1)
+			}
+		case KeyRight:
+			if pos < len(runes) {
+				cursor.Set(pos + 1)
+			}
+		case KeyHome:
+			cursor.Set(0)
+		case KeyEnd:
+			cursor.Set(len(runes))
+		case KeyBackspace:
+			if pos > 0 {
+				setValue(string(runes[:pos-1]) + string(runes[pos:]))
+				cursor.Set(pos - 1)
+			}
+		case KeyDelete:
+			if pos < len(runes) {
+				setValue(string(runes[:pos]) + string(runes[pos+1:]))
+			}
+		default:
+			if r != 0 {
+				setValue(string(runes[:pos]) + string(r) + string(runes[pos:]))
+				cursor.Set(pos + 1)
+			}
+		}
+	})
+
+	runes := []rune(value.Current)
+	pos := min(cursor.Val, len(runes))
+	return c.Wrap(HStack(
+		Text(string(runes[:pos])).Underline(),
+		Cursor(c),
+		Text(string(runes[pos:])).Underline(),
+	))
+}
+
+// clampTableOffset 保证选中行处于可见窗口内
+func clampTableOffset(selected, offset, visible int) int {
+	if visible <= 0 {
+		return 0
+	}
+	if selected < offset {
+		offset = selected
+	}
+	if selected >= offset+visible {
+		offset = selected - visible + 1
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// =============================================================================
+// tableNode - 表格渲染节点
+// =============================================================================
+
+type tableNode struct {
+	columns   []TableColumn
+	rows      [][]string
+	frozen    int
+	scrollCol int
+	rowOffset int
+	selected  int
+	activeCol int  // 活动单元格所在列，-1 表示整行选择
+	editor    Node // 正在编辑时替换活动单元格的编辑器
+	focused   bool
+	height    int
+	dragRow   int  // 正在拖动的行预览所在的位置，-1 表示没有拖动
+	base      int  // rows[0] 的行号，使用 Source 时 rows 只包含可见窗口
+	total     int  // 总行数
+	sortable  bool // 表头预留排序标记的宽度
+	sortCol   int  // 排序列，-1 表示不显示排序标记
+	sortDir   SortDir
+	loading   int   // 大于 0 时不显示数据，绘制这么多行占位
+	stripe    Color // 隔行的背景色，Default 表示不显示斑马纹
+
+	selectable bool
+	checked    map[int]bool
+}
+
+// tableLoadingRows 未指定高度时加载中显示的占位行数
+const tableLoadingRows = 3
+
+// tableCheckWidth 勾选列占用的宽度（"[x]" 加一个空格）
+const tableCheckWidth = 4
+
+// columnWidths 计算每一列的显示宽度
+func (t *tableNode) columnWidths() []int {
+	widths := make([]int, len(t.columns))
+	for i, col := range t.columns {
+		if col.Width > 0 {
+			widths[i] = col.Width
+			continue
+		}
+		w := runewidth.StringWidth(col.Title)
+		if t.sortable {
+			w += 2 // " ▲"
+		}
+		for _, row := range t.rows {
+			if i < len(row) {
+				if cw := runewidth.StringWidth(row[i]); cw > w {
+					w = cw
+				}
+			}
+		}
+		widths[i] = w
+	}
+	return widths
+}
+
+// tableCell 表示一列在屏幕上的位置
+type tableCell struct {
+	col   int
+	x     int
+	width int
+}
+
+// layout 计算当前可见列的位置，返回可见列和冻结分隔线的位置（-1 表示无）
+func (t *tableNode) layout(x, width int) ([]tableCell, int) {
+	if t.selectable {
+		x += tableCheckWidth
+		width -= tableCheckWidth
+	}
+	widths := t.columnWidths()
+	var cells []tableCell
+	cur := x
+	right := x + width
+
+	for i := 0; i < t.frozen && cur < right; i++ {
+		w := widths[i]
+		if cur+w > right {
+			w = right - cur
+		}
+		cells = append(cells, tableCell{col: i, x: cur, width: w})
+		cur += w + 1
+	}
+
+	sepX := -1
+	if t.frozen > 0 && t.frozen < len(t.columns) && cur < right {
+		sepX = cur
+		cur += 2
+	}
+
+	for i := t.frozen + t.scrollCol; i < len(t.columns) && cur < right; i++ {
+		w := widths[i]
+		if cur+w > right {
+			w = right - cur
+		}
+		cells = append(cells, tableCell{col: i, x: cur, width: w})
+		cur += w + 1
+	}
+	return cells, sepX
+}
+
+// fullyVisible 判断某列在给定宽度下是否完整可见
+func (t *tableNode) fullyVisible(col, width int) bool {
+	if width <= 0 {
+		return true
+	}
+	widths := t.columnWidths()
+	cells, _ := t.layout(0, width)
+	for _, cell := range cells {
+		if cell.col == col {
+			return cell.width >= widths[col]
+		}
+	}
+	return false
+}
+
+// columnAt 返回屏幕横坐标 px 处的列，未命中返回 -1
+func (t *tableNode) columnAt(x, width, px int) int {
+	cells, _ := t.layout(x, width)
+	for _, cell := range cells {
+		if px >= cell.x && px < cell.x+cell.width {
+			return cell.col
+		}
+	}
+	return -1
+}
+
+func (t *tableNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	if t.height > 0 && t.height < height {
+		height = t.height
+	}
+
+	cells, sepX := t.layout(x, width)
+	lastVisible := -1
+	if len(cells) > 0 {
+		lastVisible = cells[len(cells)-1].col
+	}
+
+	// 表头
+	headerStyle := tcell.StyleDefault.Bold(true)
+	for _, cell := range cells {
+		title := t.columns[cell.col].Title
+		if cell.col == t.sortCol {
+			title += If(t.sortDir == SortAsc, " ▲", " ▼")
+		}
+		drawTableCell(screen, cell, y, title, t.columns[cell.col].Align, headerStyle)
+	}
+	if sepX >= 0 {
+		screen.SetContent(sepX, y, '│', nil, tcell.StyleDefault.Foreground(tcell.ColorGray))
+	}
+	if t.selectable {
+		t.drawCheck(screen, x, y, t.allCheckedMark(), headerStyle)
+	}
+	if height == 1 {
+		return 1
+	}
+
+	// 分隔线与横向滚动指示
+	lineStyle := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	for i := 0; i < width; i++ {
+		screen.SetContent(x+i, y+1, '─', nil, lineStyle)
+	}
+	if sepX >= 0 {
+		screen.SetContent(sepX, y+1, '┼', nil, lineStyle)
+		if t.scrollCol > 0 {
+			screen.SetContent(sepX+1, y+1, '‹', nil, tcell.StyleDefault.Foreground(colorToTcell(Cyan)))
+		}
+	} else if t.scrollCol > 0 {
+		screen.SetContent(x, y+1, '‹', nil, tcell.StyleDefault.Foreground(colorToTcell(Cyan)))
+	}
+	if lastVisible >= 0 && lastVisible < len(t.columns)-1 {
+		screen.SetContent(x+width-1, y+1, '›', nil, tcell.StyleDefault.Foreground(colorToTcell(Cyan)))
+	}
+
+	// 数据行
+	used := tableHeaderHeight
+	if t.loading > 0 {
+		phase := skeletonPhase(screen)
+		for i := 0; i < t.loading && used < height; i++ {
+			for _, cell := range cells {
+				drawSkeleton(screen, cell.x, y+used, cell.width, phase-i)
+			}
+			used++
+		}
+		return used
+	}
+	for i := t.rowOffset; i-t.base < len(t.rows) && used < height; i++ {
+		rowY := y + used
+		style := tcell.StyleDefault
+		if i == t.selected {
+			if i == t.dragRow {
+				style = style.Foreground(colorToTcell(Cyan)).Bold(true).Underline(true)
+			} else if t.focused {
+				style = style.Background(colorToTcell(Cyan)).Foreground(tcell.ColorBlack)
+			} else {
+				style = style.Reverse(true)
+			}
+			for col := x; col < x+width; col++ {
+				screen.SetContent(col, rowY, ' ', nil, style)
+			}
+		} else if t.stripe != Default && i%2 == 1 {
+			style = style.Background(colorToTcell(t.stripe))
+			for col := x; col < x+width; col++ {
+				screen.SetContent(col, rowY, ' ', nil, style)
+			}
+		}
+		row := t.rows[i-t.base]
+		for _, cell := range cells {
+			if i == t.selected && cell.col == t.activeCol {
+				if t.editor != nil {
+					for col := cell.x; col < cell.x+cell.width; col++ {
+						screen.SetContent(col, rowY, ' ', nil, tcell.StyleDefault)
+					}
+					t.editor.render(screen, cell.x, rowY, cell.width, 1)
+					continue
+				}
+				cellStyle := style.Bold(true).Underline(true)
+				text := ""
+				if cell.col < len(row) {
+					text = row[cell.col]
+				}
+				drawTableCell(screen, cell, rowY, text, t.columns[cell.col].Align, cellStyle)
+				continue
+			}
+			text := ""
+			if cell.col < len(row) {
+				text = row[cell.col]
+			}
+			drawTableCell(screen, cell, rowY, text, t.columns[cell.col].Align, style)
+		}
+		if sepX >= 0 {
+			screen.SetContent(sepX, rowY, '│', nil, style.Foreground(tcell.ColorGray))
+		}
+		if t.selectable {
+			t.drawCheck(screen, x, rowY, If(t.checked[i], 'x', ' '), style)
+		}
+		used++
+	}
+	return used
+}
+
+// allCheckedMark 返回表头勾选框的标记：全部勾选为 x，部分勾选为 -，否则为空格
+func (t *tableNode) allCheckedMark() rune {
+	n := 0
+	for i, on := range t.checked {
+		if on && i >= 0 && i < t.total {
+			n++
+		}
+	}
+	switch {
+	case n > 0 && n == t.total:
+		return 'x'
+	case n > 0:
+		return '-'
+	}
+	return ' '
+}
+
+// drawCheck 在勾选列绘制 "[mark]"
+func (t *tableNode) drawCheck(screen tcell.Screen, x, y int, mark rune, style tcell.Style) {
+	for i, r := range []rune{'[', mark, ']'} {
+		screen.SetContent(x+i, y, r, nil, style)
+	}
+}
+
+// drawTableCell 在单元格内绘制文本（超出宽度时截断）
+func drawTableCell(screen tcell.Screen, cell tableCell, y int, text string, align Align, style tcell.Style) {
+	if cell.width <= 0 {
+		return
+	}
+	if runewidth.StringWidth(text) > cell.width {
+		text = runewidth.Truncate(text, cell.width, "…")
+	}
+	tw := runewidth.StringWidth(text)
+	col := cell.x
+	switch align {
+	case AlignCenter:
+		col += (cell.width - tw) / 2
+	case AlignRight:
+		col += cell.width - tw
+	}
+	for _, r := range text {
+		screen.SetContent(col, y, r, nil, style)
+		col += runewidth.RuneWidth(r)
+	}
+}
+
+func (t *tableNode) measureHeight(width int) int {
+	h := t.total + t.loading + tableHeaderHeight
+	if t.height > 0 {
+		h = t.height
+	}
+	return h
+}
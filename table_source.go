@@ -0,0 +1,177 @@
+package rego
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// TableSource - 按需读取的表格数据
+// =============================================================================
+
+// SortDir 排序方向
+type SortDir int
+
+const (
+	SortAsc SortDir = iota
+	SortDesc
+)
+
+// TableSource 为 Table 按需提供数据行，适合行数很多、不便一次读入内存的数据（如数据库查询结果）
+//
+// 方法在 UI 循环中调用，Rows 每帧都会被调用，实现应自行缓存最近读取的窗口。
+type TableSource interface {
+	// Count 返回总行数
+	Count() (int, error)
+	// Rows 返回从 offset 开始的至多 limit 行
+	Rows(offset, limit int) ([][]string, error)
+	// Sort 设置之后 Rows 返回的顺序，col 为列下标
+	Sort(col int, dir SortDir)
+}
+
+// tableSourcePage 还不知道表格高度时第一次读取的行数
+const tableSourcePage = 100
+
+// SQLSource 把一条 SQL 查询适配为 TableSource，通过 LIMIT/OFFSET 分页读取，
+// 排序时在外层加上 ORDER BY（按列序号），数据库需要支持子查询、LIMIT 和 OFFSET（SQLite、MySQL、PostgreSQL 等）
+type SQLSource struct {
+	db      *sql.DB
+	query   string
+	args    []any
+	columns []string
+
+	mu     sync.Mutex
+	order  string // ORDER BY 子句，为空表示按原查询的顺序
+	count  int    // 缓存的总行数，-1 表示需要重新查询
+	window sqlWindow
+}
+
+// sqlWindow 最近一次读取的行
+type sqlWindow struct {
+	offset, limit int
+	order         string
+	rows          [][]string
+	valid         bool
+}
+
+// NewSQLSource 创建一个 SQLSource，query 不能以分号结尾；创建时执行一次查询以取得列名
+//
+//	src, err := rego.NewSQLSource(db, "SELECT id, name, email FROM users WHERE active = ?", true)
+//	rego.Table(c.Child("users"), rego.TableProps{Columns: src.Columns(), Source: src, Height: 20})
+func NewSQLSource(db *sql.DB, query string, args ...any) (*SQLSource, error) {
+	s := &SQLSource{db: db, query: query, args: args, count: -1}
+	rows, err := db.Query(s.wrap("SELECT * FROM (%s) AS rego_t LIMIT 0"), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if s.columns, err = rows.Columns(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// wrap 把原查询放入 format 中的 %s
+func (s *SQLSource) wrap(format string) string {
+	return fmt.Sprintf(format, s.query)
+}
+
+// Columns 返回查询结果的列，可直接作为 TableProps.Columns
+func (s *SQLSource) Columns() []TableColumn {
+	cols := make([]TableColumn, len(s.columns))
+	for i, name := range s.columns {
+		cols[i] = TableColumn{Title: name}
+	}
+	return cols
+}
+
+// Refresh 丢弃缓存，下次读取时重新查询
+func (s *SQLSource) Refresh() {
+	s.mu.Lock()
+	s.count = -1
+	s.window = sqlWindow{}
+	s.mu.Unlock()
+}
+
+func (s *SQLSource) Count() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.count >= 0 {
+		return s.count, nil
+	}
+	if err := s.db.QueryRow(s.wrap("SELECT COUNT(*) FROM (%s) AS rego_t"), s.args...).Scan(&s.count); err != nil {
+		s.count = -1
+		return 0, err
+	}
+	return s.count, nil
+}
+
+func (s *SQLSource) Rows(offset, limit int) ([][]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.window
+	if w.valid && w.offset == offset && w.limit == limit && w.order == s.order {
+		return w.rows, nil
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	q := s.wrap("SELECT * FROM (%s) AS rego_t") + s.order + fmt.Sprintf(" LIMIT %d OFFSET %d", limit, max(offset, 0))
+	rows, err := s.db.Query(q, s.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out [][]string
+	values := make([]any, len(s.columns))
+	ptrs := make([]any, len(s.columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = formatSQLValue(v)
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	s.window = sqlWindow{offset: offset, limit: limit, order: s.order, rows: out, valid: true}
+	return out, nil
+}
+
+func (s *SQLSource) Sort(col int, dir SortDir) {
+	if col < 0 || col >= len(s.columns) {
+		return
+	}
+	order := fmt.Sprintf(" ORDER BY %d", col+1)
+	if dir == SortDesc {
+		order += " DESC"
+	}
+	s.mu.Lock()
+	s.order = order
+	s.mu.Unlock()
+}
+
+// formatSQLValue 把扫描到的值转换为单元格文本
+func formatSQLValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.DateTime)
+	default:
+		return fmt.Sprint(v)
+	}
+}
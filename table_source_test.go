@@ -0,0 +1,142 @@
+package rego
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// fakeSQLDriver 是只认识 SQLSource 生成的几种查询的内存数据库，数据为 id 1..n 与 name "user<id>"
+type fakeSQLDriver struct {
+	n       int
+	queries []string
+}
+
+func (d *fakeSQLDriver) Open(string) (driver.Conn, error) { return fakeSQLConn{d}, nil }
+
+type fakeSQLConn struct{ d *fakeSQLDriver }
+
+func (c fakeSQLConn) Prepare(query string) (driver.Stmt, error) { return fakeSQLStmt{c.d, query}, nil }
+func (c fakeSQLConn) Close() error                              { return nil }
+func (c fakeSQLConn) Begin() (driver.Tx, error)                 { return nil, fmt.Errorf("not supported") }
+
+type fakeSQLStmt struct {
+	d     *fakeSQLDriver
+	query string
+}
+
+var fakeSQLPage = regexp.MustCompile(`(?: ORDER BY (\d+)( DESC)?)? LIMIT (\d+) OFFSET (\d+)$`)
+
+func (s fakeSQLStmt) Close() error  { return nil }
+func (s fakeSQLStmt) NumInput() int { return -1 }
+func (s fakeSQLStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (s fakeSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.queries = append(s.d.queries, s.query)
+	if strings.HasPrefix(s.query, "SELECT COUNT(*)") {
+		return &fakeSQLRows{cols: []string{"count"}, rows: [][]driver.Value{{int64(s.d.n)}}}, nil
+	}
+	rows := make([][]driver.Value, s.d.n)
+	for i := range rows {
+		rows[i] = []driver.Value{int64(i + 1), []byte("user" + strconv.Itoa(i+1))}
+	}
+	result := &fakeSQLRows{cols: []string{"id", "name"}}
+	if strings.HasSuffix(s.query, "LIMIT 0") {
+		return result, nil
+	}
+	m := fakeSQLPage.FindStringSubmatch(s.query)
+	if m == nil {
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+	if m[1] != "" {
+		col, _ := strconv.Atoi(m[1])
+		desc := m[2] != ""
+		sort.SliceStable(rows, func(i, j int) bool {
+			a, b := fmt.Sprint(rows[i][col-1]), fmt.Sprint(rows[j][col-1])
+			return (a < b) != desc
+		})
+	}
+	limit, _ := strconv.Atoi(m[3])
+	offset, _ := strconv.Atoi(m[4])
+	result.rows = rows[min(offset, len(rows)):min(offset+limit, len(rows))]
+	return result, nil
+}
+
+type fakeSQLRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+func (r *fakeSQLRows) Columns() []string { return r.cols }
+func (r *fakeSQLRows) Close() error      { return nil }
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestTable_SQLSource(t *testing.T) {
+	fake := &fakeSQLDriver{n: 1000}
+	sql.Register("rego-fake-table", fake)
+	db, err := sql.Open("rego-fake-table", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	src, err := NewSQLSource(db, "SELECT id, name FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := func(c C) Node {
+		return Table(c.Child("table"), TableProps{Columns: src.Columns(), Source: src, Height: 7})
+	}
+	screen := newTestScreen(20, 7)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.Render()
+
+	content := getScreenContent(screen)
+	if !strings.Contains(content, "user1") || strings.Contains(content, "user6") {
+		t.Fatalf("expected first page, got:\n%s", content)
+	}
+	for _, q := range fake.queries {
+		if strings.Contains(q, "LIMIT") && !strings.HasSuffix(q, "LIMIT 5 OFFSET 0") && !strings.HasSuffix(q, "LIMIT 0") {
+			t.Errorf("expected only the visible window to be read, got %q", q)
+		}
+	}
+
+	// 翻到末尾只读取末尾的窗口
+	tr.DispatchKey(tcell.KeyEnd, 0, 0)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "user1000") {
+		t.Errorf("expected last page, got:\n%s", content)
+	}
+	if last := fake.queries[len(fake.queries)-1]; !strings.HasSuffix(last, "LIMIT 5 OFFSET 995") {
+		t.Errorf("last query = %q", last)
+	}
+
+	// 点击表头按该列升序，再次点击切换为降序
+	mouseClick(tr, 0, 0)
+	mouseClick(tr, 0, 0)
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.Contains(lines[0], "id ▼") {
+		t.Errorf("expected descending marker in header, got %q", lines[0])
+	}
+	if last := fake.queries[len(fake.queries)-1]; !strings.Contains(last, "ORDER BY 1 DESC LIMIT 5 OFFSET 0") {
+		t.Errorf("last query = %q", last)
+	}
+}
@@ -0,0 +1,301 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTable_FrozenColumns(t *testing.T) {
+	app := func(c C) Node {
+		return Table(c.Child("table"), TableProps{
+			Columns: []TableColumn{
+				{Title: "Name", Width: 6},
+				{Title: "Alpha", Width: 8},
+				{Title: "Beta", Width: 8},
+				{Title: "Gamma", Width: 8},
+			},
+			Rows: [][]string{
+				{"row1", "a1", "b1", "c1"},
+				{"row2", "a2", "b2", "c2"},
+			},
+			FrozenColumns: 1,
+		})
+	}
+
+	screen := newTestScreen(24, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(lines[0], "Name") || !strings.Contains(lines[0], "Alpha") {
+		t.Fatalf("unexpected header: %q", lines[0])
+	}
+	if strings.Contains(lines[0], "Gamma") {
+		t.Fatalf("Gamma should not fit before scrolling: %q", lines[0])
+	}
+
+	// 横向滚动两列：冻结列保持，Gamma 出现
+	tr.DispatchKey(tcell.KeyRight, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRight, 0, 0)
+	tr.Render()
+
+	lines = strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(lines[0], "Name") {
+		t.Errorf("frozen column should stay pinned: %q", lines[0])
+	}
+	if strings.Contains(lines[0], "Alpha") || !strings.Contains(lines[0], "Gamma") {
+		t.Errorf("expected scrolled header, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "row1") || !strings.Contains(lines[2], "c1") {
+		t.Errorf("expected row aligned with header, got %q", lines[2])
+	}
+
+	// 水平滚轮向左滚回两列
+	tr.handleEvent(tcell.NewEventMouse(8, 2, tcell.WheelLeft, 0))
+	tr.Render()
+	tr.handleEvent(tcell.NewEventMouse(8, 2, tcell.WheelLeft, 0))
+	tr.Render()
+	lines = strings.Split(getScreenContent(screen), "\n")
+	if !strings.Contains(lines[0], "Alpha") || strings.Contains(lines[0], "Gamma") {
+		t.Errorf("expected wheel-left to scroll back, got %q", lines[0])
+	}
+	tr.handleEvent(tcell.NewEventMouse(8, 2, tcell.WheelRight, 0))
+	tr.Render()
+	lines = strings.Split(getScreenContent(screen), "\n")
+	if strings.Contains(lines[0], "Alpha") {
+		t.Errorf("expected wheel-right to scroll one column, got %q", lines[0])
+	}
+}
+
+func TestTable_NegativeFrozenColumns(t *testing.T) {
+	app := func(c C) Node {
+		return Table(c.Child("table"), TableProps{
+			Columns:       []TableColumn{{Title: "Name", Width: 6}, {Title: "Alpha", Width: 8}},
+			Rows:          [][]string{{"row1", "a1"}},
+			FrozenColumns: -1,
+		})
+	}
+
+	screen := newTestScreen(24, 4)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRight, 0, 0)
+	tr.Render()
+
+	if lines := strings.Split(getScreenContent(screen), "\n"); !strings.Contains(lines[0], "Alpha") {
+		t.Errorf("expected negative FrozenColumns to behave like 0, got %q", lines[0])
+	}
+}
+
+func TestClampTableOffset(t *testing.T) {
+	tests := []struct {
+		name                              string
+		selected, offset, visible, expect int
+	}{
+		{"选中行在窗口内", 3, 0, 5, 0},
+		{"选中行在窗口上方", 1, 4, 5, 1},
+		{"选中行在窗口下方", 10, 0, 5, 6},
+		{"无可见行", 3, 2, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clampTableOffset(tt.selected, tt.offset, tt.visible); got != tt.expect {
+				t.Errorf("clampTableOffset(%d, %d, %d) = %d, want %d", tt.selected, tt.offset, tt.visible, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestTable_EditCell(t *testing.T) {
+	var editedRow, editedCol int
+	var editedValue string
+	edits := 0
+
+	app := func(c C) Node {
+		return Table(c.Child("table"), TableProps{
+			Columns: []TableColumn{{Title: "Key"}, {Title: "Value", Width: 10}},
+			Rows: [][]string{
+				{"host", "localhost"},
+				{"port", "8080"},
+			},
+			Editable: true,
+			OnCellEdit: func(row, col int, value string) {
+				editedRow, editedCol, editedValue = row, col, value
+				edits++
+			},
+		})
+	}
+
+	screen := newTestScreen(30, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 移到第二行第二列并进入编辑
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRight, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyBackspace2, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, '1', 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+
+	if edits != 1 {
+		t.Fatalf("expected 1 edit, got %d", edits)
+	}
+	if editedRow != 1 || editedCol != 1 || editedValue != "8081" {
+		t.Errorf("OnCellEdit(%d, %d, %q), want (1, 1, \"8081\")", editedRow, editedCol, editedValue)
+	}
+
+	// Esc 放弃编辑
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, 'x', 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEscape, 0, 0)
+	tr.Render()
+	if edits != 1 {
+		t.Errorf("Esc should not commit, got %d edits", edits)
+	}
+}
+
+func TestTable_EditCellFastTyping(t *testing.T) {
+	var editedValue string
+	app := func(c C) Node {
+		return Table(c.Child("table"), TableProps{
+			Columns:  []TableColumn{{Title: "Value", Width: 10}},
+			Rows:     [][]string{{""}},
+			Editable: true,
+			OnCellEdit: func(row, col int, value string) {
+				editedValue = value
+			},
+		})
+	}
+
+	screen := newTestScreen(30, 4)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+
+	// 两次按键之间没有渲染，第二次按键需要基于第一次的结果
+	tr.DispatchKey(tcell.KeyRune, 'a', 0)
+	tr.DispatchKey(tcell.KeyRune, 'b', 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+
+	if editedValue != "ab" {
+		t.Errorf("OnCellEdit value = %q, want \"ab\"", editedValue)
+	}
+}
+
+func TestTable_RowSelection(t *testing.T) {
+	var selected []int
+	app := func(c C) Node {
+		sel := Use(c, "sel", []int(nil))
+		selected = sel.Val
+		return Table(c.Child("table"), TableProps{
+			Columns: []TableColumn{{Title: "Name"}},
+			Rows: [][]string{
+				{"alpha"}, {"beta"}, {"gamma"}, {"delta"}, {"omega"},
+			},
+			Selectable:         true,
+			Selected:           sel.Val,
+			OnSelectionChanged: sel.Set,
+		})
+	}
+
+	screen := newTestScreen(20, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(lines[0], "[ ] Name") || !strings.HasPrefix(lines[2], "[ ] alpha") {
+		t.Fatalf("expected checkbox column, got:\n%s", getScreenContent(screen))
+	}
+
+	// Space 勾选当前行
+	tr.DispatchKey(tcell.KeyRune, ' ', 0)
+	tr.Render()
+	if len(selected) != 1 || selected[0] != 0 {
+		t.Fatalf("expected row 0 selected, got %v", selected)
+	}
+	lines = strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(lines[0], "[-]") || !strings.HasPrefix(lines[2], "[x] alpha") {
+		t.Errorf("expected checked row and partial header, got:\n%s", getScreenContent(screen))
+	}
+
+	// Shift+点击第 4 行：勾选 0~3
+	tr.handleEvent(tcell.NewEventMouse(8, 5, tcell.Button1, tcell.ModShift))
+	tr.handleEvent(tcell.NewEventMouse(8, 5, tcell.ButtonNone, tcell.ModShift))
+	tr.Render()
+	if len(selected) != 4 || selected[3] != 3 {
+		t.Fatalf("expected range 0-3 selected, got %v", selected)
+	}
+
+	// 点击勾选列切换单行
+	tr.handleEvent(tcell.NewEventMouse(1, 3, tcell.Button1, 0))
+	tr.handleEvent(tcell.NewEventMouse(1, 3, tcell.ButtonNone, 0))
+	tr.Render()
+	if len(selected) != 3 || selected[0] != 0 || selected[1] != 2 {
+		t.Fatalf("expected row 1 unchecked, got %v", selected)
+	}
+
+	// Ctrl+A 全选，再按一次全不选
+	tr.DispatchKey(tcell.KeyCtrlA, 0, 0)
+	tr.Render()
+	if len(selected) != 5 {
+		t.Fatalf("expected all rows selected, got %v", selected)
+	}
+	if lines = strings.Split(getScreenContent(screen), "\n"); !strings.HasPrefix(lines[0], "[x]") {
+		t.Errorf("expected header fully checked, got %q", lines[0])
+	}
+	tr.DispatchKey(tcell.KeyCtrlA, 0, 0)
+	tr.Render()
+	if len(selected) != 0 {
+		t.Errorf("expected selection cleared, got %v", selected)
+	}
+}
+
+func TestTable_Reorder(t *testing.T) {
+	var moves [][2]int
+	app := func(c C) Node {
+		rows := Use(c, "rows", [][]string{{"a"}, {"b"}, {"c"}})
+		return Table(c.Child("table"), TableProps{
+			Columns: []TableColumn{{Title: "Name"}},
+			Rows:    rows.Val,
+			OnReorder: func(from, to int) {
+				moves = append(moves, [2]int{from, to})
+				next := make([][]string, 0, len(rows.Val))
+				for _, i := range reorderPreview(len(rows.Val), from, to) {
+					next = append(next, rows.Val[i])
+				}
+				rows.Set(next)
+			},
+		})
+	}
+	screen := newTestScreen(10, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyDown, 0, tcell.ModAlt)
+	tr.Render()
+	mouseDrag(tr, 1, 2, 1, 4)
+	lines := strings.Split(getScreenContent(screen), "\n")
+	got := strings.TrimSpace(lines[2]) + strings.TrimSpace(lines[3]) + strings.TrimSpace(lines[4])
+	if got != "acb" {
+		t.Errorf("rows = %q, want acb", got)
+	}
+	if len(moves) != 2 || moves[0] != [2]int{0, 1} || moves[1] != [2]int{0, 2} {
+		t.Errorf("moves = %v, want [[0 1] [0 2]]", moves)
+	}
+}
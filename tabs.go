@@ -0,0 +1,135 @@
+package rego
+
+import (
+	"slices"
+	"strings"
+)
+
+// =============================================================================
+// Tabs - 标签页
+// =============================================================================
+
+// TabPane 一个标签页
+type TabPane struct {
+	Title    string
+	Key      string // 标识标签页的状态，默认使用 Title；标题会变化或重复时应设置
+	Modified bool
+	Content  func(c C) Node
+}
+
+func (p TabPane) key() string {
+	if p.Key != "" {
+		return p.Key
+	}
+	return p.Title
+}
+
+type TabsProps struct {
+	Panes    []TabPane
+	Active   int             // OnSelect 非空时由调用方控制当前标签页
+	OnSelect func(index int) // 为空时 Tabs 自己记录当前标签页
+	OnClose  func(index int)
+}
+
+// Tabs 创建带 TabStrip 的标签页，只渲染当前标签页的内容
+//
+// 每个标签页的内容使用按 Key 区分的独立上下文，其中 ScrollBox 的滚动位置、输入框的内容等状态
+// 在切换标签页后保留。焦点在标签页中时 Ctrl+PgUp/PgDn 切换标签页；焦点原本在内容中时，
+// 切换后焦点回到新标签页上次聚焦的组件（没有时为第一个可聚焦组件）。
+// 从 Panes 中移除的标签页（如在 OnClose 中关闭）的上下文被释放，其中的副作用执行清理。
+//
+//	rego.Tabs(c.Child("tabs"), rego.TabsProps{Panes: []rego.TabPane{
+//		{Title: "Logs", Content: func(c rego.C) rego.Node { return rego.ScrollBox(c.Child("scroll"), logs) }},
+//		{Title: "Config", Content: configPage},
+//	}})
+func Tabs(c C, props TabsProps) Node {
+	ctx := c.(*componentContext)
+	selected := Use(c, "active", 0)
+	focused := UseRef(c, map[string]string{}) // 各标签页上次聚焦的组件
+	shown := UseRef(c, "")                    // 上一帧显示的标签页
+
+	active, onSelect := selected.Val, selected.Set
+	if props.OnSelect != nil {
+		active, onSelect = props.Active, props.OnSelect
+	}
+	active = max(0, min(active, len(props.Panes)-1))
+
+	var fm *FocusManager
+	if ctx.runtime != nil {
+		fm = ctx.runtime.focusManager
+	}
+	within := func(key, prefix string) bool {
+		return key == prefix || strings.HasPrefix(key, prefix+"/")
+	}
+
+	// 焦点在标签页中（包括标签栏）时 Ctrl+PgUp/PgDn 切换标签页
+	UseKey(c, func(key Key, r rune) {
+		if fm == nil || ctx.keyMods()&ModCtrl == 0 || (key != KeyPageUp && key != KeyPageDown) || !within(fm.Current(), ctx.focusKey()) {
+			return
+		}
+		if next := active + If(key == KeyPageUp, -1, 1); next >= 0 && next < len(props.Panes) {
+			onSelect(next)
+		}
+	})
+
+	releaseTabs(ctx, props.Panes, focused.Current)
+
+	if len(props.Panes) == 0 {
+		return c.Wrap(Empty())
+	}
+
+	tabs := make([]Tab, len(props.Panes))
+	for i, p := range props.Panes {
+		tabs[i] = Tab{Title: p.Title, Modified: p.Modified}
+	}
+	strip := TabStrip(c.Child("strip"), TabStripProps{
+		Tabs:     tabs,
+		Active:   active,
+		OnSelect: onSelect,
+		OnClose:  props.OnClose,
+	})
+
+	// 记录焦点所在的标签页内容中的组件（切换后的第一帧里焦点仍指向旧标签页中的组件）
+	inShown := false
+	if prev, ok := ctx.children["pane:"+shown.Current]; ok && fm != nil {
+		if cur := fm.Current(); within(cur, prev.focusKey()) {
+			focused.Current[shown.Current] = cur
+			inShown = true
+		}
+	}
+
+	pane := props.Panes[active]
+	key := pane.key()
+	pc := c.Child("pane:" + key)
+	var content Node
+	if pane.Content != nil {
+		content = pane.Content(pc)
+	}
+
+	// 切换了标签页且焦点原本在内容中：恢复新标签页上次的焦点
+	if fm != nil && key != shown.Current && inShown {
+		target := focused.Current[key]
+		if fm.contextOf(target) == nil {
+			target = fm.firstWithin(pc.(*componentContext).focusKey())
+		}
+		if target != "" {
+			fm.Focus(target)
+			ctx.runtime.rebuild = true
+		}
+	}
+	shown.Current = key
+
+	return c.Wrap(VStack(strip, content))
+}
+
+// releaseTabs 清理并删除已不在标签页列表中的标签页上下文，停止其中的副作用
+func releaseTabs(ctx *componentContext, panes []TabPane, focused map[string]string) {
+	for key, child := range ctx.children {
+		paneKey, ok := strings.CutPrefix(key, "pane:")
+		if ok && !slices.ContainsFunc(panes, func(p TabPane) bool { return p.key() == paneKey }) {
+			child.cleanup()
+			delete(ctx.children, key)
+			delete(focused, paneKey)
+		}
+	}
+}
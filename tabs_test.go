@@ -0,0 +1,168 @@
+package rego
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTabs_PreservesStateAndFocusPerTab(t *testing.T) {
+	page := func(c C) Node {
+		// 两个标签页使用相同的子组件 key
+		return VStack(
+			TextInput(c.Child("input"), TextInputProps{}),
+			List(c.Child("list"), ListProps{Items: []string{"a", "b", "c"}}),
+		)
+	}
+	app := func(c C) Node {
+		return Tabs(c.Child("tabs"), TabsProps{Panes: []TabPane{
+			{Title: "One", Content: page},
+			{Title: "Two", Content: page},
+		}})
+	}
+
+	screen := newTestScreen(30, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyTab, 0, 0) // 标签栏 → 输入框
+	tr.Render()
+	for _, r := range "hi" {
+		tr.DispatchKey(tcell.KeyRune, r, 0)
+	}
+	tr.DispatchKey(tcell.KeyTab, 0, 0) // 输入框 → 列表
+	tr.Render()
+	if cur := tr.focusManager.Current(); !strings.HasSuffix(cur, "pane:One/list") {
+		t.Fatalf("focus = %q, want the list in tab One", cur)
+	}
+
+	tr.DispatchKey(tcell.KeyPgDn, 0, tcell.ModCtrl)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "hi") {
+		t.Errorf("tab Two shares tab One's input state:\n%s", content)
+	}
+	if cur := tr.focusManager.Current(); !strings.HasSuffix(cur, "pane:Two/input") {
+		t.Errorf("focus = %q, want the first focusable in tab Two", cur)
+	}
+
+	tr.DispatchKey(tcell.KeyPgUp, 0, tcell.ModCtrl)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "hi") {
+		t.Errorf("tab One lost its input state:\n%s", content)
+	}
+	if cur := tr.focusManager.Current(); !strings.HasSuffix(cur, "pane:One/list") {
+		t.Errorf("focus = %q, want the list in tab One restored", cur)
+	}
+}
+
+func TestTabs_RestoresScrollPosition(t *testing.T) {
+	rows := make([]Node, 10)
+	for i := range rows {
+		rows[i] = Text(fmt.Sprintf("row %d", i))
+	}
+	app := func(c C) Node {
+		return Tabs(c.Child("tabs"), TabsProps{Panes: []TabPane{
+			{Title: "One", Content: func(c C) Node { return Box(ScrollBox(c.Child("scroll"), VStack(rows...))).Height(3) }},
+			{Title: "Two", Content: func(c C) Node { return Text("second") }},
+		}})
+	}
+
+	screen := newTestScreen(30, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.DispatchMouse(1, 2, tcell.WheelDown, 0)
+	tr.Render()
+	tr.DispatchMouse(1, 2, tcell.WheelDown, 0)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "row 1") || !strings.Contains(content, "row 2") {
+		t.Fatalf("expected tab One scrolled by two rows:\n%s", content)
+	}
+
+	tr.DispatchKey(tcell.KeyPgDn, 0, tcell.ModCtrl)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "second") {
+		t.Fatalf("expected tab Two:\n%s", content)
+	}
+	tr.DispatchKey(tcell.KeyPgUp, 0, tcell.ModCtrl)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "row 1") || !strings.Contains(content, "row 2") {
+		t.Errorf("tab One lost its scroll position:\n%s", content)
+	}
+}
+
+func TestTabs_HiddenPaneIgnoresMouse(t *testing.T) {
+	clicks := 0
+	app := func(c C) Node {
+		return Tabs(c.Child("tabs"), TabsProps{Panes: []TabPane{
+			{Title: "One", Content: func(c C) Node { return Button(c.Child("ok"), ButtonProps{Label: "OK", OnClick: func() { clicks++ }}) }},
+			{Title: "Two", Content: func(c C) Node { return Text("second") }},
+		}})
+	}
+	click := func(tr *Runtime, x, y int) {
+		tr.DispatchMouse(x, y, tcell.Button1, 0)
+		tr.DispatchMouse(x, y, tcell.ButtonNone, 0)
+		tr.Render()
+	}
+
+	screen := newTestScreen(30, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	click(tr, 1, 1)
+	if clicks != 1 {
+		t.Fatalf("clicks = %d, want 1 on the visible button", clicks)
+	}
+
+	tr.DispatchKey(tcell.KeyPgDn, 0, tcell.ModCtrl)
+	tr.Render()
+	click(tr, 1, 1)
+	if clicks != 1 {
+		t.Errorf("button in the hidden tab received a click (clicks = %d)", clicks)
+	}
+}
+
+func TestTabs_ReleasesClosedTabs(t *testing.T) {
+	stopped := map[string]bool{}
+	page := func(name string) func(c C) Node {
+		return func(c C) Node {
+			UseEffect(c, func() func() {
+				return func() { stopped[name] = true }
+			})
+			return Text(name)
+		}
+	}
+	var panes *State[[]string]
+	app := func(c C) Node {
+		panes = Use(c, "panes", []string{"One", "Two"})
+		var list []TabPane
+		for _, name := range panes.Val {
+			list = append(list, TabPane{Title: name, Content: page(name)})
+		}
+		return Tabs(c.Child("tabs"), TabsProps{
+			Panes: list,
+			OnClose: func(i int) {
+				panes.Set(slices.Delete(slices.Clone(panes.Val), i, i+1))
+			},
+		})
+	}
+
+	screen := newTestScreen(30, 4)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyPgDn, 0, tcell.ModCtrl)
+	tr.Render()
+	if tr.findContext("tabs/pane:Two") == nil {
+		t.Fatal("tab Two was never rendered")
+	}
+
+	panes.Set([]string{"Two"})
+	tr.Render()
+	if !stopped["One"] || stopped["Two"] {
+		t.Errorf("stopped = %v, want only the closed tab One cleaned up", stopped)
+	}
+	if tr.findContext("tabs/pane:One") != nil {
+		t.Error("closed tab's context was not deleted")
+	}
+}
@@ -0,0 +1,335 @@
+package rego
+
+import (
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// TabStrip - 标签栏
+// =============================================================================
+
+// Tab 标签栏中的一个标签
+type Tab struct {
+	Title    string
+	Modified bool // 有未保存的修改，标题后显示 ●
+}
+
+type TabStripProps struct {
+	Tabs      []Tab
+	Active    int
+	OnSelect  func(index int)
+	OnClose   func(index int)    // 非空时标签显示 ×，点击 × 或中键点击标签时调用
+	OnReorder func(from, to int) // 非空时可以拖动标签或 Alt+←/→ 调整顺序，from 移到 to 之后由调用方更新 Tabs
+}
+
+// TabStrip 创建一行标签栏，适合多文档工具在 Router 的页面之间切换
+//
+// 标签放不下时两端显示 ‹ ›，点击箭头或滚动鼠标滚轮查看其余标签，切换 Active 时自动滚动到可见位置。
+// 聚焦时 ←/→ 切换标签，Delete 关闭当前标签。
+//
+//	rego.TabStrip(c.Child("tabs"), rego.TabStripProps{
+//		Tabs:     tabs,
+//		Active:   active.Val,
+//		OnSelect: func(i int) { active.Set(i); nav.Replace(docs[i].Path) },
+//		OnClose:  closeDoc,
+//	})
+func TabStrip(c C, props TabStripProps) Node {
+	ctx := c.(*componentContext)
+	focus := UseFocus(c)
+	theme := UseTheme(c)
+	first := UseRef(c, 0)                 // 第一个可见的标签
+	layout := UseRef(c, tabStripLayout{}) // 上一帧各标签的位置，用于鼠标命中
+	lastActive := UseRef(c, -1)           // Active 变化时滚动到可见位置
+	drag := Use(c, "drag", rowDrag{from: -1})
+
+	count := len(props.Tabs)
+	follow := props.Active != lastActive.Current
+	lastActive.Current = props.Active
+
+	selectTab := func(i int) {
+		if props.OnSelect != nil && i >= 0 && i < count && i != props.Active {
+			props.OnSelect(i)
+		}
+	}
+	closeTab := func(i int) {
+		if props.OnClose != nil && i >= 0 && i < count {
+			props.OnClose(i)
+		}
+	}
+	scroll := func(delta int) {
+		first.Current = max(0, min(first.Current+delta, count-1))
+		c.Refresh()
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused || count == 0 {
+			return
+		}
+		if props.OnReorder != nil && ctx.keyMods()&ModAlt != 0 && (key == KeyLeft || key == KeyRight) {
+			to := props.Active + If(key == KeyLeft, -1, 1)
+			if to >= 0 && to < count {
+				props.OnReorder(props.Active, to)
+				selectTab(to)
+			}
+			return
+		}
+		switch key {
+		case KeyLeft:
+			selectTab(props.Active - 1)
+		case KeyRight:
+			selectTab(props.Active + 1)
+		case KeyHome:
+			selectTab(0)
+		case KeyEnd:
+			selectTab(count - 1)
+		case KeyDelete:
+			closeTab(props.Active)
+		}
+	})
+
+	UseMouse(c, func(ev MouseEvent) {
+		l := layout.Current
+		if props.OnReorder != nil && handleRowDrag(drag, ev, l.nearest(ev.X), count, props.OnReorder, selectTab) {
+			return
+		}
+		if !ev.Hit {
+			return
+		}
+		switch ev.Type {
+		case MouseEventScrollUp, MouseEventScrollLeft:
+			scroll(-1)
+		case MouseEventScrollDown, MouseEventScrollRight:
+			scroll(1)
+		case MouseEventPress:
+			if i, onClose := l.at(ev.X); props.OnReorder != nil && ev.Button == MouseButtonLeft && i >= 0 && !onClose {
+				drag.Set(rowDrag{from: i, to: i})
+			}
+		case MouseEventClick:
+			focus.Focus()
+			i, onClose := l.at(ev.X)
+			switch {
+			case ev.Button == MouseButtonMiddle:
+				closeTab(i)
+			case ev.Button != MouseButtonLeft:
+			case ev.X == l.left:
+				scroll(-1)
+			case ev.X == l.right:
+				scroll(1)
+			case onClose:
+				closeTab(i)
+			default:
+				selectTab(i)
+			}
+		}
+	})
+
+	// 拖动期间按放下后的顺序预览
+	tabs, active, dragTab := props.Tabs, props.Active, -1
+	if d := drag.Val; d.from >= 0 && d.to != d.from && d.from < count && d.to < count {
+		order := reorderPreview(count, d.from, d.to)
+		tabs = make([]Tab, count)
+		for i, src := range order {
+			tabs[i] = props.Tabs[src]
+		}
+		active, dragTab, follow = d.to, d.to, false
+	}
+
+	return c.Wrap(&tabStripNode{
+		tabs:     tabs,
+		active:   active,
+		dragTab:  dragTab,
+		closable: props.OnClose != nil,
+		focused:  focus.IsFocused,
+		theme:    theme,
+		first:    first,
+		follow:   follow,
+		layout:   layout,
+	})
+}
+
+// tabSlot 一个标签在屏幕上的位置
+type tabSlot struct {
+	index  int
+	x, w   int
+	closeX int // × 所在的列，-1 表示没有
+}
+
+// tabStripLayout 上一帧标签栏的布局（屏幕坐标）
+type tabStripLayout struct {
+	slots       []tabSlot
+	left, right int // ‹ › 所在的列，-1 表示没有
+}
+
+// at 返回 x 处的标签以及是否落在它的 × 上，没有标签时返回 -1
+func (l tabStripLayout) at(x int) (int, bool) {
+	for _, s := range l.slots {
+		if x >= s.x && x < s.x+s.w {
+			return s.index, x == s.closeX
+		}
+	}
+	return -1, false
+}
+
+// nearest 返回 x 处或离 x 最近的可见标签，用于拖动
+func (l tabStripLayout) nearest(x int) int {
+	if len(l.slots) == 0 {
+		return 0
+	}
+	if i, _ := l.at(x); i >= 0 {
+		return i
+	}
+	if x < l.slots[0].x {
+		return l.slots[0].index
+	}
+	return l.slots[len(l.slots)-1].index
+}
+
+// =============================================================================
+// tabStripNode - 标签栏渲染节点
+// =============================================================================
+
+type tabStripNode struct {
+	tabs     []Tab
+	active   int
+	dragTab  int
+	closable bool
+	focused  bool
+	theme    Theme
+	first    *Ref[int]
+	follow   bool
+	layout   *Ref[tabStripLayout]
+}
+
+func (t *tabStripNode) label(i int) string {
+	s := " " + t.tabs[i].Title
+	if t.tabs[i].Modified {
+		s += " ●"
+	}
+	if t.closable {
+		s += " ×"
+	}
+	return s + " "
+}
+
+// span 返回从 from 开始、宽度 avail 内能完整显示的最后一个标签的下一个下标
+func (t *tabStripNode) span(from, avail int) int {
+	w := 0
+	for i := from; i < len(t.tabs); i++ {
+		w += runewidth.StringWidth(t.label(i))
+		if w > avail {
+			return i
+		}
+	}
+	return len(t.tabs)
+}
+
+func (t *tabStripNode) render(screen tcell.Screen, x, y, width, height int) int {
+	t.layout.Current = tabStripLayout{left: -1, right: -1}
+	if width <= 0 || height <= 0 || len(t.tabs) == 0 {
+		return 0
+	}
+	n := len(t.tabs)
+	abs, _ := screenRect(screen, Rect{X: x, Y: y, W: width, H: 1})
+	dx := abs.X - x
+
+	// 放不下时两端各留一列显示箭头
+	avail := width
+	overflow := t.measureWidth() > width
+	if overflow {
+		avail = max(width-2, 0)
+	}
+	first := max(0, min(t.first.Current, n-1))
+	if overflow {
+		if t.follow && t.active >= 0 && t.active < n {
+			if t.active < first {
+				first = t.active
+			}
+			for first < t.active && t.span(first, avail) <= t.active {
+				first++
+			}
+		}
+		// 右侧还有空间时向左补齐
+		for first > 0 && t.span(first-1, avail) == n {
+			first--
+		}
+	} else {
+		first = 0
+	}
+	t.first.Current = first
+	end := max(t.span(first, avail), min(first+1, n))
+
+	muted := tcell.StyleDefault.Foreground(colorToTcell(t.theme.Muted))
+	primary := tcell.StyleDefault.Foreground(colorToTcell(t.theme.Primary))
+	layout := tabStripLayout{left: -1, right: -1}
+	col := x
+	if overflow {
+		screen.SetContent(x, y, '‹', nil, If(first > 0, primary, muted))
+		screen.SetContent(x+width-1, y, '›', nil, If(end < n, primary, muted))
+		layout.left, layout.right = x+dx, x+width-1+dx
+		col++
+	}
+	right := x + avail + If(overflow, 1, 0)
+
+	for i := first; i < end && col < right; i++ {
+		style := tcell.StyleDefault
+		switch {
+		case i == t.dragTab:
+			style = primary.Bold(true).Underline(true)
+		case i == t.active && t.focused:
+			style = style.Background(colorToTcell(t.theme.Primary)).Foreground(colorToTcell(t.theme.OnColor)).Bold(true)
+		case i == t.active:
+			style = style.Reverse(true).Bold(true)
+		}
+		full := t.label(i)
+		label := runewidth.Truncate(full, right-col, "…")
+		// 标签以 " ● × " 结尾，按位置找出 ● 和 ×（标题中可能也有这两个字符）
+		closeAt, dotAt := -1, -1
+		if label == full {
+			tail := utf8.RuneCountInString(full) - 1
+			if t.closable {
+				tail -= 2
+				closeAt = tail + 1
+			}
+			if t.tabs[i].Modified {
+				dotAt = tail - 1
+			}
+		}
+		slot := tabSlot{index: i, x: col + dx, closeX: -1}
+		for k, r := range []rune(label) {
+			cs := style
+			switch {
+			case k == dotAt && i != t.active:
+				cs = style.Foreground(colorToTcell(t.theme.Warning))
+			case k == closeAt:
+				if i != t.active {
+					cs = muted
+				}
+				slot.closeX = col + dx
+			}
+			screen.SetContent(col, y, r, nil, cs)
+			col += runewidth.RuneWidth(r)
+		}
+		slot.w = col + dx - slot.x
+		layout.slots = append(layout.slots, slot)
+	}
+	t.layout.Current = layout
+	return 1
+}
+
+func (t *tabStripNode) measureHeight(width int) int {
+	if len(t.tabs) == 0 {
+		return 0
+	}
+	return 1
+}
+
+func (t *tabStripNode) measureWidth() int {
+	w := 0
+	for i := range t.tabs {
+		w += runewidth.StringWidth(t.label(i))
+	}
+	return w
+}
@@ -0,0 +1,109 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTabStrip_SelectAndClose(t *testing.T) {
+	var events []string
+	app := func(c C) Node {
+		return TabStrip(c.Child("tabs"), TabStripProps{
+			Tabs:     []Tab{{Title: "main.go"}, {Title: "a×b.md", Modified: true}, {Title: "go.mod"}},
+			Active:   0,
+			OnSelect: func(i int) { events = append(events, fmt.Sprint("select ", i)) },
+			OnClose:  func(i int) { events = append(events, fmt.Sprint("close ", i)) },
+		})
+	}
+	screen := newTestScreen(60, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	if got, want := strings.TrimRight(getScreenContent(screen), " "), " main.go ×  a×b.md ● ×  go.mod ×"; got != want {
+		t.Fatalf("strip = %q, want %q", got, want)
+	}
+
+	// 点击标题切换；标题中的 × 不是关闭按钮
+	x, _ := findText(screen, "a×b")
+	mouseClick(tr, x+1, 0)
+	// 点击标签末尾的 × 关闭
+	x, _ = findText(screen, "● ×")
+	mouseClick(tr, x+2, 0)
+	// 中键点击关闭
+	x, _ = findText(screen, "go.mod")
+	tr.DispatchMouse(x, 0, tcell.Button2, 0)
+	tr.DispatchMouse(x, 0, tcell.ButtonNone, 0)
+	// 聚焦后键盘切换和关闭
+	tr.DispatchKey(tcell.KeyRight, 0, 0)
+	tr.DispatchKey(tcell.KeyDelete, 0, 0)
+
+	want := []string{"select 1", "close 1", "close 2", "select 1", "close 0"}
+	if fmt.Sprint(events) != fmt.Sprint(want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
+
+func TestTabStrip_OverflowAndReorder(t *testing.T) {
+	tabs := []Tab{{Title: "one"}, {Title: "two"}, {Title: "three"}, {Title: "four"}, {Title: "five"}}
+	active := 0
+	app := func(c C) Node {
+		a := Use(c, "active", active)
+		order := Use(c, "tabs", tabs)
+		return TabStrip(c.Child("tabs"), TabStripProps{
+			Tabs:     order.Val,
+			Active:   a.Val,
+			OnSelect: a.Set,
+			OnReorder: func(from, to int) {
+				next := make([]Tab, 0, len(order.Val))
+				for _, i := range reorderPreview(len(order.Val), from, to) {
+					next = append(next, order.Val[i])
+				}
+				order.Set(next)
+			},
+		})
+	}
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	content := getScreenContent(screen)
+	if !strings.HasPrefix(content, "‹ one  two ") || !strings.HasSuffix(content, "›") || strings.Contains(content, "five") {
+		t.Fatalf("expected overflow arrows, got %q", content)
+	}
+
+	// 点击 › 滚动到末尾
+	for i := 0; i < 4; i++ {
+		mouseClick(tr, 19, 0)
+	}
+	if content = getScreenContent(screen); !strings.Contains(content, "five") || strings.Contains(content, "one") {
+		t.Fatalf("expected scrolled to the end, got %q", content)
+	}
+
+	// 键盘切换到第一个标签时自动滚回
+	x, _ := findText(screen, "five")
+	mouseClick(tr, x, 0)
+	tr.DispatchKey(tcell.KeyHome, 0, 0)
+	tr.Render()
+	if content = getScreenContent(screen); !strings.Contains(content, "one") {
+		t.Fatalf("expected active tab scrolled into view, got %q", content)
+	}
+
+	// Alt+→ 把当前标签右移
+	tr.DispatchKey(tcell.KeyRight, 0, tcell.ModAlt)
+	tr.Render()
+	if content = getScreenContent(screen); !strings.Contains(content, "two  one") {
+		t.Errorf("expected reordered tabs, got %q", content)
+	}
+
+	// 拖动 one 到 two 的位置
+	ox, _ := findText(screen, "one")
+	tx, _ := findText(screen, "two")
+	mouseDrag(tr, ox, 0, tx, 0)
+	tr.Render()
+	if content = getScreenContent(screen); !strings.Contains(content, "one  two") {
+		t.Errorf("expected drag to reorder tabs, got %q", content)
+	}
+}
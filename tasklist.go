@@ -0,0 +1,275 @@
+package rego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// TaskList / TaskRunner - 并发任务列表
+// =============================================================================
+
+// DefaultTaskConcurrency TaskList 默认同时运行的任务数
+const DefaultTaskConcurrency = 4
+
+// taskOutputLines 展开时显示的输出行数（取最后几行）
+const taskOutputLines = 10
+
+// Task 是一个待执行的任务，out 中写入的内容作为任务输出显示
+type Task struct {
+	Name string
+	Run  func(ctx context.Context, out io.Writer) error
+}
+
+// TaskStatus 任务状态
+type TaskStatus int
+
+const (
+	TaskPending TaskStatus = iota
+	TaskRunning
+	TaskDone
+	TaskFailed
+)
+
+// TaskState 是任务的运行状态快照
+type TaskState struct {
+	Name     string
+	Status   TaskStatus
+	Output   string
+	Err      error
+	Started  time.Time
+	Duration time.Duration // 运行中为已运行时长，结束后为总耗时
+}
+
+// TaskRunner 以有限的并发数执行一组任务
+type TaskRunner struct {
+	tasks []Task
+	limit int
+
+	mu       sync.Mutex
+	states   []TaskState
+	outputs  []strings.Builder
+	onChange func()
+}
+
+// NewTaskRunner 创建任务执行器，limit <= 0 时不限制并发数
+func NewTaskRunner(tasks []Task, limit int) *TaskRunner {
+	r := &TaskRunner{
+		tasks:   tasks,
+		limit:   limit,
+		states:  make([]TaskState, len(tasks)),
+		outputs: make([]strings.Builder, len(tasks)),
+	}
+	for i, t := range tasks {
+		r.states[i].Name = t.Name
+	}
+	return r
+}
+
+// Run 执行所有任务并等待结束，返回所有失败任务的错误
+func (r *TaskRunner) Run(ctx context.Context) error {
+	limit := r.limit
+	if limit <= 0 || limit > len(r.tasks) {
+		limit = len(r.tasks)
+	}
+	sem := make(chan struct{}, max(limit, 1))
+	errs := make([]error, len(r.tasks))
+
+	var wg sync.WaitGroup
+	for i, t := range r.tasks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = r.runTask(ctx, i, t)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (r *TaskRunner) runTask(ctx context.Context, i int, t Task) error {
+	r.update(func() {
+		r.states[i].Status = TaskRunning
+		r.states[i].Started = time.Now()
+	})
+
+	var err error
+	if t.Run != nil {
+		err = t.Run(ctx, &taskWriter{runner: r, index: i})
+	}
+
+	r.update(func() {
+		s := &r.states[i]
+		s.Duration = time.Since(s.Started)
+		s.Err = err
+		s.Status = TaskDone
+		if err != nil {
+			s.Status = TaskFailed
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", t.Name, err)
+	}
+	return nil
+}
+
+// update 在锁内修改状态并通知变化
+func (r *TaskRunner) update(fn func()) {
+	r.mu.Lock()
+	fn()
+	notify := r.onChange
+	r.mu.Unlock()
+	if notify != nil {
+		notify()
+	}
+}
+
+// States 返回所有任务的状态快照
+func (r *TaskRunner) States() []TaskState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]TaskState, len(r.states))
+	for i, s := range r.states {
+		s.Output = r.outputs[i].String()
+		if s.Status == TaskRunning {
+			s.Duration = time.Since(s.Started)
+		}
+		out[i] = s
+	}
+	return out
+}
+
+func (r *TaskRunner) setOnChange(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onChange = fn
+}
+
+// taskWriter 将任务输出追加到对应任务的缓冲区
+type taskWriter struct {
+	runner *TaskRunner
+	index  int
+}
+
+func (w *taskWriter) Write(p []byte) (int, error) {
+	w.runner.update(func() {
+		w.runner.outputs[w.index].Write(p)
+	})
+	return len(p), nil
+}
+
+// TaskList 在组件挂载时以 DefaultTaskConcurrency 的并发数执行 tasks，并显示每个任务的状态
+//
+// 聚焦时 ↑/↓ 选择任务，Enter/Space 展开或折叠其输出；失败的任务默认展开。
+// 组件被释放时（见 UseEffect）取消仍在运行的任务，只是不再渲染时任务继续执行。
+func TaskList(c C, tasks []Task) Node {
+	runner := UseRef(c, (*TaskRunner)(nil))
+	if runner.Current == nil {
+		runner.Current = NewTaskRunner(tasks, DefaultTaskConcurrency)
+	}
+
+	UseEffect(c, func() func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		go runner.Current.Run(ctx)
+		return cancel
+	})
+
+	return TaskRunnerView(c, runner.Current)
+}
+
+// TaskRunnerView 显示 runner 中任务的状态，适合自行控制并发数和启动时机的场景
+func TaskRunnerView(c C, runner *TaskRunner) Node {
+	focus := UseFocus(c)
+	cursor := Use(c, "cursor", 0)
+	toggled := Use(c, "toggled", map[int]bool{})
+
+	runner.setOnChange(c.Refresh)
+	states := runner.States()
+
+	expanded := func(i int) bool {
+		// 失败的任务默认展开，用户切换过则取反
+		return (states[i].Status == TaskFailed) != toggled.Val[i]
+	}
+
+	UseKey(c, func(key Key, r rune) {
+		if !focus.IsFocused || len(states) == 0 {
+			return
+		}
+		switch {
+		case key == KeyUp && cursor.Val > 0:
+			cursor.Set(cursor.Val - 1)
+		case key == KeyDown && cursor.Val < len(states)-1:
+			cursor.Set(cursor.Val + 1)
+		case key == KeyEnter || key == KeySpace || r == ' ':
+			next := make(map[int]bool, len(toggled.Val)+1)
+			for k, v := range toggled.Val {
+				next[k] = v
+			}
+			next[cursor.Val] = !next[cursor.Val]
+			toggled.Set(next)
+		}
+	})
+
+	rows := make([]Node, 0, len(states))
+	for i, s := range states {
+		var icon Node
+		switch s.Status {
+		case TaskPending:
+			icon = Text("·").Dim()
+		case TaskRunning:
+			icon = Text(spinnerFrames[int(s.Duration/(100*time.Millisecond))%len(spinnerFrames)]).Color(Cyan)
+		case TaskDone:
+			icon = Text("✓").Color(Green)
+		case TaskFailed:
+			icon = Text("✗").Color(Red)
+		}
+
+		name := Text(s.Name)
+		if focus.IsFocused && i == cursor.Val {
+			name = name.Bold().Color(Cyan)
+		}
+
+		rows = append(rows, HStack(
+			icon, Text(" "), name,
+			When(s.Status != TaskPending, Text(" "+formatTaskDuration(s.Duration)).Dim()),
+		))
+		if s.Status == TaskFailed && s.Err != nil {
+			rows = append(rows, Box(Text(s.Err.Error()).Color(Red).Wrap(true)).Padding(0, 2))
+		}
+		if expanded(i) && s.Output != "" {
+			rows = append(rows, Box(Ansi(lastLines(s.Output, taskOutputLines)).Wrap(true)).Padding(0, 2))
+		}
+	}
+
+	return c.Wrap(VStack(rows...))
+}
+
+// formatTaskDuration 格式化任务耗时，如 "0.8s"、"1m05s"
+func formatTaskDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// lastLines 返回 s 的最后 n 行
+func lastLines(s string, n int) string {
+	s = strings.TrimRight(s, "\n")
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,112 @@
+package rego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTaskRunner_ConcurrencyLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	tasks := make([]Task, 6)
+	for i := range tasks {
+		tasks[i] = Task{Name: fmt.Sprint(i), Run: func(ctx context.Context, out io.Writer) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		}}
+	}
+
+	r := NewTaskRunner(tasks, 2)
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("expected at most 2 concurrent tasks, got %d", p)
+	}
+	for _, s := range r.States() {
+		if s.Status != TaskDone {
+			t.Errorf("task %s: expected TaskDone, got %v", s.Name, s.Status)
+		}
+	}
+}
+
+func TestTaskRunner_Errors(t *testing.T) {
+	boom := errors.New("boom")
+	r := NewTaskRunner([]Task{
+		{Name: "ok", Run: func(ctx context.Context, out io.Writer) error { return nil }},
+		{Name: "bad", Run: func(ctx context.Context, out io.Writer) error {
+			fmt.Fprintln(out, "compiling")
+			return boom
+		}},
+	}, 0)
+
+	err := r.Run(context.Background())
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected joined error to wrap boom, got %v", err)
+	}
+	states := r.States()
+	if states[1].Status != TaskFailed || states[1].Output != "compiling\n" {
+		t.Errorf("unexpected state for failed task: %+v", states[1])
+	}
+}
+
+func TestTaskList_Render(t *testing.T) {
+	release := make(chan struct{})
+	tasks := []Task{
+		{Name: "build", Run: func(ctx context.Context, out io.Writer) error { return nil }},
+		{Name: "test", Run: func(ctx context.Context, out io.Writer) error {
+			fmt.Fprintln(out, "FAIL pkg")
+			<-release
+			return errors.New("exit 1")
+		}},
+	}
+
+	screen := newTestScreen(40, 6)
+	tr := NewTestRuntime(func(c C) Node {
+		return TaskList(c.Child("tasks"), tasks)
+	}, screen)
+	tr.Render()
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		tr.Render()
+		content := getScreenContent(screen)
+		if strings.Contains(content, "✗ test") {
+			if !strings.Contains(content, "✓ build") {
+				t.Errorf("expected build to succeed, got:\n%s", content)
+			}
+			if !strings.Contains(content, "FAIL pkg") {
+				t.Errorf("expected failed task output to be expanded, got:\n%s", content)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("tasks did not finish, got:\n%s", content)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// 折叠失败任务的输出
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+	if content := getScreenContent(screen); strings.Contains(content, "FAIL pkg") {
+		t.Errorf("expected output to be collapsed, got:\n%s", content)
+	}
+}
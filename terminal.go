@@ -0,0 +1,45 @@
+package rego
+
+import "fmt"
+
+// =============================================================================
+// 终端标题与响铃
+// =============================================================================
+
+// setTitle 设置终端标题，与上次相同时不重复写出
+func (r *Runtime) setTitle(title string) {
+	r.post(func() {
+		if r.screen == nil || title == r.title {
+			return
+		}
+		r.title = title
+		r.screen.SetTitle(title)
+	})
+}
+
+// bell 响铃，message 非空时额外发送 OSC 777 通知（urxvt、foot、Ghostty、WezTerm 等支持）
+func (r *Runtime) bell(message string) {
+	r.post(func() {
+		if r.screen == nil {
+			return
+		}
+		if message != "" {
+			if tty, ok := r.screen.Tty(); ok {
+				fmt.Fprintf(tty, "\x1b]777;notify;%s;%s\x07", sanitizeOSC(r.title), sanitizeOSC(message))
+			}
+		}
+		r.screen.Beep()
+	})
+}
+
+// sanitizeOSC 去掉会提前结束转义序列的控制字符和分隔符
+func sanitizeOSC(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, c := range s {
+		if c < 0x20 || c == 0x7f || c == ';' {
+			continue
+		}
+		out = append(out, c)
+	}
+	return string(out)
+}
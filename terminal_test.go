@@ -0,0 +1,50 @@
+package rego
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// recordingScreen 记录标题与响铃调用
+type recordingScreen struct {
+	tcell.SimulationScreen
+	titles []string
+	beeps  int
+}
+
+func (s *recordingScreen) SetTitle(title string) { s.titles = append(s.titles, title) }
+func (s *recordingScreen) Beep() error           { s.beeps++; return nil }
+
+func TestSetTitleAndBell(t *testing.T) {
+	screen := &recordingScreen{SimulationScreen: newTestScreen(10, 1)}
+	app := func(c C) Node {
+		c.SetTitle("myapp – building…")
+		UseKey(c, func(key Key, r rune) {
+			if r == 'b' {
+				c.Bell("done")
+			}
+		})
+		return Text("app")
+	}
+
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.Render() // 标题执行于下一帧开始时
+	tr.Render()
+	if len(screen.titles) != 1 || screen.titles[0] != "myapp – building…" {
+		t.Errorf("expected title to be set once, got %q", screen.titles)
+	}
+
+	tr.DispatchKey(tcell.KeyRune, 'b', 0)
+	tr.Render()
+	if screen.beeps != 1 {
+		t.Errorf("expected one bell, got %d", screen.beeps)
+	}
+}
+
+func TestSanitizeOSC(t *testing.T) {
+	if got := sanitizeOSC("a;b\x07c\x1bd"); got != "abcd" {
+		t.Errorf("sanitizeOSC = %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package testing
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erweixin/rego"
+)
+
+// BridgeDriver 是 rego.Handle 的无界面实现，用脚本化的回答驱动 Core，
+// 并记录 Core 发出的状态更新和问题，便于在单元测试中断言
+type BridgeDriver[S any, Q any, A any] struct {
+	t       *testing.T
+	mu      sync.Mutex
+	answers []A
+	answer  func(Q) A
+	events  []rego.BridgeEvent
+	streams map[string]*strings.Builder
+	wg      sync.WaitGroup
+}
+
+// NewBridgeDriver 创建驱动，answers 按顺序回答 Core 的 Ask
+func NewBridgeDriver[S any, Q any, A any](t *testing.T, answers ...A) *BridgeDriver[S, Q, A] {
+	return &BridgeDriver[S, Q, A]{t: t, answers: answers, streams: map[string]*strings.Builder{}}
+}
+
+// AnswerWith 使用函数根据问题生成回答，脚本化的回答用完后生效
+func (d *BridgeDriver[S, Q, A]) AnswerWith(fn func(Q) A) *BridgeDriver[S, Q, A] {
+	d.answer = fn
+	return d
+}
+
+// Run 在当前协程中运行 Core，返回时 Core 已结束且其打开的流均已关闭
+func (d *BridgeDriver[S, Q, A]) Run(core func(h rego.Handle[S, Q, A])) {
+	core(d)
+	d.wg.Wait()
+}
+
+// Update 实现 rego.Handle
+func (d *BridgeDriver[S, Q, A]) Update(state S) {
+	d.record(rego.BridgeUpdate, state)
+}
+
+// Ask 实现 rego.Handle，按脚本返回回答；没有可用回答时测试失败并返回零值
+func (d *BridgeDriver[S, Q, A]) Ask(question Q) A {
+	d.record(rego.BridgeAsk, question)
+
+	d.mu.Lock()
+	var ans A
+	switch {
+	case len(d.answers) > 0:
+		ans = d.answers[0]
+		d.answers = d.answers[1:]
+	case d.answer != nil:
+		fn := d.answer
+		d.mu.Unlock()
+		ans = fn(question)
+		d.mu.Lock()
+	default:
+		d.mu.Unlock()
+		d.t.Errorf("BridgeDriver: no scripted answer for question %v", question)
+		return ans
+	}
+	d.mu.Unlock()
+
+	d.record(rego.BridgeSubmit, ans)
+	return ans
+}
+
+// Stream 实现 rego.Handle，收集写入的文本，可通过 StreamValue 读取
+func (d *BridgeDriver[S, Q, A]) Stream(name string) chan<- string {
+	d.mu.Lock()
+	b := &strings.Builder{}
+	d.streams[name] = b
+	d.mu.Unlock()
+
+	ch := make(chan string)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for chunk := range ch {
+			d.mu.Lock()
+			b.WriteString(chunk)
+			d.mu.Unlock()
+		}
+	}()
+	return ch
+}
+
+// StreamValue 返回名为 name 的流收集到的文本
+func (d *BridgeDriver[S, Q, A]) StreamValue(name string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if b, ok := d.streams[name]; ok {
+		return b.String()
+	}
+	return ""
+}
+
+// Events 返回按发生顺序记录的全部调用
+func (d *BridgeDriver[S, Q, A]) Events() []rego.BridgeEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]rego.BridgeEvent{}, d.events...)
+}
+
+// Updates 返回 Core 发出的全部状态
+func (d *BridgeDriver[S, Q, A]) Updates() []S {
+	var out []S
+	for _, ev := range d.Events() {
+		if ev.Kind == rego.BridgeUpdate {
+			out = append(out, ev.Payload.(S))
+		}
+	}
+	return out
+}
+
+// Questions 返回 Core 提出的全部问题
+func (d *BridgeDriver[S, Q, A]) Questions() []Q {
+	var out []Q
+	for _, ev := range d.Events() {
+		if ev.Kind == rego.BridgeAsk {
+			out = append(out, ev.Payload.(Q))
+		}
+	}
+	return out
+}
+
+// LastState 返回最后一次状态更新，没有更新时返回零值
+func (d *BridgeDriver[S, Q, A]) LastState() S {
+	updates := d.Updates()
+	if len(updates) == 0 {
+		var zero S
+		return zero
+	}
+	return updates[len(updates)-1]
+}
+
+func (d *BridgeDriver[S, Q, A]) record(kind rego.BridgeEventKind, payload any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, rego.BridgeEvent{Kind: kind, Time: time.Now(), Payload: payload})
+}
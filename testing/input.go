@@ -0,0 +1,68 @@
+package testing
+
+import (
+	"time"
+
+	"github.com/erweixin/rego"
+	"github.com/gdamore/tcell/v2"
+)
+
+// ScrollDirection 滚轮方向
+type ScrollDirection int
+
+const (
+	ScrollUp ScrollDirection = iota
+	ScrollDown
+	ScrollLeft
+	ScrollRight
+)
+
+// 以下方法与真实终端事件走同一条分发路径（全局快捷键、Tab 焦点切换、点击区域、组件处理器），
+// 注入事件后立即渲染一帧，调用方可以直接断言屏幕内容
+
+// SendKey 发送一个按键，如 tr.SendKey(rego.KeyEnter)
+func (tr *TestRuntime) SendKey(key rego.Key, mods ...rego.Modifiers) {
+	if k, r, ok := rego.TcellKey(key); ok {
+		tr.DispatchKey(k, r, rego.TcellMods(mods...))
+	}
+	tr.Render()
+}
+
+// TypeText 逐字符输入文本，'\n' 作为 Enter 发送
+func (tr *TestRuntime) TypeText(text string) {
+	for _, r := range text {
+		if r == '\n' {
+			tr.DispatchKey(tcell.KeyEnter, 0, 0)
+		} else {
+			tr.DispatchKey(tcell.KeyRune, r, 0)
+		}
+		tr.Render()
+	}
+}
+
+// Click 在 (x, y) 处单击鼠标左键（按下后松开）
+func (tr *TestRuntime) Click(x, y int, mods ...rego.Modifiers) {
+	tr.DispatchMouse(x, y, tcell.Button1, rego.TcellMods(mods...))
+	tr.Render()
+	tr.DispatchMouse(x, y, tcell.ButtonNone, rego.TcellMods(mods...))
+	tr.Render()
+}
+
+// Scroll 在 (x, y) 处滚动一格滚轮
+func (tr *TestRuntime) Scroll(x, y int, dir ScrollDirection, mods ...rego.Modifiers) {
+	buttons := map[ScrollDirection]tcell.ButtonMask{
+		ScrollUp:    tcell.WheelUp,
+		ScrollDown:  tcell.WheelDown,
+		ScrollLeft:  tcell.WheelLeft,
+		ScrollRight: tcell.WheelRight,
+	}[dir]
+	tr.DispatchMouse(x, y, buttons, rego.TcellMods(mods...))
+	tr.Render()
+}
+
+// AdvanceTime 推进 FakeClock 并渲染一帧，到期的定时器（Spinner、UseInterval 等）同步触发；
+// 运行时需以 rego.Options{Clock: rego.NewFakeClock(...)} 创建
+func (tr *TestRuntime) AdvanceTime(d time.Duration) {
+	tr.Runtime.AdvanceTime(d)
+	tr.Render()
+}
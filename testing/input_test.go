@@ -0,0 +1,107 @@
+package testing
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erweixin/rego"
+)
+
+func TestTestRuntime_Interaction(t *testing.T) {
+	var submitted string
+	clicks := 0
+	app := func(c rego.C) rego.Node {
+		name := rego.Use(c, "name", "")
+		return rego.VStack(
+			rego.TextInput(c.Child("name"), rego.TextInputProps{
+				Value:     name.Val,
+				OnChanged: name.Set,
+				OnSubmit:  func(v string) { submitted = v },
+			}),
+			rego.Button(c.Child("ok"), rego.ButtonProps{
+				Label:   "OK",
+				OnClick: func() { clicks++ },
+			}),
+		)
+	}
+
+	tr := NewTestRuntime(app, 30, 8)
+	tr.Render()
+
+	// 首个可聚焦组件自动获得焦点
+	tr.TypeText("helo")
+	tr.SendKey(rego.KeyLeft)
+	tr.TypeText("l\n")
+	if submitted != "hello" {
+		t.Fatalf("expected submitted %q, got %q", "hello", submitted)
+	}
+	if !strings.Contains(tr.Screen.GetContentString(), "hello") {
+		t.Errorf("expected input text on screen, got:\n%s", tr.Screen.GetContentString())
+	}
+
+	// 点击按钮
+	lines := strings.Split(tr.Screen.GetContentString(), "\n")
+	for y, line := range lines {
+		if x := strings.Index(line, "OK"); x >= 0 {
+			tr.Click(x, y)
+			break
+		}
+	}
+	if clicks != 1 {
+		t.Errorf("expected button clicked once, got %d", clicks)
+	}
+
+	// 点击后按钮获得焦点，Enter 再次激活
+	tr.SendKey(rego.KeyEnter)
+	if clicks != 2 {
+		t.Errorf("expected Enter on focused button, got %d clicks", clicks)
+	}
+
+	// Tab 把焦点切回输入框
+	tr.SendKey(rego.KeyTab)
+	tr.TypeText("!\n")
+	if submitted != "hello!" {
+		t.Errorf("expected %q after Tab back to input, got %q", "hello!", submitted)
+	}
+}
+
+func TestTestRuntime_Scroll(t *testing.T) {
+	app := func(c rego.C) rego.Node {
+		var lines []rego.Node
+		for i := 0; i < 20; i++ {
+			lines = append(lines, rego.Text(string(rune('a'+i))+" line"))
+		}
+		return rego.ScrollBox(c.Child("scroll"), rego.VStack(lines...))
+	}
+
+	tr := NewTestRuntime(app, 20, 5)
+	tr.Render()
+	tr.Scroll(1, 1, ScrollDown)
+	tr.Scroll(1, 1, ScrollDown)
+	if content := tr.Screen.GetContentString(); strings.HasPrefix(content, "a line") {
+		t.Errorf("expected content to scroll, got:\n%s", content)
+	}
+	tr.Scroll(1, 1, ScrollUp)
+	tr.Scroll(1, 1, ScrollUp)
+	if content := tr.Screen.GetContentString(); !strings.HasPrefix(content, "a line") {
+		t.Errorf("expected content to scroll back, got:\n%s", content)
+	}
+}
+
+func TestTestRuntime_AdvanceTime(t *testing.T) {
+	app := func(c rego.C) rego.Node {
+		ticks := rego.Use(c, "ticks", 0)
+		rego.UseInterval(c, time.Second, func() { ticks.Set(ticks.Val + 1) })
+		return rego.Text("ticks " + strconv.Itoa(ticks.Val))
+	}
+
+	tr := NewTestRuntime(app, 20, 1, rego.Options{Clock: rego.NewFakeClock(time.Now())})
+	tr.Render()
+	tr.AdvanceTime(2 * time.Second)
+	tr.Render()
+	if content := tr.Screen.GetContentString(); !strings.HasPrefix(content, "ticks 2") {
+		t.Errorf("expected two ticks, got:\n%s", content)
+	}
+}
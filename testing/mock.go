@@ -46,10 +46,10 @@ type TestRuntime struct {
 	Screen *MockScreen
 }
 
-// NewTestRuntime 创建一个用于测试的运行时
-func NewTestRuntime(root func(rego.C) rego.Node, w, h int) *TestRuntime {
+// NewTestRuntime 创建一个用于测试的运行时，opts 同 rego.NewTestRuntime
+func NewTestRuntime(root func(rego.C) rego.Node, w, h int, opts ...rego.Options) *TestRuntime {
 	screen := NewMockScreen(w, h)
-	r := rego.NewTestRuntime(root, screen)
+	r := rego.NewTestRuntime(root, screen, opts...)
 	return &TestRuntime{
 		Runtime: r,
 		Screen:  screen,
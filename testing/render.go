@@ -0,0 +1,14 @@
+package testing
+
+import "testing"
+
+// ExpectNoRender 执行 fn（通常包含 SendKey 等交互），断言期间 path 处的组件没有重新执行，
+// 用于确认与之无关的状态变化不会导致其重新渲染；path 格式同 rego.Runtime.RenderCount
+func (tr *TestRuntime) ExpectNoRender(t *testing.T, path string, fn func()) {
+	t.Helper()
+	before := tr.RenderCount(path)
+	fn()
+	if after := tr.RenderCount(path); after != before {
+		t.Errorf("expected %s not to re-render, but it rendered %d more time(s)", path, after-before)
+	}
+}
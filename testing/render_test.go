@@ -0,0 +1,48 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/erweixin/rego"
+)
+
+func TestExpectNoRender(t *testing.T) {
+	details := func(c rego.C) rego.Node {
+		return rego.Text("details")
+	}
+	app := func(c rego.C) rego.Node {
+		open := rego.Use(c, "open", false)
+		count := rego.Use(c, "count", 0)
+		rego.UseKey(c, func(key rego.Key, r rune) {
+			switch r {
+			case 'o':
+				open.Set(!open.Val)
+			case '+':
+				count.Set(count.Val + 1)
+			}
+		})
+		if !open.Val {
+			return rego.Text("closed")
+		}
+		return details(c.Child("details"))
+	}
+
+	tr := NewTestRuntime(app, 20, 2)
+	tr.Render()
+	if got := tr.RenderCount("root"); got != 1 {
+		t.Fatalf("RenderCount(root) = %d, want 1", got)
+	}
+
+	// 折叠时计数变化不会执行 details
+	tr.ExpectNoRender(t, "details", func() {
+		tr.TypeText("++")
+	})
+	if got := tr.RenderCount("root"); got != 3 {
+		t.Errorf("RenderCount(root) = %d after two keys, want 3", got)
+	}
+
+	tr.TypeText("o")
+	if got := tr.RenderCount("details"); got != 1 {
+		t.Errorf("RenderCount(details) = %d after opening, want 1", got)
+	}
+}
@@ -0,0 +1,105 @@
+package testing
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erweixin/rego"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Script 按顺序向 TestRuntime 注入输入，并在指定步骤与快照比较，用于覆盖完整的交互流程：
+//
+//	regotest.NewScript(t, tr, "todo").
+//		Type("买牛奶\n").Snapshot("after-add").
+//		Key('j').Key(rego.KeyEnter).Snapshot("after-toggle")
+//
+// 快照保存在 testdata/snapshots/<脚本名>/<快照名> 下，更新方式与 AssertSnapshot 相同。
+type Script struct {
+	t     *testing.T
+	tr    *TestRuntime
+	name  string
+	opts  []SnapshotOption
+	steps []string // 上一个快照之后执行的步骤，快照不一致时输出
+}
+
+// NewScript 创建脚本并渲染第一帧，opts 应用于脚本中的所有快照
+func NewScript(t *testing.T, tr *TestRuntime, name string, opts ...SnapshotOption) *Script {
+	tr.Render()
+	return &Script{t: t, tr: tr, name: name, opts: opts}
+}
+
+// Key 发送一个按键：k 为 rune（如 'j'）或 rego.Key（如 rego.KeyEnter）
+func (s *Script) Key(k any, mods ...rego.Modifiers) *Script {
+	s.t.Helper()
+	switch k := k.(type) {
+	case rune:
+		s.tr.DispatchKey(tcell.KeyRune, k, rego.TcellMods(mods...))
+		s.tr.Render()
+		s.record(fmt.Sprintf("Key(%q)", k))
+	case rego.Key:
+		s.tr.SendKey(k, mods...)
+		s.record("Key(" + keyLabel(k) + ")")
+	default:
+		s.t.Fatalf("Script.Key: unsupported key %v (%T), use a rune or rego.Key", k, k)
+	}
+	return s
+}
+
+// Type 逐字符输入文本，'\n' 作为 Enter 发送
+func (s *Script) Type(text string) *Script {
+	s.tr.TypeText(text)
+	s.record(fmt.Sprintf("Type(%q)", text))
+	return s
+}
+
+// Click 在 (x, y) 处单击鼠标左键
+func (s *Script) Click(x, y int, mods ...rego.Modifiers) *Script {
+	s.tr.Click(x, y, mods...)
+	s.record(fmt.Sprintf("Click(%d, %d)", x, y))
+	return s
+}
+
+// Scroll 在 (x, y) 处滚动一格滚轮
+func (s *Script) Scroll(x, y int, dir ScrollDirection, mods ...rego.Modifiers) *Script {
+	s.tr.Scroll(x, y, dir, mods...)
+	s.record(fmt.Sprintf("Scroll(%d, %d, %d)", x, y, dir))
+	return s
+}
+
+// Advance 推进 FakeClock
+func (s *Script) Advance(d time.Duration) *Script {
+	s.tr.AdvanceTime(d)
+	s.record(fmt.Sprintf("Advance(%s)", d))
+	return s
+}
+
+// Snapshot 将当前屏幕与名为 name 的快照比较
+func (s *Script) Snapshot(name string) *Script {
+	s.t.Helper()
+	failed := s.t.Failed()
+	AssertSnapshot(s.t, s.tr.Screen, s.name+"/"+name, s.opts...)
+	if !failed && s.t.Failed() && len(s.steps) > 0 {
+		s.t.Logf("steps before snapshot %q: %s", name, strings.Join(s.steps, " → "))
+	}
+	s.steps = s.steps[:0]
+	return s
+}
+
+func (s *Script) record(step string) {
+	s.steps = append(s.steps, step)
+}
+
+// keyLabel 返回按键的可读名称
+func keyLabel(k rego.Key) string {
+	if k == rego.KeySpace {
+		return "Space"
+	}
+	tk, _, _ := rego.TcellKey(k)
+	if name, ok := tcell.KeyNames[tk]; ok {
+		return name
+	}
+	return fmt.Sprintf("Key(%d)", int(k))
+}
@@ -0,0 +1,69 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/erweixin/rego"
+)
+
+// todoList 聚焦时 j/k 移动光标，d 删除当前项
+func todoList(c rego.C, items []string, onDelete func(i int)) rego.Node {
+	focus := rego.UseFocus(c)
+	cursor := rego.Use(c, "cursor", 0)
+	rego.UseKey(c, func(key rego.Key, r rune) {
+		if !focus.IsFocused || len(items) == 0 {
+			return
+		}
+		switch r {
+		case 'j':
+			cursor.Set(min(cursor.Val+1, len(items)-1))
+		case 'k':
+			cursor.Set(max(cursor.Val-1, 0))
+		case 'd':
+			onDelete(cursor.Val)
+			cursor.Set(max(0, min(cursor.Val, len(items)-2)))
+		}
+	})
+
+	var rows []rego.Node
+	for i, item := range items {
+		prefix := "  "
+		if focus.IsFocused && i == cursor.Val {
+			prefix = "> "
+		}
+		rows = append(rows, rego.Text(prefix+item))
+	}
+	return c.Wrap(rego.VStack(rows...))
+}
+
+func todoApp(c rego.C) rego.Node {
+	items := rego.Use(c, "items", []string{"milk"})
+	draft := rego.Use(c, "draft", "")
+	return rego.VStack(
+		rego.TextInput(c.Child("input"), rego.TextInputProps{
+			Value:     draft.Val,
+			OnChanged: draft.Set,
+			OnSubmit: func(v string) {
+				items.Set(append(append([]string{}, items.Val...), v))
+				draft.Set("")
+			},
+		}),
+		todoList(c.Child("list"), items.Val, func(i int) {
+			next := append([]string{}, items.Val[:i]...)
+			items.Set(append(next, items.Val[i+1:]...))
+		}),
+	)
+}
+
+func TestScript_TodoFlow(t *testing.T) {
+	tr := NewTestRuntime(todoApp, 20, 6)
+	NewScript(t, tr, "todo").
+		Type("eggs\n").
+		Type("bread\n").
+		Snapshot("after-add").
+		Key(rego.KeyTab).
+		Key('j').
+		Snapshot("after-select").
+		Key('d').
+		Snapshot("after-delete")
+}
@@ -1,18 +1,96 @@
 package testing
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// SnapshotOption 快照选项
+type SnapshotOption func(*snapshotOptions)
+
+type snapshotFormat int
+
+const (
+	snapshotText   snapshotFormat = iota // 纯文本，忽略样式
+	snapshotStyled                       // 纯文本 + 每个单元格的颜色与属性
+	snapshotANSI                         // 带 ANSI 转义序列的文本
 )
 
+type snapshotOptions struct {
+	format   snapshotFormat
+	masks    []maskRect
+	replaces []snapshotReplace
+}
+
+type maskRect struct{ x, y, w, h int }
+
+type snapshotReplace struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// maskRune 被遮盖单元格显示的字符
+const maskRune = '░'
+
+// WithStyles 快照中记录每个单元格的前景色、背景色和文字属性，
+// 不一致时逐个列出样式发生变化的单元格
+func WithStyles() SnapshotOption {
+	return func(o *snapshotOptions) { o.format = snapshotStyled }
+}
+
+// WithANSI 以 ANSI 转义序列记录样式，快照文件可以直接用 cat 在终端中查看
+func WithANSI() SnapshotOption {
+	return func(o *snapshotOptions) { o.format = snapshotANSI }
+}
+
+// Mask 遮盖屏幕上的矩形区域（如时钟、Spinner）：区域内的单元格在快照中显示为 '░' 并使用默认样式，
+// 可以多次使用
+func Mask(x, y, w, h int) SnapshotOption {
+	return func(o *snapshotOptions) { o.masks = append(o.masks, maskRect{x, y, w, h}) }
+}
+
+// Replace 在生成快照后用正则替换内容（如把 "12:03:45" 替换为 "HH:MM:SS"），repl 的写法同 regexp.ReplaceAllString，
+// 按传入顺序执行；WithANSI 时替换的是带转义序列的文本
+func Replace(pattern, repl string) SnapshotOption {
+	re := regexp.MustCompile(pattern)
+	return func(o *snapshotOptions) { o.replaces = append(o.replaces, snapshotReplace{re, repl}) }
+}
+
+// maskedScreen 读取时把遮盖区域内的单元格替换为 maskRune
+type maskedScreen struct {
+	tcell.SimulationScreen
+	masks []maskRect
+}
+
+func (s maskedScreen) GetContent(x, y int) (rune, []rune, tcell.Style, int) {
+	for _, m := range s.masks {
+		if x >= m.x && x < m.x+m.w && y >= m.y && y < m.y+m.h {
+			return maskRune, nil, tcell.StyleDefault, 1
+		}
+	}
+	return s.SimulationScreen.GetContent(x, y)
+}
+
 // AssertSnapshot 比较当前屏幕内容与快照文件
-func AssertSnapshot(t *testing.T, screen *MockScreen, snapshotName string) {
+//
+// 默认只比较文字，传入 WithStyles 或 WithANSI 时同时比较样式；
+// 内容中有时间、动画等不确定部分时，用 Mask 或 Replace 使其稳定。
+func AssertSnapshot(t *testing.T, screen *MockScreen, snapshotName string, opts ...SnapshotOption) {
 	t.Helper()
 
-	content := screen.GetContentString()
-	snapshotPath := filepath.Join("testdata", "snapshots", snapshotName+".txt")
+	var o snapshotOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	content, ext := snapshotContent(screen, &o)
+	snapshotPath := filepath.Join("testdata", "snapshots", snapshotName+ext)
 
 	// 如果环境变量 REGO_UPDATE_SNAPSHOTS 为 true，则更新快照
 	if os.Getenv("REGO_UPDATE_SNAPSHOTS") == "true" {
@@ -38,12 +116,57 @@ func AssertSnapshot(t *testing.T, screen *MockScreen, snapshotName string) {
 	}
 
 	expectedStr := string(expected)
-	if content != expectedStr {
+	if content == expectedStr {
+		return
+	}
+	switch o.format {
+	case snapshotStyled:
+		t.Errorf("Snapshot mismatch for %s\n\n%s", snapshotName, styledDiff(expectedStr, content))
+	case snapshotANSI:
+		t.Errorf("Snapshot mismatch for %s\n\nDiff:\n%s",
+			snapshotName, diff(escapeANSI(expectedStr), escapeANSI(content)))
+	default:
 		t.Errorf("Snapshot mismatch for %s\n\nExpected:\n%s\n\nGot:\n%s\n\nDiff:\n%s",
 			snapshotName, expectedStr, content, diff(expectedStr, content))
 	}
 }
 
+// snapshotContent 按选项生成快照内容，返回内容和快照文件的扩展名
+func snapshotContent(screen *MockScreen, o *snapshotOptions) (content, ext string) {
+	if len(o.masks) > 0 {
+		screen = &MockScreen{maskedScreen{screen.SimulationScreen, o.masks}}
+	}
+	switch o.format {
+	case snapshotStyled:
+		content, ext = screen.GetStyledString(), ".styled.txt"
+	case snapshotANSI:
+		content, ext = screen.GetANSIString(), ".ansi"
+	default:
+		content, ext = screen.GetContentString(), ".txt"
+	}
+	return o.normalize(content), ext
+}
+
+// normalize 依次执行 Replace；带样式的快照只替换文字部分
+func (o *snapshotOptions) normalize(content string) string {
+	if len(o.replaces) == 0 {
+		return content
+	}
+	text, styles, styled := "", "", false
+	if o.format == snapshotStyled {
+		text, styles, styled = strings.Cut(content, "\n"+stylesHeader+"\n")
+	} else {
+		text = content
+	}
+	for _, r := range o.replaces {
+		text = r.re.ReplaceAllString(text, r.repl)
+	}
+	if styled {
+		return text + "\n" + stylesHeader + "\n" + styles
+	}
+	return text
+}
+
 // 简单的 diff 实现
 func diff(expected, actual string) string {
 	expLines := strings.Split(expected, "\n")
@@ -74,3 +197,59 @@ func diff(expected, actual string) string {
 	return res.String()
 }
 
+// maxStyleDiffs 样式差异最多列出的单元格数
+const maxStyleDiffs = 20
+
+// styledDiff 比较两份带样式的快照：文字不同时给出逐行 diff，并逐个列出样式变化的单元格
+func styledDiff(expected, actual string) string {
+	expText, expStyles := parseStyled(expected)
+	actText, actStyles := parseStyled(actual)
+
+	var res strings.Builder
+	if expText != actText {
+		res.WriteString("Text diff:\n")
+		res.WriteString(diff(expText, actText))
+		res.WriteString("\n")
+	}
+
+	expLines := strings.Split(expText, "\n")
+	actLines := strings.Split(actText, "\n")
+	var cells []cellPos
+	seen := map[cellPos]bool{}
+	for _, styles := range []map[cellPos]string{expStyles, actStyles} {
+		for pos := range styles {
+			if !seen[pos] && expStyles[pos] != actStyles[pos] {
+				seen[pos] = true
+				cells = append(cells, pos)
+			}
+		}
+	}
+	if len(cells) == 0 {
+		return res.String()
+	}
+	sortCells(cells)
+
+	fmt.Fprintf(&res, "Style diff (%d cells):\n", len(cells))
+	for i, pos := range cells {
+		if i == maxStyleDiffs {
+			fmt.Fprintf(&res, "  ... and %d more\n", len(cells)-maxStyleDiffs)
+			break
+		}
+		fmt.Fprintf(&res, "  (%d,%d) %q: %s -> %s\n", pos.x, pos.y,
+			cellChar(actLines, pos, cellChar(expLines, pos, "")),
+			orDefault(expStyles[pos]), orDefault(actStyles[pos]))
+	}
+	return res.String()
+}
+
+// escapeANSI 把 ESC 显示为 \e，便于在 diff 中阅读
+func escapeANSI(s string) string {
+	return strings.ReplaceAll(s, "\x1b", `\e`)
+}
+
+func orDefault(style string) string {
+	if style == "" {
+		return "default"
+	}
+	return style
+}
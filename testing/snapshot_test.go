@@ -0,0 +1,93 @@
+package testing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erweixin/rego"
+)
+
+func TestStyledSnapshot(t *testing.T) {
+	color := rego.Yellow
+	app := func(c rego.C) rego.Node {
+		return rego.VStack(
+			rego.Text("plain"),
+			rego.Text("warn").Color(color).Bold(),
+		)
+	}
+
+	tr := NewTestRuntime(app, 8, 2)
+	tr.Render()
+	before := tr.Screen.GetStyledString()
+	if !strings.HasPrefix(before, "plain   \nwarn    \n"+stylesHeader+"\n") {
+		t.Fatalf("unexpected styled text section:\n%s", before)
+	}
+	if !strings.Contains(before, "1:0-3 fg=yellow bold\n") {
+		t.Errorf("expected style run for second row, got:\n%s", before)
+	}
+
+	// 只改颜色：文字不变，diff 指出变化的单元格
+	color = rego.Red
+	tr.Render()
+	after := tr.Screen.GetStyledString()
+	d := styledDiff(before, after)
+	if strings.Contains(d, "Text diff") {
+		t.Errorf("expected no text diff, got:\n%s", d)
+	}
+	if !strings.Contains(d, "Style diff (4 cells)") || !strings.Contains(d, `(0,1) "w": fg=yellow bold -> fg=red bold`) {
+		t.Errorf("expected per-cell style diff, got:\n%s", d)
+	}
+}
+
+func TestANSISnapshot(t *testing.T) {
+	app := func(c rego.C) rego.Node {
+		return rego.HStack(rego.Text("a"), rego.Text("b").Bold().Background(rego.RGB(1, 2, 3)))
+	}
+
+	tr := NewTestRuntime(app, 3, 1)
+	tr.Render()
+	got := tr.Screen.GetANSIString()
+	want := "a\x1b[0m\x1b[1;48;2;1;2;3mb\x1b[0m "
+	if got != want {
+		t.Errorf("GetANSIString() = %q, want %q", got, want)
+	}
+}
+
+func TestSnapshotNormalizers(t *testing.T) {
+	clock := "12:03:45"
+	frame := "⠋"
+	app := func(c rego.C) rego.Node {
+		return rego.VStack(
+			rego.Text("at "+clock),
+			rego.HStack(rego.Text(frame).Color(rego.Cyan), rego.Text(" loading")),
+		)
+	}
+	tr := NewTestRuntime(app, 12, 2)
+	tr.Render()
+
+	opts := []SnapshotOption{Replace(`\d\d:\d\d:\d\d`, "HH:MM:SS"), Mask(0, 1, 1, 1)}
+	snap := func(extra ...SnapshotOption) string {
+		var o snapshotOptions
+		for _, opt := range append(opts, extra...) {
+			opt(&o)
+		}
+		content, _ := snapshotContent(tr.Screen, &o)
+		return content
+	}
+
+	want := "at HH:MM:SS \n░ loading   "
+	if got := snap(); got != want {
+		t.Fatalf("normalized = %q, want %q", got, want)
+	}
+	styled := snap(WithStyles())
+	if !strings.HasPrefix(styled, want+"\n"+stylesHeader+"\n") || strings.Contains(styled, "cyan") {
+		t.Errorf("styled snapshot not normalized:\n%s", styled)
+	}
+
+	// 时间和动画帧变化后快照不变
+	clock, frame = "23:59:01", "⠙"
+	tr.Render()
+	if got := snap(); got != want {
+		t.Errorf("after change = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,196 @@
+package testing
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// 带样式的屏幕内容
+// =============================================================================
+
+// stylesHeader 分隔带样式快照中的文字部分和样式部分
+const stylesHeader = "--- styles ---"
+
+// cellPos 单元格坐标
+type cellPos struct{ x, y int }
+
+func sortCells(cells []cellPos) {
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].y != cells[j].y {
+			return cells[i].y < cells[j].y
+		}
+		return cells[i].x < cells[j].x
+	})
+}
+
+// describeStyle 返回样式的文字描述，如 "fg=yellow bg=navy bold"；默认样式返回空串
+func describeStyle(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	var parts []string
+	if fg != tcell.ColorDefault && fg != tcell.ColorReset {
+		parts = append(parts, "fg="+fg.String())
+	}
+	if bg != tcell.ColorDefault && bg != tcell.ColorReset {
+		parts = append(parts, "bg="+bg.String())
+	}
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		name string
+	}{
+		{tcell.AttrBold, "bold"},
+		{tcell.AttrDim, "dim"},
+		{tcell.AttrItalic, "italic"},
+		{tcell.AttrBlink, "blink"},
+		{tcell.AttrReverse, "reverse"},
+		{tcell.AttrStrikeThrough, "strikethrough"},
+	} {
+		if attrs&a.mask != 0 {
+			parts = append(parts, a.name)
+		}
+	}
+	if style.GetUnderlineStyle() != tcell.UnderlineStyleNone {
+		parts = append(parts, "underline")
+	}
+	return strings.Join(parts, " ")
+}
+
+// GetStyledString 获取屏幕内容及样式：先是与 GetContentString 相同的文字，
+// 然后每行一段样式相同的连续单元格，格式为 "行:起始列-结束列 样式"，默认样式不记录
+func (s *MockScreen) GetStyledString() string {
+	var res strings.Builder
+	res.WriteString(s.GetContentString())
+	res.WriteString("\n" + stylesHeader + "\n")
+
+	w, h := s.Size()
+	for y := 0; y < h; y++ {
+		start, current := 0, ""
+		flush := func(end int) {
+			if current != "" {
+				fmt.Fprintf(&res, "%d:%d-%d %s\n", y, start, end-1, current)
+			}
+		}
+		for x := 0; x < w; x++ {
+			_, _, style, _ := s.GetContent(x, y)
+			if desc := describeStyle(style); desc != current {
+				flush(x)
+				start, current = x, desc
+			}
+		}
+		flush(w)
+	}
+	return res.String()
+}
+
+// parseStyled 解析 GetStyledString 的输出，返回文字部分和每个单元格的样式
+func parseStyled(content string) (string, map[cellPos]string) {
+	text, styles, _ := strings.Cut(content, "\n"+stylesHeader+"\n")
+	cells := map[cellPos]string{}
+	for _, line := range strings.Split(styles, "\n") {
+		head, desc, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		row, span, ok := strings.Cut(head, ":")
+		if !ok {
+			continue
+		}
+		from, to, _ := strings.Cut(span, "-")
+		y, err1 := strconv.Atoi(row)
+		x1, err2 := strconv.Atoi(from)
+		x2, err3 := strconv.Atoi(to)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		for x := x1; x <= x2; x++ {
+			cells[cellPos{x, y}] = desc
+		}
+	}
+	return text, cells
+}
+
+// cellChar 返回文字部分中某个单元格的字符（按 rune 计列，与 GetContentString 一致）
+func cellChar(lines []string, pos cellPos, fallback string) string {
+	if pos.y >= len(lines) {
+		return fallback
+	}
+	runes := []rune(lines[pos.y])
+	if pos.x >= len(runes) {
+		return fallback
+	}
+	return string(runes[pos.x])
+}
+
+// GetANSIString 获取带 ANSI 转义序列（SGR）的屏幕内容
+func (s *MockScreen) GetANSIString() string {
+	var res strings.Builder
+	w, h := s.Size()
+	for y := 0; y < h; y++ {
+		current := tcell.StyleDefault
+		for x := 0; x < w; x++ {
+			r, _, style, _ := s.GetContent(x, y)
+			if style != current {
+				res.WriteString("\x1b[0m" + sgr(style))
+				current = style
+			}
+			if r == 0 {
+				r = ' '
+			}
+			res.WriteRune(r)
+		}
+		if current != tcell.StyleDefault {
+			res.WriteString("\x1b[0m")
+		}
+		if y < h-1 {
+			res.WriteString("\n")
+		}
+	}
+	return res.String()
+}
+
+// sgr 返回设置样式的 SGR 转义序列，默认样式返回空串
+func sgr(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	var codes []string
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attrs&a.mask != 0 {
+			codes = append(codes, a.code)
+		}
+	}
+	if style.GetUnderlineStyle() != tcell.UnderlineStyleNone {
+		codes = append(codes, "4")
+	}
+	codes = append(codes, sgrColor(fg, 38)...)
+	codes = append(codes, sgrColor(bg, 48)...)
+	if len(codes) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// sgrColor 返回前景（base=38）或背景（base=48）颜色的 SGR 参数
+func sgrColor(c tcell.Color, base int) []string {
+	switch {
+	case !c.Valid():
+		return nil
+	case c.IsRGB():
+		r, g, b := c.RGB()
+		return []string{strconv.Itoa(base), "2", strconv.Itoa(int(r)), strconv.Itoa(int(g)), strconv.Itoa(int(b))}
+	default:
+		return []string{strconv.Itoa(base), "5", strconv.Itoa(int(c - tcell.ColorValid))}
+	}
+}
@@ -0,0 +1,151 @@
+package rego
+
+import (
+	"container/list"
+	"hash/maphash"
+	"sync"
+)
+
+// =============================================================================
+// 文本测量缓存
+// =============================================================================
+
+// textCacheSize 每种测量结果缓存的条目数
+const textCacheSize = 4096
+
+// textCacheMaxLen 超过该字节数的字符串不缓存：流式输出等长文本每次内容都不同，
+// 缓存只会占用内存，而且测量本身与比较缓存键一样是线性的
+const textCacheMaxLen = 256
+
+// textCacheShards 缓存分片数，减少并行测量时的锁竞争
+const textCacheShards = 16
+
+// lru 是并发安全的定长 LRU 缓存
+type lru[K comparable, V any] struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[K]*list.Element
+}
+
+type lruItem[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](size int) *lru[K, V] {
+	return &lru[K, V]{size: size, ll: list.New(), items: make(map[K]*list.Element)}
+}
+
+func (c *lru[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*lruItem[K, V]).value, true
+}
+
+func (c *lru[K, V]) put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruItem[K, V]).value = value
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&lruItem[K, V]{key: key, value: value})
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruItem[K, V]).key)
+	}
+}
+
+// shardedLRU 按键的哈希分成 textCacheShards 个 LRU，各自加锁
+type shardedLRU[K comparable, V any] struct {
+	shards [textCacheShards]*lru[K, V]
+	hash   func(K) uint64
+}
+
+func newShardedLRU[K comparable, V any](size int, hash func(K) uint64) *shardedLRU[K, V] {
+	c := &shardedLRU[K, V]{hash: hash}
+	for i := range c.shards {
+		c.shards[i] = newLRU[K, V](max(1, size/textCacheShards))
+	}
+	return c
+}
+
+func (c *shardedLRU[K, V]) shard(key K) *lru[K, V] {
+	return c.shards[c.hash(key)%textCacheShards]
+}
+
+func (c *shardedLRU[K, V]) get(key K) (V, bool) { return c.shard(key).get(key) }
+
+func (c *shardedLRU[K, V]) put(key K, value V) { c.shard(key).put(key, value) }
+
+// 字符串不可变，测量结果只与内容（和宽度）有关，因此缓存无需失效
+type wrapKey struct {
+	s     string
+	width int
+}
+
+var (
+	textCacheSeed = maphash.MakeSeed()
+	widthCache    = newShardedLRU[string, int](textCacheSize, func(s string) uint64 {
+		return maphash.String(textCacheSeed, s)
+	})
+	wrapCache = newShardedLRU[wrapKey, int](textCacheSize, func(k wrapKey) uint64 {
+		return maphash.String(textCacheSeed, k.s) + uint64(k.width)
+	})
+)
+
+// cachedStringWidth 返回 s 的显示宽度
+func cachedStringWidth(s string) int {
+	if len(s) > textCacheMaxLen {
+		return StringWidth(s)
+	}
+	if w, ok := widthCache.get(s); ok {
+		return w
+	}
+	w := StringWidth(s)
+	widthCache.put(s, w)
+	return w
+}
+
+// cachedWrapLines 返回 s 在 width 宽度下自动换行后的行数
+func cachedWrapLines(s string, width int) int {
+	if len(s) > textCacheMaxLen {
+		return wrapLines(s, width)
+	}
+	key := wrapKey{s: s, width: width}
+	if n, ok := wrapCache.get(key); ok {
+		return n
+	}
+	n := wrapLines(s, width)
+	wrapCache.put(key, n)
+	return n
+}
+
+// wrapLines 计算 s 在 width 宽度下自动换行后的行数，与 textNode 的换行规则一致
+func wrapLines(s string, width int) int {
+	currentX := 0
+	lines := 1
+	forEachGrapheme(s, func(mainc rune, combc []rune, charWidth int) bool {
+		if currentX+charWidth > width {
+			currentX = 0
+			lines++
+		}
+		if isNewline(mainc, combc) {
+			currentX = 0
+			lines++
+			return true
+		}
+		currentX += charWidth
+		return true
+	})
+	return lines
+}
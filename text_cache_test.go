@@ -0,0 +1,55 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	c := newLRU[string, int](2)
+	c.put("a", 1)
+	c.put("b", 2)
+	c.get("a") // a 最近使用，b 最先被淘汰
+	c.put("c", 3)
+
+	if _, ok := c.get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if v, ok := c.get("a"); !ok || v != 1 {
+		t.Errorf("expected a=1, got %d, %v", v, ok)
+	}
+	if v, ok := c.get("c"); !ok || v != 3 {
+		t.Errorf("expected c=3, got %d, %v", v, ok)
+	}
+}
+
+func TestCachedWrapLines(t *testing.T) {
+	s := "hello world\n中文换行测试"
+	for _, width := range []int{3, 5, 11, 40} {
+		want := wrapLines(s, width)
+		for i := 0; i < 2; i++ {
+			if got := cachedWrapLines(s, width); got != want {
+				t.Errorf("cachedWrapLines(width=%d) = %d, want %d", width, got, want)
+			}
+		}
+	}
+	if w := cachedStringWidth("中文ab"); w != 6 {
+		t.Errorf("cachedStringWidth = %d, want 6", w)
+	}
+}
+
+func TestTextCache_SkipsLongStrings(t *testing.T) {
+	long := strings.Repeat("x", textCacheMaxLen+1)
+	if w := cachedStringWidth(long); w != len(long) {
+		t.Errorf("cachedStringWidth = %d, want %d", w, len(long))
+	}
+	if n := cachedWrapLines(long, 10); n != wrapLines(long, 10) {
+		t.Errorf("cachedWrapLines = %d, want %d", n, wrapLines(long, 10))
+	}
+	if _, ok := widthCache.get(long); ok {
+		t.Error("expected long string not to be cached by width")
+	}
+	if _, ok := wrapCache.get(wrapKey{s: long, width: 10}); ok {
+		t.Error("expected long string not to be cached by wrap")
+	}
+}
@@ -3,67 +3,94 @@ package rego
 import (
 	"strings"
 	"unicode/utf8"
-
-	"github.com/mattn/go-runewidth"
 )
 
 // =============================================================================
 // TextInput - 文本输入组件 (增强支持多行)
 // =============================================================================
 
+// TextInputProps 输入框属性
+//
+// Controlled 为 true 时为受控模式：输入框始终渲染 Value，所有编辑都通过 OnChanged 交给调用方；
+// 否则为非受控模式：输入框自行维护文本，初始值取 DefaultValue（为空时取 Value），
+// 之后 Value 与上次渲染时不同才覆盖当前文本，OnChanged 只用于通知。
 type TextInputProps struct {
-	Value       string
-	Placeholder string
-	Label       string
-	Width       int
-	Height      int  // 0 表示单行，>1 表示多行
-	Multiline   bool // 是否开启多行模式
-	OnChanged   func(string)
-	OnSubmit    func(string)
-	Password    bool // 是否为密码模式
+	Value        string
+	DefaultValue string // 非受控模式下的初始值
+	Controlled   bool   // 由调用方通过 Value 和 OnChanged 维护文本
+	Placeholder  string
+	Label        string
+	Width        int
+	Height       int  // 0 表示单行，>1 表示多行
+	Multiline    bool // 是否开启多行模式
+	OnChanged    func(string)
+	OnSubmit     func(string)
+	Password     bool   // 是否为密码模式
+	Error        string // 校验错误，非空时以红色显示在输入框下方并将边框标红
+	Hint         string // 辅助说明，显示在输入框下方（有 Error 时不显示）
 }
 
 func TextInput(c C, props TextInputProps) Node {
 	focus := UseFocus(c)
-	text := Use(c, "text", props.Value)
+	UseCursorStyle(c, CursorStyle{Shape: CursorBar, Blink: true})
+	c.(*componentContext).acceptsText = true
+	controlled := props.Controlled
+
+	initial := props.DefaultValue
+	if initial == "" {
+		initial = props.Value
+	}
+	text := Use(c, "text", initial)
+
+	// value 保存最新的文本：受控模式下每帧取 props.Value，
+	// 两帧之间的连续按键基于上一次 OnChanged 的结果继续编辑，避免快速输入丢字
+	value := UseRef(c, initial)
+	lastValue := UseRef(c, props.Value)
+	if controlled {
+		value.Current = props.Value
+	} else {
+		if props.Value != lastValue.Current {
+			text.Set(props.Value) // 调用方修改了 Value（如提交后清空）
+		}
+		value.Current = text.Val
+	}
+	lastValue.Current = props.Value
+
 	// 在多行模式下，cursorPos 是整个字符串的 rune 偏移量
-	cursorPos := Use(c, "cursorPos", utf8.RuneCountInString(text.Val))
+	cursorPos := Use(c, "cursorPos", utf8.RuneCountInString(value.Current))
+
+	// 外部修改文本时保持光标合法：原本在末尾的光标跟随到新末尾，其余情况截断到文本长度
+	lastRendered := UseRef(c, value.Current)
+	if lastRendered.Current != value.Current {
+		oldLen := utf8.RuneCountInString(lastRendered.Current)
+		newLen := utf8.RuneCountInString(value.Current)
+		if cursorPos.Val == oldLen || cursorPos.Val > newLen {
+			cursorPos.Set(newLen)
+		}
+		lastRendered.Current = value.Current
+	}
 
-	// 同步外部 Value
-	UseEffect(c, func() func() {
-		if props.Value != text.Val {
-			text.Set(props.Value)
-			// 注意：这里简单重置光标到末尾，或者保持不变
+	setValue := func(v string) {
+		value.Current = v
+		if !controlled {
+			text.Set(v)
+		}
+		if props.OnChanged != nil {
+			props.OnChanged(v)
 		}
-		return nil
-	}, props.Value)
+	}
 
 	// 鼠标点击处理
 	UseMouse(c, func(ev MouseEvent) {
-		if ev.Type != MouseEventClick || ev.Button != MouseButtonLeft {
-			return
-		}
-
-		rect := c.Rect()
-		if !rect.Contains(ev.X, ev.Y) {
+		if ev.Type != MouseEventClick || ev.Button != MouseButtonLeft || !ev.Hit {
 			return
 		}
 
 		// 点击时聚焦
 		focus.Focus()
 
-		// 计算文本区域的偏移量
-		// 布局: Box > VStack > [Label?] > Box(border+padding) > content
-		// border: 1, padding: (0, 1)
-		textAreaX := rect.X + 1 + 1 // border + padding
-		textAreaY := rect.Y + 1     // border
-		if props.Label != "" {
-			textAreaY += 1 // Label 占一行
-		}
-
-		// 计算点击的相对位置
-		clickCol := ev.X - textAreaX
-		clickRow := ev.Y - textAreaY
+		// 文本区域的位置在渲染时记录，不依赖外层的边框、padding 和标签
+		clickCol, clickRow, _ := ev.In(c.ContentRect())
 
 		if clickCol < 0 {
 			clickCol = 0
@@ -73,9 +100,9 @@ func TextInput(c C, props TextInputProps) Node {
 		}
 
 		// 根据点击位置计算光标位置
-		displayVal := text.Val
+		displayVal := value.Current
 		if props.Password {
-			displayVal = strings.Repeat("*", utf8.RuneCountInString(text.Val))
+			displayVal = strings.Repeat("*", utf8.RuneCountInString(value.Current))
 		}
 
 		newPos := calculateCursorPosFromClick(displayVal, clickRow, clickCol)
@@ -88,46 +115,44 @@ func TextInput(c C, props TextInputProps) Node {
 			return
 		}
 
-		runes := []rune(text.Val)
+		runes := []rune(value.Current)
 		currentLen := len(runes)
+		if cursorPos.Val > currentLen {
+			cursorPos.Set(currentLen)
+		}
 
 		switch key {
+		// 删除和移动都以字素簇为单位，避免拆开 emoji 序列或组合字符
 		case KeyBackspace:
 			if cursorPos.Val > 0 {
-				newRunes := append(runes[:cursorPos.Val-1], runes[cursorPos.Val:]...)
-				newVal := string(newRunes)
-				text.Set(newVal)
-				cursorPos.Update(func(v int) int { return v - 1 })
-				if props.OnChanged != nil {
-					props.OnChanged(newVal)
-				}
+				prev := prevGrapheme(runes, cursorPos.Val)
+				newRunes := append(runes[:prev], runes[cursorPos.Val:]...)
+				cursorPos.Set(prev)
+				setValue(string(newRunes))
 			}
 		case KeyDelete:
 			if cursorPos.Val < currentLen {
-				newRunes := append(runes[:cursorPos.Val], runes[cursorPos.Val+1:]...)
-				newVal := string(newRunes)
-				text.Set(newVal)
-				if props.OnChanged != nil {
-					props.OnChanged(newVal)
-				}
+				next := nextGrapheme(runes, cursorPos.Val)
+				newRunes := append(runes[:cursorPos.Val], runes[next:]...)
+				setValue(string(newRunes))
 			}
 		case KeyLeft:
 			if cursorPos.Val > 0 {
-				cursorPos.Update(func(v int) int { return v - 1 })
+				cursorPos.Set(prevGrapheme(runes, cursorPos.Val))
 			}
 		case KeyRight:
 			if cursorPos.Val < currentLen {
-				cursorPos.Update(func(v int) int { return v + 1 })
+				cursorPos.Set(nextGrapheme(runes, cursorPos.Val))
 			}
 		case KeyUp:
 			if props.Multiline {
 				// 找到上一行的位置
-				cursorPos.Set(findPosAbove(runes, cursorPos.Val))
+				cursorPos.Set(snapToGrapheme(runes, findPosAbove(runes, cursorPos.Val)))
 			}
 		case KeyDown:
 			if props.Multiline {
 				// 找到下一行的位置
-				cursorPos.Set(findPosBelow(runes, cursorPos.Val))
+				cursorPos.Set(snapToGrapheme(runes, findPosBelow(runes, cursorPos.Val)))
 			}
 		case KeyEnter:
 			if props.Multiline {
@@ -136,15 +161,11 @@ func TextInput(c C, props TextInputProps) Node {
 				newRunes = append(newRunes, runes[:cursorPos.Val]...)
 				newRunes = append(newRunes, '\n')
 				newRunes = append(newRunes, runes[cursorPos.Val:]...)
-				newVal := string(newRunes)
-				text.Set(newVal)
 				cursorPos.Update(func(v int) int { return v + 1 })
-				if props.OnChanged != nil {
-					props.OnChanged(newVal)
-				}
+				setValue(string(newRunes))
 			} else {
 				if props.OnSubmit != nil {
-					props.OnSubmit(text.Val)
+					props.OnSubmit(value.Current)
 				}
 			}
 		case KeyHome:
@@ -175,27 +196,27 @@ func TextInput(c C, props TextInputProps) Node {
 				newRunes = append(newRunes, runes[:cursorPos.Val]...)
 				newRunes = append(newRunes, r)
 				newRunes = append(newRunes, runes[cursorPos.Val:]...)
-				newVal := string(newRunes)
-				text.Set(newVal)
 				cursorPos.Update(func(v int) int { return v + 1 })
-				if props.OnChanged != nil {
-					props.OnChanged(newVal)
-				}
+				setValue(string(newRunes))
 			}
 		}
 	})
 
 	// 渲染逻辑
-	displayVal := text.Val
+	displayVal := value.Current
 	if props.Password {
-		displayVal = strings.Repeat("*", utf8.RuneCountInString(text.Val))
+		displayVal = strings.Repeat("*", utf8.RuneCountInString(value.Current))
 	}
 
 	runes := []rune(displayVal)
-	before := string(runes[:cursorPos.Val])
+	pos := cursorPos.Val
+	if pos > len(runes) {
+		pos = len(runes)
+	}
+	before := string(runes[:pos])
 	after := ""
-	if cursorPos.Val < len(runes) {
-		after = string(runes[cursorPos.Val:])
+	if pos < len(runes) {
+		after = string(runes[pos:])
 	}
 
 	// 将文本按行分割渲染
@@ -225,7 +246,7 @@ func TextInput(c C, props TextInputProps) Node {
 	}
 
 	var content Node = VStack(rows...)
-	if text.Val == "" && !focus.IsFocused {
+	if value.Current == "" && !focus.IsFocused {
 		placeholder := Text(props.Placeholder).Dim()
 		if props.Multiline {
 			content = VStack(placeholder)
@@ -244,14 +265,22 @@ func TextInput(c C, props TextInputProps) Node {
 		}
 	}
 
+	content = c.WrapContent(content)
+	field := FocusRing(c, WhenElse(props.Multiline, ScrollBox(c.Child("scroll"), content), content)).
+		Padding(0, 1).
+		Height(boxHeight)
+	if props.Error != "" {
+		field.BorderColor(Red)
+	}
+
 	return c.Wrap(Box(
 		VStack(
 			When(props.Label != "", Text(props.Label).Dim().Bold()),
-			Box(WhenElse(props.Multiline, ScrollBox(c.Child("scroll"), content), content)).
-				Padding(0, 1).
-				Border(BorderSingle).
-				BorderColor(If(focus.IsFocused, Cyan, Gray)).
-				Height(boxHeight),
+			field,
+			WhenElse(props.Error != "",
+				Text("✗ "+props.Error).Color(Red).Wrap(true),
+				When(props.Hint != "", Text(props.Hint).Dim().Wrap(true)),
+			),
 		),
 	).Width(props.Width))
 }
@@ -274,23 +303,25 @@ func calculateCursorPosFromClick(text string, clickRow, clickCol int) int {
 		pos += utf8.RuneCountInString(lines[i]) + 1 // +1 for '\n'
 	}
 
-	// 在当前行中根据显示宽度找到对应的字符位置
+	// 在当前行中根据显示宽度找到对应的字素簇位置
 	line := lines[clickRow]
-	lineRunes := []rune(line)
 	currentWidth := 0
-
-	for i, r := range lineRunes {
-		charWidth := runewidth.RuneWidth(r)
+	found := false
+	forEachGrapheme(line, func(mainc rune, combc []rune, charWidth int) bool {
 		// 如果点击位置在当前字符的范围内
 		if currentWidth+charWidth > clickCol {
-			pos += i
-			return pos
+			found = true
+			return false
 		}
 		currentWidth += charWidth
+		pos += 1 + len(combc)
+		return true
+	})
+	if found {
+		return pos
 	}
 
 	// 点击在行尾之后，光标放在行尾
-	pos += len(lineRunes)
 	return pos
 }
 
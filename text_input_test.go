@@ -528,3 +528,201 @@ func TestTextInput_Snapshot_Multiline(t *testing.T) {
 	tr.Render()
 	assertSnapshot(t, screen, "text_input_multiline")
 }
+
+func TestTextInput_Snapshot_WithError(t *testing.T) {
+	app := func(c C) Node {
+		return TextInput(c.Child("input"), TextInputProps{
+			Label: "邮箱",
+			Value: "not-an-email",
+			Error: "邮箱格式不正确",
+			Hint:  "用于接收通知",
+			Width: 30,
+		})
+	}
+
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	assertSnapshot(t, screen, "text_input_with_error")
+}
+
+func TestTextInput_Snapshot_WithHint(t *testing.T) {
+	app := func(c C) Node {
+		return TextInput(c.Child("input"), TextInputProps{
+			Label: "邮箱",
+			Hint:  "用于接收通知",
+			Width: 30,
+		})
+	}
+
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	assertSnapshot(t, screen, "text_input_with_hint")
+}
+
+// =============================================================================
+// 受控/非受控模式测试
+// =============================================================================
+
+func TestTextInput_Controlled_RapidTyping(t *testing.T) {
+	var value string
+	app := func(c C) Node {
+		v := Use(c, "value", "")
+		value = v.Val
+		return TextInput(c.Child("input"), TextInputProps{
+			Value:      v.Val,
+			Controlled: true,
+			OnChanged:  func(s string) { v.Set(s) },
+		})
+	}
+
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 两次按键之间不渲染，模拟事件快于刷新
+	tr.DispatchKey(tcell.KeyRune, 'a', 0)
+	tr.DispatchKey(tcell.KeyRune, 'b', 0)
+	tr.Render()
+
+	if value != "ab" {
+		t.Errorf("expected %q, got %q", "ab", value)
+	}
+}
+
+func TestTextInput_Controlled_ExternalReset(t *testing.T) {
+	var setValue func(string)
+	app := func(c C) Node {
+		v := Use(c, "value", "hello")
+		setValue = v.Set
+		return TextInput(c.Child("input"), TextInputProps{
+			Value:      v.Val,
+			Controlled: true,
+			OnChanged:  func(s string) { v.Set(s) },
+		})
+	}
+
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 外部清空后继续输入，不应残留旧文本或越界
+	setValue("")
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, 'x', 0)
+	tr.Render()
+
+	if !strings.Contains(getScreenContent(screen), "│ x ") {
+		t.Errorf("expected input to contain only x, got:\n%s", getScreenContent(screen))
+	}
+}
+
+func TestTextInput_Uncontrolled_OnChangedOnly(t *testing.T) {
+	var changed string
+	app := func(c C) Node {
+		return TextInput(c.Child("input"), TextInputProps{
+			OnChanged: func(s string) { changed = s },
+		})
+	}
+
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 只用 OnChanged 接收通知、不传 Value 的调用方：输入的文本不能在下一帧被清空
+	tr.DispatchKey(tcell.KeyRune, 'h', 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyRune, 'i', 0)
+	tr.Render()
+
+	if changed != "hi" {
+		t.Errorf("OnChanged got %q, want %q", changed, "hi")
+	}
+	if !strings.Contains(getScreenContent(screen), "│ hi ") {
+		t.Errorf("expected input to keep the typed text, got:\n%s", getScreenContent(screen))
+	}
+}
+
+func TestTextInput_Uncontrolled_DefaultValue(t *testing.T) {
+	var submitted string
+	app := func(c C) Node {
+		return TextInput(c.Child("input"), TextInputProps{
+			DefaultValue: "ab",
+			OnSubmit:     func(s string) { submitted = s },
+		})
+	}
+
+	screen := newTestScreen(40, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	tr.DispatchKey(tcell.KeyRune, 'c', 0)
+	tr.Render()
+	tr.DispatchKey(tcell.KeyEnter, 0, 0)
+	tr.Render()
+
+	if submitted != "abc" {
+		t.Errorf("expected %q, got %q", "abc", submitted)
+	}
+}
+
+func TestTextInput_GraphemeEditing(t *testing.T) {
+	family := "👨‍👩‍👧"
+	var value string
+	app := func(c C) Node {
+		v := Use(c, "value", "a"+family+"é")
+		value = v.Val
+		return TextInput(c.Child("input"), TextInputProps{
+			Value:     v.Val,
+			OnChanged: func(s string) { v.Set(s) },
+		})
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(40, 10))
+	tr.Render()
+
+	// 光标在末尾：Backspace 删除整个 "é"（e + 组合符）
+	tr.DispatchKey(tcell.KeyBackspace2, 0, 0)
+	tr.Render()
+	if want := "a" + family; value != want {
+		t.Fatalf("expected %q, got %q", want, value)
+	}
+
+	// 左移一次越过整个 ZWJ 序列，在其前面插入字符
+	tr.DispatchKey(tcell.KeyLeft, 0, 0)
+	tr.DispatchKey(tcell.KeyRune, 'x', 0)
+	tr.Render()
+	if want := "ax" + family; value != want {
+		t.Errorf("expected %q, got %q", want, value)
+	}
+}
+
+func TestTextInput_ClickUsesContentRect(t *testing.T) {
+	var value string
+	app := func(c C) Node {
+		v := Use(c, "value", "hello world")
+		value = v.Val
+		return Box(VStack(
+			Text("title"),
+			HStack(Text("› "), TextInput(c.Child("input"), TextInputProps{
+				Label:     "Name",
+				Value:     v.Val,
+				OnChanged: func(s string) { v.Set(s) },
+			})),
+		)).Border(BorderRounded).Padding(1, 2)
+	}
+
+	screen := newTestScreen(40, 12)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	// 外层的边框、padding 和前缀不影响点击定位
+	x, y := findText(screen, "world")
+	mouseClick(tr, x, y)
+	tr.DispatchKey(tcell.KeyRune, '_', 0)
+	tr.Render()
+	if want := "hello _world"; value != want {
+		t.Errorf("expected %q, got %q", want, value)
+	}
+}
@@ -0,0 +1,138 @@
+package rego
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Theme - 内置组件的主题
+// =============================================================================
+
+// Theme 描述内置组件使用的配色
+type Theme struct {
+	Primary Color // 强调色：聚焦、开启状态等
+	Success Color
+	Warning Color
+	Error   Color
+	Muted   Color // 次要信息、关闭状态
+	Border  Color // 未聚焦时的边框
+	OnColor Color // 强调色背景上的文字颜色
+	Stripe  Color // 斑马纹中隔行的背景
+
+	BorderStyle BorderStyle // 容器类组件的边框样式
+	Markdown    string      // Markdown 的 glamour 主题，如 "dark"、"light"
+}
+
+// DefaultTheme 默认主题，与内置组件原有配色保持一致
+var DefaultTheme = Theme{
+	Primary: Cyan,
+	Success: Green,
+	Warning: Yellow,
+	Error:   Red,
+	Muted:   Gray,
+	Border:  Gray,
+	OnColor: Black,
+	Stripe:  RGB(38, 38, 38),
+
+	BorderStyle: BorderSingle,
+	Markdown:    "dark",
+}
+
+// ThemeContext 内置组件从这里读取主题，可通过 ThemeContext.Provide 覆盖
+var ThemeContext = CreateContext(DefaultTheme)
+
+// UseTheme 获取当前生效的主题
+func UseTheme(c C) Theme {
+	return UseContext(c, ThemeContext)
+}
+
+// LoadTheme 从 TOML 或 JSON 文件读取主题，未配置的项沿用 DefaultTheme，路径支持 ~ 开头
+//
+//	[theme]
+//	primary = "#5fafff"   # 颜色可写 "#rrggbb"、"#rgb" 或颜色名（cyan、gray 等）
+//	muted = "gray"
+//	border_style = "rounded" # none、single、double、rounded、thick
+//	markdown = "light"
+//
+// 返回的主题通过 ThemeContext.Provide 应用；文件中的错误会全部收集后一起返回。
+func LoadTheme(path string) (Theme, error) {
+	cfg, err := readConfig(path)
+	if err != nil {
+		return DefaultTheme, err
+	}
+	if t, ok := cfg["theme"].(map[string]any); ok {
+		cfg = t
+	}
+
+	keys := make([]string, 0, len(cfg))
+	for k := range cfg {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	theme := DefaultTheme
+	colors := map[string]*Color{
+		"primary":  &theme.Primary,
+		"success":  &theme.Success,
+		"warning":  &theme.Warning,
+		"error":    &theme.Error,
+		"muted":    &theme.Muted,
+		"border":   &theme.Border,
+		"on_color": &theme.OnColor,
+		"stripe":   &theme.Stripe,
+	}
+
+	var errs []error
+	for _, key := range keys {
+		s, ok := cfg[key].(string)
+		if !ok {
+			errs = append(errs, fmt.Errorf("theme %s: %s: expected string, got %T", path, key, cfg[key]))
+			continue
+		}
+		switch {
+		case colors[key] != nil:
+			c, err := parseColor(s)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("theme %s: %s: %w", path, key, err))
+				continue
+			}
+			*colors[key] = c
+		case key == "border_style":
+			b, ok := borderStyleNames[strings.ToLower(s)]
+			if !ok {
+				errs = append(errs, fmt.Errorf("theme %s: %s: unknown border style %q", path, key, s))
+				continue
+			}
+			theme.BorderStyle = b
+		case key == "markdown":
+			theme.Markdown = s
+		default:
+			errs = append(errs, fmt.Errorf("theme %s: unknown key %q", path, key))
+		}
+	}
+	return theme, errors.Join(errs...)
+}
+
+var colorNames = map[string]Color{
+	"default": Default, "black": Black, "red": Red, "green": Green, "yellow": Yellow,
+	"blue": Blue, "magenta": Magenta, "cyan": Cyan, "white": White, "gray": Gray, "grey": Gray,
+}
+
+var borderStyleNames = map[string]BorderStyle{
+	"none": BorderNone, "single": BorderSingle, "double": BorderDouble,
+	"rounded": BorderRounded, "thick": BorderThick,
+}
+
+// parseColor 解析颜色名或十六进制颜色
+func parseColor(s string) (Color, error) {
+	if strings.HasPrefix(s, "#") {
+		return Hex(s)
+	}
+	if c, ok := colorNames[strings.ToLower(s)]; ok {
+		return c, nil
+	}
+	return Default, fmt.Errorf("unknown color %q", s)
+}
@@ -0,0 +1,67 @@
+package rego
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestHex(t *testing.T) {
+	c, err := Hex("#5fafff")
+	if err != nil || c != RGB(0x5f, 0xaf, 0xff) {
+		t.Errorf("Hex(#5fafff) = %v, %v", c, err)
+	}
+	if short, _ := Hex("#fa0"); short != RGB(0xff, 0xaa, 0x00) {
+		t.Errorf("Hex(#fa0) = %v", short)
+	}
+	if colorToTcell(c) != tcell.NewRGBColor(0x5f, 0xaf, 0xff) {
+		t.Errorf("unexpected tcell color %v", colorToTcell(c))
+	}
+	for _, bad := range []string{"#12", "#zzzzzz", "#1234567"} {
+		if _, err := Hex(bad); err == nil {
+			t.Errorf("Hex(%q): expected error", bad)
+		}
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.toml")
+	config := `[theme]
+primary = "#5fafff"
+muted = "grey"
+border_style = "rounded"
+markdown = "light"
+error = "crimson"
+`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	theme, err := LoadTheme(path)
+	if err == nil || !strings.Contains(err.Error(), "crimson") {
+		t.Errorf("expected error for unknown color, got %v", err)
+	}
+	if theme.Primary != RGB(0x5f, 0xaf, 0xff) || theme.Muted != Gray {
+		t.Errorf("unexpected colors: %+v", theme)
+	}
+	if theme.BorderStyle != BorderRounded || theme.Markdown != "light" {
+		t.Errorf("unexpected border/markdown: %+v", theme)
+	}
+	if theme.Error != DefaultTheme.Error || theme.Success != DefaultTheme.Success {
+		t.Errorf("expected unset keys to keep defaults: %+v", theme)
+	}
+}
+
+func TestLoadTheme_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.json")
+	if err := os.WriteFile(path, []byte(`{"primary": "magenta"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	theme, err := LoadTheme(path)
+	if err != nil || theme.Primary != Magenta {
+		t.Errorf("LoadTheme = %+v, %v", theme, err)
+	}
+}
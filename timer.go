@@ -0,0 +1,119 @@
+package rego
+
+import "time"
+
+// =============================================================================
+// UseStopwatch / UseCountdown - 秒表与倒计时
+// =============================================================================
+
+// timerTick 计时中刷新显示的间隔
+const timerTick = 100 * time.Millisecond
+
+// StopwatchState 是 UseStopwatch 返回的秒表状态
+type StopwatchState struct {
+	Elapsed time.Duration // 已计时长
+	Running bool
+	Start   func() // 开始或继续计时
+	Pause   func() // 暂停，保留已计时长
+	Reset   func() // 停止并清零
+}
+
+// CountdownState 是 UseCountdown 返回的倒计时状态
+type CountdownState struct {
+	Remaining time.Duration // 剩余时长
+	Running   bool
+	Finished  bool   // 剩余时长已归零
+	Start     func() // 开始或继续倒计时，已归零时不做任何事
+	Pause     func() // 暂停，保留剩余时长
+	Reset     func() // 停止并恢复为 total
+}
+
+// timerState 计时状态：暂停前累计的时长，以及本轮开始计时的时刻（零值表示没有在计时）
+type timerState struct {
+	base    time.Duration
+	started time.Time
+}
+
+// timer 是秒表与倒计时共用的计时逻辑
+type timer struct {
+	elapsed             time.Duration
+	running             bool
+	start, pause, reset func()
+}
+
+// UseStopwatch 返回一个秒表
+//
+// 时长按组件所在运行时的时钟计算，刷新的时机不影响读数，多次暂停与继续也不会累积误差。
+//
+//	sw := rego.UseStopwatch(c)
+//	rego.Text(sw.Elapsed.Truncate(time.Second).String())
+func UseStopwatch(c C) StopwatchState {
+	t := useTimer(c, -1)
+	return StopwatchState{Elapsed: t.elapsed, Running: t.running, Start: t.start, Pause: t.pause, Reset: t.reset}
+}
+
+// UseCountdown 返回一个从 total 开始的倒计时，归零时自动停止
+//
+// total 变化时已计的时长不变、剩余时长随之变化，暂停时调整总时长通常需要再调用 Reset。
+func UseCountdown(c C, total time.Duration) CountdownState {
+	t := useTimer(c, total)
+	remaining := total - t.elapsed
+	return CountdownState{
+		Remaining: remaining,
+		Running:   t.running,
+		Finished:  remaining <= 0,
+		Start:     t.start,
+		Pause:     t.pause,
+		Reset:     t.reset,
+	}
+}
+
+// useTimer 计到 limit 时自动停止，limit 为负数时不限时长
+func useTimer(c C, limit time.Duration) timer {
+	ctx := c.(*componentContext)
+	st := Use(c, "timer", timerState{})
+
+	elapsed := func(s timerState) time.Duration {
+		d := s.base
+		if !s.started.IsZero() {
+			d += ctx.clock().Now().Sub(s.started)
+		}
+		if limit >= 0 {
+			d = min(d, limit)
+		}
+		return d
+	}
+	running := !st.Val.started.IsZero()
+
+	// 只在计时中定时刷新，暂停或组件被释放时 UseInterval 停止定时器
+	tick := time.Duration(0)
+	if running {
+		tick = timerTick
+	}
+	UseInterval(c, tick, func() {
+		if limit >= 0 && elapsed(st.Val) >= limit {
+			st.Set(timerState{base: limit})
+			return
+		}
+		ctx.Refresh()
+	})
+
+	return timer{
+		elapsed: elapsed(st.Val),
+		running: running,
+		start: func() {
+			if !st.Val.started.IsZero() || limit >= 0 && st.Val.base >= limit {
+				return
+			}
+			st.Set(timerState{base: st.Val.base, started: ctx.clock().Now()})
+		},
+		pause: func() {
+			if !st.Val.started.IsZero() {
+				st.Set(timerState{base: elapsed(st.Val)})
+			}
+		},
+		reset: func() {
+			st.Set(timerState{})
+		},
+	}
+}
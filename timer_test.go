@@ -0,0 +1,86 @@
+package rego
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUseStopwatch(t *testing.T) {
+	var sw StopwatchState
+	app := func(c C) Node {
+		sw = UseStopwatch(c)
+		return Text(sw.Elapsed.String())
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(20, 1), withFakeClock())
+	tr.Render()
+	sw.Start()
+	tr.Render()
+
+	tr.AdvanceTime(2500 * time.Millisecond)
+	tr.Render()
+	if !sw.Running || sw.Elapsed != 2500*time.Millisecond {
+		t.Fatalf("running=%v elapsed=%v, want running 2.5s", sw.Running, sw.Elapsed)
+	}
+
+	// 暂停期间不计时，继续后从暂停处累加
+	sw.Pause()
+	tr.Render()
+	tr.AdvanceTime(time.Second)
+	tr.Render()
+	if sw.Running || sw.Elapsed != 2500*time.Millisecond {
+		t.Fatalf("paused: running=%v elapsed=%v", sw.Running, sw.Elapsed)
+	}
+	sw.Start()
+	tr.Render()
+	tr.AdvanceTime(500 * time.Millisecond)
+	tr.Render()
+	if sw.Elapsed != 3*time.Second {
+		t.Errorf("elapsed after resume = %v, want 3s", sw.Elapsed)
+	}
+
+	sw.Reset()
+	tr.Render()
+	if sw.Running || sw.Elapsed != 0 {
+		t.Errorf("after reset: running=%v elapsed=%v", sw.Running, sw.Elapsed)
+	}
+}
+
+func TestUseCountdown(t *testing.T) {
+	var cd CountdownState
+	app := func(c C) Node {
+		cd = UseCountdown(c, 3*time.Second)
+		return Text(cd.Remaining.String())
+	}
+
+	tr := NewTestRuntime(app, newTestScreen(20, 1), withFakeClock())
+	tr.Render()
+	if cd.Remaining != 3*time.Second || cd.Finished {
+		t.Fatalf("initial remaining=%v finished=%v", cd.Remaining, cd.Finished)
+	}
+	cd.Start()
+	tr.Render()
+	tr.AdvanceTime(time.Second)
+	tr.Render()
+	if cd.Remaining != 2*time.Second || !cd.Running {
+		t.Fatalf("remaining=%v running=%v, want 2s running", cd.Remaining, cd.Running)
+	}
+
+	// 归零后自动停止，再次开始无效
+	tr.AdvanceTime(5 * time.Second)
+	tr.Render()
+	if cd.Remaining != 0 || cd.Running || !cd.Finished {
+		t.Fatalf("remaining=%v running=%v finished=%v, want finished", cd.Remaining, cd.Running, cd.Finished)
+	}
+	cd.Start()
+	tr.Render()
+	if cd.Running {
+		t.Error("expected Start to be ignored after finishing")
+	}
+
+	cd.Reset()
+	tr.Render()
+	if cd.Remaining != 3*time.Second || cd.Finished {
+		t.Errorf("after reset remaining=%v finished=%v", cd.Remaining, cd.Finished)
+	}
+}
@@ -0,0 +1,160 @@
+package rego
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Tooltip - 悬停/聚焦提示
+// =============================================================================
+
+// tooltipDelay 鼠标悬停多久后显示提示
+const tooltipDelay = 500 * time.Millisecond
+
+// tooltipMaxWidth 提示内容的最大宽度，超出时自动换行
+const tooltipMaxWidth = 40
+
+// Tooltip 为 child 添加提示：鼠标悬停约 500ms 或 child 内的组件获得焦点时，
+// 在其附近的浮层中显示 text，靠近屏幕边缘时自动翻转方向。
+//
+// child 中的组件应使用 c.Child(...) 创建，这样才能识别其焦点。
+func Tooltip(c C, child Node, text string) Node {
+	ctx := c.(*componentContext)
+	hovered := Use(c, "hovered", false)
+	visible := Use(c, "visible", false)
+	token := UseRef(c, new(atomic.Int64))
+
+	UseMouse(c, func(ev MouseEvent) {
+		inside := c.Rect().Contains(ev.X, ev.Y)
+		if inside && !hovered.Val {
+			hovered.Set(true)
+			t := token.Current.Add(1)
+			ctx.clock().AfterFunc(tooltipDelay, func() {
+				if token.Current.Load() == t {
+					visible.Set(true)
+				}
+			})
+		} else if !inside && hovered.Val {
+			token.Current.Add(1)
+			hovered.Set(false)
+			visible.Set(false)
+		}
+	})
+
+	// 焦点位于 Tooltip 的子树内时也显示
+	focused := false
+	if ctx.runtime != nil {
+		prefix := ctx.focusKey() + "/"
+		focused = strings.HasPrefix(ctx.runtime.focusManager.Current(), prefix)
+	}
+
+	return c.Wrap(&tooltipNode{
+		child:   child,
+		text:    text,
+		show:    visible.Val || focused,
+		runtime: ctx.runtime,
+	})
+}
+
+type tooltipNode struct {
+	child   Node
+	text    string
+	show    bool
+	runtime *Runtime
+}
+
+func (t *tooltipNode) render(screen tcell.Screen, x, y, width, height int) int {
+	used := 0
+	if t.child != nil {
+		used = t.child.render(screen, x, y, width, height)
+	}
+	if !t.show || t.runtime == nil || t.text == "" {
+		return used
+	}
+
+	anchorW := (&hstackNode{}).measureWidth(t.child)
+	if anchorW > width {
+		anchorW = width
+	}
+	showTooltip(t.runtime, screen, Rect{X: x, Y: y, W: anchorW, H: used}, t.text)
+	return used
+}
+
+// showTooltip 在 anchor 附近的浮层中显示提示气泡，靠近屏幕边缘时自动翻转方向
+func showTooltip(rt *Runtime, screen tcell.Screen, anchor Rect, text string) {
+	// 气泡尺寸：内容 + 左右 padding + 边框
+	screenW, screenH := screen.Size()
+	textW := runewidth.StringWidth(text)
+	if textW > tooltipMaxWidth {
+		textW = tooltipMaxWidth
+	}
+	if textW > screenW-4 {
+		textW = screenW - 4
+	}
+	bubble := Box(Text(text).Wrap(true)).
+		Border(BorderRounded).
+		BorderColor(Gray).
+		Padding(0, 1)
+	w := textW + 4
+	h := measureNodeHeight(bubble, w)
+
+	bx, by := placeOverlay(anchor, w, h, screenW, screenH)
+	rt.addOverlay(bubble, bx, by, w, h)
+}
+
+func (t *tooltipNode) measureHeight(width int) int {
+	return measureNodeHeight(t.child, width)
+}
+
+func (t *tooltipNode) measureWidth() int {
+	return (&hstackNode{}).measureWidth(t.child)
+}
+
+func (t *tooltipNode) getFlex() int {
+	if fn, ok := t.child.(flexNode); ok {
+		return fn.getFlex()
+	}
+	return 0
+}
+
+func (t *tooltipNode) getHeight() int {
+	if fn, ok := t.child.(flexNode); ok {
+		return fn.getHeight()
+	}
+	return 0
+}
+
+// trackHover 记录鼠标位置，移入、移出悬停区域或在其中移动时重绘
+func (r *Runtime) trackHover(x, y int) {
+	prevX, prevY, prevIn := r.mouseX, r.mouseY, r.mouseIn
+	r.mouseX, r.mouseY, r.mouseIn = x, y, true
+	for _, rect := range r.hoverRegions {
+		if rect.Contains(x, y) || prevIn && rect.Contains(prevX, prevY) {
+			r.scheduleRefresh()
+			return
+		}
+	}
+}
+
+// hoverPoint 在本帧把 rect（渲染坐标）注册为悬停区域，
+// 鼠标位于其中且没有被浮动窗口遮挡时返回鼠标的渲染坐标
+func hoverPoint(screen tcell.Screen, rect Rect) (x, y int, ok bool) {
+	rt := screenRuntime(screen)
+	if rt == nil {
+		return 0, 0, false
+	}
+	abs, visible := screenRect(screen, rect)
+	if visible.W <= 0 || visible.H <= 0 {
+		return 0, 0, false
+	}
+	rt.hoverRegions = append(rt.hoverRegions, visible)
+	if !rt.mouseIn || !visible.Contains(rt.mouseX, rt.mouseY) || rt.windows.at(rt.mouseX, rt.mouseY) != rt.windows.drawing {
+		return 0, 0, false
+	}
+	return rt.mouseX - abs.X + rect.X, rt.mouseY - abs.Y + rect.Y, true
+}
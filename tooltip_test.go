@@ -0,0 +1,51 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTooltip_ShowsOnFocusAndFlips(t *testing.T) {
+	app := func(c C) Node {
+		tip := c.Child("tip")
+		return VStack(
+			Spacer(),
+			Tooltip(tip, Button(tip.Child("save"), ButtonProps{Label: "Save"}), "保存当前文件"),
+		)
+	}
+
+	screen := newTestScreen(30, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	tipRow, buttonRow := -1, -1
+	for i, line := range lines {
+		if strings.Contains(line, "保存当前文件") {
+			tipRow = i
+		}
+		if strings.Contains(line, "[Save]") {
+			buttonRow = i
+		}
+	}
+	if tipRow < 0 || buttonRow < 0 {
+		t.Fatalf("expected tooltip and button to be rendered:\n%s", getScreenContent(screen))
+	}
+	if tipRow >= buttonRow {
+		t.Errorf("expected tooltip to flip above the button near the bottom edge, tip row %d, button row %d", tipRow, buttonRow)
+	}
+}
+
+func TestPlaceOverlay(t *testing.T) {
+	anchor := Rect{X: 25, Y: 2, W: 4, H: 1}
+	x, y := placeOverlay(anchor, 10, 3, 30, 20)
+	if x != 20 || y != 3 {
+		t.Errorf("expected (20, 3), got (%d, %d)", x, y)
+	}
+	anchor = Rect{X: 0, Y: 18, W: 4, H: 1}
+	x, y = placeOverlay(anchor, 10, 3, 30, 20)
+	if x != 0 || y != 15 {
+		t.Errorf("expected (0, 15), got (%d, %d)", x, y)
+	}
+}
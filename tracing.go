@@ -0,0 +1,170 @@
+package rego
+
+import (
+	"context"
+	"fmt"
+	"runtime/trace"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// 帧追踪（runtime/trace）
+// =============================================================================
+
+// traceSlowest 每帧在追踪日志中记录的最慢组件数
+const traceSlowest = 3
+
+// frameTrace 记录一帧的追踪信息，仅在 runtime/trace 开启或需要统计组件耗时（Options.JankThreshold）时创建
+//
+// 每帧是一个 "rego.frame" 任务，其中包含 build（执行组件函数）与 render（布局和绘制）两个区域；
+// 帧结束时以 "slowest" 为类别记录自身耗时最长的几个组件路径。
+// 使用 `go tool trace` 查看，或通过 Options.Debug 的 /debug/pprof/trace 采集。
+type frameTrace struct {
+	ctx   context.Context
+	task  *trace.Task // runtime/trace 未开启时为 nil，只统计组件耗时
+	spans []componentSpan
+	stack []time.Duration // 正在绘制的组件的子组件耗时累计
+
+	builds map[*componentContext]*buildSpan // 本帧执行的组件函数
+}
+
+type componentSpan struct {
+	phase string // "build" 或 "render"
+	path  string
+	self  time.Duration
+}
+
+// buildSpan 组件函数从 Child 到最后一次 Wrap 的耗时（包括其中执行的子组件）
+type buildSpan struct {
+	start time.Time
+	total time.Duration
+}
+
+// beginFrameTrace 开始追踪一帧，未开启追踪且 spans 为 false 时返回 nil
+func beginFrameTrace(name string, spans bool) *frameTrace {
+	if !trace.IsEnabled() {
+		if !spans {
+			return nil
+		}
+		return &frameTrace{builds: map[*componentContext]*buildSpan{}}
+	}
+	ctx, task := trace.NewTask(context.Background(), name)
+	return &frameTrace{ctx: ctx, task: task, builds: map[*componentContext]*buildSpan{}}
+}
+
+// region 开始一个区域，返回结束函数
+func (ft *frameTrace) region(name string) func() {
+	if ft == nil || ft.task == nil {
+		return func() {}
+	}
+	return trace.StartRegion(ft.ctx, name).End
+}
+
+// enterBuild 开始执行一个组件函数（Child 返回上下文时）
+func (ft *frameTrace) enterBuild(ctx *componentContext) {
+	if ft == nil {
+		return
+	}
+	ft.builds[ctx] = &buildSpan{start: time.Now()}
+}
+
+// wrapBuild 组件函数包装返回的节点，视为执行结束
+func (ft *frameTrace) wrapBuild(ctx *componentContext) {
+	if ft == nil {
+		return
+	}
+	if b := ft.builds[ctx]; b != nil {
+		b.total = time.Since(b.start)
+	}
+}
+
+// endBuild 把组件函数的耗时扣除子组件后记为 build 阶段的自身耗时
+func (ft *frameTrace) endBuild() {
+	if ft == nil {
+		return
+	}
+	self := make(map[*componentContext]time.Duration, len(ft.builds))
+	for ctx, b := range ft.builds {
+		if b.total == 0 {
+			continue // 没有调用 Wrap，耗时算在最近的祖先中
+		}
+		self[ctx] += b.total
+		for p := ctx.parent; p != nil; p = p.parent {
+			if pb := ft.builds[p]; pb != nil && pb.total > 0 {
+				self[p] -= b.total
+				break
+			}
+		}
+	}
+	for ctx, d := range self {
+		ft.spans = append(ft.spans, componentSpan{phase: "build", path: ctx.focusKey(), self: d})
+	}
+	clear(ft.builds)
+}
+
+// enterComponent 开始绘制一个组件，返回结束函数
+func (ft *frameTrace) enterComponent(ctx *componentContext) func() {
+	if ft == nil || ctx == nil {
+		return func() {}
+	}
+	start := time.Now()
+	ft.stack = append(ft.stack, 0)
+	return func() {
+		total := time.Since(start)
+		children := ft.stack[len(ft.stack)-1]
+		ft.stack = ft.stack[:len(ft.stack)-1]
+		if len(ft.stack) > 0 {
+			ft.stack[len(ft.stack)-1] += total
+		}
+		ft.spans = append(ft.spans, componentSpan{phase: "render", path: ctx.focusKey(), self: total - children})
+	}
+}
+
+// slowest 返回自身耗时最长的 n 个组件
+func (ft *frameTrace) slowest(n int) []componentSpan {
+	if ft == nil {
+		return nil
+	}
+	sort.Slice(ft.spans, func(i, j int) bool { return ft.spans[i].self > ft.spans[j].self })
+	return ft.spans[:min(n, len(ft.spans))]
+}
+
+// end 记录最慢的组件并结束任务
+func (ft *frameTrace) end() {
+	if ft == nil || ft.task == nil {
+		return
+	}
+	for _, s := range ft.slowest(traceSlowest) {
+		trace.Logf(ft.ctx, "slowest", "%s %s %v", s.phase, s.path, s.self)
+	}
+	ft.task.End()
+}
+
+// reportJank 帧耗时超过阈值时以 LogWarn 记录，附上最慢的组件
+func (r *Runtime) reportJank(elapsed time.Duration) {
+	if r.jankThreshold <= 0 || elapsed <= r.jankThreshold {
+		return
+	}
+	parts := make([]string, 0, traceSlowest)
+	for _, s := range r.frameTrace.slowest(traceSlowest) {
+		parts = append(parts, fmt.Sprintf("%s %s %v", s.phase, s.path, s.self.Round(time.Microsecond)))
+	}
+	Log(LogWarn, "slow frame %v (> %v); slowest: %s", elapsed.Round(time.Microsecond), r.jankThreshold, strings.Join(parts, ", "))
+}
+
+// eventKind 返回事件处理区域的名称
+func eventKind(event tcell.Event) string {
+	switch event.(type) {
+	case *tcell.EventKey:
+		return "key"
+	case *tcell.EventMouse:
+		return "mouse"
+	case *tcell.EventResize:
+		return "resize"
+	}
+	return "event"
+}
@@ -0,0 +1,80 @@
+package rego
+
+import (
+	"bytes"
+	"runtime/trace"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFrameTrace_SelfTime(t *testing.T) {
+	ft := &frameTrace{}
+	root := newComponentContext("root", nil, nil)
+	child := newComponentContext("child", root, nil)
+
+	endRoot := ft.enterComponent(root)
+	endChild := ft.enterComponent(child)
+	time.Sleep(20 * time.Millisecond)
+	endChild()
+	endRoot()
+
+	if len(ft.spans) != 2 || ft.spans[0].path != "root/child" || ft.spans[1].path != "root" {
+		t.Fatalf("unexpected spans: %+v", ft.spans)
+	}
+	if ft.spans[1].self >= ft.spans[0].self {
+		t.Errorf("expected root self time to exclude its child: %+v", ft.spans)
+	}
+}
+
+func TestFrameTrace_Render(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("tracing unavailable: %v", err)
+	}
+	tr := NewTestRuntime(func(c C) Node {
+		return c.Wrap(Text("traced"))
+	}, newTestScreen(10, 1))
+	tr.Render()
+	trace.Stop()
+
+	if tr.frameTrace != nil {
+		t.Error("expected frame trace to be cleared after render")
+	}
+	if buf.Len() == 0 {
+		t.Error("expected trace output")
+	}
+}
+
+func TestJankThreshold_LogsSlowestComponent(t *testing.T) {
+	debugLog = &logBuffer{}
+	t.Cleanup(func() { debugLog = &logBuffer{} })
+
+	slow := func(c C) Node {
+		time.Sleep(15 * time.Millisecond)
+		return c.Wrap(Text("slow"))
+	}
+	fast := func(c C) Node {
+		return c.Wrap(Text("fast"))
+	}
+	tr := NewTestRuntime(func(c C) Node {
+		return c.Wrap(VStack(fast(c.Child("fast")), slow(c.Child("slow"))))
+	}, newTestScreen(10, 2))
+	tr.apply(Options{JankThreshold: 5 * time.Millisecond})
+	tr.Render()
+
+	records, _ := debugLog.snapshot()
+	if len(records) != 1 || records[0].Level != LogWarn {
+		t.Fatalf("expected one warning, got %+v", records)
+	}
+	// 父组件的耗时不包括子组件，最慢的是 slow 自身
+	if msg := records[0].Message; !strings.Contains(msg, "slowest: build root/slow") {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	tr.apply(Options{})
+	tr.Render()
+	if records, _ := debugLog.snapshot(); len(records) != 1 {
+		t.Errorf("expected no warning when disabled, got %d records", len(records))
+	}
+}
@@ -0,0 +1,174 @@
+package rego
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// Typewriter - 打字机效果
+// =============================================================================
+
+// defaultTypewriterSpeed 默认每个字符的显示间隔
+const defaultTypewriterSpeed = 30 * time.Millisecond
+
+// typewriterProgress 打字进度，保存在组件中跨帧使用，只在 UI 循环中读写
+type typewriterProgress struct {
+	text    string
+	shown   int       // 已显示的字符数
+	last    time.Time // shown 对应的时刻
+	done    bool      // 已全部显示并调用过 OnDone
+	pending bool      // 已安排下一次刷新
+	stop    func()    // 取消已安排的刷新
+}
+
+type typewriterNode struct {
+	ctx      *componentContext
+	progress *typewriterProgress
+	runes    []rune
+	speed    time.Duration
+	onDone   func()
+	cursor   string
+	markdown bool
+
+	content Node // 本帧显示的内容，首次布局时生成
+}
+
+// Typewriter 创建一个逐字显示 text 的节点
+//
+// text 在原有内容之后追加时（例如流式输出）从已显示的位置继续，其他变化会从头开始显示。
+// 需要从头重放同一段文字时，使用新的 c.Child key。
+//
+//	rego.Typewriter(c.Child("intro"), intro).Speed(30 * time.Millisecond).Cursor("▍").OnDone(next)
+func Typewriter(c C, text string) *typewriterNode {
+	ctx := c.(*componentContext)
+	progress := UseRef(c, &typewriterProgress{}).Current
+	now := ctx.clock().Now()
+
+	if text != progress.text {
+		caughtUp := progress.shown >= len([]rune(progress.text))
+		if !strings.HasPrefix(text, progress.text) {
+			progress.shown = 0
+			caughtUp = true
+		}
+		if caughtUp || progress.last.IsZero() {
+			progress.last = now
+		}
+		progress.text = text
+		progress.done = false
+	}
+
+	// 组件被释放时取消尚未执行的刷新
+	UseEffect(c, func() func() {
+		return func() {
+			if progress.stop != nil {
+				progress.stop()
+			}
+		}
+	})
+
+	return &typewriterNode{
+		ctx:      ctx,
+		progress: progress,
+		runes:    []rune(text),
+		speed:    defaultTypewriterSpeed,
+	}
+}
+
+// Speed 设置每个字符的显示间隔，小于等于 0 时立即显示全部内容
+func (t *typewriterNode) Speed(d time.Duration) *typewriterNode {
+	t.speed = d
+	return t
+}
+
+// OnDone 设置全部内容显示完成后的回调
+func (t *typewriterNode) OnDone(fn func()) *typewriterNode {
+	t.onDone = fn
+	return t
+}
+
+// Cursor 设置显示过程中跟在末尾的光标，如 "▍"
+func (t *typewriterNode) Cursor(s string) *typewriterNode {
+	t.cursor = s
+	return t
+}
+
+// Markdown 将已显示的内容按 Markdown 渲染
+func (t *typewriterNode) Markdown() *typewriterNode {
+	t.markdown = true
+	return t
+}
+
+// advance 按经过的时间推进进度，未显示完时安排下一次刷新，显示完时调用 OnDone
+func (t *typewriterNode) advance() {
+	p := t.progress
+	n := len(t.runes)
+	now := t.ctx.clock().Now()
+	if t.speed <= 0 {
+		p.shown = n
+	} else if p.shown < n {
+		if steps := int(now.Sub(p.last) / t.speed); steps > 0 {
+			p.shown = min(p.shown+steps, n)
+			p.last = p.last.Add(time.Duration(steps) * t.speed)
+		}
+	}
+	p.shown = min(p.shown, n)
+
+	r := t.ctx.runtime
+	if p.shown >= n {
+		if !p.done {
+			p.done = true
+			if t.onDone != nil {
+				if r != nil {
+					r.post(t.onDone)
+				} else {
+					t.onDone()
+				}
+			}
+		}
+		return
+	}
+	if p.pending || r == nil {
+		return
+	}
+	p.pending = true
+	ctx := t.ctx
+	p.stop = ctx.clock().AfterFunc(p.last.Add(t.speed).Sub(now), func() {
+		r.post(func() {
+			p.pending = false
+			ctx.Refresh()
+		})
+	})
+}
+
+// node 返回本帧显示的内容
+func (t *typewriterNode) node() Node {
+	if t.content != nil {
+		return t.content
+	}
+	t.advance()
+	text := string(t.runes[:t.progress.shown])
+	if t.progress.shown < len(t.runes) {
+		text += t.cursor
+	}
+	if t.markdown {
+		t.content = Markdown(text)
+	} else {
+		t.content = Text(text).Wrap(true)
+	}
+	return t.content
+}
+
+func (t *typewriterNode) render(screen tcell.Screen, x, y, width, height int) int {
+	return t.node().render(screen, x, y, width, height)
+}
+
+func (t *typewriterNode) measureHeight(width int) int {
+	return measureNodeHeight(t.node(), width)
+}
+
+func (t *typewriterNode) measureWidth() int {
+	return (&hstackNode{}).measureWidth(t.node())
+}
@@ -0,0 +1,65 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTypewriter_RevealsProgressively(t *testing.T) {
+	done := 0
+	app := func(c C) Node {
+		return Typewriter(c.Child("tw"), "hello").Speed(10 * time.Millisecond).Cursor("_").OnDone(func() { done++ })
+	}
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+
+	steps := []struct {
+		advance time.Duration
+		want    string
+	}{
+		{0, "_"},
+		{25 * time.Millisecond, "he_"},
+		{10 * time.Millisecond, "hel_"},
+		{time.Second, "hello"},
+	}
+	for _, s := range steps {
+		tr.AdvanceTime(s.advance)
+		tr.Render()
+		if got := strings.TrimSpace(getScreenContent(screen)); got != s.want {
+			t.Fatalf("after %v: got %q, want %q", s.advance, got, s.want)
+		}
+	}
+
+	tr.Render()
+	if done != 1 {
+		t.Errorf("OnDone called %d times, want 1", done)
+	}
+}
+
+func TestTypewriter_ContinuesAppendedText(t *testing.T) {
+	text := "ab"
+	app := func(c C) Node {
+		return Typewriter(c.Child("tw"), text).Speed(10 * time.Millisecond)
+	}
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen, withFakeClock())
+	tr.Render()
+	tr.AdvanceTime(10 * time.Millisecond)
+	tr.Render()
+
+	// 追加的内容从已显示的位置继续
+	text = "abcd"
+	tr.AdvanceTime(10 * time.Millisecond)
+	tr.Render()
+	if got := strings.TrimSpace(getScreenContent(screen)); got != "ab" {
+		t.Fatalf("got %q, want %q", got, "ab")
+	}
+
+	// 其他变化从头开始
+	text = "xyz"
+	tr.Render()
+	if got := strings.TrimSpace(getScreenContent(screen)); got != "" {
+		t.Errorf("got %q, want replaced text to restart", got)
+	}
+}
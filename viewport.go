@@ -0,0 +1,266 @@
+package rego
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// =============================================================================
+// Viewport - 二维平移容器
+// =============================================================================
+
+// viewportPan 当前的平移偏移
+type viewportPan struct {
+	x, y int
+}
+
+// viewportDrag 鼠标拖动平移的起点
+type viewportDrag struct {
+	active           bool
+	startX, startY   int
+	originX, originY int
+}
+
+// viewportShiftStep 按住 Shift 时方向键一次移动的格数
+const viewportShiftStep = 8
+
+// Viewport 创建一个可在横纵两个方向平移的容器，用于显示比可视区域更大的内容（如图表、宽表格）
+//
+// 内容按自然宽度和高度渲染，超出部分被裁切并显示滚动条。按住鼠标左键拖动平移，
+// 滚轮上下滚动（按住 Shift 或横向滚轮左右滚动）；聚焦时方向键移动一格（按住 Shift 移动 8 格），
+// PgUp/PgDn 翻页，Home 回到左上角。与 ScrollBox 一样默认占满剩余高度。
+func Viewport(c C, child Node) *componentNode {
+	node, _ := useViewport(c, child, true)
+	return node
+}
+
+// viewportControl 供内部组件控制 Viewport 的平移
+type viewportControl struct {
+	pan   *State[viewportPan]
+	limit *Ref[viewportPan] // 上一帧可平移的最大偏移
+	page  *Ref[viewportPan] // 上一帧可视区域的大小
+}
+
+func (v *viewportControl) panTo(x, y int) {
+	x = max(0, min(x, v.limit.Current.x))
+	y = max(0, min(y, v.limit.Current.y))
+	if x != v.pan.Val.x || y != v.pan.Val.y {
+		v.pan.Set(viewportPan{x, y})
+	}
+}
+
+// reveal 平移最少的距离使内容中的区域 r 可见
+func (v *viewportControl) reveal(r Rect) {
+	p, view := v.pan.Val, v.page.Current
+	x, y := p.x, p.y
+	if r.X+r.W > x+view.x {
+		x = r.X + r.W - view.x
+	}
+	if r.X < x {
+		x = r.X
+	}
+	if r.Y+r.H > y+view.y {
+		y = r.Y + r.H - view.y
+	}
+	if r.Y < y {
+		y = r.Y
+	}
+	v.panTo(x, y)
+}
+
+// useViewport 创建 Viewport，keys 为 false 时不参与焦点、不处理键盘，由调用方通过返回的控制器平移
+func useViewport(c C, child Node, keys bool) (*componentNode, *viewportControl) {
+	ctx := c.(*componentContext)
+	var focus FocusState
+	if keys {
+		focus = UseFocus(c)
+	}
+	pan := Use(c, "pan", viewportPan{})
+	drag := UseRef(c, viewportDrag{})
+	vc := &viewportControl{pan: pan, limit: UseRef(c, viewportPan{}), page: UseRef(c, viewportPan{})}
+	panTo, page := vc.panTo, vc.page
+
+	UseKey(c, func(key Key, r rune) {
+		if !keys || !focus.IsFocused {
+			return
+		}
+		step := If(ctx.keyMods()&ModShift != 0, viewportShiftStep, 1)
+		p := pan.Val
+		switch key {
+		case KeyLeft:
+			panTo(p.x-step, p.y)
+		case KeyRight:
+			panTo(p.x+step, p.y)
+		case KeyUp:
+			panTo(p.x, p.y-step)
+		case KeyDown:
+			panTo(p.x, p.y+step)
+		case KeyPageUp:
+			panTo(p.x, p.y-page.Current.y)
+		case KeyPageDown:
+			panTo(p.x, p.y+page.Current.y)
+		case KeyHome:
+			panTo(0, 0)
+		}
+	})
+
+	UseMouse(c, func(ev MouseEvent) {
+		d := drag.Current
+		if d.active {
+			switch {
+			case ev.Type == MouseEventMove && ev.Button == MouseButtonLeft:
+				panTo(d.originX-(ev.X-d.startX), d.originY-(ev.Y-d.startY))
+				return
+			case ev.Type == MouseEventRelease:
+				drag.Current.active = false
+				return
+			}
+		}
+		if !ev.Hit {
+			return
+		}
+		p := pan.Val
+		horizontal := ev.Mod&ModShift != 0
+		switch ev.Type {
+		case MouseEventPress:
+			if ev.Button == MouseButtonLeft {
+				drag.Current = viewportDrag{active: true, startX: ev.X, startY: ev.Y, originX: p.x, originY: p.y}
+			}
+		case MouseEventClick:
+			if keys {
+				focus.Focus()
+			}
+		case MouseEventScrollUp:
+			panTo(p.x-If(horizontal, 1, 0), p.y-If(horizontal, 0, 1))
+		case MouseEventScrollDown:
+			panTo(p.x+If(horizontal, 1, 0), p.y+If(horizontal, 0, 1))
+		case MouseEventScrollLeft:
+			panTo(p.x-1, p.y)
+		case MouseEventScrollRight:
+			panTo(p.x+1, p.y)
+		}
+	})
+
+	return c.Wrap(&viewportNode{
+		ctx:   ctx,
+		child: child,
+		pan:   pan.Val,
+		limit: vc.limit,
+		page:  vc.page,
+	}), vc
+}
+
+// =============================================================================
+// viewportNode - Viewport 渲染节点
+// =============================================================================
+
+type viewportNode struct {
+	ctx   *componentContext
+	child Node
+	pan   viewportPan
+	limit *Ref[viewportPan]
+	page  *Ref[viewportPan]
+}
+
+// contentSize 返回内容的自然宽度和在该宽度下的高度
+func (v *viewportNode) contentSize() (int, int) {
+	w := (&hstackNode{}).measureWidth(v.child)
+	return w, measureNodeHeight(v.child, w)
+}
+
+func (v *viewportNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if v.child == nil || width <= 0 || height <= 0 {
+		return 0
+	}
+	contentW, contentH := v.contentSize()
+
+	// 超出的方向显示滚动条，各占一行/一列
+	viewW, viewH := width, height
+	needH, needV := contentW > viewW, contentH > viewH
+	if needV {
+		viewW--
+		needH = contentW > viewW
+	}
+	if needH {
+		viewH--
+		if !needV && contentH > viewH {
+			needV = true
+			viewW--
+		}
+	}
+	viewW, viewH = max(viewW, 0), max(viewH, 0)
+
+	v.limit.Current = viewportPan{max(contentW-viewW, 0), max(contentH-viewH, 0)}
+	v.page.Current = viewportPan{viewW, viewH}
+	offX := max(0, min(v.pan.x, v.limit.Current.x))
+	offY := max(0, min(v.pan.y, v.limit.Current.y))
+
+	proxy := &clipScreen{
+		Screen:  screen,
+		viewX:   x,
+		viewY:   y,
+		viewW:   viewW,
+		viewH:   viewH,
+		offX:    -offX,
+		offY:    -offY,
+		runtime: v.ctx.runtime,
+	}
+	v.child.render(proxy, x, y, max(contentW, viewW), max(contentH, viewH))
+
+	track := tcell.StyleDefault.Foreground(tcell.ColorGray)
+	thumb := tcell.StyleDefault.Foreground(colorToTcell(Cyan))
+	if needV {
+		pos, size := scrollThumb(offY, contentH, viewH)
+		for i := 0; i < viewH; i++ {
+			if i >= pos && i < pos+size {
+				screen.SetContent(x+width-1, y+i, '┃', nil, thumb)
+			} else {
+				screen.SetContent(x+width-1, y+i, '│', nil, track)
+			}
+		}
+	}
+	if needH {
+		pos, size := scrollThumb(offX, contentW, viewW)
+		for i := 0; i < viewW; i++ {
+			if i >= pos && i < pos+size {
+				screen.SetContent(x+i, y+height-1, '━', nil, thumb)
+			} else {
+				screen.SetContent(x+i, y+height-1, '─', nil, track)
+			}
+		}
+	}
+	return height
+}
+
+// scrollThumb 返回滚动条滑块的位置和长度
+func scrollThumb(offset, content, view int) (int, int) {
+	if content <= 0 || view <= 0 {
+		return 0, 0
+	}
+	size := max(view*view/content, 1)
+	pos := offset * view / content
+	if pos+size > view {
+		pos = view - size
+	}
+	return pos, size
+}
+
+// getFlex Viewport 与 ScrollBox 一样默认占满剩余高度
+func (v *viewportNode) getFlex() int {
+	return 1
+}
+
+func (v *viewportNode) getHeight() int {
+	return 0
+}
+
+func (v *viewportNode) measureHeight(width int) int {
+	return 0
+}
+
+func (v *viewportNode) measureWidth() int {
+	if v.child == nil {
+		return 0
+	}
+	w, _ := v.contentSize()
+	return w
+}
@@ -0,0 +1,61 @@
+package rego
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestViewport_Pan(t *testing.T) {
+	app := func(c C) Node {
+		var rows []Node
+		for i := 0; i < 30; i++ {
+			rows = append(rows, Text(fmt.Sprintf("r%02d", i)+strings.Repeat(".", 40)+fmt.Sprintf("end%02d", i)))
+		}
+		return Viewport(c.Child("view"), VStack(rows...))
+	}
+	screen := newTestScreen(20, 6)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.HasPrefix(lines[0], "r00") || !strings.HasSuffix(lines[0], "┃") {
+		t.Fatalf("expected top-left corner with vertical scrollbar, got %q", lines[0])
+	}
+	if !strings.Contains(lines[5], "━") {
+		t.Errorf("expected horizontal scrollbar, got %q", lines[5])
+	}
+
+	// 聚焦后方向键平移，Shift 一次移动 8 格
+	mouseClick(tr, 1, 1)
+	tr.DispatchKey(tcell.KeyDown, 0, 0)
+	tr.DispatchKey(tcell.KeyRight, 0, tcell.ModShift)
+	tr.Render()
+	if line := getScreenContent(screen); !strings.HasPrefix(line, "........") {
+		t.Errorf("expected panned right, got:\n%s", line)
+	}
+	tr.DispatchKey(tcell.KeyLeft, 0, tcell.ModShift)
+	tr.Render()
+	if line := getScreenContent(screen); !strings.HasPrefix(line, "r01") {
+		t.Errorf("expected panned down one row, got:\n%s", line)
+	}
+
+	// 拖到最右下角：偏移被限制在内容范围内
+	for i := 0; i < 8; i++ {
+		mouseDrag(tr, 15, 4, 0, 0)
+	}
+	tr.Render()
+	lines = strings.Split(getScreenContent(screen), "\n")
+	if !strings.Contains(lines[4], "end29") {
+		t.Errorf("expected bottom-right corner, got:\n%s", strings.Join(lines, "\n"))
+	}
+
+	// Home 回到左上角
+	tr.DispatchKey(tcell.KeyHome, 0, 0)
+	tr.Render()
+	if line := getScreenContent(screen); !strings.HasPrefix(line, "r00") {
+		t.Errorf("expected top-left after Home, got:\n%s", line)
+	}
+}
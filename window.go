@@ -0,0 +1,293 @@
+package rego
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// =============================================================================
+// Window - 浮动窗口
+// =============================================================================
+
+// 窗口的最小尺寸（含边框）
+const (
+	windowMinW = 10
+	windowMinH = 3
+)
+
+// WindowProps 浮动窗口属性
+type WindowProps struct {
+	Title     string
+	X, Y      int    // 初始位置（终端坐标）
+	W, H      int    // 初始尺寸（含边框）
+	Resizable bool   // 是否可以拖动右下角调整大小
+	OnClose   func() // 设置后标题栏右侧显示 [x]，点击时调用
+}
+
+type windowDragMode int
+
+const (
+	windowDragNone windowDragMode = iota
+	windowDragMove
+	windowDragResize
+)
+
+// windowDrag 正在进行的拖动：grabX 为按下位置相对窗口左边的偏移
+type windowDrag struct {
+	mode  windowDragMode
+	grabX int
+}
+
+// Window 在浮层中显示一个窗口，child 为窗口内容
+//
+// 拖动标题栏移动窗口，Resizable 时拖动右下角调整大小；按下鼠标时窗口置于最上层并成为活动窗口。
+// 窗口不占用所在位置的布局空间，被窗口覆盖的区域不会收到鼠标事件。
+//
+//	rego.Window(c.Child("log"), rego.WindowProps{Title: "Log", X: 4, Y: 2, W: 40, H: 12, Resizable: true},
+//		LogPanel(c.Child("panel")),
+//	)
+func Window(c C, props WindowProps, child Node) Node {
+	ctx := c.(*componentContext)
+	rect := Use(c, "rect", Rect{X: props.X, Y: props.Y, W: max(props.W, windowMinW), H: max(props.H, windowMinH)})
+	drag := Use(c, "drag", windowDrag{})
+
+	node := &windowNode{
+		ctx:       ctx,
+		title:     props.Title,
+		rect:      rect.Val,
+		child:     child,
+		resizable: props.Resizable,
+		closable:  props.OnClose != nil,
+	}
+
+	UseMouse(c, func(ev MouseEvent) {
+		r := rect.Val
+		switch {
+		case ev.Type == MouseEventPress && ev.Button == MouseButtonLeft:
+			// 只有窗口是该位置最上层的窗口时才会收到 Press
+			if ctx.runtime != nil {
+				ctx.runtime.windows.raise(ctx)
+			}
+			switch {
+			case props.Resizable && ev.X == r.X+r.W-1 && ev.Y == r.Y+r.H-1:
+				drag.Set(windowDrag{mode: windowDragResize})
+			case ev.Y == r.Y && !node.onClose(ev.X):
+				drag.Set(windowDrag{mode: windowDragMove, grabX: ev.X - r.X})
+			}
+		case ev.Type == MouseEventMove && ev.Button == MouseButtonLeft && drag.Val.mode != windowDragNone:
+			screenW, screenH := 0, 0
+			if ctx.runtime != nil && ctx.runtime.screen != nil {
+				screenW, screenH = ctx.runtime.screen.Size()
+			}
+			if drag.Val.mode == windowDragMove {
+				r.X = max(min(ev.X-drag.Val.grabX, screenW-r.W), 0)
+				r.Y = max(min(ev.Y, screenH-1), 0)
+			} else {
+				r.W = max(ev.X-r.X+1, windowMinW)
+				r.H = max(ev.Y-r.Y+1, windowMinH)
+			}
+			rect.Set(r)
+		case ev.Type == MouseEventRelease:
+			if drag.Val.mode != windowDragNone {
+				drag.Set(windowDrag{})
+			}
+		case ev.Type == MouseEventClick && ev.Button == MouseButtonLeft:
+			if props.OnClose != nil && ev.Y == r.Y && node.onClose(ev.X) {
+				props.OnClose()
+			}
+		}
+	})
+
+	return node
+}
+
+// windowNode 在布局中不占空间，渲染时把自己登记到 WindowManager，由运行时在浮层阶段绘制
+type windowNode struct {
+	ctx       *componentContext
+	title     string
+	rect      Rect
+	child     Node
+	resizable bool
+	closable  bool
+}
+
+func (w *windowNode) render(screen tcell.Screen, x, y, width, height int) int {
+	if w.ctx.runtime != nil {
+		w.ctx.runtime.windows.place(w)
+	}
+	return 0
+}
+
+func (w *windowNode) measureHeight(width int) int {
+	return 0
+}
+
+func (w *windowNode) measureWidth() int {
+	return 0
+}
+
+// onClose x 是否落在标题栏的 [x] 上
+func (w *windowNode) onClose(x int) bool {
+	return w.closable && x >= w.rect.X+w.rect.W-4 && x < w.rect.X+w.rect.W-1
+}
+
+// draw 绘制窗口边框、标题栏与内容
+func (w *windowNode) draw(screen tcell.Screen, active bool) {
+	r := w.rect
+	for row := r.Y; row < r.Y+r.H; row++ {
+		for col := r.X; col < r.X+r.W; col++ {
+			screen.SetContent(col, row, ' ', nil, tcell.StyleDefault)
+		}
+	}
+
+	color := Gray
+	if active {
+		color = Cyan
+	}
+	Box(nil).Border(BorderSingle).BorderColor(color).renderBorder(screen, r.X, r.Y, r.W, r.H)
+
+	style := tcell.StyleDefault.Foreground(colorToTcell(color)).Bold(active)
+	titleW := r.W - 4
+	if w.closable {
+		titleW -= 3
+		screen.SetContent(r.X+r.W-4, r.Y, '[', nil, style)
+		screen.SetContent(r.X+r.W-3, r.Y, 'x', nil, style)
+		screen.SetContent(r.X+r.W-2, r.Y, ']', nil, style)
+	}
+	col := r.X + 1
+	for _, ch := range runewidth.Truncate(" "+w.title+" ", titleW, "…") {
+		screen.SetContent(col, r.Y, ch, nil, style)
+		col += runewidth.RuneWidth(ch)
+	}
+	if w.resizable {
+		screen.SetContent(r.X+r.W-1, r.Y+r.H-1, '◢', nil, style)
+	}
+
+	inner := Rect{X: r.X + 1, Y: r.Y + 1, W: r.W - 2, H: r.H - 2}
+	if w.child != nil && inner.W > 0 && inner.H > 0 {
+		clip := &clipScreen{Screen: screen, viewX: inner.X, viewY: inner.Y, viewW: inner.W, viewH: inner.H, runtime: w.ctx.runtime}
+		w.child.render(clip, inner.X, inner.Y, inner.W, inner.H)
+	}
+	w.ctx.rect, w.ctx.visible = screenRect(screen, r)
+}
+
+// =============================================================================
+// WindowManager - 窗口层叠顺序与活动窗口
+// =============================================================================
+
+// WindowManager 跟踪浮动窗口的层叠顺序，最上层的窗口为活动窗口
+type WindowManager struct {
+	runtime *Runtime
+	order   []*componentContext // 从底到顶
+	placed  []*windowNode       // 本帧登记的窗口
+	shown   []*windowNode       // 最近一次绘制的窗口（从底到顶），用于鼠标命中
+	drawing *componentContext   // 正在绘制内容的窗口
+}
+
+// UseWindowManager 获取运行时的窗口管理器
+func UseWindowManager(c C) *WindowManager {
+	ctx := c.(*componentContext)
+	if ctx.runtime == nil {
+		return &WindowManager{}
+	}
+	return ctx.runtime.windows
+}
+
+// Raise 将 c 对应的窗口置于最上层（c 为传给 Window 的上下文）
+func (wm *WindowManager) Raise(c C) {
+	wm.raise(c.(*componentContext))
+}
+
+// IsActive c 对应的窗口是否为最上层窗口
+func (wm *WindowManager) IsActive(c C) bool {
+	return len(wm.order) > 0 && wm.order[len(wm.order)-1] == c.(*componentContext)
+}
+
+// Titles 返回当前显示的窗口标题，从底到顶
+func (wm *WindowManager) Titles() []string {
+	titles := make([]string, 0, len(wm.shown))
+	for _, w := range wm.shown {
+		titles = append(titles, w.title)
+	}
+	return titles
+}
+
+func (wm *WindowManager) raise(ctx *componentContext) {
+	for i, c := range wm.order {
+		if c == ctx {
+			if i == len(wm.order)-1 {
+				return
+			}
+			wm.order = append(wm.order[:i], wm.order[i+1:]...)
+			break
+		}
+	}
+	wm.order = append(wm.order, ctx)
+	if wm.runtime != nil {
+		wm.runtime.scheduleRefresh()
+	}
+}
+
+// place 登记本帧要绘制的窗口，新窗口出现在最上层
+func (wm *WindowManager) place(w *windowNode) {
+	wm.placed = append(wm.placed, w)
+	for _, c := range wm.order {
+		if c == w.ctx {
+			return
+		}
+	}
+	wm.order = append(wm.order, w.ctx)
+}
+
+// render 按层叠顺序绘制本帧登记的窗口，并移除不再显示的窗口
+func (wm *WindowManager) render(screen tcell.Screen) {
+	index := make(map[*componentContext]int, len(wm.placed))
+	for _, w := range wm.placed {
+		index[w.ctx] = -1
+	}
+	order := wm.order[:0]
+	for _, c := range wm.order {
+		if _, ok := index[c]; ok {
+			index[c] = len(order)
+			order = append(order, c)
+		}
+	}
+	wm.order = order
+
+	sort.SliceStable(wm.placed, func(i, j int) bool {
+		return index[wm.placed[i].ctx] < index[wm.placed[j].ctx]
+	})
+	wm.shown = append(wm.shown[:0], wm.placed...)
+	wm.placed = wm.placed[:0]
+	for i, w := range wm.shown {
+		wm.drawing = w.ctx
+		w.draw(screen, i == len(wm.shown)-1)
+	}
+	wm.drawing = nil
+}
+
+// at 返回 (x, y) 处最上层的窗口，没有窗口时返回 nil
+func (wm *WindowManager) at(x, y int) *componentContext {
+	for i := len(wm.shown) - 1; i >= 0; i-- {
+		if wm.shown[i].rect.Contains(x, y) {
+			return wm.shown[i].ctx
+		}
+	}
+	return nil
+}
+
+// reachable 鼠标位于 (x, y) 时 c 能否收到事件：该位置被窗口覆盖时只有窗口内的组件可以
+func (wm *WindowManager) reachable(c *componentContext, x, y int) bool {
+	top := wm.at(x, y)
+	if top == nil {
+		return true
+	}
+	for p := c; p != nil; p = p.parent {
+		if p == top {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package rego
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// mouseClick 在 (x, y) 处按下并松开左键
+func mouseClick(tr *Runtime, x, y int) {
+	tr.DispatchMouse(x, y, tcell.Button1, 0)
+	tr.DispatchMouse(x, y, tcell.ButtonNone, 0)
+	tr.Render()
+}
+
+// mouseDrag 从 from 拖动到 to
+func mouseDrag(tr *Runtime, fromX, fromY, toX, toY int) {
+	tr.DispatchMouse(fromX, fromY, tcell.Button1, 0)
+	tr.DispatchMouse(toX, toY, tcell.Button1, 0)
+	tr.DispatchMouse(toX, toY, tcell.ButtonNone, 0)
+	tr.Render()
+}
+
+func TestWindow_ZOrderAndMouseRouting(t *testing.T) {
+	backgroundClicks := 0
+	app := func(c C) Node {
+		bg := c.Child("bg")
+		UseMouse(bg, func(ev MouseEvent) {
+			if ev.Type == MouseEventClick && bg.Rect().Contains(ev.X, ev.Y) {
+				backgroundClicks++
+			}
+		})
+		return VStack(
+			bg.Wrap(VStack(Text("background"), Text(""), Text(""), Text(""), Text(""), Text(""))),
+			Window(c.Child("a"), WindowProps{Title: "A", X: 2, Y: 1, W: 12, H: 4}, Text("alpha")),
+			Window(c.Child("b"), WindowProps{Title: "B", X: 8, Y: 2, W: 12, H: 4}, Text("beta")),
+		)
+	}
+
+	screen := newTestScreen(30, 8)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+
+	wm := tr.windows
+	if got := wm.Titles(); !reflect.DeepEqual(got, []string{"A", "B"}) {
+		t.Fatalf("initial order = %v", got)
+	}
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.Contains(lines[1], " A ") || !strings.Contains(lines[2], " B ") || !strings.Contains(lines[3], "beta") {
+		t.Fatalf("unexpected screen:\n%s", getScreenContent(screen))
+	}
+
+	// 按下 A 的可见部分：A 置顶
+	mouseClick(tr, 3, 2)
+	if got := wm.Titles(); !reflect.DeepEqual(got, []string{"B", "A"}) {
+		t.Errorf("order after raising A = %v", got)
+	}
+	if !wm.IsActive(tr.rootContext.children["a"]) {
+		t.Error("expected A to be active")
+	}
+
+	// 窗口覆盖的位置不会点到背景，窗口外可以
+	if backgroundClicks != 0 {
+		t.Errorf("background received %d clicks through a window", backgroundClicks)
+	}
+	mouseClick(tr, 0, 0)
+	if backgroundClicks != 1 {
+		t.Errorf("expected background click outside windows, got %d", backgroundClicks)
+	}
+}
+
+func TestWindow_DragAndResize(t *testing.T) {
+	closed := false
+	app := func(c C) Node {
+		if closed {
+			return Text("")
+		}
+		return Window(c.Child("w"), WindowProps{
+			Title: "Log", X: 1, Y: 1, W: 12, H: 4, Resizable: true,
+			OnClose: func() { closed = true },
+		}, Text("content"))
+	}
+
+	screen := newTestScreen(30, 10)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	win := tr.rootContext.children["w"]
+
+	// 拖动标题栏：抓取点相对窗口左边的偏移保持不变
+	mouseDrag(tr, 3, 1, 8, 4)
+	if got := win.Rect(); got != (Rect{X: 6, Y: 4, W: 12, H: 4}) {
+		t.Fatalf("rect after move = %+v", got)
+	}
+	lines := strings.Split(getScreenContent(screen), "\n")
+	if !strings.Contains(lines[5], "content") {
+		t.Errorf("expected content to move with the window:\n%s", getScreenContent(screen))
+	}
+
+	// 拖动右下角调整大小，不小于最小尺寸
+	mouseDrag(tr, 17, 7, 25, 9)
+	if got := win.Rect(); got != (Rect{X: 6, Y: 4, W: 20, H: 6}) {
+		t.Fatalf("rect after resize = %+v", got)
+	}
+	mouseDrag(tr, 25, 9, 7, 5)
+	if got := win.Rect(); got.W != windowMinW || got.H != windowMinH {
+		t.Fatalf("expected minimum size, got %+v", got)
+	}
+
+	// 点击 [x]
+	r := win.Rect()
+	mouseClick(tr, r.X+r.W-3, r.Y)
+	if !closed {
+		t.Error("expected OnClose to be called")
+	}
+	tr.Render()
+	if got := tr.windows.Titles(); len(got) != 0 {
+		t.Errorf("expected closed window to be removed, got %v", got)
+	}
+}
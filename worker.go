@@ -0,0 +1,67 @@
+package rego
+
+import (
+	"context"
+	"runtime"
+)
+
+// =============================================================================
+// UseWorker - 后台计算
+// =============================================================================
+
+// WorkerState 是 UseWorker 返回的计算状态
+type WorkerState[O any] struct {
+	Output O    // 最近一次完成的结果，计算新输入期间保留旧值
+	Busy   bool // 是否有输入正在排队或计算
+}
+
+// workerSlots 限制所有 UseWorker 同时计算的协程数，其余的排队等待
+var workerSlots = make(chan struct{}, runtime.GOMAXPROCS(0))
+
+// UseWorker 在后台协程中执行 fn(ctx, input)，input 或 deps 变化时重新计算；结果在 UI 循环中写回并触发刷新
+//
+// 适合模糊匹配大量条目、计算 diff 等会卡住界面的工作。新的输入到来或组件被释放（见 UseEffect）时，
+// 上一次计算的 ctx 被取消、结果被丢弃，fn 应在循环中检查 ctx.Err() 尽早返回。
+// input 与 deps 用 reflect.DeepEqual 比较，大的数据集放在 deps 中时应传递不变的引用（如同一个 slice）。
+//
+//	matches := rego.UseWorker(c, query.Val, func(ctx context.Context, q string) []int {
+//		return fuzzyFilter(ctx, items, q)
+//	}, items)
+func UseWorker[I, O any](c C, input I, fn func(ctx context.Context, input I) O, deps ...any) WorkerState[O] {
+	ctx := c.(*componentContext)
+	state := Use(c, "worker", WorkerState[O]{Busy: true})
+	fnRef := UseRef(c, fn)
+	fnRef.Current = fn // 始终使用最新一次渲染传入的 fn
+
+	UseEffect(c, func() func() {
+		base, cancel := context.WithCancel(context.Background())
+		state.Set(WorkerState[O]{Output: state.Val.Output, Busy: true})
+
+		fn := fnRef.Current
+		go func() {
+			select {
+			case workerSlots <- struct{}{}:
+			case <-base.Done():
+				return // 排队期间已被新的输入取代
+			}
+			defer func() { <-workerSlots }()
+			if base.Err() != nil {
+				return
+			}
+			out := fn(base, input)
+			apply := func() {
+				if base.Err() == nil {
+					state.Set(WorkerState[O]{Output: out})
+				}
+			}
+			if ctx.runtime != nil {
+				ctx.runtime.post(apply)
+			} else {
+				apply()
+			}
+		}()
+		return cancel
+	}, append([]any{input}, deps...)...)
+
+	return state.Val
+}
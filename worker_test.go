@@ -0,0 +1,59 @@
+package rego
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUseWorker_CancelsSupersededInput(t *testing.T) {
+	started, cancelled := make(chan struct{}), make(chan string, 1)
+	var setQuery func(string)
+	app := func(c C) Node {
+		query := Use(c, "query", "slow")
+		setQuery = query.Set
+		result := UseWorker(c, query.Val, func(ctx context.Context, q string) string {
+			if q == "slow" {
+				close(started)
+				<-ctx.Done()
+				cancelled <- q
+				return "stale"
+			}
+			return strings.ToUpper(q)
+		})
+		return Text("result: " + result.Output + If(result.Busy, " …", ""))
+	}
+
+	screen := newTestScreen(20, 1)
+	tr := NewTestRuntime(app, screen)
+	tr.Render()
+	if content := getScreenContent(screen); !strings.Contains(content, "result:  …") {
+		t.Fatalf("expected busy state, got %q", content)
+	}
+
+	<-started
+	setQuery("fast")
+	tr.Render()
+	select {
+	case q := <-cancelled:
+		if q != "slow" {
+			t.Errorf("cancelled %q, want slow", q)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("superseded input was not cancelled")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		tr.Render()
+		if content := getScreenContent(screen); strings.Contains(content, "result: FAST") {
+			if strings.Contains(content, "…") {
+				t.Errorf("expected idle state, got %q", content)
+			}
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Errorf("expected worker result, got %q", getScreenContent(screen))
+}